@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/vovanwin/api-my-site/pkg/services"
+)
+
+// cacheFlushResult is the JSON representation of a cache flush
+type cacheFlushResult struct {
+	Group string   `json:"group,omitempty"`
+	Key   string   `json:"key,omitempty"`
+	Tags  []string `json:"tags,omitempty"`
+}
+
+func (a *app) cacheCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the cache",
+	}
+	cmd.AddCommand(a.cacheFlushCommand())
+	return cmd
+}
+
+func (a *app) cacheFlushCommand() *cobra.Command {
+	var in cacheFlushResult
+
+	cmd := &cobra.Command{
+		Use:   "flush",
+		Short: "Flush a cache group, a single key of a group, or everything with the tags",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if in.Group == "" && len(in.Tags) == 0 {
+				return usageError{msg: "either --group or --tag is required"}
+			}
+			if in.Key != "" && in.Group == "" {
+				return usageError{msg: "--key requires --group"}
+			}
+
+			return a.withContainer(func(ctx context.Context, c *services.Container) error {
+				err := c.Cache.
+					Flush().
+					Group(in.Group).
+					Key(in.Key).
+					Tags(in.Tags...).
+					Execute(ctx)
+				if err != nil {
+					return err
+				}
+
+				return a.result(in, "flushed the cache")
+			})
+		},
+	}
+	cmd.Flags().StringVar(&in.Group, "group", "", "the cache group")
+	cmd.Flags().StringVar(&in.Key, "key", "", "only flush the key of the group")
+	cmd.Flags().StringSliceVar(&in.Tags, "tag", nil, "flush the entries with the tag (repeatable)")
+
+	return cmd
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/vovanwin/api-my-site/config"
+)
+
+// configCheckResult is the JSON representation of a config check
+type configCheckResult struct {
+	Valid       bool   `json:"valid"`
+	Environment string `json:"environment,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+func (a *app) configCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect the configuration",
+	}
+	cmd.AddCommand(
+		a.configCheckCommand(),
+		a.configPrintCommand(),
+	)
+	return cmd
+}
+
+func (a *app) configCheckCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "check",
+		Short: "Load and validate the configuration; exits with 3 if it's invalid",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.GetConfig()
+			res := configCheckResult{
+				Valid:       err == nil,
+				Environment: string(cfg.App.Environment),
+			}
+			text := "the configuration is valid"
+			if err != nil {
+				res.Error = err.Error()
+				text = "the configuration is invalid:\n" + err.Error()
+			}
+
+			if printErr := a.result(res, text); printErr != nil {
+				return printErr
+			}
+			if err != nil {
+				return checkFailedError{msg: "the configuration is invalid"}
+			}
+			return nil
+		},
+	}
+}
+
+func (a *app) configPrintCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "print",
+		Short: "Print the effective configuration with the secrets masked; exits with 3 if it's invalid",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// The configuration is printed even if it's invalid, since that's when it's most needed
+			cfg, err := config.GetConfig()
+
+			var printErr error
+			if a.json {
+				printErr = a.result(config.Masked(cfg), "")
+			} else {
+				printErr = config.Print(a.out, cfg)
+			}
+			if printErr != nil {
+				return printErr
+			}
+
+			if err != nil {
+				return checkFailedError{msg: err.Error()}
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/vovanwin/api-my-site/pkg/logging"
+	"github.com/vovanwin/api-my-site/pkg/services"
+)
+
+// Exit codes, so scripts and CI jobs can tell the failures apart
+const (
+	// exitOK is returned when the command succeeded
+	exitOK = 0
+
+	// exitFailed is returned when the command failed
+	exitFailed = 1
+
+	// exitUsage is returned when the command was invoked incorrectly
+	exitUsage = 2
+
+	// exitCheckFailed is returned when a check ran and found a problem, such as pending migrations
+	exitCheckFailed = 3
+)
+
+// checkFailedError is returned by commands whose check found a problem
+// The result has already been written, so only the exit code is affected
+type checkFailedError struct {
+	msg string
+}
+
+func (e checkFailedError) Error() string {
+	return e.msg
+}
+
+// usageError is returned by commands whose flags or arguments are invalid in a way cobra can't check
+type usageError struct {
+	msg string
+}
+
+func (e usageError) Error() string {
+	return e.msg
+}
+
+// app stores the state shared by the commands
+type app struct {
+	// json stores whether the output is written as JSON
+	json bool
+
+	// started stores whether the arguments were parsed and a command started to run
+	started bool
+
+	// out stores the writer the results are written to
+	out io.Writer
+}
+
+func main() {
+	// Logs would mix with the output which scripts parse
+	logging.SetOutput(os.Stderr)
+
+	a := &app{out: os.Stdout}
+	root := a.rootCommand()
+
+	err := root.Execute()
+	if err != nil {
+		a.printError(err)
+	}
+	os.Exit(a.exitCode(err))
+}
+
+// rootCommand builds the command tree
+func (a *app) rootCommand() *cobra.Command {
+	root := &cobra.Command{
+		Use:           "admin",
+		Short:         "Administer the application: users, migrations, cache, tasks, seeding and config",
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			a.started = true
+			return nil
+		},
+	}
+	root.PersistentFlags().BoolVar(&a.json, "json", false, "write the output as JSON")
+
+	root.AddCommand(
+		a.userCommand(),
+		a.migrateCommand(),
+		a.cacheCommand(),
+		a.tasksCommand(),
+		a.seedCommand(),
+		a.configCommand(),
+	)
+
+	return root
+}
+
+// exitCode maps the error returned by a command to the exit code
+func (a *app) exitCode(err error) int {
+	var (
+		check checkFailedError
+		usage usageError
+	)
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.As(err, &check):
+		return exitCheckFailed
+	case !a.started, errors.As(err, &usage):
+		return exitUsage
+	default:
+		return exitFailed
+	}
+}
+
+// printError writes the error to stderr
+func (a *app) printError(err error) {
+	if a.json {
+		_ = json.NewEncoder(os.Stderr).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "error: %v\n", err)
+	if !a.started {
+		fmt.Fprintln(os.Stderr, "run 'admin --help' for usage")
+	}
+}
+
+// withContainer runs fn with a new container, which is shut down afterwards
+// Migrations are never run on start, since that is what the migrate commands are for
+func (a *app) withContainer(fn func(ctx context.Context, c *services.Container) error) (err error) {
+	if err := os.Setenv("PAGODA_DATABASE_MIGRATE", "false"); err != nil {
+		return err
+	}
+
+	c, err := newContainer()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if shutdownErr := c.Shutdown(); shutdownErr != nil && err == nil {
+			err = shutdownErr
+		}
+	}()
+
+	ctx := services.WithAuditActor(context.Background(), services.AuditActor{UserAgent: "admin"})
+	return fn(ctx, c)
+}
+
+// newContainer creates a new container, turning the panics of a failed initialization into an error
+func newContainer() (c *services.Container, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	return services.NewContainer(), nil
+}
+
+// result writes the result of a command
+// In JSON mode v is encoded as is, otherwise the text is written, if any
+func (a *app) result(v interface{}, text string) error {
+	if a.json {
+		enc := json.NewEncoder(a.out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+
+	if text != "" {
+		_, err := fmt.Fprintln(a.out, text)
+		return err
+	}
+	return nil
+}
+
+// table writes rows as a table in text mode, or v in JSON mode
+func (a *app) table(v interface{}, header []string, rows [][]string) error {
+	if a.json {
+		return a.result(v, "")
+	}
+
+	w := tabwriter.NewWriter(a.out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	return w.Flush()
+}
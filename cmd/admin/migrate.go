@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/vovanwin/api-my-site/pkg/services"
+)
+
+// migrationResult is the JSON representation of a migration
+type migrationResult struct {
+	Statements []string `json:"statements"`
+	Applied    bool     `json:"applied"`
+}
+
+func (a *app) migrateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Migrate the database schema",
+	}
+	cmd.AddCommand(
+		a.migrateStatusCommand(),
+		a.migrateUpCommand(),
+		a.migrateDownCommand(),
+	)
+	return cmd
+}
+
+func (a *app) migrateStatusCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "List the pending schema changes; exits with 3 if there are any",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return a.withContainer(func(ctx context.Context, c *services.Container) error {
+				plan, err := services.MigrationPlan(ctx, c.ORM, false)
+				if err != nil {
+					return err
+				}
+
+				text := "the schema is up to date"
+				if len(plan) > 0 {
+					text = fmt.Sprintf("%d pending statement(s):\n%s", len(plan), strings.Join(plan, "\n"))
+				}
+				if err := a.result(migrationResult{Statements: plan}, text); err != nil {
+					return err
+				}
+
+				if len(plan) > 0 {
+					return checkFailedError{msg: "the schema has pending changes"}
+				}
+				return nil
+			})
+		},
+	}
+}
+
+func (a *app) migrateUpCommand() *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "up",
+		Short: "Apply the pending schema changes; nothing is dropped",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return a.withContainer(func(ctx context.Context, c *services.Container) error {
+				return a.migrate(ctx, c, false, !dryRun)
+			})
+		},
+	}
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "only print the statements")
+
+	return cmd
+}
+
+func (a *app) migrateDownCommand() *cobra.Command {
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use: "down",
+		Short: "Roll the schema back to the one of this build by dropping the columns and indexes it doesn't have; " +
+			"only prints the statements unless --yes is given",
+		Long: "Roll the schema back to the one of this build by dropping the columns and indexes it doesn't have, " +
+			"along with their data.\n" +
+			"The schema is migrated automatically rather than by numbered migrations, so there is no step to undo: " +
+			"to roll back a release, run this command with the binary of the release to roll back to. " +
+			"Tables are never dropped. The statements are only printed unless --yes is given.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return a.withContainer(func(ctx context.Context, c *services.Container) error {
+				return a.migrate(ctx, c, true, yes)
+			})
+		},
+	}
+	cmd.Flags().BoolVar(&yes, "yes", false, "apply the statements, dropping the data irreversibly")
+
+	return cmd
+}
+
+// migrate prints the migration plan and applies it if apply is set
+func (a *app) migrate(ctx context.Context, c *services.Container, drop, apply bool) error {
+	plan, err := services.MigrationPlan(ctx, c.ORM, drop)
+	if err != nil {
+		return err
+	}
+
+	if len(plan) == 0 {
+		return a.result(migrationResult{Statements: plan}, "the schema is up to date")
+	}
+
+	if apply {
+		if err := services.Migrate(ctx, c.ORM, drop); err != nil {
+			return err
+		}
+	}
+
+	verb := "applied"
+	if !apply {
+		verb = "would apply"
+	}
+	text := fmt.Sprintf("%s %d statement(s):\n%s", verb, len(plan), strings.Join(plan, "\n"))
+	if !apply && drop {
+		text += "\nrerun with --yes to apply them"
+	}
+	return a.result(migrationResult{Statements: plan, Applied: apply}, text)
+}
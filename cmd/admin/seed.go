@@ -0,0 +1,285 @@
+package main
+
+import (
+	"context"
+	crand "crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/rand"
+
+	"github.com/spf13/cobra"
+	"github.com/vovanwin/api-my-site/config"
+	"github.com/vovanwin/api-my-site/ent"
+	"github.com/vovanwin/api-my-site/ent/user"
+	"github.com/vovanwin/api-my-site/pkg/factory"
+	"github.com/vovanwin/api-my-site/pkg/services"
+)
+
+// seedResult is the JSON representation of a seed run
+type seedResult struct {
+	Admin         userResult    `json:"admin"`
+	AdminCreated  bool          `json:"admin_created"`
+	AdminPassword string        `json:"admin_password,omitempty"`
+	Sample        *sampleResult `json:"sample,omitempty"`
+}
+
+// sampleResult is the JSON representation of the sample content added by a seed run
+type sampleResult struct {
+	Seed       int64 `json:"seed"`
+	Skipped    bool  `json:"skipped"`
+	Users      int   `json:"users"`
+	Categories int   `json:"categories"`
+	Tags       int   `json:"tags"`
+	Posts      int   `json:"posts"`
+	Pages      int   `json:"pages"`
+	Projects   int   `json:"projects"`
+	Messages   int   `json:"messages"`
+}
+
+func (a *app) seedCommand() *cobra.Command {
+	var (
+		adminEmail    string
+		adminPassword string
+		sample        bool
+		seed          int64
+		posts         int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "seed",
+		Short: "Seed the database with the data the application needs, and sample content with --sample; safe to run repeatedly",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if posts < 0 {
+				return usageError{msg: "--posts must not be negative"}
+			}
+
+			return a.withContainer(func(ctx context.Context, c *services.Container) error {
+				res, err := seedAdmin(ctx, c, adminEmail, adminPassword)
+				if err != nil {
+					return err
+				}
+
+				if sample {
+					if c.Config.App.Environment == config.EnvProduction {
+						return errors.New("sample content can't be seeded in production")
+					}
+
+					admin, err := c.ORM.User.Get(ctx, res.Admin.ID)
+					if err != nil {
+						return err
+					}
+					if res.Sample, err = seedSample(ctx, c, admin, seed, posts); err != nil {
+						return err
+					}
+				}
+
+				text := fmt.Sprintf("admin user %d <%s> already exists", res.Admin.ID, res.Admin.Email)
+				if res.AdminCreated {
+					text = fmt.Sprintf("created admin user %d <%s>", res.Admin.ID, res.Admin.Email)
+					if res.AdminPassword != "" {
+						text += fmt.Sprintf(" with the password %s", res.AdminPassword)
+					}
+				}
+
+				switch {
+				case res.Sample == nil:
+				case res.Sample.Skipped:
+					text += "\nthe database already has posts, skipped the sample content"
+				default:
+					text += fmt.Sprintf(
+						"\nadded sample content from the seed %d: %d users, %d categories, %d tags, %d posts, %d pages, %d projects, %d messages",
+						res.Sample.Seed, res.Sample.Users, res.Sample.Categories, res.Sample.Tags,
+						res.Sample.Posts, res.Sample.Pages, res.Sample.Projects, res.Sample.Messages,
+					)
+				}
+
+				return a.result(res, text)
+			})
+		},
+	}
+	cmd.Flags().StringVar(&adminEmail, "admin-email", "admin@localhost", "the email address of the admin user")
+	cmd.Flags().StringVar(&adminPassword, "admin-password", "", "the password of the admin user, generated if empty")
+	cmd.Flags().BoolVar(&sample, "sample", false, "add Russian and English sample content unless there are posts already; not allowed in production")
+	cmd.Flags().Int64Var(&seed, "seed", 1, "the seed of the sample content; the same seed gives the same content")
+	cmd.Flags().IntVar(&posts, "posts", 30, "the number of sample posts")
+
+	return cmd
+}
+
+// seedAdmin creates the admin user unless a user with the email exists
+// A generated password is returned in the result, since there's no other way to learn it
+func seedAdmin(ctx context.Context, c *services.Container, email, password string) (seedResult, error) {
+	var res seedResult
+
+	u, err := c.ORM.User.
+		Query().
+		Where(user.EmailEqualFold(email)).
+		Only(ctx)
+	switch {
+	case err == nil:
+		res.Admin = newUserResult(u)
+		return res, nil
+	case !ent.IsNotFound(err):
+		return res, err
+	}
+
+	if password == "" {
+		if password, err = generatePassword(); err != nil {
+			return res, err
+		}
+		res.AdminPassword = password
+	}
+
+	hash, err := c.Auth.HashPassword(password)
+	if err != nil {
+		return res, err
+	}
+
+	u, err = c.ORM.User.
+		Create().
+		SetEmail(email).
+		SetName("Admin").
+		SetPassword(hash).
+		SetRole(user.RoleAdmin).
+		SetVerified(true).
+		Save(ctx)
+	if err != nil {
+		return res, err
+	}
+
+	res.Admin = newUserResult(u)
+	res.AdminCreated = true
+	return res, nil
+}
+
+// generatePassword generates a random password
+func generatePassword() (string, error) {
+	b := make([]byte, 18)
+	if _, err := crand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// seedSample fills the database with sample content built by the factory
+// Nothing is added if there are posts already, and either all of the content is added or none of it.
+// The sample users have the password factory.DefaultPassword. Media isn't added, since it needs files in the storage
+func seedSample(ctx context.Context, c *services.Container, admin *ent.User, seed int64, posts int) (*sampleResult, error) {
+	res := &sampleResult{Seed: seed}
+
+	exists, err := c.ORM.Post.Query().Exist(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		res.Skipped = true
+		return res, nil
+	}
+
+	tx, err := c.ORM.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = seedSampleTx(ctx, tx.Client(), res, admin, seed, posts); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return nil, fmt.Errorf("%w: rolling back: %v", err, rbErr)
+		}
+		return nil, err
+	}
+
+	return res, tx.Commit()
+}
+
+// seedSampleTx adds the sample content using the transactional client
+func seedSampleTx(ctx context.Context, orm *ent.Client, res *sampleResult, admin *ent.User, seed int64, posts int) error {
+	f := factory.New(orm, seed)
+	pick := rand.New(rand.NewSource(seed))
+
+	authors := []*ent.User{admin}
+	for i := 0; i < 4; i++ {
+		u, err := f.User().Verified().Create(ctx)
+		if err != nil {
+			return err
+		}
+		authors = append(authors, u)
+		res.Users++
+	}
+
+	categories := make([]*ent.Category, 0, 4)
+	for i := 0; i < cap(categories); i++ {
+		cat, err := f.Category().Create(ctx)
+		if err != nil {
+			return err
+		}
+		categories = append(categories, cat)
+		res.Categories++
+	}
+
+	tags := make([]*ent.Tag, 0, 8)
+	for i := 0; i < cap(tags); i++ {
+		t, err := f.Tag().Create(ctx)
+		if err != nil {
+			return err
+		}
+		tags = append(tags, t)
+		res.Tags++
+	}
+
+	for i := 0; i < posts; i++ {
+		b := f.Post().
+			Author(authors[pick.Intn(len(authors))]).
+			Category(categories[pick.Intn(len(categories))])
+
+		for _, j := range pick.Perm(len(tags))[:1+pick.Intn(3)] {
+			b.Tags(tags[j])
+		}
+
+		// Leave some drafts
+		if pick.Intn(5) > 0 {
+			b.Published()
+		}
+
+		if _, err := b.Create(ctx); err != nil {
+			return err
+		}
+		res.Posts++
+	}
+
+	pages := []struct{ title, slug string }{
+		{"Обо мне", "about"},
+		{"Uses", "uses"},
+		{"Контакты", "contact"},
+	}
+	for i, page := range pages {
+		if _, err := f.Page().Title(page.title).Slug(page.slug).Published().InMenu(i).Create(ctx); err != nil {
+			return err
+		}
+		res.Pages++
+	}
+
+	for i := 0; i < 5; i++ {
+		b := f.Project().Position(i)
+		if i < 2 {
+			b.Featured()
+		}
+		if _, err := b.Create(ctx); err != nil {
+			return err
+		}
+		res.Projects++
+	}
+
+	for i := 0; i < 5; i++ {
+		b := f.ContactMessage()
+		if i < 2 {
+			b.Handled()
+		}
+		if _, err := b.Create(ctx); err != nil {
+			return err
+		}
+		res.Messages++
+	}
+
+	return nil
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/spf13/cobra"
+	"github.com/vovanwin/api-my-site/pkg/services"
+)
+
+// taskResult is the JSON representation of a task
+type taskResult struct {
+	ID          string          `json:"id"`
+	Queue       string          `json:"queue"`
+	Type        string          `json:"type"`
+	State       string          `json:"state"`
+	Payload     json.RawMessage `json:"payload,omitempty"`
+	Retried     int             `json:"retried"`
+	MaxRetry    int             `json:"max_retry"`
+	LastErr     string          `json:"last_error,omitempty"`
+	NextProcess *time.Time      `json:"next_process_at,omitempty"`
+}
+
+func newTaskResult(t *asynq.TaskInfo) taskResult {
+	r := taskResult{
+		ID:       t.ID,
+		Queue:    t.Queue,
+		Type:     t.Type,
+		State:    t.State.String(),
+		Retried:  t.Retried,
+		MaxRetry: t.MaxRetry,
+		LastErr:  t.LastErr,
+	}
+	if json.Valid(t.Payload) {
+		r.Payload = t.Payload
+	}
+	if !t.NextProcessAt.IsZero() {
+		r.NextProcess = &t.NextProcessAt
+	}
+	return r
+}
+
+func (a *app) tasksCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tasks",
+		Short: "Manage the task queues",
+	}
+	cmd.AddCommand(
+		a.tasksEnqueueCommand(),
+		a.tasksListCommand(),
+		a.tasksRetryCommand(),
+	)
+	return cmd
+}
+
+func (a *app) tasksEnqueueCommand() *cobra.Command {
+	var (
+		payload    string
+		queue      string
+		in         time.Duration
+		maxRetries int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "enqueue <type>",
+		Short: "Queue a task",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if payload != "" && !json.Valid([]byte(payload)) {
+				return usageError{msg: "--payload must be valid JSON"}
+			}
+
+			return a.withContainer(func(ctx context.Context, c *services.Container) error {
+				t := c.Tasks.New(args[0]).Context(ctx)
+				if payload != "" {
+					t.Payload(json.RawMessage(payload))
+				}
+				if queue != "" {
+					t.Queue(queue)
+				}
+				if in > 0 {
+					t.Wait(in)
+				}
+				if cmd.Flags().Changed("max-retries") {
+					t.MaxRetries(maxRetries)
+				}
+				if err := t.Save(); err != nil {
+					return err
+				}
+
+				return a.result(map[string]string{"type": args[0]}, fmt.Sprintf("queued the task %s", args[0]))
+			})
+		},
+	}
+	cmd.Flags().StringVar(&payload, "payload", "", "the JSON payload")
+	cmd.Flags().StringVar(&queue, "queue", "", "the queue, the default queue if empty")
+	cmd.Flags().DurationVar(&in, "in", 0, "process the task after the duration")
+	cmd.Flags().IntVar(&maxRetries, "max-retries", 0, "the maximum number of retries")
+
+	return cmd
+}
+
+func (a *app) tasksListCommand() *cobra.Command {
+	var (
+		queue string
+		state string
+		limit int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: fmt.Sprintf("List the tasks in a state: %v", services.TaskStates),
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return a.withContainer(func(ctx context.Context, c *services.Container) error {
+				queues := []string{queue}
+				if queue == "" {
+					var err error
+					if queues, err = c.Tasks.Queues(); err != nil {
+						return err
+					}
+				}
+
+				results := make([]taskResult, 0)
+				rows := make([][]string, 0)
+				for _, q := range queues {
+					tasks, err := c.Tasks.List(q, state, limit)
+					if err != nil {
+						return err
+					}
+
+					for _, t := range tasks {
+						r := newTaskResult(t)
+						results = append(results, r)
+						rows = append(rows, []string{
+							r.ID,
+							r.Queue,
+							r.Type,
+							r.State,
+							strconv.Itoa(r.Retried),
+							r.LastErr,
+						})
+					}
+				}
+
+				return a.table(results, []string{"ID", "QUEUE", "TYPE", "STATE", "RETRIED", "LAST ERROR"}, rows)
+			})
+		},
+	}
+	cmd.Flags().StringVar(&queue, "queue", "", "the queue, all queues if empty")
+	cmd.Flags().StringVar(&state, "state", "pending", "the state of the tasks")
+	cmd.Flags().IntVar(&limit, "limit", 50, "the maximum number of tasks per queue")
+
+	return cmd
+}
+
+func (a *app) tasksRetryCommand() *cobra.Command {
+	var (
+		queue       string
+		allArchived bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "retry [id]",
+		Short: "Run a task now, or all the archived tasks of the queue with --all-archived",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if allArchived {
+				return cobra.NoArgs(cmd, args)
+			}
+			return cobra.ExactArgs(1)(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return a.withContainer(func(ctx context.Context, c *services.Container) error {
+				if allArchived {
+					n, err := c.Tasks.RetryArchived(queue)
+					if err != nil {
+						return err
+					}
+					return a.result(map[string]int{"retried": n}, fmt.Sprintf("retrying %d archived task(s)", n))
+				}
+
+				if err := c.Tasks.Retry(queue, args[0]); err != nil {
+					return err
+				}
+				return a.result(map[string]int{"retried": 1}, fmt.Sprintf("retrying the task %s", args[0]))
+			})
+		},
+	}
+	cmd.Flags().StringVar(&queue, "queue", "default", "the queue of the task")
+	cmd.Flags().BoolVar(&allArchived, "all-archived", false, "retry all the archived tasks of the queue")
+
+	return cmd
+}
@@ -0,0 +1,267 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/vovanwin/api-my-site/ent"
+	"github.com/vovanwin/api-my-site/ent/user"
+	"github.com/vovanwin/api-my-site/pkg/services"
+)
+
+// userResult is the JSON representation of a user
+type userResult struct {
+	ID        int       `json:"id"`
+	Email     string    `json:"email"`
+	Name      string    `json:"name"`
+	Role      string    `json:"role"`
+	Verified  bool      `json:"verified"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func newUserResult(u *ent.User) userResult {
+	return userResult{
+		ID:        u.ID,
+		Email:     u.Email,
+		Name:      u.Name,
+		Role:      string(u.Role),
+		Verified:  u.Verified,
+		CreatedAt: u.CreatedAt,
+	}
+}
+
+func (a *app) userCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "user",
+		Short: "Manage users",
+	}
+	cmd.AddCommand(
+		a.userCreateCommand(),
+		a.userListCommand(),
+		a.userSetPasswordCommand(),
+		a.userGrantRoleCommand(),
+	)
+	return cmd
+}
+
+func (a *app) userCreateCommand() *cobra.Command {
+	var (
+		input struct {
+			Email    string `validate:"required,email,unique_email"`
+			Name     string `validate:"required"`
+			Password string `validate:"required,password"`
+		}
+		role          string
+		verified      bool
+		passwordStdin bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a user",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if passwordStdin {
+				password, err := readPassword()
+				if err != nil {
+					return err
+				}
+				input.Password = password
+			}
+			if err := user.RoleValidator(user.Role(role)); err != nil {
+				return err
+			}
+
+			return a.withContainer(func(ctx context.Context, c *services.Container) error {
+				if err := c.Validator.ValidateCtx(ctx, input); err != nil {
+					return err
+				}
+
+				hash, err := c.Auth.HashPassword(input.Password)
+				if err != nil {
+					return err
+				}
+
+				u, err := c.ORM.User.
+					Create().
+					SetEmail(input.Email).
+					SetName(input.Name).
+					SetPassword(hash).
+					SetRole(user.Role(role)).
+					SetVerified(verified).
+					Save(ctx)
+				if err != nil {
+					return err
+				}
+
+				return a.result(newUserResult(u), fmt.Sprintf("created user %d <%s>", u.ID, u.Email))
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&input.Email, "email", "", "the email address")
+	cmd.Flags().StringVar(&input.Name, "name", "", "the name")
+	cmd.Flags().StringVar(&input.Password, "password", "", "the password")
+	cmd.Flags().BoolVar(&passwordStdin, "password-stdin", false, "read the password from stdin")
+	cmd.Flags().StringVar(&role, "role", string(user.DefaultRole), "the role")
+	cmd.Flags().BoolVar(&verified, "verified", false, "mark the email address as verified")
+	cmd.MarkFlagsMutuallyExclusive("password", "password-stdin")
+	_ = cmd.MarkFlagRequired("email")
+	_ = cmd.MarkFlagRequired("name")
+
+	return cmd
+}
+
+func (a *app) userListCommand() *cobra.Command {
+	var role string
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the users",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return a.withContainer(func(ctx context.Context, c *services.Container) error {
+				query := c.ORM.User.Query().Order(ent.Asc(user.FieldID))
+				if role != "" {
+					query = query.Where(user.RoleEQ(user.Role(role)))
+				}
+
+				users, err := query.All(ctx)
+				if err != nil {
+					return err
+				}
+
+				results := make([]userResult, 0, len(users))
+				rows := make([][]string, 0, len(users))
+				for _, u := range users {
+					results = append(results, newUserResult(u))
+					rows = append(rows, []string{
+						strconv.Itoa(u.ID),
+						u.Email,
+						u.Name,
+						string(u.Role),
+						strconv.FormatBool(u.Verified),
+					})
+				}
+
+				return a.table(results, []string{"ID", "EMAIL", "NAME", "ROLE", "VERIFIED"}, rows)
+			})
+		},
+	}
+	cmd.Flags().StringVar(&role, "role", "", "only list the users with the role")
+
+	return cmd
+}
+
+func (a *app) userSetPasswordCommand() *cobra.Command {
+	var (
+		input struct {
+			Password string `validate:"required,password"`
+		}
+		passwordStdin bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "set-password <email>",
+		Short: "Set the password of a user and sign them out everywhere",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if passwordStdin {
+				password, err := readPassword()
+				if err != nil {
+					return err
+				}
+				input.Password = password
+			}
+
+			return a.withContainer(func(ctx context.Context, c *services.Container) error {
+				if err := c.Validator.ValidateCtx(ctx, input); err != nil {
+					return err
+				}
+
+				u, err := findUser(ctx, c, args[0])
+				if err != nil {
+					return err
+				}
+
+				hash, err := c.Auth.HashPassword(input.Password)
+				if err != nil {
+					return err
+				}
+
+				// Bumping the token version revokes the issued tokens
+				u, err = u.Update().
+					SetPassword(hash).
+					AddTokenVersion(1).
+					Save(ctx)
+				if err != nil {
+					return err
+				}
+
+				return a.result(newUserResult(u), fmt.Sprintf("updated the password of user %d <%s>", u.ID, u.Email))
+			})
+		},
+	}
+	cmd.Flags().StringVar(&input.Password, "password", "", "the password")
+	cmd.Flags().BoolVar(&passwordStdin, "password-stdin", false, "read the password from stdin")
+	cmd.MarkFlagsMutuallyExclusive("password", "password-stdin")
+
+	return cmd
+}
+
+func (a *app) userGrantRoleCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "grant-role <email> <role>",
+		Short: "Change the role of a user",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			role := user.Role(args[1])
+			if err := user.RoleValidator(role); err != nil {
+				return err
+			}
+
+			return a.withContainer(func(ctx context.Context, c *services.Container) error {
+				u, err := findUser(ctx, c, args[0])
+				if err != nil {
+					return err
+				}
+
+				u, err = u.Update().
+					SetRole(role).
+					Save(ctx)
+				if err != nil {
+					return err
+				}
+
+				return a.result(newUserResult(u), fmt.Sprintf("user %d <%s> now has the role %s", u.ID, u.Email, u.Role))
+			})
+		},
+	}
+}
+
+// findUser loads the user with the email address
+func findUser(ctx context.Context, c *services.Container, email string) (*ent.User, error) {
+	u, err := c.ORM.User.
+		Query().
+		Where(user.EmailEqualFold(email)).
+		Only(ctx)
+	if ent.IsNotFound(err) {
+		return nil, fmt.Errorf("no user with the email %s", email)
+	}
+	return u, err
+}
+
+// readPassword reads the password from the first line of stdin
+func readPassword() (string, error) {
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("could not read the password from stdin: %w", err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
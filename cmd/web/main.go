@@ -7,8 +7,10 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"syscall"
 	"time"
 
+	"github.com/vovanwin/api-my-site/pkg/logging"
 	"github.com/vovanwin/api-my-site/pkg/routes"
 	"github.com/vovanwin/api-my-site/pkg/services"
 )
@@ -18,13 +20,18 @@ func main() {
 	c := services.NewContainer()
 	defer func() {
 		if err := c.Shutdown(); err != nil {
-			c.Web.Logger.Fatal(err)
+			logging.Fatal(context.Background(), err)
 		}
 	}()
 
 	// Build the router
 	routes.BuildRouter(c)
 
+	// Reload the settings when the config files change or on SIGHUP
+	if err := c.Settings.Watch(); err != nil {
+		logging.Fatalf(context.Background(), "cannot watch the config files: %v", err)
+	}
+
 	// Start the server
 	go func() {
 		srv := http.Server{
@@ -38,7 +45,7 @@ func main() {
 		if c.Config.HTTP.TLS.Enabled {
 			certs, err := tls.LoadX509KeyPair(c.Config.HTTP.TLS.Certificate, c.Config.HTTP.TLS.Key)
 			if err != nil {
-				c.Web.Logger.Fatalf("cannot load TLS certificate: %v", err)
+				logging.Fatalf(context.Background(), "cannot load TLS certificate: %v", err)
 			}
 
 			srv.TLSConfig = &tls.Config{
@@ -47,25 +54,40 @@ func main() {
 		}
 
 		if err := c.Web.StartServer(&srv); err != http.ErrServerClosed {
-			c.Web.Logger.Fatalf("shutting down the server: %v", err)
+			logging.Fatalf(context.Background(), "shutting down the server: %v", err)
 		}
 	}()
 
+	// Start the metrics server, if the metrics are served on a separate port
+	if c.Config.Metrics.Enabled && c.Config.Metrics.Port != 0 {
+		go func() {
+			addr := fmt.Sprintf("%s:%d", c.Config.HTTP.Hostname, c.Config.Metrics.Port)
+			if err := http.ListenAndServe(addr, c.Metrics.ProtectedHandler(c.Config.Metrics.Token)); err != nil {
+				logging.Fatalf(context.Background(), "metrics server shutdown: %v", err)
+			}
+		}()
+	}
+
 	// Start the scheduler service to queue periodic tasks
 	go func() {
 		if err := c.Tasks.StartScheduler(); err != nil {
-			c.Web.Logger.Fatalf("scheduler shutdown: %v", err)
+			logging.Fatalf(context.Background(), "scheduler shutdown: %v", err)
 		}
 	}()
 
-	// Wait for interrupt signal to gracefully shutdown the server with a timeout of 10 seconds.
+	// Wait for an interrupt or termination signal to gracefully shutdown the server with a timeout of 10 seconds.
+	// Orchestrators stop containers with SIGTERM; SIGKILL can't be caught.
 	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, os.Interrupt)
-	signal.Notify(quit, os.Kill)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
 	<-quit
+
+	// Fail readiness straight away and give the orchestrator time to stop routing traffic here
+	c.Health.Drain()
+	time.Sleep(c.Config.Health.DrainDelay)
+
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 	if err := c.Web.Shutdown(ctx); err != nil {
-		c.Web.Logger.Fatal(err)
+		logging.Fatal(context.Background(), err)
 	}
 }
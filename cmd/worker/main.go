@@ -1,27 +1,36 @@
 package main
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"net/http"
 
 	"github.com/hibiken/asynq"
-	"github.com/vovanwin/api-my-site/config"
+	"github.com/vovanwin/api-my-site/pkg/logging"
+	"github.com/vovanwin/api-my-site/pkg/services"
 	"github.com/vovanwin/api-my-site/pkg/tasks"
 )
 
 func main() {
-	// Load the configuration
-	cfg, err := config.GetConfig()
-	if err != nil {
-		panic(fmt.Sprintf("failed to load config: %v", err))
+	// Start a new container
+	c := services.NewContainer()
+	defer func() {
+		if err := c.Shutdown(); err != nil {
+			logging.Fatal(context.Background(), err)
+		}
+	}()
+
+	// Reload the settings when the config files change or on SIGHUP
+	if err := c.Settings.Watch(); err != nil {
+		logging.Fatalf(context.Background(), "could not watch the config files: %v", err)
 	}
 
 	// Build the worker server
 	srv := asynq.NewServer(
 		asynq.RedisClientOpt{
-			Addr:     fmt.Sprintf("%s:%d", cfg.Cache.Hostname, cfg.Cache.Port),
-			DB:       cfg.Cache.Database,
-			Password: cfg.Cache.Password,
+			Addr:     fmt.Sprintf("%s:%d", c.Config.Cache.Hostname, c.Config.Cache.Port),
+			DB:       c.Config.Cache.Database,
+			Password: c.Config.Cache.Password,
 		},
 		asynq.Config{
 			// See asynq.Config for all available options and explanation
@@ -36,10 +45,42 @@ func main() {
 
 	// Map task types to the handlers
 	mux := asynq.NewServeMux()
+	mux.Use(tasks.Metrics(c.Metrics), tasks.Tracing(c.Tracing))
 	mux.Handle(tasks.TypeExample, new(tasks.ExampleProcessor))
+	mux.Handle(tasks.TypeMediaVariants, &tasks.MediaVariantsProcessor{Media: c.Media})
+	mux.Handle(tasks.TypeContactForward, &tasks.ContactForwardProcessor{Contact: c.Contact})
+	mux.Handle(tasks.TypeUserDelete, &tasks.UserDeleteProcessor{ORM: c.ORM, Media: c.Media})
+	mux.Handle(tasks.TypeAuditPrune, &tasks.AuditPruneProcessor{Audit: c.Audit, Retention: c.Config.Audit.Retention})
+	mux.Handle(tasks.TypeHeartbeat, &tasks.HeartbeatProcessor{Health: c.Health})
+
+	// Schedule the periodic tasks
+	if err := c.Tasks.New(tasks.TypeAuditPrune).Periodic("@daily").Save(); err != nil {
+		logging.Fatalf(context.Background(), "could not schedule audit prune task: %v", err)
+	}
+	heartbeat := c.Tasks.New(tasks.TypeHeartbeat).
+		Periodic(fmt.Sprintf("@every %s", c.Config.Health.HeartbeatInterval)).
+		Timeout(c.Config.Health.HeartbeatInterval)
+	if err := heartbeat.Save(); err != nil {
+		logging.Fatalf(context.Background(), "could not schedule heartbeat task: %v", err)
+	}
+	go func() {
+		if err := c.Tasks.StartScheduler(); err != nil {
+			logging.Fatalf(context.Background(), "could not run task scheduler: %v", err)
+		}
+	}()
+
+	// Start the metrics server
+	if c.Config.Metrics.Enabled {
+		go func() {
+			addr := fmt.Sprintf("%s:%d", c.Config.HTTP.Hostname, c.Config.Metrics.WorkerPort)
+			if err := http.ListenAndServe(addr, c.Metrics.ProtectedHandler(c.Config.Metrics.Token)); err != nil {
+				logging.Fatalf(context.Background(), "could not run metrics server: %v", err)
+			}
+		}()
+	}
 
 	// Start the worker server
 	if err := srv.Run(mux); err != nil {
-		log.Fatalf("could not run worker server: %v", err)
+		logging.Fatalf(context.Background(), "could not run worker server: %v", err)
 	}
 }
@@ -1,7 +1,9 @@
 package config
 
 import (
+	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -22,6 +24,12 @@ const (
 	StaticPrefix = "files"
 )
 
+// envPrefix stores the prefix of the environment variables overriding the configuration
+const envPrefix = "pagoda"
+
+// configPaths contains the directories the config files are looked up in
+var configPaths = []string{".", "config", "../config", "../../config"}
+
 type environment string
 
 const (
@@ -53,6 +61,8 @@ func SwitchEnvironment(env environment) {
 	}
 }
 
+// Fields tagged with reload:"true" are reloadable, so changes to their values take effect while the application
+// is running, see Reload. Changes to the other fields require a restart
 type (
 	// Config stores complete configuration
 	Config struct {
@@ -61,92 +71,240 @@ type (
 		Cache    CacheConfig
 		Database DatabaseConfig
 		Mail     MailConfig
+		Storage  StorageConfig
+		Feed     FeedConfig
+		Contact  ContactConfig
+		Audit    AuditConfig
+		Metrics  MetricsConfig
+		Tracing  TracingConfig
+		Health   HealthConfig
+		Logging  LoggingConfig
+		Features map[string]bool `reload:"true"`
 	}
 
 	// HTTPConfig stores HTTP configuration
 	HTTPConfig struct {
 		Hostname     string
-		Port         uint16
-		ReadTimeout  time.Duration
-		WriteTimeout time.Duration
-		IdleTimeout  time.Duration
-		TLS          struct {
+		Port         uint16        `validate:"required"`
+		ReadTimeout  time.Duration `validate:"gt=0"`
+		WriteTimeout time.Duration `validate:"gt=0"`
+		IdleTimeout  time.Duration `validate:"gt=0"`
+		BodyLimit    int64         `validate:"gt=0"`
+		// TrustedProxies lists the CIDR ranges of the proxies whose X-Forwarded-For header is trusted
+		// When empty, the client IP is taken from the connection
+		TrustedProxies []string `validate:"dive,cidr"`
+		TLS            struct {
 			Enabled     bool
-			Certificate string
-			Key         string
+			Certificate string `validate:"required_if=Enabled true"`
+			Key         string `validate:"required_if=Enabled true"`
 		}
 	}
 
 	// AppConfig stores application configuration
 	AppConfig struct {
-		Name          string
-		Environment   environment
-		EncryptionKey string
-		Timeout       time.Duration
+		Name          string        `validate:"required"`
+		URL           string        `validate:"required,url"`
+		Environment   environment   `validate:"required"`
+		EncryptionKey string        `validate:"required,min=32" secret:"true"`
+		Timeout       time.Duration `validate:"gt=0"`
 		PasswordToken struct {
-			Expiration time.Duration
-			Length     int
+			Expiration time.Duration `validate:"gt=0"`
+			Length     int           `validate:"gt=0"`
 		}
-		EmailVerificationTokenExpiration time.Duration
+		EmailVerificationTokenExpiration time.Duration `validate:"gt=0"`
+		AccountDeletionGracePeriod       time.Duration `validate:"gte=0"`
+		ImpersonationTokenExpiration     time.Duration `validate:"gt=0"`
 	}
 
 	// CacheConfig stores the cache configuration
 	CacheConfig struct {
-		Hostname     string
-		Port         uint16
-		Password     string
+		Hostname     string `validate:"required"`
+		Port         uint16 `validate:"required"`
+		Password     string `secret:"true"`
 		Database     int
 		TestDatabase int
 		Expiration   struct {
-			StaticFile time.Duration
-			Page       time.Duration
-		}
+			StaticFile time.Duration `validate:"gt=0"`
+			Page       time.Duration `validate:"gt=0"`
+		} `reload:"true"`
 	}
 
 	// DatabaseConfig stores the database configuration
 	DatabaseConfig struct {
-		Hostname     string
-		Port         uint16
-		User         string
-		Password     string
-		Database     string
-		TestDatabase string
+		Hostname     string `validate:"required"`
+		Port         uint16 `validate:"required"`
+		User         string `validate:"required"`
+		Password     string `secret:"true"`
+		Database     string `validate:"required"`
+		TestDatabase string `validate:"required"`
+		Migrate      bool
 	}
 
 	// MailConfig stores the mail configuration
 	MailConfig struct {
-		Hostname    string
-		Port        uint16
+		Hostname    string `validate:"required"`
+		Port        uint16 `validate:"required"`
 		User        string
-		Password    string
-		FromAddress string
+		Password    string `secret:"true"`
+		FromAddress string `validate:"required"`
+	}
+
+	// StorageConfig stores the media storage configuration
+	StorageConfig struct {
+		Driver              string        `validate:"oneof=local s3"`
+		MaxUploadSize       int64         `validate:"gt=0"`
+		UserQuota           int64         `validate:"gte=0"`
+		MaxImagePixels      int64         `validate:"gt=0"`
+		SignedURLExpiration time.Duration `validate:"gt=0"`
+		Local               struct {
+			Directory string
+		}
+		S3 struct {
+			Endpoint  string
+			Region    string
+			Bucket    string
+			AccessKey string `secret:"true"`
+			SecretKey string `secret:"true"`
+			UseSSL    bool
+		}
+	}
+
+	// FeedConfig stores the syndication feed configuration
+	FeedConfig struct {
+		Description string
+		Language    string
+		Limit       int `validate:"gt=0"`
+	}
+
+	// ContactConfig stores the contact form configuration
+	ContactConfig struct {
+		Recipient       string        `validate:"required"`
+		MinSubmitTime   time.Duration `validate:"gte=0"`
+		TokenExpiration time.Duration `validate:"gt=0"`
+		RateLimit       struct {
+			Requests int           `validate:"gt=0"`
+			Window   time.Duration `validate:"gt=0"`
+		} `reload:"true"`
+	}
+
+	// MetricsConfig stores the Prometheus metrics configuration
+	MetricsConfig struct {
+		Enabled    bool
+		Port       uint16
+		WorkerPort uint16 `validate:"required"`
+		Token      string `secret:"true"`
+	}
+
+	// TracingConfig stores the OpenTelemetry tracing configuration
+	TracingConfig struct {
+		Enabled     bool
+		Exporter    string `validate:"oneof=otlp stdout"`
+		Endpoint    string
+		Insecure    bool
+		SampleRatio float64 `validate:"gte=0,lte=1"`
+	}
+
+	// AuditConfig stores the audit log configuration
+	AuditConfig struct {
+		Retention time.Duration `validate:"gte=0"`
+	}
+
+	// LoggingConfig stores the logging configuration
+	LoggingConfig struct {
+		Level     string `validate:"oneof=trace debug info warn error" reload:"true"`
+		Redaction struct {
+			Keys        []string
+			PartialKeys []string
+		} `reload:"true"`
+	}
+
+	// HealthConfig stores the health check configuration
+	HealthConfig struct {
+		Timeout           time.Duration `validate:"gt=0"`
+		HeartbeatInterval time.Duration `validate:"gt=0"`
+		HeartbeatMaxAge   time.Duration `validate:"gtfield=HeartbeatInterval"`
+		DrainDelay        time.Duration `validate:"gte=0"`
 	}
 )
 
 // GetConfig loads and returns configuration
+// The base config.yaml is overlaid by the config file of the environment, such as config.prod.yaml, if there is
+// one, which in turn is overridden by PAGODA_ environment variables. A variable with the _FILE suffix, such as
+// PAGODA_APP_ENCRYPTIONKEY_FILE, reads the value from the file at the given path, as Docker and Kubernetes
+// secrets are provided. The configuration is validated, and in production insecure defaults are rejected
 func GetConfig() (Config, error) {
 	var c Config
 
-	// Load the config file
-	viper.SetConfigName("config")
-	viper.SetConfigType("yaml")
-	viper.AddConfigPath(".")
-	viper.AddConfigPath("config")
-	viper.AddConfigPath("../config")
-	viper.AddConfigPath("../../config")
-
-	// Load env variables
-	viper.SetEnvPrefix("pagoda")
-	viper.AutomaticEnv()
-	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v, _, err := load()
+	if err != nil {
+		return c, err
+	}
 
-	if err := viper.ReadInConfig(); err != nil {
+	if err := v.Unmarshal(&c); err != nil {
 		return c, err
 	}
 
-	if err := viper.Unmarshal(&c); err != nil {
+	if err := c.Validate(); err != nil {
 		return c, err
 	}
 
+	if c.App.Environment == EnvProduction {
+		if err := checkInsecureDefaults(v); err != nil {
+			return c, err
+		}
+	}
+
 	return c, nil
 }
+
+// load reads the configuration files and environment variables, and returns the paths of the files read
+func load() (*viper.Viper, []string, error) {
+	v := viper.New()
+
+	// Load the config file
+	v.SetConfigName("config")
+	v.SetConfigType("yaml")
+	for _, path := range configPaths {
+		v.AddConfigPath(path)
+	}
+
+	// Load env variables
+	v.SetEnvPrefix(envPrefix)
+	v.AutomaticEnv()
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+
+	if err := v.ReadInConfig(); err != nil {
+		return nil, nil, err
+	}
+	files := []string{v.ConfigFileUsed()}
+
+	// Overlay the config file of the environment
+	overlay := filepath.Join(
+		filepath.Dir(v.ConfigFileUsed()),
+		fmt.Sprintf("config.%s.yaml", v.GetString("app.environment")),
+	)
+	if _, err := os.Stat(overlay); err == nil {
+		v.SetConfigFile(overlay)
+		if err := v.MergeInConfig(); err != nil {
+			return nil, nil, fmt.Errorf("failed to load %s: %w", overlay, err)
+		}
+		files = append(files, overlay)
+	}
+
+	// Read values from the files named by _FILE env variables
+	for _, key := range v.AllKeys() {
+		name := fmt.Sprintf("%s_%s_FILE", strings.ToUpper(envPrefix), strings.ToUpper(strings.ReplaceAll(key, ".", "_")))
+		path, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+
+		value, err := os.ReadFile(path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		v.Set(key, strings.TrimRight(string(value), "\r\n"))
+	}
+
+	return v, files, nil
+}
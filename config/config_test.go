@@ -1,7 +1,13 @@
 package config
 
 import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -18,3 +24,143 @@ func TestGetConfig(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, env, cfg.App.Environment)
 }
+
+func TestGetConfig_Production(t *testing.T) {
+	t.Setenv("PAGODA_APP_ENVIRONMENT", string(EnvProduction))
+	t.Setenv("PAGODA_METRICS_TOKEN", "metrics-secret")
+	t.Setenv("PAGODA_APP_URL", "https://example.com")
+
+	// The secrets are left at their defaults
+	_, err := GetConfig()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "app.encryptionKey")
+	assert.Contains(t, err.Error(), "database.password")
+
+	key := strings.Repeat("k", 40)
+	path := filepath.Join(t.TempDir(), "encryption-key")
+	require.NoError(t, os.WriteFile(path, []byte(key+"\n"), 0600))
+	t.Setenv("PAGODA_APP_ENCRYPTIONKEY_FILE", path)
+	t.Setenv("PAGODA_DATABASE_PASSWORD", "db-secret")
+	t.Setenv("PAGODA_MAIL_PASSWORD", "mail-secret")
+
+	cfg, err := GetConfig()
+	require.NoError(t, err)
+	assert.Equal(t, key, cfg.App.EncryptionKey)
+	assert.Equal(t, "db-secret", cfg.Database.Password)
+
+	// The production overlay is applied over the base config
+	assert.Equal(t, "otlp", cfg.Tracing.Exporter)
+	assert.Equal(t, "localhost:4318", cfg.Tracing.Endpoint)
+}
+
+func TestConfig_Validate(t *testing.T) {
+	cfg, err := GetConfig()
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+
+	cfg.App.EncryptionKey = "short"
+	cfg.HTTP.Port = 0
+	cfg.App.Timeout = -time.Second
+	cfg.Health.HeartbeatMaxAge = cfg.Health.HeartbeatInterval
+	cfg.Storage.Driver = "s3"
+	cfg.Storage.S3.Bucket = ""
+	cfg.HTTP.TrustedProxies = []string{"10.0.0.1"}
+
+	err = cfg.Validate()
+	require.Error(t, err)
+	for _, field := range []string{"App.EncryptionKey", "HTTP.Port", "App.Timeout", "Health.HeartbeatMaxAge", "Storage.S3.Bucket", "HTTP.TrustedProxies[0]"} {
+		assert.Contains(t, err.Error(), field)
+	}
+}
+
+func TestConfig_ValidateProduction(t *testing.T) {
+	cfg, err := GetConfig()
+	require.NoError(t, err)
+	cfg.App.Environment = EnvProduction
+	cfg.App.URL = "https://example.com"
+	cfg.Metrics.Enabled = true
+	cfg.Metrics.Port = 0
+	cfg.Metrics.Token = ""
+
+	// The metrics would be public
+	err = cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Metrics.Token")
+
+	cfg.Metrics.Port = 9100
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Metrics.Port = 0
+	cfg.Metrics.Token = "secret"
+	assert.NoError(t, cfg.Validate())
+
+	// Links in emails would point to localhost
+	for _, u := range []string{"http://localhost:8000", "http://127.0.0.1", "http://[::1]:8000", "http://app.localhost"} {
+		cfg.App.URL = u
+		err = cfg.Validate()
+		require.Error(t, err, u)
+		assert.Contains(t, err.Error(), "App.URL")
+	}
+	cfg.App.URL = "https://example.com"
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestSecretKeys(t *testing.T) {
+	keys := secretKeys(reflect.TypeOf(Config{}), "")
+	for _, key := range []string{"app.encryptionKey", "database.password", "mail.password", "storage.s3.secretKey", "metrics.token"} {
+		assert.Contains(t, keys, key)
+	}
+	assert.NotContains(t, keys, "app.url")
+}
+
+func TestPrint(t *testing.T) {
+	cfg, err := GetConfig()
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, Print(&buf, cfg))
+	out := buf.String()
+
+	assert.NotContains(t, out, cfg.App.EncryptionKey)
+	assert.Contains(t, out, "encryptionKey: '********'")
+	assert.Contains(t, out, "readTimeout: 5s")
+	assert.Contains(t, out, "http:\n")
+
+	masked := Masked(cfg)
+	assert.Equal(t, "********", masked["app"].(map[string]interface{})["encryptionKey"])
+}
+
+func TestReload(t *testing.T) {
+	cfg, err := GetConfig()
+	require.NoError(t, err)
+
+	t.Setenv("PAGODA_LOGGING_LEVEL", "debug")
+	t.Setenv("PAGODA_CONTACT_RATELIMIT_REQUESTS", "10")
+	t.Setenv("PAGODA_HTTP_PORT", "9000")
+
+	next, changed, restart, err := Reload(cfg)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"contact.rateLimit", "logging.level"}, changed)
+	assert.Equal(t, []string{"http.port"}, restart)
+	assert.Equal(t, "debug", next.Logging.Level)
+	assert.Equal(t, 10, next.Contact.RateLimit.Requests)
+	assert.Equal(t, cfg.HTTP.Port, next.HTTP.Port)
+
+	// Invalid settings are not applied
+	t.Setenv("PAGODA_LOGGING_LEVEL", "loud")
+	next, _, _, err = Reload(cfg)
+	require.Error(t, err)
+	assert.Equal(t, cfg, next)
+}
+
+func TestReloadable(t *testing.T) {
+	cfg, err := GetConfig()
+	require.NoError(t, err)
+
+	values := Reloadable(cfg)
+	assert.Equal(t, "info", values["logging.level"])
+	assert.Equal(t, map[string]interface{}{"requests": 5, "window": "1h0m0s"}, values["contact.rateLimit"])
+	assert.Contains(t, values, "cache.expiration")
+	assert.Contains(t, values, "logging.redaction")
+	assert.NotContains(t, values, "http.port")
+}
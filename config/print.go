@@ -0,0 +1,66 @@
+package config
+
+import (
+	"io"
+	"reflect"
+	"time"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// masked replaces the values of the secrets when the configuration is printed
+const masked = "********"
+
+// Print writes the configuration as YAML, with the values of the fields tagged as secret masked
+func Print(w io.Writer, c Config) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(Masked(c)); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// Masked returns the configuration keyed the way it's written in config.yaml, with the values of the fields
+// tagged as secret masked
+func Masked(c Config) map[string]interface{} {
+	return printable(reflect.ValueOf(c)).(map[string]interface{})
+}
+
+// printable converts a configuration value into one which is printed the way it's written in config.yaml
+func printable(v reflect.Value) interface{} {
+	switch {
+	case v.Type() == reflect.TypeOf(time.Duration(0)):
+		return time.Duration(v.Int()).String()
+	case v.Kind() == reflect.Struct:
+		fields := make(map[string]interface{}, v.NumField())
+		for i := 0; i < v.NumField(); i++ {
+			f := v.Type().Field(i)
+			if f.Tag.Get("secret") == "true" && !v.Field(i).IsZero() {
+				fields[configKey(f.Name)] = masked
+			} else {
+				fields[configKey(f.Name)] = printable(v.Field(i))
+			}
+		}
+		return fields
+	default:
+		return v.Interface()
+	}
+}
+
+// configKey converts a field name into its key in config.yaml, such as HTTP into http and
+// EncryptionKey into encryptionKey
+func configKey(name string) string {
+	r := []rune(name)
+	for i := range r {
+		if !unicode.IsUpper(r[i]) {
+			break
+		}
+		if i > 0 && i+1 < len(r) && unicode.IsLower(r[i+1]) {
+			break
+		}
+		r[i] = unicode.ToLower(r[i])
+	}
+	return string(r)
+}
@@ -0,0 +1,66 @@
+package config
+
+import "reflect"
+
+// Reload loads the configuration again and returns a copy of the current configuration with the values of the
+// reloadable fields replaced by the loaded ones. It also returns the keys of the reloadable values which changed,
+// and the keys of the other values which changed, since those only take effect after a restart.
+// The current configuration is returned unchanged if the loaded one is invalid
+func Reload(current Config) (Config, []string, []string, error) {
+	next, err := GetConfig()
+	if err != nil {
+		return current, nil, nil, err
+	}
+
+	var changed, restart []string
+	merge(reflect.ValueOf(&current).Elem(), reflect.ValueOf(next), "", &changed, &restart)
+	return current, changed, restart, nil
+}
+
+// Reloadable returns the values of the reloadable fields by their keys, such as logging.level, printed the way
+// they're written in config.yaml
+func Reloadable(c Config) map[string]interface{} {
+	values := make(map[string]interface{})
+	collect(reflect.ValueOf(c), "", values)
+	return values
+}
+
+// Files returns the paths of the config files the configuration is loaded from
+func Files() ([]string, error) {
+	_, files, err := load()
+	return files, err
+}
+
+// merge copies the reloadable fields of src to dst, and records the keys of the fields which differ
+func merge(dst, src reflect.Value, prefix string, changed, restart *[]string) {
+	for i := 0; i < dst.NumField(); i++ {
+		f := dst.Type().Field(i)
+		key := prefix + configKey(f.Name)
+
+		switch {
+		case reflect.DeepEqual(dst.Field(i).Interface(), src.Field(i).Interface()):
+		case f.Tag.Get("reload") == "true":
+			dst.Field(i).Set(src.Field(i))
+			*changed = append(*changed, key)
+		case f.Type.Kind() == reflect.Struct:
+			merge(dst.Field(i), src.Field(i), key+".", changed, restart)
+		default:
+			*restart = append(*restart, key)
+		}
+	}
+}
+
+// collect adds the printable values of the reloadable fields to values
+func collect(v reflect.Value, prefix string, values map[string]interface{}) {
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Type().Field(i)
+		key := prefix + configKey(f.Name)
+
+		switch {
+		case f.Tag.Get("reload") == "true":
+			values[key] = printable(v.Field(i))
+		case f.Type.Kind() == reflect.Struct:
+			collect(v.Field(i), key+".", values)
+		}
+	}
+}
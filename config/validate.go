@@ -0,0 +1,126 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/spf13/viper"
+)
+
+// Validate checks that the required values are set and that all the values are within their ranges
+func (c *Config) Validate() error {
+	v := validator.New()
+	v.RegisterStructValidation(validateStorage, StorageConfig{})
+	v.RegisterStructValidation(validateProduction, Config{})
+
+	err := v.Struct(c)
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		return err
+	}
+
+	msgs := make([]string, len(fieldErrs))
+	for i, fe := range fieldErrs {
+		msgs[i] = fmt.Sprintf("%s fails %s", strings.TrimPrefix(fe.Namespace(), "Config."), fe.Tag())
+		if fe.Param() != "" {
+			msgs[i] += "=" + fe.Param()
+		}
+	}
+
+	return fmt.Errorf("invalid config: %s", strings.Join(msgs, "; "))
+}
+
+// validateStorage checks that the settings of the selected storage driver are set
+func validateStorage(sl validator.StructLevel) {
+	s := sl.Current().Interface().(StorageConfig)
+
+	switch s.Driver {
+	case "local":
+		if s.Local.Directory == "" {
+			sl.ReportError(s.Local.Directory, "Local.Directory", "Directory", "required_if", "Driver local")
+		}
+	case "s3":
+		if s.S3.Endpoint == "" {
+			sl.ReportError(s.S3.Endpoint, "S3.Endpoint", "Endpoint", "required_if", "Driver s3")
+		}
+		if s.S3.Bucket == "" {
+			sl.ReportError(s.S3.Bucket, "S3.Bucket", "Bucket", "required_if", "Driver s3")
+		}
+	}
+}
+
+// validateProduction checks the settings which are only required in production
+func validateProduction(sl validator.StructLevel) {
+	c := sl.Current().Interface().(Config)
+	if c.App.Environment != EnvProduction {
+		return
+	}
+
+	// Links in emails, such as password resets, are built from the URL
+	if u, err := url.Parse(c.App.URL); err == nil && isLocalhost(u.Hostname()) {
+		sl.ReportError(c.App.URL, "App.URL", "URL", "public_url", "")
+	}
+
+	// The metrics are either protected by the token or served on a separate port, which isn't exposed
+	if c.Metrics.Enabled && c.Metrics.Token == "" && c.Metrics.Port == 0 {
+		sl.ReportError(c.Metrics.Token, "Metrics.Token", "Token", "required_unless", "Metrics.Port")
+	}
+}
+
+// isLocalhost returns true if the host refers to the local machine
+func isLocalhost(host string) bool {
+	if strings.EqualFold(host, "localhost") || strings.HasSuffix(strings.ToLower(host), ".localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// secretKeys returns the keys of the fields tagged as secret
+func secretKeys(t reflect.Type, prefix string) []string {
+	var keys []string
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		key := prefix + configKey(f.Name)
+
+		switch {
+		case f.Tag.Get("secret") == "true":
+			keys = append(keys, key)
+		case f.Type.Kind() == reflect.Struct:
+			keys = append(keys, secretKeys(f.Type, key+".")...)
+		}
+	}
+	return keys
+}
+
+// checkInsecureDefaults fails if any of the secrets is left at its default from config.yaml, since those
+// are public
+func checkInsecureDefaults(v *viper.Viper) error {
+	defaults := viper.New()
+	defaults.SetConfigName("config")
+	defaults.SetConfigType("yaml")
+	for _, path := range configPaths {
+		defaults.AddConfigPath(path)
+	}
+	if err := defaults.ReadInConfig(); err != nil {
+		return err
+	}
+
+	var insecure []string
+	for _, key := range secretKeys(reflect.TypeOf(Config{}), "") {
+		if value := defaults.GetString(key); value != "" && v.GetString(key) == value {
+			insecure = append(insecure, key)
+		}
+	}
+
+	if len(insecure) > 0 {
+		return fmt.Errorf("insecure config: %s must be changed from the defaults in production", strings.Join(insecure, ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,715 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/vovanwin/api-my-site/ent/auditevent"
+	"github.com/vovanwin/api-my-site/ent/predicate"
+)
+
+// AuditEventUpdate is the builder for updating AuditEvent entities.
+type AuditEventUpdate struct {
+	config
+	hooks    []Hook
+	mutation *AuditEventMutation
+}
+
+// Where appends a list predicates to the AuditEventUpdate builder.
+func (aeu *AuditEventUpdate) Where(ps ...predicate.AuditEvent) *AuditEventUpdate {
+	aeu.mutation.Where(ps...)
+	return aeu
+}
+
+// SetActorID sets the "actor_id" field.
+func (aeu *AuditEventUpdate) SetActorID(i int) *AuditEventUpdate {
+	aeu.mutation.ResetActorID()
+	aeu.mutation.SetActorID(i)
+	return aeu
+}
+
+// SetNillableActorID sets the "actor_id" field if the given value is not nil.
+func (aeu *AuditEventUpdate) SetNillableActorID(i *int) *AuditEventUpdate {
+	if i != nil {
+		aeu.SetActorID(*i)
+	}
+	return aeu
+}
+
+// AddActorID adds i to the "actor_id" field.
+func (aeu *AuditEventUpdate) AddActorID(i int) *AuditEventUpdate {
+	aeu.mutation.AddActorID(i)
+	return aeu
+}
+
+// ClearActorID clears the value of the "actor_id" field.
+func (aeu *AuditEventUpdate) ClearActorID() *AuditEventUpdate {
+	aeu.mutation.ClearActorID()
+	return aeu
+}
+
+// SetImpersonatorID sets the "impersonator_id" field.
+func (aeu *AuditEventUpdate) SetImpersonatorID(i int) *AuditEventUpdate {
+	aeu.mutation.ResetImpersonatorID()
+	aeu.mutation.SetImpersonatorID(i)
+	return aeu
+}
+
+// SetNillableImpersonatorID sets the "impersonator_id" field if the given value is not nil.
+func (aeu *AuditEventUpdate) SetNillableImpersonatorID(i *int) *AuditEventUpdate {
+	if i != nil {
+		aeu.SetImpersonatorID(*i)
+	}
+	return aeu
+}
+
+// AddImpersonatorID adds i to the "impersonator_id" field.
+func (aeu *AuditEventUpdate) AddImpersonatorID(i int) *AuditEventUpdate {
+	aeu.mutation.AddImpersonatorID(i)
+	return aeu
+}
+
+// ClearImpersonatorID clears the value of the "impersonator_id" field.
+func (aeu *AuditEventUpdate) ClearImpersonatorID() *AuditEventUpdate {
+	aeu.mutation.ClearImpersonatorID()
+	return aeu
+}
+
+// SetAction sets the "action" field.
+func (aeu *AuditEventUpdate) SetAction(s string) *AuditEventUpdate {
+	aeu.mutation.SetAction(s)
+	return aeu
+}
+
+// SetTargetType sets the "target_type" field.
+func (aeu *AuditEventUpdate) SetTargetType(s string) *AuditEventUpdate {
+	aeu.mutation.SetTargetType(s)
+	return aeu
+}
+
+// SetNillableTargetType sets the "target_type" field if the given value is not nil.
+func (aeu *AuditEventUpdate) SetNillableTargetType(s *string) *AuditEventUpdate {
+	if s != nil {
+		aeu.SetTargetType(*s)
+	}
+	return aeu
+}
+
+// ClearTargetType clears the value of the "target_type" field.
+func (aeu *AuditEventUpdate) ClearTargetType() *AuditEventUpdate {
+	aeu.mutation.ClearTargetType()
+	return aeu
+}
+
+// SetTargetID sets the "target_id" field.
+func (aeu *AuditEventUpdate) SetTargetID(i int) *AuditEventUpdate {
+	aeu.mutation.ResetTargetID()
+	aeu.mutation.SetTargetID(i)
+	return aeu
+}
+
+// SetNillableTargetID sets the "target_id" field if the given value is not nil.
+func (aeu *AuditEventUpdate) SetNillableTargetID(i *int) *AuditEventUpdate {
+	if i != nil {
+		aeu.SetTargetID(*i)
+	}
+	return aeu
+}
+
+// AddTargetID adds i to the "target_id" field.
+func (aeu *AuditEventUpdate) AddTargetID(i int) *AuditEventUpdate {
+	aeu.mutation.AddTargetID(i)
+	return aeu
+}
+
+// ClearTargetID clears the value of the "target_id" field.
+func (aeu *AuditEventUpdate) ClearTargetID() *AuditEventUpdate {
+	aeu.mutation.ClearTargetID()
+	return aeu
+}
+
+// SetIP sets the "ip" field.
+func (aeu *AuditEventUpdate) SetIP(s string) *AuditEventUpdate {
+	aeu.mutation.SetIP(s)
+	return aeu
+}
+
+// SetNillableIP sets the "ip" field if the given value is not nil.
+func (aeu *AuditEventUpdate) SetNillableIP(s *string) *AuditEventUpdate {
+	if s != nil {
+		aeu.SetIP(*s)
+	}
+	return aeu
+}
+
+// ClearIP clears the value of the "ip" field.
+func (aeu *AuditEventUpdate) ClearIP() *AuditEventUpdate {
+	aeu.mutation.ClearIP()
+	return aeu
+}
+
+// SetUserAgent sets the "user_agent" field.
+func (aeu *AuditEventUpdate) SetUserAgent(s string) *AuditEventUpdate {
+	aeu.mutation.SetUserAgent(s)
+	return aeu
+}
+
+// SetNillableUserAgent sets the "user_agent" field if the given value is not nil.
+func (aeu *AuditEventUpdate) SetNillableUserAgent(s *string) *AuditEventUpdate {
+	if s != nil {
+		aeu.SetUserAgent(*s)
+	}
+	return aeu
+}
+
+// ClearUserAgent clears the value of the "user_agent" field.
+func (aeu *AuditEventUpdate) ClearUserAgent() *AuditEventUpdate {
+	aeu.mutation.ClearUserAgent()
+	return aeu
+}
+
+// SetRequestID sets the "request_id" field.
+func (aeu *AuditEventUpdate) SetRequestID(s string) *AuditEventUpdate {
+	aeu.mutation.SetRequestID(s)
+	return aeu
+}
+
+// SetNillableRequestID sets the "request_id" field if the given value is not nil.
+func (aeu *AuditEventUpdate) SetNillableRequestID(s *string) *AuditEventUpdate {
+	if s != nil {
+		aeu.SetRequestID(*s)
+	}
+	return aeu
+}
+
+// ClearRequestID clears the value of the "request_id" field.
+func (aeu *AuditEventUpdate) ClearRequestID() *AuditEventUpdate {
+	aeu.mutation.ClearRequestID()
+	return aeu
+}
+
+// SetBefore sets the "before" field.
+func (aeu *AuditEventUpdate) SetBefore(m map[string]interface{}) *AuditEventUpdate {
+	aeu.mutation.SetBefore(m)
+	return aeu
+}
+
+// ClearBefore clears the value of the "before" field.
+func (aeu *AuditEventUpdate) ClearBefore() *AuditEventUpdate {
+	aeu.mutation.ClearBefore()
+	return aeu
+}
+
+// SetAfter sets the "after" field.
+func (aeu *AuditEventUpdate) SetAfter(m map[string]interface{}) *AuditEventUpdate {
+	aeu.mutation.SetAfter(m)
+	return aeu
+}
+
+// ClearAfter clears the value of the "after" field.
+func (aeu *AuditEventUpdate) ClearAfter() *AuditEventUpdate {
+	aeu.mutation.ClearAfter()
+	return aeu
+}
+
+// Mutation returns the AuditEventMutation object of the builder.
+func (aeu *AuditEventUpdate) Mutation() *AuditEventMutation {
+	return aeu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (aeu *AuditEventUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks[int, AuditEventMutation](ctx, aeu.sqlSave, aeu.mutation, aeu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (aeu *AuditEventUpdate) SaveX(ctx context.Context) int {
+	affected, err := aeu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (aeu *AuditEventUpdate) Exec(ctx context.Context) error {
+	_, err := aeu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (aeu *AuditEventUpdate) ExecX(ctx context.Context) {
+	if err := aeu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (aeu *AuditEventUpdate) check() error {
+	if v, ok := aeu.mutation.Action(); ok {
+		if err := auditevent.ActionValidator(v); err != nil {
+			return &ValidationError{Name: "action", err: fmt.Errorf(`ent: validator failed for field "AuditEvent.action": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (aeu *AuditEventUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := aeu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(auditevent.Table, auditevent.Columns, sqlgraph.NewFieldSpec(auditevent.FieldID, field.TypeInt))
+	if ps := aeu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := aeu.mutation.ActorID(); ok {
+		_spec.SetField(auditevent.FieldActorID, field.TypeInt, value)
+	}
+	if value, ok := aeu.mutation.AddedActorID(); ok {
+		_spec.AddField(auditevent.FieldActorID, field.TypeInt, value)
+	}
+	if aeu.mutation.ActorIDCleared() {
+		_spec.ClearField(auditevent.FieldActorID, field.TypeInt)
+	}
+	if value, ok := aeu.mutation.ImpersonatorID(); ok {
+		_spec.SetField(auditevent.FieldImpersonatorID, field.TypeInt, value)
+	}
+	if value, ok := aeu.mutation.AddedImpersonatorID(); ok {
+		_spec.AddField(auditevent.FieldImpersonatorID, field.TypeInt, value)
+	}
+	if aeu.mutation.ImpersonatorIDCleared() {
+		_spec.ClearField(auditevent.FieldImpersonatorID, field.TypeInt)
+	}
+	if value, ok := aeu.mutation.Action(); ok {
+		_spec.SetField(auditevent.FieldAction, field.TypeString, value)
+	}
+	if value, ok := aeu.mutation.TargetType(); ok {
+		_spec.SetField(auditevent.FieldTargetType, field.TypeString, value)
+	}
+	if aeu.mutation.TargetTypeCleared() {
+		_spec.ClearField(auditevent.FieldTargetType, field.TypeString)
+	}
+	if value, ok := aeu.mutation.TargetID(); ok {
+		_spec.SetField(auditevent.FieldTargetID, field.TypeInt, value)
+	}
+	if value, ok := aeu.mutation.AddedTargetID(); ok {
+		_spec.AddField(auditevent.FieldTargetID, field.TypeInt, value)
+	}
+	if aeu.mutation.TargetIDCleared() {
+		_spec.ClearField(auditevent.FieldTargetID, field.TypeInt)
+	}
+	if value, ok := aeu.mutation.IP(); ok {
+		_spec.SetField(auditevent.FieldIP, field.TypeString, value)
+	}
+	if aeu.mutation.IPCleared() {
+		_spec.ClearField(auditevent.FieldIP, field.TypeString)
+	}
+	if value, ok := aeu.mutation.UserAgent(); ok {
+		_spec.SetField(auditevent.FieldUserAgent, field.TypeString, value)
+	}
+	if aeu.mutation.UserAgentCleared() {
+		_spec.ClearField(auditevent.FieldUserAgent, field.TypeString)
+	}
+	if value, ok := aeu.mutation.RequestID(); ok {
+		_spec.SetField(auditevent.FieldRequestID, field.TypeString, value)
+	}
+	if aeu.mutation.RequestIDCleared() {
+		_spec.ClearField(auditevent.FieldRequestID, field.TypeString)
+	}
+	if value, ok := aeu.mutation.Before(); ok {
+		_spec.SetField(auditevent.FieldBefore, field.TypeJSON, value)
+	}
+	if aeu.mutation.BeforeCleared() {
+		_spec.ClearField(auditevent.FieldBefore, field.TypeJSON)
+	}
+	if value, ok := aeu.mutation.After(); ok {
+		_spec.SetField(auditevent.FieldAfter, field.TypeJSON, value)
+	}
+	if aeu.mutation.AfterCleared() {
+		_spec.ClearField(auditevent.FieldAfter, field.TypeJSON)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, aeu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{auditevent.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	aeu.mutation.done = true
+	return n, nil
+}
+
+// AuditEventUpdateOne is the builder for updating a single AuditEvent entity.
+type AuditEventUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *AuditEventMutation
+}
+
+// SetActorID sets the "actor_id" field.
+func (aeuo *AuditEventUpdateOne) SetActorID(i int) *AuditEventUpdateOne {
+	aeuo.mutation.ResetActorID()
+	aeuo.mutation.SetActorID(i)
+	return aeuo
+}
+
+// SetNillableActorID sets the "actor_id" field if the given value is not nil.
+func (aeuo *AuditEventUpdateOne) SetNillableActorID(i *int) *AuditEventUpdateOne {
+	if i != nil {
+		aeuo.SetActorID(*i)
+	}
+	return aeuo
+}
+
+// AddActorID adds i to the "actor_id" field.
+func (aeuo *AuditEventUpdateOne) AddActorID(i int) *AuditEventUpdateOne {
+	aeuo.mutation.AddActorID(i)
+	return aeuo
+}
+
+// ClearActorID clears the value of the "actor_id" field.
+func (aeuo *AuditEventUpdateOne) ClearActorID() *AuditEventUpdateOne {
+	aeuo.mutation.ClearActorID()
+	return aeuo
+}
+
+// SetImpersonatorID sets the "impersonator_id" field.
+func (aeuo *AuditEventUpdateOne) SetImpersonatorID(i int) *AuditEventUpdateOne {
+	aeuo.mutation.ResetImpersonatorID()
+	aeuo.mutation.SetImpersonatorID(i)
+	return aeuo
+}
+
+// SetNillableImpersonatorID sets the "impersonator_id" field if the given value is not nil.
+func (aeuo *AuditEventUpdateOne) SetNillableImpersonatorID(i *int) *AuditEventUpdateOne {
+	if i != nil {
+		aeuo.SetImpersonatorID(*i)
+	}
+	return aeuo
+}
+
+// AddImpersonatorID adds i to the "impersonator_id" field.
+func (aeuo *AuditEventUpdateOne) AddImpersonatorID(i int) *AuditEventUpdateOne {
+	aeuo.mutation.AddImpersonatorID(i)
+	return aeuo
+}
+
+// ClearImpersonatorID clears the value of the "impersonator_id" field.
+func (aeuo *AuditEventUpdateOne) ClearImpersonatorID() *AuditEventUpdateOne {
+	aeuo.mutation.ClearImpersonatorID()
+	return aeuo
+}
+
+// SetAction sets the "action" field.
+func (aeuo *AuditEventUpdateOne) SetAction(s string) *AuditEventUpdateOne {
+	aeuo.mutation.SetAction(s)
+	return aeuo
+}
+
+// SetTargetType sets the "target_type" field.
+func (aeuo *AuditEventUpdateOne) SetTargetType(s string) *AuditEventUpdateOne {
+	aeuo.mutation.SetTargetType(s)
+	return aeuo
+}
+
+// SetNillableTargetType sets the "target_type" field if the given value is not nil.
+func (aeuo *AuditEventUpdateOne) SetNillableTargetType(s *string) *AuditEventUpdateOne {
+	if s != nil {
+		aeuo.SetTargetType(*s)
+	}
+	return aeuo
+}
+
+// ClearTargetType clears the value of the "target_type" field.
+func (aeuo *AuditEventUpdateOne) ClearTargetType() *AuditEventUpdateOne {
+	aeuo.mutation.ClearTargetType()
+	return aeuo
+}
+
+// SetTargetID sets the "target_id" field.
+func (aeuo *AuditEventUpdateOne) SetTargetID(i int) *AuditEventUpdateOne {
+	aeuo.mutation.ResetTargetID()
+	aeuo.mutation.SetTargetID(i)
+	return aeuo
+}
+
+// SetNillableTargetID sets the "target_id" field if the given value is not nil.
+func (aeuo *AuditEventUpdateOne) SetNillableTargetID(i *int) *AuditEventUpdateOne {
+	if i != nil {
+		aeuo.SetTargetID(*i)
+	}
+	return aeuo
+}
+
+// AddTargetID adds i to the "target_id" field.
+func (aeuo *AuditEventUpdateOne) AddTargetID(i int) *AuditEventUpdateOne {
+	aeuo.mutation.AddTargetID(i)
+	return aeuo
+}
+
+// ClearTargetID clears the value of the "target_id" field.
+func (aeuo *AuditEventUpdateOne) ClearTargetID() *AuditEventUpdateOne {
+	aeuo.mutation.ClearTargetID()
+	return aeuo
+}
+
+// SetIP sets the "ip" field.
+func (aeuo *AuditEventUpdateOne) SetIP(s string) *AuditEventUpdateOne {
+	aeuo.mutation.SetIP(s)
+	return aeuo
+}
+
+// SetNillableIP sets the "ip" field if the given value is not nil.
+func (aeuo *AuditEventUpdateOne) SetNillableIP(s *string) *AuditEventUpdateOne {
+	if s != nil {
+		aeuo.SetIP(*s)
+	}
+	return aeuo
+}
+
+// ClearIP clears the value of the "ip" field.
+func (aeuo *AuditEventUpdateOne) ClearIP() *AuditEventUpdateOne {
+	aeuo.mutation.ClearIP()
+	return aeuo
+}
+
+// SetUserAgent sets the "user_agent" field.
+func (aeuo *AuditEventUpdateOne) SetUserAgent(s string) *AuditEventUpdateOne {
+	aeuo.mutation.SetUserAgent(s)
+	return aeuo
+}
+
+// SetNillableUserAgent sets the "user_agent" field if the given value is not nil.
+func (aeuo *AuditEventUpdateOne) SetNillableUserAgent(s *string) *AuditEventUpdateOne {
+	if s != nil {
+		aeuo.SetUserAgent(*s)
+	}
+	return aeuo
+}
+
+// ClearUserAgent clears the value of the "user_agent" field.
+func (aeuo *AuditEventUpdateOne) ClearUserAgent() *AuditEventUpdateOne {
+	aeuo.mutation.ClearUserAgent()
+	return aeuo
+}
+
+// SetRequestID sets the "request_id" field.
+func (aeuo *AuditEventUpdateOne) SetRequestID(s string) *AuditEventUpdateOne {
+	aeuo.mutation.SetRequestID(s)
+	return aeuo
+}
+
+// SetNillableRequestID sets the "request_id" field if the given value is not nil.
+func (aeuo *AuditEventUpdateOne) SetNillableRequestID(s *string) *AuditEventUpdateOne {
+	if s != nil {
+		aeuo.SetRequestID(*s)
+	}
+	return aeuo
+}
+
+// ClearRequestID clears the value of the "request_id" field.
+func (aeuo *AuditEventUpdateOne) ClearRequestID() *AuditEventUpdateOne {
+	aeuo.mutation.ClearRequestID()
+	return aeuo
+}
+
+// SetBefore sets the "before" field.
+func (aeuo *AuditEventUpdateOne) SetBefore(m map[string]interface{}) *AuditEventUpdateOne {
+	aeuo.mutation.SetBefore(m)
+	return aeuo
+}
+
+// ClearBefore clears the value of the "before" field.
+func (aeuo *AuditEventUpdateOne) ClearBefore() *AuditEventUpdateOne {
+	aeuo.mutation.ClearBefore()
+	return aeuo
+}
+
+// SetAfter sets the "after" field.
+func (aeuo *AuditEventUpdateOne) SetAfter(m map[string]interface{}) *AuditEventUpdateOne {
+	aeuo.mutation.SetAfter(m)
+	return aeuo
+}
+
+// ClearAfter clears the value of the "after" field.
+func (aeuo *AuditEventUpdateOne) ClearAfter() *AuditEventUpdateOne {
+	aeuo.mutation.ClearAfter()
+	return aeuo
+}
+
+// Mutation returns the AuditEventMutation object of the builder.
+func (aeuo *AuditEventUpdateOne) Mutation() *AuditEventMutation {
+	return aeuo.mutation
+}
+
+// Where appends a list predicates to the AuditEventUpdate builder.
+func (aeuo *AuditEventUpdateOne) Where(ps ...predicate.AuditEvent) *AuditEventUpdateOne {
+	aeuo.mutation.Where(ps...)
+	return aeuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (aeuo *AuditEventUpdateOne) Select(field string, fields ...string) *AuditEventUpdateOne {
+	aeuo.fields = append([]string{field}, fields...)
+	return aeuo
+}
+
+// Save executes the query and returns the updated AuditEvent entity.
+func (aeuo *AuditEventUpdateOne) Save(ctx context.Context) (*AuditEvent, error) {
+	return withHooks[*AuditEvent, AuditEventMutation](ctx, aeuo.sqlSave, aeuo.mutation, aeuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (aeuo *AuditEventUpdateOne) SaveX(ctx context.Context) *AuditEvent {
+	node, err := aeuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (aeuo *AuditEventUpdateOne) Exec(ctx context.Context) error {
+	_, err := aeuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (aeuo *AuditEventUpdateOne) ExecX(ctx context.Context) {
+	if err := aeuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (aeuo *AuditEventUpdateOne) check() error {
+	if v, ok := aeuo.mutation.Action(); ok {
+		if err := auditevent.ActionValidator(v); err != nil {
+			return &ValidationError{Name: "action", err: fmt.Errorf(`ent: validator failed for field "AuditEvent.action": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (aeuo *AuditEventUpdateOne) sqlSave(ctx context.Context) (_node *AuditEvent, err error) {
+	if err := aeuo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(auditevent.Table, auditevent.Columns, sqlgraph.NewFieldSpec(auditevent.FieldID, field.TypeInt))
+	id, ok := aeuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "AuditEvent.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := aeuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, auditevent.FieldID)
+		for _, f := range fields {
+			if !auditevent.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != auditevent.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := aeuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := aeuo.mutation.ActorID(); ok {
+		_spec.SetField(auditevent.FieldActorID, field.TypeInt, value)
+	}
+	if value, ok := aeuo.mutation.AddedActorID(); ok {
+		_spec.AddField(auditevent.FieldActorID, field.TypeInt, value)
+	}
+	if aeuo.mutation.ActorIDCleared() {
+		_spec.ClearField(auditevent.FieldActorID, field.TypeInt)
+	}
+	if value, ok := aeuo.mutation.ImpersonatorID(); ok {
+		_spec.SetField(auditevent.FieldImpersonatorID, field.TypeInt, value)
+	}
+	if value, ok := aeuo.mutation.AddedImpersonatorID(); ok {
+		_spec.AddField(auditevent.FieldImpersonatorID, field.TypeInt, value)
+	}
+	if aeuo.mutation.ImpersonatorIDCleared() {
+		_spec.ClearField(auditevent.FieldImpersonatorID, field.TypeInt)
+	}
+	if value, ok := aeuo.mutation.Action(); ok {
+		_spec.SetField(auditevent.FieldAction, field.TypeString, value)
+	}
+	if value, ok := aeuo.mutation.TargetType(); ok {
+		_spec.SetField(auditevent.FieldTargetType, field.TypeString, value)
+	}
+	if aeuo.mutation.TargetTypeCleared() {
+		_spec.ClearField(auditevent.FieldTargetType, field.TypeString)
+	}
+	if value, ok := aeuo.mutation.TargetID(); ok {
+		_spec.SetField(auditevent.FieldTargetID, field.TypeInt, value)
+	}
+	if value, ok := aeuo.mutation.AddedTargetID(); ok {
+		_spec.AddField(auditevent.FieldTargetID, field.TypeInt, value)
+	}
+	if aeuo.mutation.TargetIDCleared() {
+		_spec.ClearField(auditevent.FieldTargetID, field.TypeInt)
+	}
+	if value, ok := aeuo.mutation.IP(); ok {
+		_spec.SetField(auditevent.FieldIP, field.TypeString, value)
+	}
+	if aeuo.mutation.IPCleared() {
+		_spec.ClearField(auditevent.FieldIP, field.TypeString)
+	}
+	if value, ok := aeuo.mutation.UserAgent(); ok {
+		_spec.SetField(auditevent.FieldUserAgent, field.TypeString, value)
+	}
+	if aeuo.mutation.UserAgentCleared() {
+		_spec.ClearField(auditevent.FieldUserAgent, field.TypeString)
+	}
+	if value, ok := aeuo.mutation.RequestID(); ok {
+		_spec.SetField(auditevent.FieldRequestID, field.TypeString, value)
+	}
+	if aeuo.mutation.RequestIDCleared() {
+		_spec.ClearField(auditevent.FieldRequestID, field.TypeString)
+	}
+	if value, ok := aeuo.mutation.Before(); ok {
+		_spec.SetField(auditevent.FieldBefore, field.TypeJSON, value)
+	}
+	if aeuo.mutation.BeforeCleared() {
+		_spec.ClearField(auditevent.FieldBefore, field.TypeJSON)
+	}
+	if value, ok := aeuo.mutation.After(); ok {
+		_spec.SetField(auditevent.FieldAfter, field.TypeJSON, value)
+	}
+	if aeuo.mutation.AfterCleared() {
+		_spec.ClearField(auditevent.FieldAfter, field.TypeJSON)
+	}
+	_node = &AuditEvent{config: aeuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, aeuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{auditevent.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	aeuo.mutation.done = true
+	return _node, nil
+}
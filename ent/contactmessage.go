@@ -0,0 +1,200 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/vovanwin/api-my-site/ent/contactmessage"
+)
+
+// ContactMessage is the model entity for the ContactMessage schema.
+type ContactMessage struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// Name holds the value of the "name" field.
+	Name string `json:"name,omitempty"`
+	// Email holds the value of the "email" field.
+	Email string `json:"email,omitempty"`
+	// Subject holds the value of the "subject" field.
+	Subject string `json:"subject,omitempty"`
+	// Message holds the value of the "message" field.
+	Message string `json:"message,omitempty"`
+	// IP holds the value of the "ip" field.
+	IP string `json:"ip,omitempty"`
+	// UserAgent holds the value of the "user_agent" field.
+	UserAgent string `json:"user_agent,omitempty"`
+	// ForwardedAt holds the value of the "forwarded_at" field.
+	ForwardedAt *time.Time `json:"forwarded_at,omitempty"`
+	// HandledAt holds the value of the "handled_at" field.
+	HandledAt *time.Time `json:"handled_at,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt    time.Time `json:"created_at,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*ContactMessage) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case contactmessage.FieldID:
+			values[i] = new(sql.NullInt64)
+		case contactmessage.FieldName, contactmessage.FieldEmail, contactmessage.FieldSubject, contactmessage.FieldMessage, contactmessage.FieldIP, contactmessage.FieldUserAgent:
+			values[i] = new(sql.NullString)
+		case contactmessage.FieldForwardedAt, contactmessage.FieldHandledAt, contactmessage.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the ContactMessage fields.
+func (cm *ContactMessage) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case contactmessage.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			cm.ID = int(value.Int64)
+		case contactmessage.FieldName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field name", values[i])
+			} else if value.Valid {
+				cm.Name = value.String
+			}
+		case contactmessage.FieldEmail:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field email", values[i])
+			} else if value.Valid {
+				cm.Email = value.String
+			}
+		case contactmessage.FieldSubject:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field subject", values[i])
+			} else if value.Valid {
+				cm.Subject = value.String
+			}
+		case contactmessage.FieldMessage:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field message", values[i])
+			} else if value.Valid {
+				cm.Message = value.String
+			}
+		case contactmessage.FieldIP:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field ip", values[i])
+			} else if value.Valid {
+				cm.IP = value.String
+			}
+		case contactmessage.FieldUserAgent:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field user_agent", values[i])
+			} else if value.Valid {
+				cm.UserAgent = value.String
+			}
+		case contactmessage.FieldForwardedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field forwarded_at", values[i])
+			} else if value.Valid {
+				cm.ForwardedAt = new(time.Time)
+				*cm.ForwardedAt = value.Time
+			}
+		case contactmessage.FieldHandledAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field handled_at", values[i])
+			} else if value.Valid {
+				cm.HandledAt = new(time.Time)
+				*cm.HandledAt = value.Time
+			}
+		case contactmessage.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				cm.CreatedAt = value.Time
+			}
+		default:
+			cm.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the ContactMessage.
+// This includes values selected through modifiers, order, etc.
+func (cm *ContactMessage) Value(name string) (ent.Value, error) {
+	return cm.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this ContactMessage.
+// Note that you need to call ContactMessage.Unwrap() before calling this method if this ContactMessage
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (cm *ContactMessage) Update() *ContactMessageUpdateOne {
+	return NewContactMessageClient(cm.config).UpdateOne(cm)
+}
+
+// Unwrap unwraps the ContactMessage entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (cm *ContactMessage) Unwrap() *ContactMessage {
+	_tx, ok := cm.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: ContactMessage is not a transactional entity")
+	}
+	cm.config.driver = _tx.drv
+	return cm
+}
+
+// String implements the fmt.Stringer.
+func (cm *ContactMessage) String() string {
+	var builder strings.Builder
+	builder.WriteString("ContactMessage(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", cm.ID))
+	builder.WriteString("name=")
+	builder.WriteString(cm.Name)
+	builder.WriteString(", ")
+	builder.WriteString("email=")
+	builder.WriteString(cm.Email)
+	builder.WriteString(", ")
+	builder.WriteString("subject=")
+	builder.WriteString(cm.Subject)
+	builder.WriteString(", ")
+	builder.WriteString("message=")
+	builder.WriteString(cm.Message)
+	builder.WriteString(", ")
+	builder.WriteString("ip=")
+	builder.WriteString(cm.IP)
+	builder.WriteString(", ")
+	builder.WriteString("user_agent=")
+	builder.WriteString(cm.UserAgent)
+	builder.WriteString(", ")
+	if v := cm.ForwardedAt; v != nil {
+		builder.WriteString("forwarded_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	if v := cm.HandledAt; v != nil {
+		builder.WriteString("handled_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(cm.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// ContactMessages is a parsable slice of ContactMessage.
+type ContactMessages []*ContactMessage
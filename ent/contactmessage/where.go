@@ -0,0 +1,692 @@
+// Code generated by ent, DO NOT EDIT.
+
+package contactmessage
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/vovanwin/api-my-site/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldLTE(FieldID, id))
+}
+
+// Name applies equality check predicate on the "name" field. It's identical to NameEQ.
+func Name(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldEQ(FieldName, v))
+}
+
+// Email applies equality check predicate on the "email" field. It's identical to EmailEQ.
+func Email(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldEQ(FieldEmail, v))
+}
+
+// Subject applies equality check predicate on the "subject" field. It's identical to SubjectEQ.
+func Subject(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldEQ(FieldSubject, v))
+}
+
+// Message applies equality check predicate on the "message" field. It's identical to MessageEQ.
+func Message(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldEQ(FieldMessage, v))
+}
+
+// IP applies equality check predicate on the "ip" field. It's identical to IPEQ.
+func IP(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldEQ(FieldIP, v))
+}
+
+// UserAgent applies equality check predicate on the "user_agent" field. It's identical to UserAgentEQ.
+func UserAgent(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldEQ(FieldUserAgent, v))
+}
+
+// ForwardedAt applies equality check predicate on the "forwarded_at" field. It's identical to ForwardedAtEQ.
+func ForwardedAt(v time.Time) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldEQ(FieldForwardedAt, v))
+}
+
+// HandledAt applies equality check predicate on the "handled_at" field. It's identical to HandledAtEQ.
+func HandledAt(v time.Time) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldEQ(FieldHandledAt, v))
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// NameEQ applies the EQ predicate on the "name" field.
+func NameEQ(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldEQ(FieldName, v))
+}
+
+// NameNEQ applies the NEQ predicate on the "name" field.
+func NameNEQ(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldNEQ(FieldName, v))
+}
+
+// NameIn applies the In predicate on the "name" field.
+func NameIn(vs ...string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldIn(FieldName, vs...))
+}
+
+// NameNotIn applies the NotIn predicate on the "name" field.
+func NameNotIn(vs ...string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldNotIn(FieldName, vs...))
+}
+
+// NameGT applies the GT predicate on the "name" field.
+func NameGT(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldGT(FieldName, v))
+}
+
+// NameGTE applies the GTE predicate on the "name" field.
+func NameGTE(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldGTE(FieldName, v))
+}
+
+// NameLT applies the LT predicate on the "name" field.
+func NameLT(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldLT(FieldName, v))
+}
+
+// NameLTE applies the LTE predicate on the "name" field.
+func NameLTE(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldLTE(FieldName, v))
+}
+
+// NameContains applies the Contains predicate on the "name" field.
+func NameContains(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldContains(FieldName, v))
+}
+
+// NameHasPrefix applies the HasPrefix predicate on the "name" field.
+func NameHasPrefix(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldHasPrefix(FieldName, v))
+}
+
+// NameHasSuffix applies the HasSuffix predicate on the "name" field.
+func NameHasSuffix(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldHasSuffix(FieldName, v))
+}
+
+// NameEqualFold applies the EqualFold predicate on the "name" field.
+func NameEqualFold(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldEqualFold(FieldName, v))
+}
+
+// NameContainsFold applies the ContainsFold predicate on the "name" field.
+func NameContainsFold(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldContainsFold(FieldName, v))
+}
+
+// EmailEQ applies the EQ predicate on the "email" field.
+func EmailEQ(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldEQ(FieldEmail, v))
+}
+
+// EmailNEQ applies the NEQ predicate on the "email" field.
+func EmailNEQ(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldNEQ(FieldEmail, v))
+}
+
+// EmailIn applies the In predicate on the "email" field.
+func EmailIn(vs ...string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldIn(FieldEmail, vs...))
+}
+
+// EmailNotIn applies the NotIn predicate on the "email" field.
+func EmailNotIn(vs ...string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldNotIn(FieldEmail, vs...))
+}
+
+// EmailGT applies the GT predicate on the "email" field.
+func EmailGT(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldGT(FieldEmail, v))
+}
+
+// EmailGTE applies the GTE predicate on the "email" field.
+func EmailGTE(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldGTE(FieldEmail, v))
+}
+
+// EmailLT applies the LT predicate on the "email" field.
+func EmailLT(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldLT(FieldEmail, v))
+}
+
+// EmailLTE applies the LTE predicate on the "email" field.
+func EmailLTE(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldLTE(FieldEmail, v))
+}
+
+// EmailContains applies the Contains predicate on the "email" field.
+func EmailContains(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldContains(FieldEmail, v))
+}
+
+// EmailHasPrefix applies the HasPrefix predicate on the "email" field.
+func EmailHasPrefix(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldHasPrefix(FieldEmail, v))
+}
+
+// EmailHasSuffix applies the HasSuffix predicate on the "email" field.
+func EmailHasSuffix(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldHasSuffix(FieldEmail, v))
+}
+
+// EmailEqualFold applies the EqualFold predicate on the "email" field.
+func EmailEqualFold(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldEqualFold(FieldEmail, v))
+}
+
+// EmailContainsFold applies the ContainsFold predicate on the "email" field.
+func EmailContainsFold(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldContainsFold(FieldEmail, v))
+}
+
+// SubjectEQ applies the EQ predicate on the "subject" field.
+func SubjectEQ(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldEQ(FieldSubject, v))
+}
+
+// SubjectNEQ applies the NEQ predicate on the "subject" field.
+func SubjectNEQ(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldNEQ(FieldSubject, v))
+}
+
+// SubjectIn applies the In predicate on the "subject" field.
+func SubjectIn(vs ...string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldIn(FieldSubject, vs...))
+}
+
+// SubjectNotIn applies the NotIn predicate on the "subject" field.
+func SubjectNotIn(vs ...string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldNotIn(FieldSubject, vs...))
+}
+
+// SubjectGT applies the GT predicate on the "subject" field.
+func SubjectGT(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldGT(FieldSubject, v))
+}
+
+// SubjectGTE applies the GTE predicate on the "subject" field.
+func SubjectGTE(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldGTE(FieldSubject, v))
+}
+
+// SubjectLT applies the LT predicate on the "subject" field.
+func SubjectLT(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldLT(FieldSubject, v))
+}
+
+// SubjectLTE applies the LTE predicate on the "subject" field.
+func SubjectLTE(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldLTE(FieldSubject, v))
+}
+
+// SubjectContains applies the Contains predicate on the "subject" field.
+func SubjectContains(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldContains(FieldSubject, v))
+}
+
+// SubjectHasPrefix applies the HasPrefix predicate on the "subject" field.
+func SubjectHasPrefix(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldHasPrefix(FieldSubject, v))
+}
+
+// SubjectHasSuffix applies the HasSuffix predicate on the "subject" field.
+func SubjectHasSuffix(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldHasSuffix(FieldSubject, v))
+}
+
+// SubjectIsNil applies the IsNil predicate on the "subject" field.
+func SubjectIsNil() predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldIsNull(FieldSubject))
+}
+
+// SubjectNotNil applies the NotNil predicate on the "subject" field.
+func SubjectNotNil() predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldNotNull(FieldSubject))
+}
+
+// SubjectEqualFold applies the EqualFold predicate on the "subject" field.
+func SubjectEqualFold(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldEqualFold(FieldSubject, v))
+}
+
+// SubjectContainsFold applies the ContainsFold predicate on the "subject" field.
+func SubjectContainsFold(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldContainsFold(FieldSubject, v))
+}
+
+// MessageEQ applies the EQ predicate on the "message" field.
+func MessageEQ(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldEQ(FieldMessage, v))
+}
+
+// MessageNEQ applies the NEQ predicate on the "message" field.
+func MessageNEQ(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldNEQ(FieldMessage, v))
+}
+
+// MessageIn applies the In predicate on the "message" field.
+func MessageIn(vs ...string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldIn(FieldMessage, vs...))
+}
+
+// MessageNotIn applies the NotIn predicate on the "message" field.
+func MessageNotIn(vs ...string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldNotIn(FieldMessage, vs...))
+}
+
+// MessageGT applies the GT predicate on the "message" field.
+func MessageGT(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldGT(FieldMessage, v))
+}
+
+// MessageGTE applies the GTE predicate on the "message" field.
+func MessageGTE(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldGTE(FieldMessage, v))
+}
+
+// MessageLT applies the LT predicate on the "message" field.
+func MessageLT(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldLT(FieldMessage, v))
+}
+
+// MessageLTE applies the LTE predicate on the "message" field.
+func MessageLTE(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldLTE(FieldMessage, v))
+}
+
+// MessageContains applies the Contains predicate on the "message" field.
+func MessageContains(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldContains(FieldMessage, v))
+}
+
+// MessageHasPrefix applies the HasPrefix predicate on the "message" field.
+func MessageHasPrefix(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldHasPrefix(FieldMessage, v))
+}
+
+// MessageHasSuffix applies the HasSuffix predicate on the "message" field.
+func MessageHasSuffix(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldHasSuffix(FieldMessage, v))
+}
+
+// MessageEqualFold applies the EqualFold predicate on the "message" field.
+func MessageEqualFold(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldEqualFold(FieldMessage, v))
+}
+
+// MessageContainsFold applies the ContainsFold predicate on the "message" field.
+func MessageContainsFold(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldContainsFold(FieldMessage, v))
+}
+
+// IPEQ applies the EQ predicate on the "ip" field.
+func IPEQ(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldEQ(FieldIP, v))
+}
+
+// IPNEQ applies the NEQ predicate on the "ip" field.
+func IPNEQ(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldNEQ(FieldIP, v))
+}
+
+// IPIn applies the In predicate on the "ip" field.
+func IPIn(vs ...string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldIn(FieldIP, vs...))
+}
+
+// IPNotIn applies the NotIn predicate on the "ip" field.
+func IPNotIn(vs ...string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldNotIn(FieldIP, vs...))
+}
+
+// IPGT applies the GT predicate on the "ip" field.
+func IPGT(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldGT(FieldIP, v))
+}
+
+// IPGTE applies the GTE predicate on the "ip" field.
+func IPGTE(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldGTE(FieldIP, v))
+}
+
+// IPLT applies the LT predicate on the "ip" field.
+func IPLT(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldLT(FieldIP, v))
+}
+
+// IPLTE applies the LTE predicate on the "ip" field.
+func IPLTE(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldLTE(FieldIP, v))
+}
+
+// IPContains applies the Contains predicate on the "ip" field.
+func IPContains(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldContains(FieldIP, v))
+}
+
+// IPHasPrefix applies the HasPrefix predicate on the "ip" field.
+func IPHasPrefix(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldHasPrefix(FieldIP, v))
+}
+
+// IPHasSuffix applies the HasSuffix predicate on the "ip" field.
+func IPHasSuffix(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldHasSuffix(FieldIP, v))
+}
+
+// IPIsNil applies the IsNil predicate on the "ip" field.
+func IPIsNil() predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldIsNull(FieldIP))
+}
+
+// IPNotNil applies the NotNil predicate on the "ip" field.
+func IPNotNil() predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldNotNull(FieldIP))
+}
+
+// IPEqualFold applies the EqualFold predicate on the "ip" field.
+func IPEqualFold(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldEqualFold(FieldIP, v))
+}
+
+// IPContainsFold applies the ContainsFold predicate on the "ip" field.
+func IPContainsFold(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldContainsFold(FieldIP, v))
+}
+
+// UserAgentEQ applies the EQ predicate on the "user_agent" field.
+func UserAgentEQ(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldEQ(FieldUserAgent, v))
+}
+
+// UserAgentNEQ applies the NEQ predicate on the "user_agent" field.
+func UserAgentNEQ(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldNEQ(FieldUserAgent, v))
+}
+
+// UserAgentIn applies the In predicate on the "user_agent" field.
+func UserAgentIn(vs ...string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldIn(FieldUserAgent, vs...))
+}
+
+// UserAgentNotIn applies the NotIn predicate on the "user_agent" field.
+func UserAgentNotIn(vs ...string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldNotIn(FieldUserAgent, vs...))
+}
+
+// UserAgentGT applies the GT predicate on the "user_agent" field.
+func UserAgentGT(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldGT(FieldUserAgent, v))
+}
+
+// UserAgentGTE applies the GTE predicate on the "user_agent" field.
+func UserAgentGTE(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldGTE(FieldUserAgent, v))
+}
+
+// UserAgentLT applies the LT predicate on the "user_agent" field.
+func UserAgentLT(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldLT(FieldUserAgent, v))
+}
+
+// UserAgentLTE applies the LTE predicate on the "user_agent" field.
+func UserAgentLTE(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldLTE(FieldUserAgent, v))
+}
+
+// UserAgentContains applies the Contains predicate on the "user_agent" field.
+func UserAgentContains(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldContains(FieldUserAgent, v))
+}
+
+// UserAgentHasPrefix applies the HasPrefix predicate on the "user_agent" field.
+func UserAgentHasPrefix(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldHasPrefix(FieldUserAgent, v))
+}
+
+// UserAgentHasSuffix applies the HasSuffix predicate on the "user_agent" field.
+func UserAgentHasSuffix(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldHasSuffix(FieldUserAgent, v))
+}
+
+// UserAgentIsNil applies the IsNil predicate on the "user_agent" field.
+func UserAgentIsNil() predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldIsNull(FieldUserAgent))
+}
+
+// UserAgentNotNil applies the NotNil predicate on the "user_agent" field.
+func UserAgentNotNil() predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldNotNull(FieldUserAgent))
+}
+
+// UserAgentEqualFold applies the EqualFold predicate on the "user_agent" field.
+func UserAgentEqualFold(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldEqualFold(FieldUserAgent, v))
+}
+
+// UserAgentContainsFold applies the ContainsFold predicate on the "user_agent" field.
+func UserAgentContainsFold(v string) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldContainsFold(FieldUserAgent, v))
+}
+
+// ForwardedAtEQ applies the EQ predicate on the "forwarded_at" field.
+func ForwardedAtEQ(v time.Time) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldEQ(FieldForwardedAt, v))
+}
+
+// ForwardedAtNEQ applies the NEQ predicate on the "forwarded_at" field.
+func ForwardedAtNEQ(v time.Time) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldNEQ(FieldForwardedAt, v))
+}
+
+// ForwardedAtIn applies the In predicate on the "forwarded_at" field.
+func ForwardedAtIn(vs ...time.Time) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldIn(FieldForwardedAt, vs...))
+}
+
+// ForwardedAtNotIn applies the NotIn predicate on the "forwarded_at" field.
+func ForwardedAtNotIn(vs ...time.Time) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldNotIn(FieldForwardedAt, vs...))
+}
+
+// ForwardedAtGT applies the GT predicate on the "forwarded_at" field.
+func ForwardedAtGT(v time.Time) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldGT(FieldForwardedAt, v))
+}
+
+// ForwardedAtGTE applies the GTE predicate on the "forwarded_at" field.
+func ForwardedAtGTE(v time.Time) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldGTE(FieldForwardedAt, v))
+}
+
+// ForwardedAtLT applies the LT predicate on the "forwarded_at" field.
+func ForwardedAtLT(v time.Time) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldLT(FieldForwardedAt, v))
+}
+
+// ForwardedAtLTE applies the LTE predicate on the "forwarded_at" field.
+func ForwardedAtLTE(v time.Time) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldLTE(FieldForwardedAt, v))
+}
+
+// ForwardedAtIsNil applies the IsNil predicate on the "forwarded_at" field.
+func ForwardedAtIsNil() predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldIsNull(FieldForwardedAt))
+}
+
+// ForwardedAtNotNil applies the NotNil predicate on the "forwarded_at" field.
+func ForwardedAtNotNil() predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldNotNull(FieldForwardedAt))
+}
+
+// HandledAtEQ applies the EQ predicate on the "handled_at" field.
+func HandledAtEQ(v time.Time) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldEQ(FieldHandledAt, v))
+}
+
+// HandledAtNEQ applies the NEQ predicate on the "handled_at" field.
+func HandledAtNEQ(v time.Time) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldNEQ(FieldHandledAt, v))
+}
+
+// HandledAtIn applies the In predicate on the "handled_at" field.
+func HandledAtIn(vs ...time.Time) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldIn(FieldHandledAt, vs...))
+}
+
+// HandledAtNotIn applies the NotIn predicate on the "handled_at" field.
+func HandledAtNotIn(vs ...time.Time) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldNotIn(FieldHandledAt, vs...))
+}
+
+// HandledAtGT applies the GT predicate on the "handled_at" field.
+func HandledAtGT(v time.Time) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldGT(FieldHandledAt, v))
+}
+
+// HandledAtGTE applies the GTE predicate on the "handled_at" field.
+func HandledAtGTE(v time.Time) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldGTE(FieldHandledAt, v))
+}
+
+// HandledAtLT applies the LT predicate on the "handled_at" field.
+func HandledAtLT(v time.Time) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldLT(FieldHandledAt, v))
+}
+
+// HandledAtLTE applies the LTE predicate on the "handled_at" field.
+func HandledAtLTE(v time.Time) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldLTE(FieldHandledAt, v))
+}
+
+// HandledAtIsNil applies the IsNil predicate on the "handled_at" field.
+func HandledAtIsNil() predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldIsNull(FieldHandledAt))
+}
+
+// HandledAtNotNil applies the NotNil predicate on the "handled_at" field.
+func HandledAtNotNil() predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldNotNull(FieldHandledAt))
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v time.Time) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v time.Time) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldNEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...time.Time) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldNotIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v time.Time) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldGT(FieldCreatedAt, v))
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v time.Time) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldGTE(FieldCreatedAt, v))
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v time.Time) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldLT(FieldCreatedAt, v))
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v time.Time) predicate.ContactMessage {
+	return predicate.ContactMessage(sql.FieldLTE(FieldCreatedAt, v))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.ContactMessage) predicate.ContactMessage {
+	return predicate.ContactMessage(func(s *sql.Selector) {
+		s1 := s.Clone().SetP(nil)
+		for _, p := range predicates {
+			p(s1)
+		}
+		s.Where(s1.P())
+	})
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.ContactMessage) predicate.ContactMessage {
+	return predicate.ContactMessage(func(s *sql.Selector) {
+		s1 := s.Clone().SetP(nil)
+		for i, p := range predicates {
+			if i > 0 {
+				s1.Or()
+			}
+			p(s1)
+		}
+		s.Where(s1.P())
+	})
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.ContactMessage) predicate.ContactMessage {
+	return predicate.ContactMessage(func(s *sql.Selector) {
+		p(s.Not())
+	})
+}
@@ -0,0 +1,337 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/vovanwin/api-my-site/ent/contactmessage"
+)
+
+// ContactMessageCreate is the builder for creating a ContactMessage entity.
+type ContactMessageCreate struct {
+	config
+	mutation *ContactMessageMutation
+	hooks    []Hook
+}
+
+// SetName sets the "name" field.
+func (cmc *ContactMessageCreate) SetName(s string) *ContactMessageCreate {
+	cmc.mutation.SetName(s)
+	return cmc
+}
+
+// SetEmail sets the "email" field.
+func (cmc *ContactMessageCreate) SetEmail(s string) *ContactMessageCreate {
+	cmc.mutation.SetEmail(s)
+	return cmc
+}
+
+// SetSubject sets the "subject" field.
+func (cmc *ContactMessageCreate) SetSubject(s string) *ContactMessageCreate {
+	cmc.mutation.SetSubject(s)
+	return cmc
+}
+
+// SetNillableSubject sets the "subject" field if the given value is not nil.
+func (cmc *ContactMessageCreate) SetNillableSubject(s *string) *ContactMessageCreate {
+	if s != nil {
+		cmc.SetSubject(*s)
+	}
+	return cmc
+}
+
+// SetMessage sets the "message" field.
+func (cmc *ContactMessageCreate) SetMessage(s string) *ContactMessageCreate {
+	cmc.mutation.SetMessage(s)
+	return cmc
+}
+
+// SetIP sets the "ip" field.
+func (cmc *ContactMessageCreate) SetIP(s string) *ContactMessageCreate {
+	cmc.mutation.SetIP(s)
+	return cmc
+}
+
+// SetNillableIP sets the "ip" field if the given value is not nil.
+func (cmc *ContactMessageCreate) SetNillableIP(s *string) *ContactMessageCreate {
+	if s != nil {
+		cmc.SetIP(*s)
+	}
+	return cmc
+}
+
+// SetUserAgent sets the "user_agent" field.
+func (cmc *ContactMessageCreate) SetUserAgent(s string) *ContactMessageCreate {
+	cmc.mutation.SetUserAgent(s)
+	return cmc
+}
+
+// SetNillableUserAgent sets the "user_agent" field if the given value is not nil.
+func (cmc *ContactMessageCreate) SetNillableUserAgent(s *string) *ContactMessageCreate {
+	if s != nil {
+		cmc.SetUserAgent(*s)
+	}
+	return cmc
+}
+
+// SetForwardedAt sets the "forwarded_at" field.
+func (cmc *ContactMessageCreate) SetForwardedAt(t time.Time) *ContactMessageCreate {
+	cmc.mutation.SetForwardedAt(t)
+	return cmc
+}
+
+// SetNillableForwardedAt sets the "forwarded_at" field if the given value is not nil.
+func (cmc *ContactMessageCreate) SetNillableForwardedAt(t *time.Time) *ContactMessageCreate {
+	if t != nil {
+		cmc.SetForwardedAt(*t)
+	}
+	return cmc
+}
+
+// SetHandledAt sets the "handled_at" field.
+func (cmc *ContactMessageCreate) SetHandledAt(t time.Time) *ContactMessageCreate {
+	cmc.mutation.SetHandledAt(t)
+	return cmc
+}
+
+// SetNillableHandledAt sets the "handled_at" field if the given value is not nil.
+func (cmc *ContactMessageCreate) SetNillableHandledAt(t *time.Time) *ContactMessageCreate {
+	if t != nil {
+		cmc.SetHandledAt(*t)
+	}
+	return cmc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (cmc *ContactMessageCreate) SetCreatedAt(t time.Time) *ContactMessageCreate {
+	cmc.mutation.SetCreatedAt(t)
+	return cmc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (cmc *ContactMessageCreate) SetNillableCreatedAt(t *time.Time) *ContactMessageCreate {
+	if t != nil {
+		cmc.SetCreatedAt(*t)
+	}
+	return cmc
+}
+
+// Mutation returns the ContactMessageMutation object of the builder.
+func (cmc *ContactMessageCreate) Mutation() *ContactMessageMutation {
+	return cmc.mutation
+}
+
+// Save creates the ContactMessage in the database.
+func (cmc *ContactMessageCreate) Save(ctx context.Context) (*ContactMessage, error) {
+	cmc.defaults()
+	return withHooks[*ContactMessage, ContactMessageMutation](ctx, cmc.sqlSave, cmc.mutation, cmc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (cmc *ContactMessageCreate) SaveX(ctx context.Context) *ContactMessage {
+	v, err := cmc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (cmc *ContactMessageCreate) Exec(ctx context.Context) error {
+	_, err := cmc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (cmc *ContactMessageCreate) ExecX(ctx context.Context) {
+	if err := cmc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (cmc *ContactMessageCreate) defaults() {
+	if _, ok := cmc.mutation.CreatedAt(); !ok {
+		v := contactmessage.DefaultCreatedAt()
+		cmc.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (cmc *ContactMessageCreate) check() error {
+	if _, ok := cmc.mutation.Name(); !ok {
+		return &ValidationError{Name: "name", err: errors.New(`ent: missing required field "ContactMessage.name"`)}
+	}
+	if v, ok := cmc.mutation.Name(); ok {
+		if err := contactmessage.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "ContactMessage.name": %w`, err)}
+		}
+	}
+	if _, ok := cmc.mutation.Email(); !ok {
+		return &ValidationError{Name: "email", err: errors.New(`ent: missing required field "ContactMessage.email"`)}
+	}
+	if v, ok := cmc.mutation.Email(); ok {
+		if err := contactmessage.EmailValidator(v); err != nil {
+			return &ValidationError{Name: "email", err: fmt.Errorf(`ent: validator failed for field "ContactMessage.email": %w`, err)}
+		}
+	}
+	if _, ok := cmc.mutation.Message(); !ok {
+		return &ValidationError{Name: "message", err: errors.New(`ent: missing required field "ContactMessage.message"`)}
+	}
+	if _, ok := cmc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "ContactMessage.created_at"`)}
+	}
+	return nil
+}
+
+func (cmc *ContactMessageCreate) sqlSave(ctx context.Context) (*ContactMessage, error) {
+	if err := cmc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := cmc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, cmc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	cmc.mutation.id = &_node.ID
+	cmc.mutation.done = true
+	return _node, nil
+}
+
+func (cmc *ContactMessageCreate) createSpec() (*ContactMessage, *sqlgraph.CreateSpec) {
+	var (
+		_node = &ContactMessage{config: cmc.config}
+		_spec = sqlgraph.NewCreateSpec(contactmessage.Table, sqlgraph.NewFieldSpec(contactmessage.FieldID, field.TypeInt))
+	)
+	if value, ok := cmc.mutation.Name(); ok {
+		_spec.SetField(contactmessage.FieldName, field.TypeString, value)
+		_node.Name = value
+	}
+	if value, ok := cmc.mutation.Email(); ok {
+		_spec.SetField(contactmessage.FieldEmail, field.TypeString, value)
+		_node.Email = value
+	}
+	if value, ok := cmc.mutation.Subject(); ok {
+		_spec.SetField(contactmessage.FieldSubject, field.TypeString, value)
+		_node.Subject = value
+	}
+	if value, ok := cmc.mutation.Message(); ok {
+		_spec.SetField(contactmessage.FieldMessage, field.TypeString, value)
+		_node.Message = value
+	}
+	if value, ok := cmc.mutation.IP(); ok {
+		_spec.SetField(contactmessage.FieldIP, field.TypeString, value)
+		_node.IP = value
+	}
+	if value, ok := cmc.mutation.UserAgent(); ok {
+		_spec.SetField(contactmessage.FieldUserAgent, field.TypeString, value)
+		_node.UserAgent = value
+	}
+	if value, ok := cmc.mutation.ForwardedAt(); ok {
+		_spec.SetField(contactmessage.FieldForwardedAt, field.TypeTime, value)
+		_node.ForwardedAt = &value
+	}
+	if value, ok := cmc.mutation.HandledAt(); ok {
+		_spec.SetField(contactmessage.FieldHandledAt, field.TypeTime, value)
+		_node.HandledAt = &value
+	}
+	if value, ok := cmc.mutation.CreatedAt(); ok {
+		_spec.SetField(contactmessage.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	return _node, _spec
+}
+
+// ContactMessageCreateBulk is the builder for creating many ContactMessage entities in bulk.
+type ContactMessageCreateBulk struct {
+	config
+	builders []*ContactMessageCreate
+}
+
+// Save creates the ContactMessage entities in the database.
+func (cmcb *ContactMessageCreateBulk) Save(ctx context.Context) ([]*ContactMessage, error) {
+	specs := make([]*sqlgraph.CreateSpec, len(cmcb.builders))
+	nodes := make([]*ContactMessage, len(cmcb.builders))
+	mutators := make([]Mutator, len(cmcb.builders))
+	for i := range cmcb.builders {
+		func(i int, root context.Context) {
+			builder := cmcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*ContactMessageMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, cmcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, cmcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, cmcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (cmcb *ContactMessageCreateBulk) SaveX(ctx context.Context) []*ContactMessage {
+	v, err := cmcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (cmcb *ContactMessageCreateBulk) Exec(ctx context.Context) error {
+	_, err := cmcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (cmcb *ContactMessageCreateBulk) ExecX(ctx context.Context) {
+	if err := cmcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,563 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/vovanwin/api-my-site/ent/contactmessage"
+	"github.com/vovanwin/api-my-site/ent/predicate"
+)
+
+// ContactMessageQuery is the builder for querying ContactMessage entities.
+type ContactMessageQuery struct {
+	config
+	ctx        *QueryContext
+	order      []contactmessage.OrderOption
+	inters     []Interceptor
+	predicates []predicate.ContactMessage
+	modifiers  []func(*sql.Selector)
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Where adds a new predicate for the ContactMessageQuery builder.
+func (cmq *ContactMessageQuery) Where(ps ...predicate.ContactMessage) *ContactMessageQuery {
+	cmq.predicates = append(cmq.predicates, ps...)
+	return cmq
+}
+
+// Limit the number of records to be returned by this query.
+func (cmq *ContactMessageQuery) Limit(limit int) *ContactMessageQuery {
+	cmq.ctx.Limit = &limit
+	return cmq
+}
+
+// Offset to start from.
+func (cmq *ContactMessageQuery) Offset(offset int) *ContactMessageQuery {
+	cmq.ctx.Offset = &offset
+	return cmq
+}
+
+// Unique configures the query builder to filter duplicate records on query.
+// By default, unique is set to true, and can be disabled using this method.
+func (cmq *ContactMessageQuery) Unique(unique bool) *ContactMessageQuery {
+	cmq.ctx.Unique = &unique
+	return cmq
+}
+
+// Order specifies how the records should be ordered.
+func (cmq *ContactMessageQuery) Order(o ...contactmessage.OrderOption) *ContactMessageQuery {
+	cmq.order = append(cmq.order, o...)
+	return cmq
+}
+
+// First returns the first ContactMessage entity from the query.
+// Returns a *NotFoundError when no ContactMessage was found.
+func (cmq *ContactMessageQuery) First(ctx context.Context) (*ContactMessage, error) {
+	nodes, err := cmq.Limit(1).All(setContextOp(ctx, cmq.ctx, "First"))
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, &NotFoundError{contactmessage.Label}
+	}
+	return nodes[0], nil
+}
+
+// FirstX is like First, but panics if an error occurs.
+func (cmq *ContactMessageQuery) FirstX(ctx context.Context) *ContactMessage {
+	node, err := cmq.First(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return node
+}
+
+// FirstID returns the first ContactMessage ID from the query.
+// Returns a *NotFoundError when no ContactMessage ID was found.
+func (cmq *ContactMessageQuery) FirstID(ctx context.Context) (id int, err error) {
+	var ids []int
+	if ids, err = cmq.Limit(1).IDs(setContextOp(ctx, cmq.ctx, "FirstID")); err != nil {
+		return
+	}
+	if len(ids) == 0 {
+		err = &NotFoundError{contactmessage.Label}
+		return
+	}
+	return ids[0], nil
+}
+
+// FirstIDX is like FirstID, but panics if an error occurs.
+func (cmq *ContactMessageQuery) FirstIDX(ctx context.Context) int {
+	id, err := cmq.FirstID(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return id
+}
+
+// Only returns a single ContactMessage entity found by the query, ensuring it only returns one.
+// Returns a *NotSingularError when more than one ContactMessage entity is found.
+// Returns a *NotFoundError when no ContactMessage entities are found.
+func (cmq *ContactMessageQuery) Only(ctx context.Context) (*ContactMessage, error) {
+	nodes, err := cmq.Limit(2).All(setContextOp(ctx, cmq.ctx, "Only"))
+	if err != nil {
+		return nil, err
+	}
+	switch len(nodes) {
+	case 1:
+		return nodes[0], nil
+	case 0:
+		return nil, &NotFoundError{contactmessage.Label}
+	default:
+		return nil, &NotSingularError{contactmessage.Label}
+	}
+}
+
+// OnlyX is like Only, but panics if an error occurs.
+func (cmq *ContactMessageQuery) OnlyX(ctx context.Context) *ContactMessage {
+	node, err := cmq.Only(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// OnlyID is like Only, but returns the only ContactMessage ID in the query.
+// Returns a *NotSingularError when more than one ContactMessage ID is found.
+// Returns a *NotFoundError when no entities are found.
+func (cmq *ContactMessageQuery) OnlyID(ctx context.Context) (id int, err error) {
+	var ids []int
+	if ids, err = cmq.Limit(2).IDs(setContextOp(ctx, cmq.ctx, "OnlyID")); err != nil {
+		return
+	}
+	switch len(ids) {
+	case 1:
+		id = ids[0]
+	case 0:
+		err = &NotFoundError{contactmessage.Label}
+	default:
+		err = &NotSingularError{contactmessage.Label}
+	}
+	return
+}
+
+// OnlyIDX is like OnlyID, but panics if an error occurs.
+func (cmq *ContactMessageQuery) OnlyIDX(ctx context.Context) int {
+	id, err := cmq.OnlyID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// All executes the query and returns a list of ContactMessages.
+func (cmq *ContactMessageQuery) All(ctx context.Context) ([]*ContactMessage, error) {
+	ctx = setContextOp(ctx, cmq.ctx, "All")
+	if err := cmq.prepareQuery(ctx); err != nil {
+		return nil, err
+	}
+	qr := querierAll[[]*ContactMessage, *ContactMessageQuery]()
+	return withInterceptors[[]*ContactMessage](ctx, cmq, qr, cmq.inters)
+}
+
+// AllX is like All, but panics if an error occurs.
+func (cmq *ContactMessageQuery) AllX(ctx context.Context) []*ContactMessage {
+	nodes, err := cmq.All(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return nodes
+}
+
+// IDs executes the query and returns a list of ContactMessage IDs.
+func (cmq *ContactMessageQuery) IDs(ctx context.Context) (ids []int, err error) {
+	if cmq.ctx.Unique == nil && cmq.path != nil {
+		cmq.Unique(true)
+	}
+	ctx = setContextOp(ctx, cmq.ctx, "IDs")
+	if err = cmq.Select(contactmessage.FieldID).Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// IDsX is like IDs, but panics if an error occurs.
+func (cmq *ContactMessageQuery) IDsX(ctx context.Context) []int {
+	ids, err := cmq.IDs(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return ids
+}
+
+// Count returns the count of the given query.
+func (cmq *ContactMessageQuery) Count(ctx context.Context) (int, error) {
+	ctx = setContextOp(ctx, cmq.ctx, "Count")
+	if err := cmq.prepareQuery(ctx); err != nil {
+		return 0, err
+	}
+	return withInterceptors[int](ctx, cmq, querierCount[*ContactMessageQuery](), cmq.inters)
+}
+
+// CountX is like Count, but panics if an error occurs.
+func (cmq *ContactMessageQuery) CountX(ctx context.Context) int {
+	count, err := cmq.Count(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return count
+}
+
+// Exist returns true if the query has elements in the graph.
+func (cmq *ContactMessageQuery) Exist(ctx context.Context) (bool, error) {
+	ctx = setContextOp(ctx, cmq.ctx, "Exist")
+	switch _, err := cmq.FirstID(ctx); {
+	case IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("ent: check existence: %w", err)
+	default:
+		return true, nil
+	}
+}
+
+// ExistX is like Exist, but panics if an error occurs.
+func (cmq *ContactMessageQuery) ExistX(ctx context.Context) bool {
+	exist, err := cmq.Exist(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return exist
+}
+
+// Clone returns a duplicate of the ContactMessageQuery builder, including all associated steps. It can be
+// used to prepare common query builders and use them differently after the clone is made.
+func (cmq *ContactMessageQuery) Clone() *ContactMessageQuery {
+	if cmq == nil {
+		return nil
+	}
+	return &ContactMessageQuery{
+		config:     cmq.config,
+		ctx:        cmq.ctx.Clone(),
+		order:      append([]contactmessage.OrderOption{}, cmq.order...),
+		inters:     append([]Interceptor{}, cmq.inters...),
+		predicates: append([]predicate.ContactMessage{}, cmq.predicates...),
+		// clone intermediate query.
+		sql:  cmq.sql.Clone(),
+		path: cmq.path,
+	}
+}
+
+// GroupBy is used to group vertices by one or more fields/columns.
+// It is often used with aggregate functions, like: count, max, mean, min, sum.
+//
+// Example:
+//
+//	var v []struct {
+//		Name string `json:"name,omitempty"`
+//		Count int `json:"count,omitempty"`
+//	}
+//
+//	client.ContactMessage.Query().
+//		GroupBy(contactmessage.FieldName).
+//		Aggregate(ent.Count()).
+//		Scan(ctx, &v)
+func (cmq *ContactMessageQuery) GroupBy(field string, fields ...string) *ContactMessageGroupBy {
+	cmq.ctx.Fields = append([]string{field}, fields...)
+	grbuild := &ContactMessageGroupBy{build: cmq}
+	grbuild.flds = &cmq.ctx.Fields
+	grbuild.label = contactmessage.Label
+	grbuild.scan = grbuild.Scan
+	return grbuild
+}
+
+// Select allows the selection one or more fields/columns for the given query,
+// instead of selecting all fields in the entity.
+//
+// Example:
+//
+//	var v []struct {
+//		Name string `json:"name,omitempty"`
+//	}
+//
+//	client.ContactMessage.Query().
+//		Select(contactmessage.FieldName).
+//		Scan(ctx, &v)
+func (cmq *ContactMessageQuery) Select(fields ...string) *ContactMessageSelect {
+	cmq.ctx.Fields = append(cmq.ctx.Fields, fields...)
+	sbuild := &ContactMessageSelect{ContactMessageQuery: cmq}
+	sbuild.label = contactmessage.Label
+	sbuild.flds, sbuild.scan = &cmq.ctx.Fields, sbuild.Scan
+	return sbuild
+}
+
+// Aggregate returns a ContactMessageSelect configured with the given aggregations.
+func (cmq *ContactMessageQuery) Aggregate(fns ...AggregateFunc) *ContactMessageSelect {
+	return cmq.Select().Aggregate(fns...)
+}
+
+func (cmq *ContactMessageQuery) prepareQuery(ctx context.Context) error {
+	for _, inter := range cmq.inters {
+		if inter == nil {
+			return fmt.Errorf("ent: uninitialized interceptor (forgotten import ent/runtime?)")
+		}
+		if trv, ok := inter.(Traverser); ok {
+			if err := trv.Traverse(ctx, cmq); err != nil {
+				return err
+			}
+		}
+	}
+	for _, f := range cmq.ctx.Fields {
+		if !contactmessage.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+		}
+	}
+	if cmq.path != nil {
+		prev, err := cmq.path(ctx)
+		if err != nil {
+			return err
+		}
+		cmq.sql = prev
+	}
+	return nil
+}
+
+func (cmq *ContactMessageQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*ContactMessage, error) {
+	var (
+		nodes = []*ContactMessage{}
+		_spec = cmq.querySpec()
+	)
+	_spec.ScanValues = func(columns []string) ([]any, error) {
+		return (*ContactMessage).scanValues(nil, columns)
+	}
+	_spec.Assign = func(columns []string, values []any) error {
+		node := &ContactMessage{config: cmq.config}
+		nodes = append(nodes, node)
+		return node.assignValues(columns, values)
+	}
+	if len(cmq.modifiers) > 0 {
+		_spec.Modifiers = cmq.modifiers
+	}
+	for i := range hooks {
+		hooks[i](ctx, _spec)
+	}
+	if err := sqlgraph.QueryNodes(ctx, cmq.driver, _spec); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nodes, nil
+	}
+	return nodes, nil
+}
+
+func (cmq *ContactMessageQuery) sqlCount(ctx context.Context) (int, error) {
+	_spec := cmq.querySpec()
+	if len(cmq.modifiers) > 0 {
+		_spec.Modifiers = cmq.modifiers
+	}
+	_spec.Node.Columns = cmq.ctx.Fields
+	if len(cmq.ctx.Fields) > 0 {
+		_spec.Unique = cmq.ctx.Unique != nil && *cmq.ctx.Unique
+	}
+	return sqlgraph.CountNodes(ctx, cmq.driver, _spec)
+}
+
+func (cmq *ContactMessageQuery) querySpec() *sqlgraph.QuerySpec {
+	_spec := sqlgraph.NewQuerySpec(contactmessage.Table, contactmessage.Columns, sqlgraph.NewFieldSpec(contactmessage.FieldID, field.TypeInt))
+	_spec.From = cmq.sql
+	if unique := cmq.ctx.Unique; unique != nil {
+		_spec.Unique = *unique
+	} else if cmq.path != nil {
+		_spec.Unique = true
+	}
+	if fields := cmq.ctx.Fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, contactmessage.FieldID)
+		for i := range fields {
+			if fields[i] != contactmessage.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, fields[i])
+			}
+		}
+	}
+	if ps := cmq.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if limit := cmq.ctx.Limit; limit != nil {
+		_spec.Limit = *limit
+	}
+	if offset := cmq.ctx.Offset; offset != nil {
+		_spec.Offset = *offset
+	}
+	if ps := cmq.order; len(ps) > 0 {
+		_spec.Order = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	return _spec
+}
+
+func (cmq *ContactMessageQuery) sqlQuery(ctx context.Context) *sql.Selector {
+	builder := sql.Dialect(cmq.driver.Dialect())
+	t1 := builder.Table(contactmessage.Table)
+	columns := cmq.ctx.Fields
+	if len(columns) == 0 {
+		columns = contactmessage.Columns
+	}
+	selector := builder.Select(t1.Columns(columns...)...).From(t1)
+	if cmq.sql != nil {
+		selector = cmq.sql
+		selector.Select(selector.Columns(columns...)...)
+	}
+	if cmq.ctx.Unique != nil && *cmq.ctx.Unique {
+		selector.Distinct()
+	}
+	for _, m := range cmq.modifiers {
+		m(selector)
+	}
+	for _, p := range cmq.predicates {
+		p(selector)
+	}
+	for _, p := range cmq.order {
+		p(selector)
+	}
+	if offset := cmq.ctx.Offset; offset != nil {
+		// limit is mandatory for offset clause. We start
+		// with default value, and override it below if needed.
+		selector.Offset(*offset).Limit(math.MaxInt32)
+	}
+	if limit := cmq.ctx.Limit; limit != nil {
+		selector.Limit(*limit)
+	}
+	return selector
+}
+
+// ForUpdate locks the selected rows against concurrent updates, and prevent them from being
+// updated, deleted or "selected ... for update" by other sessions, until the transaction is
+// either committed or rolled-back.
+func (cmq *ContactMessageQuery) ForUpdate(opts ...sql.LockOption) *ContactMessageQuery {
+	if cmq.driver.Dialect() == dialect.Postgres {
+		cmq.Unique(false)
+	}
+	cmq.modifiers = append(cmq.modifiers, func(s *sql.Selector) {
+		s.ForUpdate(opts...)
+	})
+	return cmq
+}
+
+// ForShare behaves similarly to ForUpdate, except that it acquires a shared mode lock
+// on any rows that are read. Other sessions can read the rows, but cannot modify them
+// until your transaction commits.
+func (cmq *ContactMessageQuery) ForShare(opts ...sql.LockOption) *ContactMessageQuery {
+	if cmq.driver.Dialect() == dialect.Postgres {
+		cmq.Unique(false)
+	}
+	cmq.modifiers = append(cmq.modifiers, func(s *sql.Selector) {
+		s.ForShare(opts...)
+	})
+	return cmq
+}
+
+// ContactMessageGroupBy is the group-by builder for ContactMessage entities.
+type ContactMessageGroupBy struct {
+	selector
+	build *ContactMessageQuery
+}
+
+// Aggregate adds the given aggregation functions to the group-by query.
+func (cmgb *ContactMessageGroupBy) Aggregate(fns ...AggregateFunc) *ContactMessageGroupBy {
+	cmgb.fns = append(cmgb.fns, fns...)
+	return cmgb
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (cmgb *ContactMessageGroupBy) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, cmgb.build.ctx, "GroupBy")
+	if err := cmgb.build.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*ContactMessageQuery, *ContactMessageGroupBy](ctx, cmgb.build, cmgb, cmgb.build.inters, v)
+}
+
+func (cmgb *ContactMessageGroupBy) sqlScan(ctx context.Context, root *ContactMessageQuery, v any) error {
+	selector := root.sqlQuery(ctx).Select()
+	aggregation := make([]string, 0, len(cmgb.fns))
+	for _, fn := range cmgb.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	if len(selector.SelectedColumns()) == 0 {
+		columns := make([]string, 0, len(*cmgb.flds)+len(cmgb.fns))
+		for _, f := range *cmgb.flds {
+			columns = append(columns, selector.C(f))
+		}
+		columns = append(columns, aggregation...)
+		selector.Select(columns...)
+	}
+	selector.GroupBy(selector.Columns(*cmgb.flds...)...)
+	if err := selector.Err(); err != nil {
+		return err
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := cmgb.build.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+// ContactMessageSelect is the builder for selecting fields of ContactMessage entities.
+type ContactMessageSelect struct {
+	*ContactMessageQuery
+	selector
+}
+
+// Aggregate adds the given aggregation functions to the selector query.
+func (cms *ContactMessageSelect) Aggregate(fns ...AggregateFunc) *ContactMessageSelect {
+	cms.fns = append(cms.fns, fns...)
+	return cms
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (cms *ContactMessageSelect) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, cms.ctx, "Select")
+	if err := cms.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*ContactMessageQuery, *ContactMessageSelect](ctx, cms.ContactMessageQuery, cms, cms.inters, v)
+}
+
+func (cms *ContactMessageSelect) sqlScan(ctx context.Context, root *ContactMessageQuery, v any) error {
+	selector := root.sqlQuery(ctx)
+	aggregation := make([]string, 0, len(cms.fns))
+	for _, fn := range cms.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	switch n := len(*cms.selector.flds); {
+	case n == 0 && len(aggregation) > 0:
+		selector.Select(aggregation...)
+	case n != 0 && len(aggregation) > 0:
+		selector.AppendSelect(aggregation...)
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := cms.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
@@ -0,0 +1,526 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/vovanwin/api-my-site/ent/contactmessage"
+	"github.com/vovanwin/api-my-site/ent/predicate"
+)
+
+// ContactMessageUpdate is the builder for updating ContactMessage entities.
+type ContactMessageUpdate struct {
+	config
+	hooks    []Hook
+	mutation *ContactMessageMutation
+}
+
+// Where appends a list predicates to the ContactMessageUpdate builder.
+func (cmu *ContactMessageUpdate) Where(ps ...predicate.ContactMessage) *ContactMessageUpdate {
+	cmu.mutation.Where(ps...)
+	return cmu
+}
+
+// SetName sets the "name" field.
+func (cmu *ContactMessageUpdate) SetName(s string) *ContactMessageUpdate {
+	cmu.mutation.SetName(s)
+	return cmu
+}
+
+// SetEmail sets the "email" field.
+func (cmu *ContactMessageUpdate) SetEmail(s string) *ContactMessageUpdate {
+	cmu.mutation.SetEmail(s)
+	return cmu
+}
+
+// SetSubject sets the "subject" field.
+func (cmu *ContactMessageUpdate) SetSubject(s string) *ContactMessageUpdate {
+	cmu.mutation.SetSubject(s)
+	return cmu
+}
+
+// SetNillableSubject sets the "subject" field if the given value is not nil.
+func (cmu *ContactMessageUpdate) SetNillableSubject(s *string) *ContactMessageUpdate {
+	if s != nil {
+		cmu.SetSubject(*s)
+	}
+	return cmu
+}
+
+// ClearSubject clears the value of the "subject" field.
+func (cmu *ContactMessageUpdate) ClearSubject() *ContactMessageUpdate {
+	cmu.mutation.ClearSubject()
+	return cmu
+}
+
+// SetMessage sets the "message" field.
+func (cmu *ContactMessageUpdate) SetMessage(s string) *ContactMessageUpdate {
+	cmu.mutation.SetMessage(s)
+	return cmu
+}
+
+// SetIP sets the "ip" field.
+func (cmu *ContactMessageUpdate) SetIP(s string) *ContactMessageUpdate {
+	cmu.mutation.SetIP(s)
+	return cmu
+}
+
+// SetNillableIP sets the "ip" field if the given value is not nil.
+func (cmu *ContactMessageUpdate) SetNillableIP(s *string) *ContactMessageUpdate {
+	if s != nil {
+		cmu.SetIP(*s)
+	}
+	return cmu
+}
+
+// ClearIP clears the value of the "ip" field.
+func (cmu *ContactMessageUpdate) ClearIP() *ContactMessageUpdate {
+	cmu.mutation.ClearIP()
+	return cmu
+}
+
+// SetUserAgent sets the "user_agent" field.
+func (cmu *ContactMessageUpdate) SetUserAgent(s string) *ContactMessageUpdate {
+	cmu.mutation.SetUserAgent(s)
+	return cmu
+}
+
+// SetNillableUserAgent sets the "user_agent" field if the given value is not nil.
+func (cmu *ContactMessageUpdate) SetNillableUserAgent(s *string) *ContactMessageUpdate {
+	if s != nil {
+		cmu.SetUserAgent(*s)
+	}
+	return cmu
+}
+
+// ClearUserAgent clears the value of the "user_agent" field.
+func (cmu *ContactMessageUpdate) ClearUserAgent() *ContactMessageUpdate {
+	cmu.mutation.ClearUserAgent()
+	return cmu
+}
+
+// SetForwardedAt sets the "forwarded_at" field.
+func (cmu *ContactMessageUpdate) SetForwardedAt(t time.Time) *ContactMessageUpdate {
+	cmu.mutation.SetForwardedAt(t)
+	return cmu
+}
+
+// SetNillableForwardedAt sets the "forwarded_at" field if the given value is not nil.
+func (cmu *ContactMessageUpdate) SetNillableForwardedAt(t *time.Time) *ContactMessageUpdate {
+	if t != nil {
+		cmu.SetForwardedAt(*t)
+	}
+	return cmu
+}
+
+// ClearForwardedAt clears the value of the "forwarded_at" field.
+func (cmu *ContactMessageUpdate) ClearForwardedAt() *ContactMessageUpdate {
+	cmu.mutation.ClearForwardedAt()
+	return cmu
+}
+
+// SetHandledAt sets the "handled_at" field.
+func (cmu *ContactMessageUpdate) SetHandledAt(t time.Time) *ContactMessageUpdate {
+	cmu.mutation.SetHandledAt(t)
+	return cmu
+}
+
+// SetNillableHandledAt sets the "handled_at" field if the given value is not nil.
+func (cmu *ContactMessageUpdate) SetNillableHandledAt(t *time.Time) *ContactMessageUpdate {
+	if t != nil {
+		cmu.SetHandledAt(*t)
+	}
+	return cmu
+}
+
+// ClearHandledAt clears the value of the "handled_at" field.
+func (cmu *ContactMessageUpdate) ClearHandledAt() *ContactMessageUpdate {
+	cmu.mutation.ClearHandledAt()
+	return cmu
+}
+
+// Mutation returns the ContactMessageMutation object of the builder.
+func (cmu *ContactMessageUpdate) Mutation() *ContactMessageMutation {
+	return cmu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (cmu *ContactMessageUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks[int, ContactMessageMutation](ctx, cmu.sqlSave, cmu.mutation, cmu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (cmu *ContactMessageUpdate) SaveX(ctx context.Context) int {
+	affected, err := cmu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (cmu *ContactMessageUpdate) Exec(ctx context.Context) error {
+	_, err := cmu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (cmu *ContactMessageUpdate) ExecX(ctx context.Context) {
+	if err := cmu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (cmu *ContactMessageUpdate) check() error {
+	if v, ok := cmu.mutation.Name(); ok {
+		if err := contactmessage.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "ContactMessage.name": %w`, err)}
+		}
+	}
+	if v, ok := cmu.mutation.Email(); ok {
+		if err := contactmessage.EmailValidator(v); err != nil {
+			return &ValidationError{Name: "email", err: fmt.Errorf(`ent: validator failed for field "ContactMessage.email": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (cmu *ContactMessageUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := cmu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(contactmessage.Table, contactmessage.Columns, sqlgraph.NewFieldSpec(contactmessage.FieldID, field.TypeInt))
+	if ps := cmu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := cmu.mutation.Name(); ok {
+		_spec.SetField(contactmessage.FieldName, field.TypeString, value)
+	}
+	if value, ok := cmu.mutation.Email(); ok {
+		_spec.SetField(contactmessage.FieldEmail, field.TypeString, value)
+	}
+	if value, ok := cmu.mutation.Subject(); ok {
+		_spec.SetField(contactmessage.FieldSubject, field.TypeString, value)
+	}
+	if cmu.mutation.SubjectCleared() {
+		_spec.ClearField(contactmessage.FieldSubject, field.TypeString)
+	}
+	if value, ok := cmu.mutation.Message(); ok {
+		_spec.SetField(contactmessage.FieldMessage, field.TypeString, value)
+	}
+	if value, ok := cmu.mutation.IP(); ok {
+		_spec.SetField(contactmessage.FieldIP, field.TypeString, value)
+	}
+	if cmu.mutation.IPCleared() {
+		_spec.ClearField(contactmessage.FieldIP, field.TypeString)
+	}
+	if value, ok := cmu.mutation.UserAgent(); ok {
+		_spec.SetField(contactmessage.FieldUserAgent, field.TypeString, value)
+	}
+	if cmu.mutation.UserAgentCleared() {
+		_spec.ClearField(contactmessage.FieldUserAgent, field.TypeString)
+	}
+	if value, ok := cmu.mutation.ForwardedAt(); ok {
+		_spec.SetField(contactmessage.FieldForwardedAt, field.TypeTime, value)
+	}
+	if cmu.mutation.ForwardedAtCleared() {
+		_spec.ClearField(contactmessage.FieldForwardedAt, field.TypeTime)
+	}
+	if value, ok := cmu.mutation.HandledAt(); ok {
+		_spec.SetField(contactmessage.FieldHandledAt, field.TypeTime, value)
+	}
+	if cmu.mutation.HandledAtCleared() {
+		_spec.ClearField(contactmessage.FieldHandledAt, field.TypeTime)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, cmu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{contactmessage.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	cmu.mutation.done = true
+	return n, nil
+}
+
+// ContactMessageUpdateOne is the builder for updating a single ContactMessage entity.
+type ContactMessageUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *ContactMessageMutation
+}
+
+// SetName sets the "name" field.
+func (cmuo *ContactMessageUpdateOne) SetName(s string) *ContactMessageUpdateOne {
+	cmuo.mutation.SetName(s)
+	return cmuo
+}
+
+// SetEmail sets the "email" field.
+func (cmuo *ContactMessageUpdateOne) SetEmail(s string) *ContactMessageUpdateOne {
+	cmuo.mutation.SetEmail(s)
+	return cmuo
+}
+
+// SetSubject sets the "subject" field.
+func (cmuo *ContactMessageUpdateOne) SetSubject(s string) *ContactMessageUpdateOne {
+	cmuo.mutation.SetSubject(s)
+	return cmuo
+}
+
+// SetNillableSubject sets the "subject" field if the given value is not nil.
+func (cmuo *ContactMessageUpdateOne) SetNillableSubject(s *string) *ContactMessageUpdateOne {
+	if s != nil {
+		cmuo.SetSubject(*s)
+	}
+	return cmuo
+}
+
+// ClearSubject clears the value of the "subject" field.
+func (cmuo *ContactMessageUpdateOne) ClearSubject() *ContactMessageUpdateOne {
+	cmuo.mutation.ClearSubject()
+	return cmuo
+}
+
+// SetMessage sets the "message" field.
+func (cmuo *ContactMessageUpdateOne) SetMessage(s string) *ContactMessageUpdateOne {
+	cmuo.mutation.SetMessage(s)
+	return cmuo
+}
+
+// SetIP sets the "ip" field.
+func (cmuo *ContactMessageUpdateOne) SetIP(s string) *ContactMessageUpdateOne {
+	cmuo.mutation.SetIP(s)
+	return cmuo
+}
+
+// SetNillableIP sets the "ip" field if the given value is not nil.
+func (cmuo *ContactMessageUpdateOne) SetNillableIP(s *string) *ContactMessageUpdateOne {
+	if s != nil {
+		cmuo.SetIP(*s)
+	}
+	return cmuo
+}
+
+// ClearIP clears the value of the "ip" field.
+func (cmuo *ContactMessageUpdateOne) ClearIP() *ContactMessageUpdateOne {
+	cmuo.mutation.ClearIP()
+	return cmuo
+}
+
+// SetUserAgent sets the "user_agent" field.
+func (cmuo *ContactMessageUpdateOne) SetUserAgent(s string) *ContactMessageUpdateOne {
+	cmuo.mutation.SetUserAgent(s)
+	return cmuo
+}
+
+// SetNillableUserAgent sets the "user_agent" field if the given value is not nil.
+func (cmuo *ContactMessageUpdateOne) SetNillableUserAgent(s *string) *ContactMessageUpdateOne {
+	if s != nil {
+		cmuo.SetUserAgent(*s)
+	}
+	return cmuo
+}
+
+// ClearUserAgent clears the value of the "user_agent" field.
+func (cmuo *ContactMessageUpdateOne) ClearUserAgent() *ContactMessageUpdateOne {
+	cmuo.mutation.ClearUserAgent()
+	return cmuo
+}
+
+// SetForwardedAt sets the "forwarded_at" field.
+func (cmuo *ContactMessageUpdateOne) SetForwardedAt(t time.Time) *ContactMessageUpdateOne {
+	cmuo.mutation.SetForwardedAt(t)
+	return cmuo
+}
+
+// SetNillableForwardedAt sets the "forwarded_at" field if the given value is not nil.
+func (cmuo *ContactMessageUpdateOne) SetNillableForwardedAt(t *time.Time) *ContactMessageUpdateOne {
+	if t != nil {
+		cmuo.SetForwardedAt(*t)
+	}
+	return cmuo
+}
+
+// ClearForwardedAt clears the value of the "forwarded_at" field.
+func (cmuo *ContactMessageUpdateOne) ClearForwardedAt() *ContactMessageUpdateOne {
+	cmuo.mutation.ClearForwardedAt()
+	return cmuo
+}
+
+// SetHandledAt sets the "handled_at" field.
+func (cmuo *ContactMessageUpdateOne) SetHandledAt(t time.Time) *ContactMessageUpdateOne {
+	cmuo.mutation.SetHandledAt(t)
+	return cmuo
+}
+
+// SetNillableHandledAt sets the "handled_at" field if the given value is not nil.
+func (cmuo *ContactMessageUpdateOne) SetNillableHandledAt(t *time.Time) *ContactMessageUpdateOne {
+	if t != nil {
+		cmuo.SetHandledAt(*t)
+	}
+	return cmuo
+}
+
+// ClearHandledAt clears the value of the "handled_at" field.
+func (cmuo *ContactMessageUpdateOne) ClearHandledAt() *ContactMessageUpdateOne {
+	cmuo.mutation.ClearHandledAt()
+	return cmuo
+}
+
+// Mutation returns the ContactMessageMutation object of the builder.
+func (cmuo *ContactMessageUpdateOne) Mutation() *ContactMessageMutation {
+	return cmuo.mutation
+}
+
+// Where appends a list predicates to the ContactMessageUpdate builder.
+func (cmuo *ContactMessageUpdateOne) Where(ps ...predicate.ContactMessage) *ContactMessageUpdateOne {
+	cmuo.mutation.Where(ps...)
+	return cmuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (cmuo *ContactMessageUpdateOne) Select(field string, fields ...string) *ContactMessageUpdateOne {
+	cmuo.fields = append([]string{field}, fields...)
+	return cmuo
+}
+
+// Save executes the query and returns the updated ContactMessage entity.
+func (cmuo *ContactMessageUpdateOne) Save(ctx context.Context) (*ContactMessage, error) {
+	return withHooks[*ContactMessage, ContactMessageMutation](ctx, cmuo.sqlSave, cmuo.mutation, cmuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (cmuo *ContactMessageUpdateOne) SaveX(ctx context.Context) *ContactMessage {
+	node, err := cmuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (cmuo *ContactMessageUpdateOne) Exec(ctx context.Context) error {
+	_, err := cmuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (cmuo *ContactMessageUpdateOne) ExecX(ctx context.Context) {
+	if err := cmuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (cmuo *ContactMessageUpdateOne) check() error {
+	if v, ok := cmuo.mutation.Name(); ok {
+		if err := contactmessage.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "ContactMessage.name": %w`, err)}
+		}
+	}
+	if v, ok := cmuo.mutation.Email(); ok {
+		if err := contactmessage.EmailValidator(v); err != nil {
+			return &ValidationError{Name: "email", err: fmt.Errorf(`ent: validator failed for field "ContactMessage.email": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (cmuo *ContactMessageUpdateOne) sqlSave(ctx context.Context) (_node *ContactMessage, err error) {
+	if err := cmuo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(contactmessage.Table, contactmessage.Columns, sqlgraph.NewFieldSpec(contactmessage.FieldID, field.TypeInt))
+	id, ok := cmuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "ContactMessage.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := cmuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, contactmessage.FieldID)
+		for _, f := range fields {
+			if !contactmessage.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != contactmessage.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := cmuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := cmuo.mutation.Name(); ok {
+		_spec.SetField(contactmessage.FieldName, field.TypeString, value)
+	}
+	if value, ok := cmuo.mutation.Email(); ok {
+		_spec.SetField(contactmessage.FieldEmail, field.TypeString, value)
+	}
+	if value, ok := cmuo.mutation.Subject(); ok {
+		_spec.SetField(contactmessage.FieldSubject, field.TypeString, value)
+	}
+	if cmuo.mutation.SubjectCleared() {
+		_spec.ClearField(contactmessage.FieldSubject, field.TypeString)
+	}
+	if value, ok := cmuo.mutation.Message(); ok {
+		_spec.SetField(contactmessage.FieldMessage, field.TypeString, value)
+	}
+	if value, ok := cmuo.mutation.IP(); ok {
+		_spec.SetField(contactmessage.FieldIP, field.TypeString, value)
+	}
+	if cmuo.mutation.IPCleared() {
+		_spec.ClearField(contactmessage.FieldIP, field.TypeString)
+	}
+	if value, ok := cmuo.mutation.UserAgent(); ok {
+		_spec.SetField(contactmessage.FieldUserAgent, field.TypeString, value)
+	}
+	if cmuo.mutation.UserAgentCleared() {
+		_spec.ClearField(contactmessage.FieldUserAgent, field.TypeString)
+	}
+	if value, ok := cmuo.mutation.ForwardedAt(); ok {
+		_spec.SetField(contactmessage.FieldForwardedAt, field.TypeTime, value)
+	}
+	if cmuo.mutation.ForwardedAtCleared() {
+		_spec.ClearField(contactmessage.FieldForwardedAt, field.TypeTime)
+	}
+	if value, ok := cmuo.mutation.HandledAt(); ok {
+		_spec.SetField(contactmessage.FieldHandledAt, field.TypeTime, value)
+	}
+	if cmuo.mutation.HandledAtCleared() {
+		_spec.ClearField(contactmessage.FieldHandledAt, field.TypeTime)
+	}
+	_node = &ContactMessage{config: cmuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, cmuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{contactmessage.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	cmuo.mutation.done = true
+	return _node, nil
+}
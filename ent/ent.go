@@ -12,7 +12,17 @@ import (
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/vovanwin/api-my-site/ent/auditevent"
+	"github.com/vovanwin/api-my-site/ent/category"
+	"github.com/vovanwin/api-my-site/ent/contactmessage"
+	"github.com/vovanwin/api-my-site/ent/featureflag"
+	"github.com/vovanwin/api-my-site/ent/media"
+	"github.com/vovanwin/api-my-site/ent/mediavariant"
+	"github.com/vovanwin/api-my-site/ent/page"
 	"github.com/vovanwin/api-my-site/ent/passwordtoken"
+	"github.com/vovanwin/api-my-site/ent/post"
+	"github.com/vovanwin/api-my-site/ent/project"
+	"github.com/vovanwin/api-my-site/ent/tag"
 	"github.com/vovanwin/api-my-site/ent/user"
 )
 
@@ -74,8 +84,18 @@ var (
 func checkColumn(table, column string) error {
 	initCheck.Do(func() {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
-			passwordtoken.Table: passwordtoken.ValidColumn,
-			user.Table:          user.ValidColumn,
+			auditevent.Table:     auditevent.ValidColumn,
+			category.Table:       category.ValidColumn,
+			contactmessage.Table: contactmessage.ValidColumn,
+			featureflag.Table:    featureflag.ValidColumn,
+			media.Table:          media.ValidColumn,
+			mediavariant.Table:   mediavariant.ValidColumn,
+			page.Table:           page.ValidColumn,
+			passwordtoken.Table:  passwordtoken.ValidColumn,
+			post.Table:           post.ValidColumn,
+			project.Table:        project.ValidColumn,
+			tag.Table:            tag.ValidColumn,
+			user.Table:           user.ValidColumn,
 		})
 	})
 	return columnCheck(table, column)
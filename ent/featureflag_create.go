@@ -0,0 +1,334 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/vovanwin/api-my-site/ent/featureflag"
+)
+
+// FeatureFlagCreate is the builder for creating a FeatureFlag entity.
+type FeatureFlagCreate struct {
+	config
+	mutation *FeatureFlagMutation
+	hooks    []Hook
+}
+
+// SetKey sets the "key" field.
+func (ffc *FeatureFlagCreate) SetKey(s string) *FeatureFlagCreate {
+	ffc.mutation.SetKey(s)
+	return ffc
+}
+
+// SetDescription sets the "description" field.
+func (ffc *FeatureFlagCreate) SetDescription(s string) *FeatureFlagCreate {
+	ffc.mutation.SetDescription(s)
+	return ffc
+}
+
+// SetNillableDescription sets the "description" field if the given value is not nil.
+func (ffc *FeatureFlagCreate) SetNillableDescription(s *string) *FeatureFlagCreate {
+	if s != nil {
+		ffc.SetDescription(*s)
+	}
+	return ffc
+}
+
+// SetEnabled sets the "enabled" field.
+func (ffc *FeatureFlagCreate) SetEnabled(b bool) *FeatureFlagCreate {
+	ffc.mutation.SetEnabled(b)
+	return ffc
+}
+
+// SetNillableEnabled sets the "enabled" field if the given value is not nil.
+func (ffc *FeatureFlagCreate) SetNillableEnabled(b *bool) *FeatureFlagCreate {
+	if b != nil {
+		ffc.SetEnabled(*b)
+	}
+	return ffc
+}
+
+// SetRollout sets the "rollout" field.
+func (ffc *FeatureFlagCreate) SetRollout(i int) *FeatureFlagCreate {
+	ffc.mutation.SetRollout(i)
+	return ffc
+}
+
+// SetNillableRollout sets the "rollout" field if the given value is not nil.
+func (ffc *FeatureFlagCreate) SetNillableRollout(i *int) *FeatureFlagCreate {
+	if i != nil {
+		ffc.SetRollout(*i)
+	}
+	return ffc
+}
+
+// SetUserIds sets the "user_ids" field.
+func (ffc *FeatureFlagCreate) SetUserIds(i []int) *FeatureFlagCreate {
+	ffc.mutation.SetUserIds(i)
+	return ffc
+}
+
+// SetRoles sets the "roles" field.
+func (ffc *FeatureFlagCreate) SetRoles(s []string) *FeatureFlagCreate {
+	ffc.mutation.SetRoles(s)
+	return ffc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (ffc *FeatureFlagCreate) SetCreatedAt(t time.Time) *FeatureFlagCreate {
+	ffc.mutation.SetCreatedAt(t)
+	return ffc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (ffc *FeatureFlagCreate) SetNillableCreatedAt(t *time.Time) *FeatureFlagCreate {
+	if t != nil {
+		ffc.SetCreatedAt(*t)
+	}
+	return ffc
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (ffc *FeatureFlagCreate) SetUpdatedAt(t time.Time) *FeatureFlagCreate {
+	ffc.mutation.SetUpdatedAt(t)
+	return ffc
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (ffc *FeatureFlagCreate) SetNillableUpdatedAt(t *time.Time) *FeatureFlagCreate {
+	if t != nil {
+		ffc.SetUpdatedAt(*t)
+	}
+	return ffc
+}
+
+// Mutation returns the FeatureFlagMutation object of the builder.
+func (ffc *FeatureFlagCreate) Mutation() *FeatureFlagMutation {
+	return ffc.mutation
+}
+
+// Save creates the FeatureFlag in the database.
+func (ffc *FeatureFlagCreate) Save(ctx context.Context) (*FeatureFlag, error) {
+	ffc.defaults()
+	return withHooks[*FeatureFlag, FeatureFlagMutation](ctx, ffc.sqlSave, ffc.mutation, ffc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (ffc *FeatureFlagCreate) SaveX(ctx context.Context) *FeatureFlag {
+	v, err := ffc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (ffc *FeatureFlagCreate) Exec(ctx context.Context) error {
+	_, err := ffc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ffc *FeatureFlagCreate) ExecX(ctx context.Context) {
+	if err := ffc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (ffc *FeatureFlagCreate) defaults() {
+	if _, ok := ffc.mutation.Enabled(); !ok {
+		v := featureflag.DefaultEnabled
+		ffc.mutation.SetEnabled(v)
+	}
+	if _, ok := ffc.mutation.Rollout(); !ok {
+		v := featureflag.DefaultRollout
+		ffc.mutation.SetRollout(v)
+	}
+	if _, ok := ffc.mutation.CreatedAt(); !ok {
+		v := featureflag.DefaultCreatedAt()
+		ffc.mutation.SetCreatedAt(v)
+	}
+	if _, ok := ffc.mutation.UpdatedAt(); !ok {
+		v := featureflag.DefaultUpdatedAt()
+		ffc.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (ffc *FeatureFlagCreate) check() error {
+	if _, ok := ffc.mutation.Key(); !ok {
+		return &ValidationError{Name: "key", err: errors.New(`ent: missing required field "FeatureFlag.key"`)}
+	}
+	if v, ok := ffc.mutation.Key(); ok {
+		if err := featureflag.KeyValidator(v); err != nil {
+			return &ValidationError{Name: "key", err: fmt.Errorf(`ent: validator failed for field "FeatureFlag.key": %w`, err)}
+		}
+	}
+	if _, ok := ffc.mutation.Enabled(); !ok {
+		return &ValidationError{Name: "enabled", err: errors.New(`ent: missing required field "FeatureFlag.enabled"`)}
+	}
+	if _, ok := ffc.mutation.Rollout(); !ok {
+		return &ValidationError{Name: "rollout", err: errors.New(`ent: missing required field "FeatureFlag.rollout"`)}
+	}
+	if v, ok := ffc.mutation.Rollout(); ok {
+		if err := featureflag.RolloutValidator(v); err != nil {
+			return &ValidationError{Name: "rollout", err: fmt.Errorf(`ent: validator failed for field "FeatureFlag.rollout": %w`, err)}
+		}
+	}
+	if _, ok := ffc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "FeatureFlag.created_at"`)}
+	}
+	if _, ok := ffc.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New(`ent: missing required field "FeatureFlag.updated_at"`)}
+	}
+	return nil
+}
+
+func (ffc *FeatureFlagCreate) sqlSave(ctx context.Context) (*FeatureFlag, error) {
+	if err := ffc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := ffc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, ffc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	ffc.mutation.id = &_node.ID
+	ffc.mutation.done = true
+	return _node, nil
+}
+
+func (ffc *FeatureFlagCreate) createSpec() (*FeatureFlag, *sqlgraph.CreateSpec) {
+	var (
+		_node = &FeatureFlag{config: ffc.config}
+		_spec = sqlgraph.NewCreateSpec(featureflag.Table, sqlgraph.NewFieldSpec(featureflag.FieldID, field.TypeInt))
+	)
+	if value, ok := ffc.mutation.Key(); ok {
+		_spec.SetField(featureflag.FieldKey, field.TypeString, value)
+		_node.Key = value
+	}
+	if value, ok := ffc.mutation.Description(); ok {
+		_spec.SetField(featureflag.FieldDescription, field.TypeString, value)
+		_node.Description = value
+	}
+	if value, ok := ffc.mutation.Enabled(); ok {
+		_spec.SetField(featureflag.FieldEnabled, field.TypeBool, value)
+		_node.Enabled = value
+	}
+	if value, ok := ffc.mutation.Rollout(); ok {
+		_spec.SetField(featureflag.FieldRollout, field.TypeInt, value)
+		_node.Rollout = value
+	}
+	if value, ok := ffc.mutation.UserIds(); ok {
+		_spec.SetField(featureflag.FieldUserIds, field.TypeJSON, value)
+		_node.UserIds = value
+	}
+	if value, ok := ffc.mutation.Roles(); ok {
+		_spec.SetField(featureflag.FieldRoles, field.TypeJSON, value)
+		_node.Roles = value
+	}
+	if value, ok := ffc.mutation.CreatedAt(); ok {
+		_spec.SetField(featureflag.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := ffc.mutation.UpdatedAt(); ok {
+		_spec.SetField(featureflag.FieldUpdatedAt, field.TypeTime, value)
+		_node.UpdatedAt = value
+	}
+	return _node, _spec
+}
+
+// FeatureFlagCreateBulk is the builder for creating many FeatureFlag entities in bulk.
+type FeatureFlagCreateBulk struct {
+	config
+	builders []*FeatureFlagCreate
+}
+
+// Save creates the FeatureFlag entities in the database.
+func (ffcb *FeatureFlagCreateBulk) Save(ctx context.Context) ([]*FeatureFlag, error) {
+	specs := make([]*sqlgraph.CreateSpec, len(ffcb.builders))
+	nodes := make([]*FeatureFlag, len(ffcb.builders))
+	mutators := make([]Mutator, len(ffcb.builders))
+	for i := range ffcb.builders {
+		func(i int, root context.Context) {
+			builder := ffcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*FeatureFlagMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, ffcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, ffcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, ffcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ffcb *FeatureFlagCreateBulk) SaveX(ctx context.Context) []*FeatureFlag {
+	v, err := ffcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (ffcb *FeatureFlagCreateBulk) Exec(ctx context.Context) error {
+	_, err := ffcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ffcb *FeatureFlagCreateBulk) ExecX(ctx context.Context) {
+	if err := ffcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,495 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/dialect/sql/sqljson"
+	"entgo.io/ent/schema/field"
+	"github.com/vovanwin/api-my-site/ent/featureflag"
+	"github.com/vovanwin/api-my-site/ent/predicate"
+)
+
+// FeatureFlagUpdate is the builder for updating FeatureFlag entities.
+type FeatureFlagUpdate struct {
+	config
+	hooks    []Hook
+	mutation *FeatureFlagMutation
+}
+
+// Where appends a list predicates to the FeatureFlagUpdate builder.
+func (ffu *FeatureFlagUpdate) Where(ps ...predicate.FeatureFlag) *FeatureFlagUpdate {
+	ffu.mutation.Where(ps...)
+	return ffu
+}
+
+// SetDescription sets the "description" field.
+func (ffu *FeatureFlagUpdate) SetDescription(s string) *FeatureFlagUpdate {
+	ffu.mutation.SetDescription(s)
+	return ffu
+}
+
+// SetNillableDescription sets the "description" field if the given value is not nil.
+func (ffu *FeatureFlagUpdate) SetNillableDescription(s *string) *FeatureFlagUpdate {
+	if s != nil {
+		ffu.SetDescription(*s)
+	}
+	return ffu
+}
+
+// ClearDescription clears the value of the "description" field.
+func (ffu *FeatureFlagUpdate) ClearDescription() *FeatureFlagUpdate {
+	ffu.mutation.ClearDescription()
+	return ffu
+}
+
+// SetEnabled sets the "enabled" field.
+func (ffu *FeatureFlagUpdate) SetEnabled(b bool) *FeatureFlagUpdate {
+	ffu.mutation.SetEnabled(b)
+	return ffu
+}
+
+// SetNillableEnabled sets the "enabled" field if the given value is not nil.
+func (ffu *FeatureFlagUpdate) SetNillableEnabled(b *bool) *FeatureFlagUpdate {
+	if b != nil {
+		ffu.SetEnabled(*b)
+	}
+	return ffu
+}
+
+// SetRollout sets the "rollout" field.
+func (ffu *FeatureFlagUpdate) SetRollout(i int) *FeatureFlagUpdate {
+	ffu.mutation.ResetRollout()
+	ffu.mutation.SetRollout(i)
+	return ffu
+}
+
+// SetNillableRollout sets the "rollout" field if the given value is not nil.
+func (ffu *FeatureFlagUpdate) SetNillableRollout(i *int) *FeatureFlagUpdate {
+	if i != nil {
+		ffu.SetRollout(*i)
+	}
+	return ffu
+}
+
+// AddRollout adds i to the "rollout" field.
+func (ffu *FeatureFlagUpdate) AddRollout(i int) *FeatureFlagUpdate {
+	ffu.mutation.AddRollout(i)
+	return ffu
+}
+
+// SetUserIds sets the "user_ids" field.
+func (ffu *FeatureFlagUpdate) SetUserIds(i []int) *FeatureFlagUpdate {
+	ffu.mutation.SetUserIds(i)
+	return ffu
+}
+
+// AppendUserIds appends i to the "user_ids" field.
+func (ffu *FeatureFlagUpdate) AppendUserIds(i []int) *FeatureFlagUpdate {
+	ffu.mutation.AppendUserIds(i)
+	return ffu
+}
+
+// ClearUserIds clears the value of the "user_ids" field.
+func (ffu *FeatureFlagUpdate) ClearUserIds() *FeatureFlagUpdate {
+	ffu.mutation.ClearUserIds()
+	return ffu
+}
+
+// SetRoles sets the "roles" field.
+func (ffu *FeatureFlagUpdate) SetRoles(s []string) *FeatureFlagUpdate {
+	ffu.mutation.SetRoles(s)
+	return ffu
+}
+
+// AppendRoles appends s to the "roles" field.
+func (ffu *FeatureFlagUpdate) AppendRoles(s []string) *FeatureFlagUpdate {
+	ffu.mutation.AppendRoles(s)
+	return ffu
+}
+
+// ClearRoles clears the value of the "roles" field.
+func (ffu *FeatureFlagUpdate) ClearRoles() *FeatureFlagUpdate {
+	ffu.mutation.ClearRoles()
+	return ffu
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (ffu *FeatureFlagUpdate) SetUpdatedAt(t time.Time) *FeatureFlagUpdate {
+	ffu.mutation.SetUpdatedAt(t)
+	return ffu
+}
+
+// Mutation returns the FeatureFlagMutation object of the builder.
+func (ffu *FeatureFlagUpdate) Mutation() *FeatureFlagMutation {
+	return ffu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (ffu *FeatureFlagUpdate) Save(ctx context.Context) (int, error) {
+	ffu.defaults()
+	return withHooks[int, FeatureFlagMutation](ctx, ffu.sqlSave, ffu.mutation, ffu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ffu *FeatureFlagUpdate) SaveX(ctx context.Context) int {
+	affected, err := ffu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (ffu *FeatureFlagUpdate) Exec(ctx context.Context) error {
+	_, err := ffu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ffu *FeatureFlagUpdate) ExecX(ctx context.Context) {
+	if err := ffu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (ffu *FeatureFlagUpdate) defaults() {
+	if _, ok := ffu.mutation.UpdatedAt(); !ok {
+		v := featureflag.UpdateDefaultUpdatedAt()
+		ffu.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (ffu *FeatureFlagUpdate) check() error {
+	if v, ok := ffu.mutation.Rollout(); ok {
+		if err := featureflag.RolloutValidator(v); err != nil {
+			return &ValidationError{Name: "rollout", err: fmt.Errorf(`ent: validator failed for field "FeatureFlag.rollout": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (ffu *FeatureFlagUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := ffu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(featureflag.Table, featureflag.Columns, sqlgraph.NewFieldSpec(featureflag.FieldID, field.TypeInt))
+	if ps := ffu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := ffu.mutation.Description(); ok {
+		_spec.SetField(featureflag.FieldDescription, field.TypeString, value)
+	}
+	if ffu.mutation.DescriptionCleared() {
+		_spec.ClearField(featureflag.FieldDescription, field.TypeString)
+	}
+	if value, ok := ffu.mutation.Enabled(); ok {
+		_spec.SetField(featureflag.FieldEnabled, field.TypeBool, value)
+	}
+	if value, ok := ffu.mutation.Rollout(); ok {
+		_spec.SetField(featureflag.FieldRollout, field.TypeInt, value)
+	}
+	if value, ok := ffu.mutation.AddedRollout(); ok {
+		_spec.AddField(featureflag.FieldRollout, field.TypeInt, value)
+	}
+	if value, ok := ffu.mutation.UserIds(); ok {
+		_spec.SetField(featureflag.FieldUserIds, field.TypeJSON, value)
+	}
+	if value, ok := ffu.mutation.AppendedUserIds(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, featureflag.FieldUserIds, value)
+		})
+	}
+	if ffu.mutation.UserIdsCleared() {
+		_spec.ClearField(featureflag.FieldUserIds, field.TypeJSON)
+	}
+	if value, ok := ffu.mutation.Roles(); ok {
+		_spec.SetField(featureflag.FieldRoles, field.TypeJSON, value)
+	}
+	if value, ok := ffu.mutation.AppendedRoles(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, featureflag.FieldRoles, value)
+		})
+	}
+	if ffu.mutation.RolesCleared() {
+		_spec.ClearField(featureflag.FieldRoles, field.TypeJSON)
+	}
+	if value, ok := ffu.mutation.UpdatedAt(); ok {
+		_spec.SetField(featureflag.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, ffu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{featureflag.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	ffu.mutation.done = true
+	return n, nil
+}
+
+// FeatureFlagUpdateOne is the builder for updating a single FeatureFlag entity.
+type FeatureFlagUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *FeatureFlagMutation
+}
+
+// SetDescription sets the "description" field.
+func (ffuo *FeatureFlagUpdateOne) SetDescription(s string) *FeatureFlagUpdateOne {
+	ffuo.mutation.SetDescription(s)
+	return ffuo
+}
+
+// SetNillableDescription sets the "description" field if the given value is not nil.
+func (ffuo *FeatureFlagUpdateOne) SetNillableDescription(s *string) *FeatureFlagUpdateOne {
+	if s != nil {
+		ffuo.SetDescription(*s)
+	}
+	return ffuo
+}
+
+// ClearDescription clears the value of the "description" field.
+func (ffuo *FeatureFlagUpdateOne) ClearDescription() *FeatureFlagUpdateOne {
+	ffuo.mutation.ClearDescription()
+	return ffuo
+}
+
+// SetEnabled sets the "enabled" field.
+func (ffuo *FeatureFlagUpdateOne) SetEnabled(b bool) *FeatureFlagUpdateOne {
+	ffuo.mutation.SetEnabled(b)
+	return ffuo
+}
+
+// SetNillableEnabled sets the "enabled" field if the given value is not nil.
+func (ffuo *FeatureFlagUpdateOne) SetNillableEnabled(b *bool) *FeatureFlagUpdateOne {
+	if b != nil {
+		ffuo.SetEnabled(*b)
+	}
+	return ffuo
+}
+
+// SetRollout sets the "rollout" field.
+func (ffuo *FeatureFlagUpdateOne) SetRollout(i int) *FeatureFlagUpdateOne {
+	ffuo.mutation.ResetRollout()
+	ffuo.mutation.SetRollout(i)
+	return ffuo
+}
+
+// SetNillableRollout sets the "rollout" field if the given value is not nil.
+func (ffuo *FeatureFlagUpdateOne) SetNillableRollout(i *int) *FeatureFlagUpdateOne {
+	if i != nil {
+		ffuo.SetRollout(*i)
+	}
+	return ffuo
+}
+
+// AddRollout adds i to the "rollout" field.
+func (ffuo *FeatureFlagUpdateOne) AddRollout(i int) *FeatureFlagUpdateOne {
+	ffuo.mutation.AddRollout(i)
+	return ffuo
+}
+
+// SetUserIds sets the "user_ids" field.
+func (ffuo *FeatureFlagUpdateOne) SetUserIds(i []int) *FeatureFlagUpdateOne {
+	ffuo.mutation.SetUserIds(i)
+	return ffuo
+}
+
+// AppendUserIds appends i to the "user_ids" field.
+func (ffuo *FeatureFlagUpdateOne) AppendUserIds(i []int) *FeatureFlagUpdateOne {
+	ffuo.mutation.AppendUserIds(i)
+	return ffuo
+}
+
+// ClearUserIds clears the value of the "user_ids" field.
+func (ffuo *FeatureFlagUpdateOne) ClearUserIds() *FeatureFlagUpdateOne {
+	ffuo.mutation.ClearUserIds()
+	return ffuo
+}
+
+// SetRoles sets the "roles" field.
+func (ffuo *FeatureFlagUpdateOne) SetRoles(s []string) *FeatureFlagUpdateOne {
+	ffuo.mutation.SetRoles(s)
+	return ffuo
+}
+
+// AppendRoles appends s to the "roles" field.
+func (ffuo *FeatureFlagUpdateOne) AppendRoles(s []string) *FeatureFlagUpdateOne {
+	ffuo.mutation.AppendRoles(s)
+	return ffuo
+}
+
+// ClearRoles clears the value of the "roles" field.
+func (ffuo *FeatureFlagUpdateOne) ClearRoles() *FeatureFlagUpdateOne {
+	ffuo.mutation.ClearRoles()
+	return ffuo
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (ffuo *FeatureFlagUpdateOne) SetUpdatedAt(t time.Time) *FeatureFlagUpdateOne {
+	ffuo.mutation.SetUpdatedAt(t)
+	return ffuo
+}
+
+// Mutation returns the FeatureFlagMutation object of the builder.
+func (ffuo *FeatureFlagUpdateOne) Mutation() *FeatureFlagMutation {
+	return ffuo.mutation
+}
+
+// Where appends a list predicates to the FeatureFlagUpdate builder.
+func (ffuo *FeatureFlagUpdateOne) Where(ps ...predicate.FeatureFlag) *FeatureFlagUpdateOne {
+	ffuo.mutation.Where(ps...)
+	return ffuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (ffuo *FeatureFlagUpdateOne) Select(field string, fields ...string) *FeatureFlagUpdateOne {
+	ffuo.fields = append([]string{field}, fields...)
+	return ffuo
+}
+
+// Save executes the query and returns the updated FeatureFlag entity.
+func (ffuo *FeatureFlagUpdateOne) Save(ctx context.Context) (*FeatureFlag, error) {
+	ffuo.defaults()
+	return withHooks[*FeatureFlag, FeatureFlagMutation](ctx, ffuo.sqlSave, ffuo.mutation, ffuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ffuo *FeatureFlagUpdateOne) SaveX(ctx context.Context) *FeatureFlag {
+	node, err := ffuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (ffuo *FeatureFlagUpdateOne) Exec(ctx context.Context) error {
+	_, err := ffuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ffuo *FeatureFlagUpdateOne) ExecX(ctx context.Context) {
+	if err := ffuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (ffuo *FeatureFlagUpdateOne) defaults() {
+	if _, ok := ffuo.mutation.UpdatedAt(); !ok {
+		v := featureflag.UpdateDefaultUpdatedAt()
+		ffuo.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (ffuo *FeatureFlagUpdateOne) check() error {
+	if v, ok := ffuo.mutation.Rollout(); ok {
+		if err := featureflag.RolloutValidator(v); err != nil {
+			return &ValidationError{Name: "rollout", err: fmt.Errorf(`ent: validator failed for field "FeatureFlag.rollout": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (ffuo *FeatureFlagUpdateOne) sqlSave(ctx context.Context) (_node *FeatureFlag, err error) {
+	if err := ffuo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(featureflag.Table, featureflag.Columns, sqlgraph.NewFieldSpec(featureflag.FieldID, field.TypeInt))
+	id, ok := ffuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "FeatureFlag.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := ffuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, featureflag.FieldID)
+		for _, f := range fields {
+			if !featureflag.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != featureflag.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := ffuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := ffuo.mutation.Description(); ok {
+		_spec.SetField(featureflag.FieldDescription, field.TypeString, value)
+	}
+	if ffuo.mutation.DescriptionCleared() {
+		_spec.ClearField(featureflag.FieldDescription, field.TypeString)
+	}
+	if value, ok := ffuo.mutation.Enabled(); ok {
+		_spec.SetField(featureflag.FieldEnabled, field.TypeBool, value)
+	}
+	if value, ok := ffuo.mutation.Rollout(); ok {
+		_spec.SetField(featureflag.FieldRollout, field.TypeInt, value)
+	}
+	if value, ok := ffuo.mutation.AddedRollout(); ok {
+		_spec.AddField(featureflag.FieldRollout, field.TypeInt, value)
+	}
+	if value, ok := ffuo.mutation.UserIds(); ok {
+		_spec.SetField(featureflag.FieldUserIds, field.TypeJSON, value)
+	}
+	if value, ok := ffuo.mutation.AppendedUserIds(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, featureflag.FieldUserIds, value)
+		})
+	}
+	if ffuo.mutation.UserIdsCleared() {
+		_spec.ClearField(featureflag.FieldUserIds, field.TypeJSON)
+	}
+	if value, ok := ffuo.mutation.Roles(); ok {
+		_spec.SetField(featureflag.FieldRoles, field.TypeJSON, value)
+	}
+	if value, ok := ffuo.mutation.AppendedRoles(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, featureflag.FieldRoles, value)
+		})
+	}
+	if ffuo.mutation.RolesCleared() {
+		_spec.ClearField(featureflag.FieldRoles, field.TypeJSON)
+	}
+	if value, ok := ffuo.mutation.UpdatedAt(); ok {
+		_spec.SetField(featureflag.FieldUpdatedAt, field.TypeTime, value)
+	}
+	_node = &FeatureFlag{config: ffuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, ffuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{featureflag.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	ffuo.mutation.done = true
+	return _node, nil
+}
@@ -0,0 +1,431 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/vovanwin/api-my-site/ent/media"
+	"github.com/vovanwin/api-my-site/ent/mediavariant"
+	"github.com/vovanwin/api-my-site/ent/user"
+)
+
+// MediaCreate is the builder for creating a Media entity.
+type MediaCreate struct {
+	config
+	mutation *MediaMutation
+	hooks    []Hook
+}
+
+// SetKey sets the "key" field.
+func (mc *MediaCreate) SetKey(s string) *MediaCreate {
+	mc.mutation.SetKey(s)
+	return mc
+}
+
+// SetFilename sets the "filename" field.
+func (mc *MediaCreate) SetFilename(s string) *MediaCreate {
+	mc.mutation.SetFilename(s)
+	return mc
+}
+
+// SetMimeType sets the "mime_type" field.
+func (mc *MediaCreate) SetMimeType(s string) *MediaCreate {
+	mc.mutation.SetMimeType(s)
+	return mc
+}
+
+// SetSize sets the "size" field.
+func (mc *MediaCreate) SetSize(i int64) *MediaCreate {
+	mc.mutation.SetSize(i)
+	return mc
+}
+
+// SetChecksum sets the "checksum" field.
+func (mc *MediaCreate) SetChecksum(s string) *MediaCreate {
+	mc.mutation.SetChecksum(s)
+	return mc
+}
+
+// SetWidth sets the "width" field.
+func (mc *MediaCreate) SetWidth(i int) *MediaCreate {
+	mc.mutation.SetWidth(i)
+	return mc
+}
+
+// SetNillableWidth sets the "width" field if the given value is not nil.
+func (mc *MediaCreate) SetNillableWidth(i *int) *MediaCreate {
+	if i != nil {
+		mc.SetWidth(*i)
+	}
+	return mc
+}
+
+// SetHeight sets the "height" field.
+func (mc *MediaCreate) SetHeight(i int) *MediaCreate {
+	mc.mutation.SetHeight(i)
+	return mc
+}
+
+// SetNillableHeight sets the "height" field if the given value is not nil.
+func (mc *MediaCreate) SetNillableHeight(i *int) *MediaCreate {
+	if i != nil {
+		mc.SetHeight(*i)
+	}
+	return mc
+}
+
+// SetPrivate sets the "private" field.
+func (mc *MediaCreate) SetPrivate(b bool) *MediaCreate {
+	mc.mutation.SetPrivate(b)
+	return mc
+}
+
+// SetNillablePrivate sets the "private" field if the given value is not nil.
+func (mc *MediaCreate) SetNillablePrivate(b *bool) *MediaCreate {
+	if b != nil {
+		mc.SetPrivate(*b)
+	}
+	return mc
+}
+
+// SetProcessedAt sets the "processed_at" field.
+func (mc *MediaCreate) SetProcessedAt(t time.Time) *MediaCreate {
+	mc.mutation.SetProcessedAt(t)
+	return mc
+}
+
+// SetNillableProcessedAt sets the "processed_at" field if the given value is not nil.
+func (mc *MediaCreate) SetNillableProcessedAt(t *time.Time) *MediaCreate {
+	if t != nil {
+		mc.SetProcessedAt(*t)
+	}
+	return mc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (mc *MediaCreate) SetCreatedAt(t time.Time) *MediaCreate {
+	mc.mutation.SetCreatedAt(t)
+	return mc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (mc *MediaCreate) SetNillableCreatedAt(t *time.Time) *MediaCreate {
+	if t != nil {
+		mc.SetCreatedAt(*t)
+	}
+	return mc
+}
+
+// SetOwnerID sets the "owner" edge to the User entity by ID.
+func (mc *MediaCreate) SetOwnerID(id int) *MediaCreate {
+	mc.mutation.SetOwnerID(id)
+	return mc
+}
+
+// SetOwner sets the "owner" edge to the User entity.
+func (mc *MediaCreate) SetOwner(u *User) *MediaCreate {
+	return mc.SetOwnerID(u.ID)
+}
+
+// AddVariantIDs adds the "variants" edge to the MediaVariant entity by IDs.
+func (mc *MediaCreate) AddVariantIDs(ids ...int) *MediaCreate {
+	mc.mutation.AddVariantIDs(ids...)
+	return mc
+}
+
+// AddVariants adds the "variants" edges to the MediaVariant entity.
+func (mc *MediaCreate) AddVariants(m ...*MediaVariant) *MediaCreate {
+	ids := make([]int, len(m))
+	for i := range m {
+		ids[i] = m[i].ID
+	}
+	return mc.AddVariantIDs(ids...)
+}
+
+// Mutation returns the MediaMutation object of the builder.
+func (mc *MediaCreate) Mutation() *MediaMutation {
+	return mc.mutation
+}
+
+// Save creates the Media in the database.
+func (mc *MediaCreate) Save(ctx context.Context) (*Media, error) {
+	mc.defaults()
+	return withHooks[*Media, MediaMutation](ctx, mc.sqlSave, mc.mutation, mc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (mc *MediaCreate) SaveX(ctx context.Context) *Media {
+	v, err := mc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (mc *MediaCreate) Exec(ctx context.Context) error {
+	_, err := mc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (mc *MediaCreate) ExecX(ctx context.Context) {
+	if err := mc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (mc *MediaCreate) defaults() {
+	if _, ok := mc.mutation.Private(); !ok {
+		v := media.DefaultPrivate
+		mc.mutation.SetPrivate(v)
+	}
+	if _, ok := mc.mutation.CreatedAt(); !ok {
+		v := media.DefaultCreatedAt()
+		mc.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (mc *MediaCreate) check() error {
+	if _, ok := mc.mutation.Key(); !ok {
+		return &ValidationError{Name: "key", err: errors.New(`ent: missing required field "Media.key"`)}
+	}
+	if v, ok := mc.mutation.Key(); ok {
+		if err := media.KeyValidator(v); err != nil {
+			return &ValidationError{Name: "key", err: fmt.Errorf(`ent: validator failed for field "Media.key": %w`, err)}
+		}
+	}
+	if _, ok := mc.mutation.Filename(); !ok {
+		return &ValidationError{Name: "filename", err: errors.New(`ent: missing required field "Media.filename"`)}
+	}
+	if v, ok := mc.mutation.Filename(); ok {
+		if err := media.FilenameValidator(v); err != nil {
+			return &ValidationError{Name: "filename", err: fmt.Errorf(`ent: validator failed for field "Media.filename": %w`, err)}
+		}
+	}
+	if _, ok := mc.mutation.MimeType(); !ok {
+		return &ValidationError{Name: "mime_type", err: errors.New(`ent: missing required field "Media.mime_type"`)}
+	}
+	if v, ok := mc.mutation.MimeType(); ok {
+		if err := media.MimeTypeValidator(v); err != nil {
+			return &ValidationError{Name: "mime_type", err: fmt.Errorf(`ent: validator failed for field "Media.mime_type": %w`, err)}
+		}
+	}
+	if _, ok := mc.mutation.Size(); !ok {
+		return &ValidationError{Name: "size", err: errors.New(`ent: missing required field "Media.size"`)}
+	}
+	if v, ok := mc.mutation.Size(); ok {
+		if err := media.SizeValidator(v); err != nil {
+			return &ValidationError{Name: "size", err: fmt.Errorf(`ent: validator failed for field "Media.size": %w`, err)}
+		}
+	}
+	if _, ok := mc.mutation.Checksum(); !ok {
+		return &ValidationError{Name: "checksum", err: errors.New(`ent: missing required field "Media.checksum"`)}
+	}
+	if v, ok := mc.mutation.Checksum(); ok {
+		if err := media.ChecksumValidator(v); err != nil {
+			return &ValidationError{Name: "checksum", err: fmt.Errorf(`ent: validator failed for field "Media.checksum": %w`, err)}
+		}
+	}
+	if _, ok := mc.mutation.Private(); !ok {
+		return &ValidationError{Name: "private", err: errors.New(`ent: missing required field "Media.private"`)}
+	}
+	if _, ok := mc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Media.created_at"`)}
+	}
+	if _, ok := mc.mutation.OwnerID(); !ok {
+		return &ValidationError{Name: "owner", err: errors.New(`ent: missing required edge "Media.owner"`)}
+	}
+	return nil
+}
+
+func (mc *MediaCreate) sqlSave(ctx context.Context) (*Media, error) {
+	if err := mc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := mc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, mc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	mc.mutation.id = &_node.ID
+	mc.mutation.done = true
+	return _node, nil
+}
+
+func (mc *MediaCreate) createSpec() (*Media, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Media{config: mc.config}
+		_spec = sqlgraph.NewCreateSpec(media.Table, sqlgraph.NewFieldSpec(media.FieldID, field.TypeInt))
+	)
+	if value, ok := mc.mutation.Key(); ok {
+		_spec.SetField(media.FieldKey, field.TypeString, value)
+		_node.Key = value
+	}
+	if value, ok := mc.mutation.Filename(); ok {
+		_spec.SetField(media.FieldFilename, field.TypeString, value)
+		_node.Filename = value
+	}
+	if value, ok := mc.mutation.MimeType(); ok {
+		_spec.SetField(media.FieldMimeType, field.TypeString, value)
+		_node.MimeType = value
+	}
+	if value, ok := mc.mutation.Size(); ok {
+		_spec.SetField(media.FieldSize, field.TypeInt64, value)
+		_node.Size = value
+	}
+	if value, ok := mc.mutation.Checksum(); ok {
+		_spec.SetField(media.FieldChecksum, field.TypeString, value)
+		_node.Checksum = value
+	}
+	if value, ok := mc.mutation.Width(); ok {
+		_spec.SetField(media.FieldWidth, field.TypeInt, value)
+		_node.Width = value
+	}
+	if value, ok := mc.mutation.Height(); ok {
+		_spec.SetField(media.FieldHeight, field.TypeInt, value)
+		_node.Height = value
+	}
+	if value, ok := mc.mutation.Private(); ok {
+		_spec.SetField(media.FieldPrivate, field.TypeBool, value)
+		_node.Private = value
+	}
+	if value, ok := mc.mutation.ProcessedAt(); ok {
+		_spec.SetField(media.FieldProcessedAt, field.TypeTime, value)
+		_node.ProcessedAt = &value
+	}
+	if value, ok := mc.mutation.CreatedAt(); ok {
+		_spec.SetField(media.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if nodes := mc.mutation.OwnerIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   media.OwnerTable,
+			Columns: []string{media.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.media_owner = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := mc.mutation.VariantsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   media.VariantsTable,
+			Columns: []string{media.VariantsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(mediavariant.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// MediaCreateBulk is the builder for creating many Media entities in bulk.
+type MediaCreateBulk struct {
+	config
+	builders []*MediaCreate
+}
+
+// Save creates the Media entities in the database.
+func (mcb *MediaCreateBulk) Save(ctx context.Context) ([]*Media, error) {
+	specs := make([]*sqlgraph.CreateSpec, len(mcb.builders))
+	nodes := make([]*Media, len(mcb.builders))
+	mutators := make([]Mutator, len(mcb.builders))
+	for i := range mcb.builders {
+		func(i int, root context.Context) {
+			builder := mcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*MediaMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, mcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, mcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, mcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (mcb *MediaCreateBulk) SaveX(ctx context.Context) []*Media {
+	v, err := mcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (mcb *MediaCreateBulk) Exec(ctx context.Context) error {
+	_, err := mcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (mcb *MediaCreateBulk) ExecX(ctx context.Context) {
+	if err := mcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,816 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/vovanwin/api-my-site/ent/media"
+	"github.com/vovanwin/api-my-site/ent/mediavariant"
+	"github.com/vovanwin/api-my-site/ent/predicate"
+	"github.com/vovanwin/api-my-site/ent/user"
+)
+
+// MediaUpdate is the builder for updating Media entities.
+type MediaUpdate struct {
+	config
+	hooks    []Hook
+	mutation *MediaMutation
+}
+
+// Where appends a list predicates to the MediaUpdate builder.
+func (mu *MediaUpdate) Where(ps ...predicate.Media) *MediaUpdate {
+	mu.mutation.Where(ps...)
+	return mu
+}
+
+// SetFilename sets the "filename" field.
+func (mu *MediaUpdate) SetFilename(s string) *MediaUpdate {
+	mu.mutation.SetFilename(s)
+	return mu
+}
+
+// SetMimeType sets the "mime_type" field.
+func (mu *MediaUpdate) SetMimeType(s string) *MediaUpdate {
+	mu.mutation.SetMimeType(s)
+	return mu
+}
+
+// SetSize sets the "size" field.
+func (mu *MediaUpdate) SetSize(i int64) *MediaUpdate {
+	mu.mutation.ResetSize()
+	mu.mutation.SetSize(i)
+	return mu
+}
+
+// AddSize adds i to the "size" field.
+func (mu *MediaUpdate) AddSize(i int64) *MediaUpdate {
+	mu.mutation.AddSize(i)
+	return mu
+}
+
+// SetChecksum sets the "checksum" field.
+func (mu *MediaUpdate) SetChecksum(s string) *MediaUpdate {
+	mu.mutation.SetChecksum(s)
+	return mu
+}
+
+// SetWidth sets the "width" field.
+func (mu *MediaUpdate) SetWidth(i int) *MediaUpdate {
+	mu.mutation.ResetWidth()
+	mu.mutation.SetWidth(i)
+	return mu
+}
+
+// SetNillableWidth sets the "width" field if the given value is not nil.
+func (mu *MediaUpdate) SetNillableWidth(i *int) *MediaUpdate {
+	if i != nil {
+		mu.SetWidth(*i)
+	}
+	return mu
+}
+
+// AddWidth adds i to the "width" field.
+func (mu *MediaUpdate) AddWidth(i int) *MediaUpdate {
+	mu.mutation.AddWidth(i)
+	return mu
+}
+
+// ClearWidth clears the value of the "width" field.
+func (mu *MediaUpdate) ClearWidth() *MediaUpdate {
+	mu.mutation.ClearWidth()
+	return mu
+}
+
+// SetHeight sets the "height" field.
+func (mu *MediaUpdate) SetHeight(i int) *MediaUpdate {
+	mu.mutation.ResetHeight()
+	mu.mutation.SetHeight(i)
+	return mu
+}
+
+// SetNillableHeight sets the "height" field if the given value is not nil.
+func (mu *MediaUpdate) SetNillableHeight(i *int) *MediaUpdate {
+	if i != nil {
+		mu.SetHeight(*i)
+	}
+	return mu
+}
+
+// AddHeight adds i to the "height" field.
+func (mu *MediaUpdate) AddHeight(i int) *MediaUpdate {
+	mu.mutation.AddHeight(i)
+	return mu
+}
+
+// ClearHeight clears the value of the "height" field.
+func (mu *MediaUpdate) ClearHeight() *MediaUpdate {
+	mu.mutation.ClearHeight()
+	return mu
+}
+
+// SetPrivate sets the "private" field.
+func (mu *MediaUpdate) SetPrivate(b bool) *MediaUpdate {
+	mu.mutation.SetPrivate(b)
+	return mu
+}
+
+// SetNillablePrivate sets the "private" field if the given value is not nil.
+func (mu *MediaUpdate) SetNillablePrivate(b *bool) *MediaUpdate {
+	if b != nil {
+		mu.SetPrivate(*b)
+	}
+	return mu
+}
+
+// SetProcessedAt sets the "processed_at" field.
+func (mu *MediaUpdate) SetProcessedAt(t time.Time) *MediaUpdate {
+	mu.mutation.SetProcessedAt(t)
+	return mu
+}
+
+// SetNillableProcessedAt sets the "processed_at" field if the given value is not nil.
+func (mu *MediaUpdate) SetNillableProcessedAt(t *time.Time) *MediaUpdate {
+	if t != nil {
+		mu.SetProcessedAt(*t)
+	}
+	return mu
+}
+
+// ClearProcessedAt clears the value of the "processed_at" field.
+func (mu *MediaUpdate) ClearProcessedAt() *MediaUpdate {
+	mu.mutation.ClearProcessedAt()
+	return mu
+}
+
+// SetOwnerID sets the "owner" edge to the User entity by ID.
+func (mu *MediaUpdate) SetOwnerID(id int) *MediaUpdate {
+	mu.mutation.SetOwnerID(id)
+	return mu
+}
+
+// SetOwner sets the "owner" edge to the User entity.
+func (mu *MediaUpdate) SetOwner(u *User) *MediaUpdate {
+	return mu.SetOwnerID(u.ID)
+}
+
+// AddVariantIDs adds the "variants" edge to the MediaVariant entity by IDs.
+func (mu *MediaUpdate) AddVariantIDs(ids ...int) *MediaUpdate {
+	mu.mutation.AddVariantIDs(ids...)
+	return mu
+}
+
+// AddVariants adds the "variants" edges to the MediaVariant entity.
+func (mu *MediaUpdate) AddVariants(m ...*MediaVariant) *MediaUpdate {
+	ids := make([]int, len(m))
+	for i := range m {
+		ids[i] = m[i].ID
+	}
+	return mu.AddVariantIDs(ids...)
+}
+
+// Mutation returns the MediaMutation object of the builder.
+func (mu *MediaUpdate) Mutation() *MediaMutation {
+	return mu.mutation
+}
+
+// ClearOwner clears the "owner" edge to the User entity.
+func (mu *MediaUpdate) ClearOwner() *MediaUpdate {
+	mu.mutation.ClearOwner()
+	return mu
+}
+
+// ClearVariants clears all "variants" edges to the MediaVariant entity.
+func (mu *MediaUpdate) ClearVariants() *MediaUpdate {
+	mu.mutation.ClearVariants()
+	return mu
+}
+
+// RemoveVariantIDs removes the "variants" edge to MediaVariant entities by IDs.
+func (mu *MediaUpdate) RemoveVariantIDs(ids ...int) *MediaUpdate {
+	mu.mutation.RemoveVariantIDs(ids...)
+	return mu
+}
+
+// RemoveVariants removes "variants" edges to MediaVariant entities.
+func (mu *MediaUpdate) RemoveVariants(m ...*MediaVariant) *MediaUpdate {
+	ids := make([]int, len(m))
+	for i := range m {
+		ids[i] = m[i].ID
+	}
+	return mu.RemoveVariantIDs(ids...)
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (mu *MediaUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks[int, MediaMutation](ctx, mu.sqlSave, mu.mutation, mu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (mu *MediaUpdate) SaveX(ctx context.Context) int {
+	affected, err := mu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (mu *MediaUpdate) Exec(ctx context.Context) error {
+	_, err := mu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (mu *MediaUpdate) ExecX(ctx context.Context) {
+	if err := mu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (mu *MediaUpdate) check() error {
+	if v, ok := mu.mutation.Filename(); ok {
+		if err := media.FilenameValidator(v); err != nil {
+			return &ValidationError{Name: "filename", err: fmt.Errorf(`ent: validator failed for field "Media.filename": %w`, err)}
+		}
+	}
+	if v, ok := mu.mutation.MimeType(); ok {
+		if err := media.MimeTypeValidator(v); err != nil {
+			return &ValidationError{Name: "mime_type", err: fmt.Errorf(`ent: validator failed for field "Media.mime_type": %w`, err)}
+		}
+	}
+	if v, ok := mu.mutation.Size(); ok {
+		if err := media.SizeValidator(v); err != nil {
+			return &ValidationError{Name: "size", err: fmt.Errorf(`ent: validator failed for field "Media.size": %w`, err)}
+		}
+	}
+	if v, ok := mu.mutation.Checksum(); ok {
+		if err := media.ChecksumValidator(v); err != nil {
+			return &ValidationError{Name: "checksum", err: fmt.Errorf(`ent: validator failed for field "Media.checksum": %w`, err)}
+		}
+	}
+	if _, ok := mu.mutation.OwnerID(); mu.mutation.OwnerCleared() && !ok {
+		return errors.New(`ent: clearing a required unique edge "Media.owner"`)
+	}
+	return nil
+}
+
+func (mu *MediaUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := mu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(media.Table, media.Columns, sqlgraph.NewFieldSpec(media.FieldID, field.TypeInt))
+	if ps := mu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := mu.mutation.Filename(); ok {
+		_spec.SetField(media.FieldFilename, field.TypeString, value)
+	}
+	if value, ok := mu.mutation.MimeType(); ok {
+		_spec.SetField(media.FieldMimeType, field.TypeString, value)
+	}
+	if value, ok := mu.mutation.Size(); ok {
+		_spec.SetField(media.FieldSize, field.TypeInt64, value)
+	}
+	if value, ok := mu.mutation.AddedSize(); ok {
+		_spec.AddField(media.FieldSize, field.TypeInt64, value)
+	}
+	if value, ok := mu.mutation.Checksum(); ok {
+		_spec.SetField(media.FieldChecksum, field.TypeString, value)
+	}
+	if value, ok := mu.mutation.Width(); ok {
+		_spec.SetField(media.FieldWidth, field.TypeInt, value)
+	}
+	if value, ok := mu.mutation.AddedWidth(); ok {
+		_spec.AddField(media.FieldWidth, field.TypeInt, value)
+	}
+	if mu.mutation.WidthCleared() {
+		_spec.ClearField(media.FieldWidth, field.TypeInt)
+	}
+	if value, ok := mu.mutation.Height(); ok {
+		_spec.SetField(media.FieldHeight, field.TypeInt, value)
+	}
+	if value, ok := mu.mutation.AddedHeight(); ok {
+		_spec.AddField(media.FieldHeight, field.TypeInt, value)
+	}
+	if mu.mutation.HeightCleared() {
+		_spec.ClearField(media.FieldHeight, field.TypeInt)
+	}
+	if value, ok := mu.mutation.Private(); ok {
+		_spec.SetField(media.FieldPrivate, field.TypeBool, value)
+	}
+	if value, ok := mu.mutation.ProcessedAt(); ok {
+		_spec.SetField(media.FieldProcessedAt, field.TypeTime, value)
+	}
+	if mu.mutation.ProcessedAtCleared() {
+		_spec.ClearField(media.FieldProcessedAt, field.TypeTime)
+	}
+	if mu.mutation.OwnerCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   media.OwnerTable,
+			Columns: []string{media.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := mu.mutation.OwnerIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   media.OwnerTable,
+			Columns: []string{media.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if mu.mutation.VariantsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   media.VariantsTable,
+			Columns: []string{media.VariantsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(mediavariant.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := mu.mutation.RemovedVariantsIDs(); len(nodes) > 0 && !mu.mutation.VariantsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   media.VariantsTable,
+			Columns: []string{media.VariantsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(mediavariant.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := mu.mutation.VariantsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   media.VariantsTable,
+			Columns: []string{media.VariantsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(mediavariant.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, mu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{media.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	mu.mutation.done = true
+	return n, nil
+}
+
+// MediaUpdateOne is the builder for updating a single Media entity.
+type MediaUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *MediaMutation
+}
+
+// SetFilename sets the "filename" field.
+func (muo *MediaUpdateOne) SetFilename(s string) *MediaUpdateOne {
+	muo.mutation.SetFilename(s)
+	return muo
+}
+
+// SetMimeType sets the "mime_type" field.
+func (muo *MediaUpdateOne) SetMimeType(s string) *MediaUpdateOne {
+	muo.mutation.SetMimeType(s)
+	return muo
+}
+
+// SetSize sets the "size" field.
+func (muo *MediaUpdateOne) SetSize(i int64) *MediaUpdateOne {
+	muo.mutation.ResetSize()
+	muo.mutation.SetSize(i)
+	return muo
+}
+
+// AddSize adds i to the "size" field.
+func (muo *MediaUpdateOne) AddSize(i int64) *MediaUpdateOne {
+	muo.mutation.AddSize(i)
+	return muo
+}
+
+// SetChecksum sets the "checksum" field.
+func (muo *MediaUpdateOne) SetChecksum(s string) *MediaUpdateOne {
+	muo.mutation.SetChecksum(s)
+	return muo
+}
+
+// SetWidth sets the "width" field.
+func (muo *MediaUpdateOne) SetWidth(i int) *MediaUpdateOne {
+	muo.mutation.ResetWidth()
+	muo.mutation.SetWidth(i)
+	return muo
+}
+
+// SetNillableWidth sets the "width" field if the given value is not nil.
+func (muo *MediaUpdateOne) SetNillableWidth(i *int) *MediaUpdateOne {
+	if i != nil {
+		muo.SetWidth(*i)
+	}
+	return muo
+}
+
+// AddWidth adds i to the "width" field.
+func (muo *MediaUpdateOne) AddWidth(i int) *MediaUpdateOne {
+	muo.mutation.AddWidth(i)
+	return muo
+}
+
+// ClearWidth clears the value of the "width" field.
+func (muo *MediaUpdateOne) ClearWidth() *MediaUpdateOne {
+	muo.mutation.ClearWidth()
+	return muo
+}
+
+// SetHeight sets the "height" field.
+func (muo *MediaUpdateOne) SetHeight(i int) *MediaUpdateOne {
+	muo.mutation.ResetHeight()
+	muo.mutation.SetHeight(i)
+	return muo
+}
+
+// SetNillableHeight sets the "height" field if the given value is not nil.
+func (muo *MediaUpdateOne) SetNillableHeight(i *int) *MediaUpdateOne {
+	if i != nil {
+		muo.SetHeight(*i)
+	}
+	return muo
+}
+
+// AddHeight adds i to the "height" field.
+func (muo *MediaUpdateOne) AddHeight(i int) *MediaUpdateOne {
+	muo.mutation.AddHeight(i)
+	return muo
+}
+
+// ClearHeight clears the value of the "height" field.
+func (muo *MediaUpdateOne) ClearHeight() *MediaUpdateOne {
+	muo.mutation.ClearHeight()
+	return muo
+}
+
+// SetPrivate sets the "private" field.
+func (muo *MediaUpdateOne) SetPrivate(b bool) *MediaUpdateOne {
+	muo.mutation.SetPrivate(b)
+	return muo
+}
+
+// SetNillablePrivate sets the "private" field if the given value is not nil.
+func (muo *MediaUpdateOne) SetNillablePrivate(b *bool) *MediaUpdateOne {
+	if b != nil {
+		muo.SetPrivate(*b)
+	}
+	return muo
+}
+
+// SetProcessedAt sets the "processed_at" field.
+func (muo *MediaUpdateOne) SetProcessedAt(t time.Time) *MediaUpdateOne {
+	muo.mutation.SetProcessedAt(t)
+	return muo
+}
+
+// SetNillableProcessedAt sets the "processed_at" field if the given value is not nil.
+func (muo *MediaUpdateOne) SetNillableProcessedAt(t *time.Time) *MediaUpdateOne {
+	if t != nil {
+		muo.SetProcessedAt(*t)
+	}
+	return muo
+}
+
+// ClearProcessedAt clears the value of the "processed_at" field.
+func (muo *MediaUpdateOne) ClearProcessedAt() *MediaUpdateOne {
+	muo.mutation.ClearProcessedAt()
+	return muo
+}
+
+// SetOwnerID sets the "owner" edge to the User entity by ID.
+func (muo *MediaUpdateOne) SetOwnerID(id int) *MediaUpdateOne {
+	muo.mutation.SetOwnerID(id)
+	return muo
+}
+
+// SetOwner sets the "owner" edge to the User entity.
+func (muo *MediaUpdateOne) SetOwner(u *User) *MediaUpdateOne {
+	return muo.SetOwnerID(u.ID)
+}
+
+// AddVariantIDs adds the "variants" edge to the MediaVariant entity by IDs.
+func (muo *MediaUpdateOne) AddVariantIDs(ids ...int) *MediaUpdateOne {
+	muo.mutation.AddVariantIDs(ids...)
+	return muo
+}
+
+// AddVariants adds the "variants" edges to the MediaVariant entity.
+func (muo *MediaUpdateOne) AddVariants(m ...*MediaVariant) *MediaUpdateOne {
+	ids := make([]int, len(m))
+	for i := range m {
+		ids[i] = m[i].ID
+	}
+	return muo.AddVariantIDs(ids...)
+}
+
+// Mutation returns the MediaMutation object of the builder.
+func (muo *MediaUpdateOne) Mutation() *MediaMutation {
+	return muo.mutation
+}
+
+// ClearOwner clears the "owner" edge to the User entity.
+func (muo *MediaUpdateOne) ClearOwner() *MediaUpdateOne {
+	muo.mutation.ClearOwner()
+	return muo
+}
+
+// ClearVariants clears all "variants" edges to the MediaVariant entity.
+func (muo *MediaUpdateOne) ClearVariants() *MediaUpdateOne {
+	muo.mutation.ClearVariants()
+	return muo
+}
+
+// RemoveVariantIDs removes the "variants" edge to MediaVariant entities by IDs.
+func (muo *MediaUpdateOne) RemoveVariantIDs(ids ...int) *MediaUpdateOne {
+	muo.mutation.RemoveVariantIDs(ids...)
+	return muo
+}
+
+// RemoveVariants removes "variants" edges to MediaVariant entities.
+func (muo *MediaUpdateOne) RemoveVariants(m ...*MediaVariant) *MediaUpdateOne {
+	ids := make([]int, len(m))
+	for i := range m {
+		ids[i] = m[i].ID
+	}
+	return muo.RemoveVariantIDs(ids...)
+}
+
+// Where appends a list predicates to the MediaUpdate builder.
+func (muo *MediaUpdateOne) Where(ps ...predicate.Media) *MediaUpdateOne {
+	muo.mutation.Where(ps...)
+	return muo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (muo *MediaUpdateOne) Select(field string, fields ...string) *MediaUpdateOne {
+	muo.fields = append([]string{field}, fields...)
+	return muo
+}
+
+// Save executes the query and returns the updated Media entity.
+func (muo *MediaUpdateOne) Save(ctx context.Context) (*Media, error) {
+	return withHooks[*Media, MediaMutation](ctx, muo.sqlSave, muo.mutation, muo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (muo *MediaUpdateOne) SaveX(ctx context.Context) *Media {
+	node, err := muo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (muo *MediaUpdateOne) Exec(ctx context.Context) error {
+	_, err := muo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (muo *MediaUpdateOne) ExecX(ctx context.Context) {
+	if err := muo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (muo *MediaUpdateOne) check() error {
+	if v, ok := muo.mutation.Filename(); ok {
+		if err := media.FilenameValidator(v); err != nil {
+			return &ValidationError{Name: "filename", err: fmt.Errorf(`ent: validator failed for field "Media.filename": %w`, err)}
+		}
+	}
+	if v, ok := muo.mutation.MimeType(); ok {
+		if err := media.MimeTypeValidator(v); err != nil {
+			return &ValidationError{Name: "mime_type", err: fmt.Errorf(`ent: validator failed for field "Media.mime_type": %w`, err)}
+		}
+	}
+	if v, ok := muo.mutation.Size(); ok {
+		if err := media.SizeValidator(v); err != nil {
+			return &ValidationError{Name: "size", err: fmt.Errorf(`ent: validator failed for field "Media.size": %w`, err)}
+		}
+	}
+	if v, ok := muo.mutation.Checksum(); ok {
+		if err := media.ChecksumValidator(v); err != nil {
+			return &ValidationError{Name: "checksum", err: fmt.Errorf(`ent: validator failed for field "Media.checksum": %w`, err)}
+		}
+	}
+	if _, ok := muo.mutation.OwnerID(); muo.mutation.OwnerCleared() && !ok {
+		return errors.New(`ent: clearing a required unique edge "Media.owner"`)
+	}
+	return nil
+}
+
+func (muo *MediaUpdateOne) sqlSave(ctx context.Context) (_node *Media, err error) {
+	if err := muo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(media.Table, media.Columns, sqlgraph.NewFieldSpec(media.FieldID, field.TypeInt))
+	id, ok := muo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Media.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := muo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, media.FieldID)
+		for _, f := range fields {
+			if !media.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != media.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := muo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := muo.mutation.Filename(); ok {
+		_spec.SetField(media.FieldFilename, field.TypeString, value)
+	}
+	if value, ok := muo.mutation.MimeType(); ok {
+		_spec.SetField(media.FieldMimeType, field.TypeString, value)
+	}
+	if value, ok := muo.mutation.Size(); ok {
+		_spec.SetField(media.FieldSize, field.TypeInt64, value)
+	}
+	if value, ok := muo.mutation.AddedSize(); ok {
+		_spec.AddField(media.FieldSize, field.TypeInt64, value)
+	}
+	if value, ok := muo.mutation.Checksum(); ok {
+		_spec.SetField(media.FieldChecksum, field.TypeString, value)
+	}
+	if value, ok := muo.mutation.Width(); ok {
+		_spec.SetField(media.FieldWidth, field.TypeInt, value)
+	}
+	if value, ok := muo.mutation.AddedWidth(); ok {
+		_spec.AddField(media.FieldWidth, field.TypeInt, value)
+	}
+	if muo.mutation.WidthCleared() {
+		_spec.ClearField(media.FieldWidth, field.TypeInt)
+	}
+	if value, ok := muo.mutation.Height(); ok {
+		_spec.SetField(media.FieldHeight, field.TypeInt, value)
+	}
+	if value, ok := muo.mutation.AddedHeight(); ok {
+		_spec.AddField(media.FieldHeight, field.TypeInt, value)
+	}
+	if muo.mutation.HeightCleared() {
+		_spec.ClearField(media.FieldHeight, field.TypeInt)
+	}
+	if value, ok := muo.mutation.Private(); ok {
+		_spec.SetField(media.FieldPrivate, field.TypeBool, value)
+	}
+	if value, ok := muo.mutation.ProcessedAt(); ok {
+		_spec.SetField(media.FieldProcessedAt, field.TypeTime, value)
+	}
+	if muo.mutation.ProcessedAtCleared() {
+		_spec.ClearField(media.FieldProcessedAt, field.TypeTime)
+	}
+	if muo.mutation.OwnerCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   media.OwnerTable,
+			Columns: []string{media.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := muo.mutation.OwnerIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   media.OwnerTable,
+			Columns: []string{media.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if muo.mutation.VariantsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   media.VariantsTable,
+			Columns: []string{media.VariantsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(mediavariant.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := muo.mutation.RemovedVariantsIDs(); len(nodes) > 0 && !muo.mutation.VariantsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   media.VariantsTable,
+			Columns: []string{media.VariantsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(mediavariant.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := muo.mutation.VariantsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   media.VariantsTable,
+			Columns: []string{media.VariantsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(mediavariant.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &Media{config: muo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, muo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{media.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	muo.mutation.done = true
+	return _node, nil
+}
@@ -0,0 +1,213 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/vovanwin/api-my-site/ent/media"
+	"github.com/vovanwin/api-my-site/ent/mediavariant"
+)
+
+// MediaVariant is the model entity for the MediaVariant schema.
+type MediaVariant struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// Name holds the value of the "name" field.
+	Name string `json:"name,omitempty"`
+	// Key holds the value of the "key" field.
+	Key string `json:"key,omitempty"`
+	// MimeType holds the value of the "mime_type" field.
+	MimeType string `json:"mime_type,omitempty"`
+	// Size holds the value of the "size" field.
+	Size int64 `json:"size,omitempty"`
+	// Width holds the value of the "width" field.
+	Width int `json:"width,omitempty"`
+	// Height holds the value of the "height" field.
+	Height int `json:"height,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the MediaVariantQuery when eager-loading is set.
+	Edges               MediaVariantEdges `json:"edges"`
+	media_variant_media *int
+	selectValues        sql.SelectValues
+}
+
+// MediaVariantEdges holds the relations/edges for other nodes in the graph.
+type MediaVariantEdges struct {
+	// Media holds the value of the media edge.
+	Media *Media `json:"media,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// MediaOrErr returns the Media value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e MediaVariantEdges) MediaOrErr() (*Media, error) {
+	if e.loadedTypes[0] {
+		if e.Media == nil {
+			// Edge was loaded but was not found.
+			return nil, &NotFoundError{label: media.Label}
+		}
+		return e.Media, nil
+	}
+	return nil, &NotLoadedError{edge: "media"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*MediaVariant) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case mediavariant.FieldID, mediavariant.FieldSize, mediavariant.FieldWidth, mediavariant.FieldHeight:
+			values[i] = new(sql.NullInt64)
+		case mediavariant.FieldName, mediavariant.FieldKey, mediavariant.FieldMimeType:
+			values[i] = new(sql.NullString)
+		case mediavariant.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		case mediavariant.ForeignKeys[0]: // media_variant_media
+			values[i] = new(sql.NullInt64)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the MediaVariant fields.
+func (mv *MediaVariant) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case mediavariant.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			mv.ID = int(value.Int64)
+		case mediavariant.FieldName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field name", values[i])
+			} else if value.Valid {
+				mv.Name = value.String
+			}
+		case mediavariant.FieldKey:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field key", values[i])
+			} else if value.Valid {
+				mv.Key = value.String
+			}
+		case mediavariant.FieldMimeType:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field mime_type", values[i])
+			} else if value.Valid {
+				mv.MimeType = value.String
+			}
+		case mediavariant.FieldSize:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field size", values[i])
+			} else if value.Valid {
+				mv.Size = value.Int64
+			}
+		case mediavariant.FieldWidth:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field width", values[i])
+			} else if value.Valid {
+				mv.Width = int(value.Int64)
+			}
+		case mediavariant.FieldHeight:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field height", values[i])
+			} else if value.Valid {
+				mv.Height = int(value.Int64)
+			}
+		case mediavariant.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				mv.CreatedAt = value.Time
+			}
+		case mediavariant.ForeignKeys[0]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field media_variant_media", value)
+			} else if value.Valid {
+				mv.media_variant_media = new(int)
+				*mv.media_variant_media = int(value.Int64)
+			}
+		default:
+			mv.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the MediaVariant.
+// This includes values selected through modifiers, order, etc.
+func (mv *MediaVariant) Value(name string) (ent.Value, error) {
+	return mv.selectValues.Get(name)
+}
+
+// QueryMedia queries the "media" edge of the MediaVariant entity.
+func (mv *MediaVariant) QueryMedia() *MediaQuery {
+	return NewMediaVariantClient(mv.config).QueryMedia(mv)
+}
+
+// Update returns a builder for updating this MediaVariant.
+// Note that you need to call MediaVariant.Unwrap() before calling this method if this MediaVariant
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (mv *MediaVariant) Update() *MediaVariantUpdateOne {
+	return NewMediaVariantClient(mv.config).UpdateOne(mv)
+}
+
+// Unwrap unwraps the MediaVariant entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (mv *MediaVariant) Unwrap() *MediaVariant {
+	_tx, ok := mv.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: MediaVariant is not a transactional entity")
+	}
+	mv.config.driver = _tx.drv
+	return mv
+}
+
+// String implements the fmt.Stringer.
+func (mv *MediaVariant) String() string {
+	var builder strings.Builder
+	builder.WriteString("MediaVariant(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", mv.ID))
+	builder.WriteString("name=")
+	builder.WriteString(mv.Name)
+	builder.WriteString(", ")
+	builder.WriteString("key=")
+	builder.WriteString(mv.Key)
+	builder.WriteString(", ")
+	builder.WriteString("mime_type=")
+	builder.WriteString(mv.MimeType)
+	builder.WriteString(", ")
+	builder.WriteString("size=")
+	builder.WriteString(fmt.Sprintf("%v", mv.Size))
+	builder.WriteString(", ")
+	builder.WriteString("width=")
+	builder.WriteString(fmt.Sprintf("%v", mv.Width))
+	builder.WriteString(", ")
+	builder.WriteString("height=")
+	builder.WriteString(fmt.Sprintf("%v", mv.Height))
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(mv.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// MediaVariants is a parsable slice of MediaVariant.
+type MediaVariants []*MediaVariant
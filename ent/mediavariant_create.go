@@ -0,0 +1,338 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/vovanwin/api-my-site/ent/media"
+	"github.com/vovanwin/api-my-site/ent/mediavariant"
+)
+
+// MediaVariantCreate is the builder for creating a MediaVariant entity.
+type MediaVariantCreate struct {
+	config
+	mutation *MediaVariantMutation
+	hooks    []Hook
+}
+
+// SetName sets the "name" field.
+func (mvc *MediaVariantCreate) SetName(s string) *MediaVariantCreate {
+	mvc.mutation.SetName(s)
+	return mvc
+}
+
+// SetKey sets the "key" field.
+func (mvc *MediaVariantCreate) SetKey(s string) *MediaVariantCreate {
+	mvc.mutation.SetKey(s)
+	return mvc
+}
+
+// SetMimeType sets the "mime_type" field.
+func (mvc *MediaVariantCreate) SetMimeType(s string) *MediaVariantCreate {
+	mvc.mutation.SetMimeType(s)
+	return mvc
+}
+
+// SetSize sets the "size" field.
+func (mvc *MediaVariantCreate) SetSize(i int64) *MediaVariantCreate {
+	mvc.mutation.SetSize(i)
+	return mvc
+}
+
+// SetWidth sets the "width" field.
+func (mvc *MediaVariantCreate) SetWidth(i int) *MediaVariantCreate {
+	mvc.mutation.SetWidth(i)
+	return mvc
+}
+
+// SetHeight sets the "height" field.
+func (mvc *MediaVariantCreate) SetHeight(i int) *MediaVariantCreate {
+	mvc.mutation.SetHeight(i)
+	return mvc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (mvc *MediaVariantCreate) SetCreatedAt(t time.Time) *MediaVariantCreate {
+	mvc.mutation.SetCreatedAt(t)
+	return mvc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (mvc *MediaVariantCreate) SetNillableCreatedAt(t *time.Time) *MediaVariantCreate {
+	if t != nil {
+		mvc.SetCreatedAt(*t)
+	}
+	return mvc
+}
+
+// SetMediaID sets the "media" edge to the Media entity by ID.
+func (mvc *MediaVariantCreate) SetMediaID(id int) *MediaVariantCreate {
+	mvc.mutation.SetMediaID(id)
+	return mvc
+}
+
+// SetMedia sets the "media" edge to the Media entity.
+func (mvc *MediaVariantCreate) SetMedia(m *Media) *MediaVariantCreate {
+	return mvc.SetMediaID(m.ID)
+}
+
+// Mutation returns the MediaVariantMutation object of the builder.
+func (mvc *MediaVariantCreate) Mutation() *MediaVariantMutation {
+	return mvc.mutation
+}
+
+// Save creates the MediaVariant in the database.
+func (mvc *MediaVariantCreate) Save(ctx context.Context) (*MediaVariant, error) {
+	mvc.defaults()
+	return withHooks[*MediaVariant, MediaVariantMutation](ctx, mvc.sqlSave, mvc.mutation, mvc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (mvc *MediaVariantCreate) SaveX(ctx context.Context) *MediaVariant {
+	v, err := mvc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (mvc *MediaVariantCreate) Exec(ctx context.Context) error {
+	_, err := mvc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (mvc *MediaVariantCreate) ExecX(ctx context.Context) {
+	if err := mvc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (mvc *MediaVariantCreate) defaults() {
+	if _, ok := mvc.mutation.CreatedAt(); !ok {
+		v := mediavariant.DefaultCreatedAt()
+		mvc.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (mvc *MediaVariantCreate) check() error {
+	if _, ok := mvc.mutation.Name(); !ok {
+		return &ValidationError{Name: "name", err: errors.New(`ent: missing required field "MediaVariant.name"`)}
+	}
+	if v, ok := mvc.mutation.Name(); ok {
+		if err := mediavariant.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "MediaVariant.name": %w`, err)}
+		}
+	}
+	if _, ok := mvc.mutation.Key(); !ok {
+		return &ValidationError{Name: "key", err: errors.New(`ent: missing required field "MediaVariant.key"`)}
+	}
+	if v, ok := mvc.mutation.Key(); ok {
+		if err := mediavariant.KeyValidator(v); err != nil {
+			return &ValidationError{Name: "key", err: fmt.Errorf(`ent: validator failed for field "MediaVariant.key": %w`, err)}
+		}
+	}
+	if _, ok := mvc.mutation.MimeType(); !ok {
+		return &ValidationError{Name: "mime_type", err: errors.New(`ent: missing required field "MediaVariant.mime_type"`)}
+	}
+	if v, ok := mvc.mutation.MimeType(); ok {
+		if err := mediavariant.MimeTypeValidator(v); err != nil {
+			return &ValidationError{Name: "mime_type", err: fmt.Errorf(`ent: validator failed for field "MediaVariant.mime_type": %w`, err)}
+		}
+	}
+	if _, ok := mvc.mutation.Size(); !ok {
+		return &ValidationError{Name: "size", err: errors.New(`ent: missing required field "MediaVariant.size"`)}
+	}
+	if v, ok := mvc.mutation.Size(); ok {
+		if err := mediavariant.SizeValidator(v); err != nil {
+			return &ValidationError{Name: "size", err: fmt.Errorf(`ent: validator failed for field "MediaVariant.size": %w`, err)}
+		}
+	}
+	if _, ok := mvc.mutation.Width(); !ok {
+		return &ValidationError{Name: "width", err: errors.New(`ent: missing required field "MediaVariant.width"`)}
+	}
+	if v, ok := mvc.mutation.Width(); ok {
+		if err := mediavariant.WidthValidator(v); err != nil {
+			return &ValidationError{Name: "width", err: fmt.Errorf(`ent: validator failed for field "MediaVariant.width": %w`, err)}
+		}
+	}
+	if _, ok := mvc.mutation.Height(); !ok {
+		return &ValidationError{Name: "height", err: errors.New(`ent: missing required field "MediaVariant.height"`)}
+	}
+	if v, ok := mvc.mutation.Height(); ok {
+		if err := mediavariant.HeightValidator(v); err != nil {
+			return &ValidationError{Name: "height", err: fmt.Errorf(`ent: validator failed for field "MediaVariant.height": %w`, err)}
+		}
+	}
+	if _, ok := mvc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "MediaVariant.created_at"`)}
+	}
+	if _, ok := mvc.mutation.MediaID(); !ok {
+		return &ValidationError{Name: "media", err: errors.New(`ent: missing required edge "MediaVariant.media"`)}
+	}
+	return nil
+}
+
+func (mvc *MediaVariantCreate) sqlSave(ctx context.Context) (*MediaVariant, error) {
+	if err := mvc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := mvc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, mvc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	mvc.mutation.id = &_node.ID
+	mvc.mutation.done = true
+	return _node, nil
+}
+
+func (mvc *MediaVariantCreate) createSpec() (*MediaVariant, *sqlgraph.CreateSpec) {
+	var (
+		_node = &MediaVariant{config: mvc.config}
+		_spec = sqlgraph.NewCreateSpec(mediavariant.Table, sqlgraph.NewFieldSpec(mediavariant.FieldID, field.TypeInt))
+	)
+	if value, ok := mvc.mutation.Name(); ok {
+		_spec.SetField(mediavariant.FieldName, field.TypeString, value)
+		_node.Name = value
+	}
+	if value, ok := mvc.mutation.Key(); ok {
+		_spec.SetField(mediavariant.FieldKey, field.TypeString, value)
+		_node.Key = value
+	}
+	if value, ok := mvc.mutation.MimeType(); ok {
+		_spec.SetField(mediavariant.FieldMimeType, field.TypeString, value)
+		_node.MimeType = value
+	}
+	if value, ok := mvc.mutation.Size(); ok {
+		_spec.SetField(mediavariant.FieldSize, field.TypeInt64, value)
+		_node.Size = value
+	}
+	if value, ok := mvc.mutation.Width(); ok {
+		_spec.SetField(mediavariant.FieldWidth, field.TypeInt, value)
+		_node.Width = value
+	}
+	if value, ok := mvc.mutation.Height(); ok {
+		_spec.SetField(mediavariant.FieldHeight, field.TypeInt, value)
+		_node.Height = value
+	}
+	if value, ok := mvc.mutation.CreatedAt(); ok {
+		_spec.SetField(mediavariant.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if nodes := mvc.mutation.MediaIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   mediavariant.MediaTable,
+			Columns: []string{mediavariant.MediaColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(media.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.media_variant_media = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// MediaVariantCreateBulk is the builder for creating many MediaVariant entities in bulk.
+type MediaVariantCreateBulk struct {
+	config
+	builders []*MediaVariantCreate
+}
+
+// Save creates the MediaVariant entities in the database.
+func (mvcb *MediaVariantCreateBulk) Save(ctx context.Context) ([]*MediaVariant, error) {
+	specs := make([]*sqlgraph.CreateSpec, len(mvcb.builders))
+	nodes := make([]*MediaVariant, len(mvcb.builders))
+	mutators := make([]Mutator, len(mvcb.builders))
+	for i := range mvcb.builders {
+		func(i int, root context.Context) {
+			builder := mvcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*MediaVariantMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, mvcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, mvcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, mvcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (mvcb *MediaVariantCreateBulk) SaveX(ctx context.Context) []*MediaVariant {
+	v, err := mvcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (mvcb *MediaVariantCreateBulk) Exec(ctx context.Context) error {
+	_, err := mvcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (mvcb *MediaVariantCreateBulk) ExecX(ctx context.Context) {
+	if err := mvcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
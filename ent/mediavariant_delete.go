@@ -0,0 +1,88 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/vovanwin/api-my-site/ent/mediavariant"
+	"github.com/vovanwin/api-my-site/ent/predicate"
+)
+
+// MediaVariantDelete is the builder for deleting a MediaVariant entity.
+type MediaVariantDelete struct {
+	config
+	hooks    []Hook
+	mutation *MediaVariantMutation
+}
+
+// Where appends a list predicates to the MediaVariantDelete builder.
+func (mvd *MediaVariantDelete) Where(ps ...predicate.MediaVariant) *MediaVariantDelete {
+	mvd.mutation.Where(ps...)
+	return mvd
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (mvd *MediaVariantDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks[int, MediaVariantMutation](ctx, mvd.sqlExec, mvd.mutation, mvd.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (mvd *MediaVariantDelete) ExecX(ctx context.Context) int {
+	n, err := mvd.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (mvd *MediaVariantDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(mediavariant.Table, sqlgraph.NewFieldSpec(mediavariant.FieldID, field.TypeInt))
+	if ps := mvd.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, mvd.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	mvd.mutation.done = true
+	return affected, err
+}
+
+// MediaVariantDeleteOne is the builder for deleting a single MediaVariant entity.
+type MediaVariantDeleteOne struct {
+	mvd *MediaVariantDelete
+}
+
+// Where appends a list predicates to the MediaVariantDelete builder.
+func (mvdo *MediaVariantDeleteOne) Where(ps ...predicate.MediaVariant) *MediaVariantDeleteOne {
+	mvdo.mvd.mutation.Where(ps...)
+	return mvdo
+}
+
+// Exec executes the deletion query.
+func (mvdo *MediaVariantDeleteOne) Exec(ctx context.Context) error {
+	n, err := mvdo.mvd.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{mediavariant.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (mvdo *MediaVariantDeleteOne) ExecX(ctx context.Context) {
+	if err := mvdo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,650 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/vovanwin/api-my-site/ent/media"
+	"github.com/vovanwin/api-my-site/ent/mediavariant"
+	"github.com/vovanwin/api-my-site/ent/predicate"
+)
+
+// MediaVariantQuery is the builder for querying MediaVariant entities.
+type MediaVariantQuery struct {
+	config
+	ctx        *QueryContext
+	order      []mediavariant.OrderOption
+	inters     []Interceptor
+	predicates []predicate.MediaVariant
+	withMedia  *MediaQuery
+	withFKs    bool
+	modifiers  []func(*sql.Selector)
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Where adds a new predicate for the MediaVariantQuery builder.
+func (mvq *MediaVariantQuery) Where(ps ...predicate.MediaVariant) *MediaVariantQuery {
+	mvq.predicates = append(mvq.predicates, ps...)
+	return mvq
+}
+
+// Limit the number of records to be returned by this query.
+func (mvq *MediaVariantQuery) Limit(limit int) *MediaVariantQuery {
+	mvq.ctx.Limit = &limit
+	return mvq
+}
+
+// Offset to start from.
+func (mvq *MediaVariantQuery) Offset(offset int) *MediaVariantQuery {
+	mvq.ctx.Offset = &offset
+	return mvq
+}
+
+// Unique configures the query builder to filter duplicate records on query.
+// By default, unique is set to true, and can be disabled using this method.
+func (mvq *MediaVariantQuery) Unique(unique bool) *MediaVariantQuery {
+	mvq.ctx.Unique = &unique
+	return mvq
+}
+
+// Order specifies how the records should be ordered.
+func (mvq *MediaVariantQuery) Order(o ...mediavariant.OrderOption) *MediaVariantQuery {
+	mvq.order = append(mvq.order, o...)
+	return mvq
+}
+
+// QueryMedia chains the current query on the "media" edge.
+func (mvq *MediaVariantQuery) QueryMedia() *MediaQuery {
+	query := (&MediaClient{config: mvq.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := mvq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := mvq.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(mediavariant.Table, mediavariant.FieldID, selector),
+			sqlgraph.To(media.Table, media.FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, false, mediavariant.MediaTable, mediavariant.MediaColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(mvq.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
+// First returns the first MediaVariant entity from the query.
+// Returns a *NotFoundError when no MediaVariant was found.
+func (mvq *MediaVariantQuery) First(ctx context.Context) (*MediaVariant, error) {
+	nodes, err := mvq.Limit(1).All(setContextOp(ctx, mvq.ctx, "First"))
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, &NotFoundError{mediavariant.Label}
+	}
+	return nodes[0], nil
+}
+
+// FirstX is like First, but panics if an error occurs.
+func (mvq *MediaVariantQuery) FirstX(ctx context.Context) *MediaVariant {
+	node, err := mvq.First(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return node
+}
+
+// FirstID returns the first MediaVariant ID from the query.
+// Returns a *NotFoundError when no MediaVariant ID was found.
+func (mvq *MediaVariantQuery) FirstID(ctx context.Context) (id int, err error) {
+	var ids []int
+	if ids, err = mvq.Limit(1).IDs(setContextOp(ctx, mvq.ctx, "FirstID")); err != nil {
+		return
+	}
+	if len(ids) == 0 {
+		err = &NotFoundError{mediavariant.Label}
+		return
+	}
+	return ids[0], nil
+}
+
+// FirstIDX is like FirstID, but panics if an error occurs.
+func (mvq *MediaVariantQuery) FirstIDX(ctx context.Context) int {
+	id, err := mvq.FirstID(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return id
+}
+
+// Only returns a single MediaVariant entity found by the query, ensuring it only returns one.
+// Returns a *NotSingularError when more than one MediaVariant entity is found.
+// Returns a *NotFoundError when no MediaVariant entities are found.
+func (mvq *MediaVariantQuery) Only(ctx context.Context) (*MediaVariant, error) {
+	nodes, err := mvq.Limit(2).All(setContextOp(ctx, mvq.ctx, "Only"))
+	if err != nil {
+		return nil, err
+	}
+	switch len(nodes) {
+	case 1:
+		return nodes[0], nil
+	case 0:
+		return nil, &NotFoundError{mediavariant.Label}
+	default:
+		return nil, &NotSingularError{mediavariant.Label}
+	}
+}
+
+// OnlyX is like Only, but panics if an error occurs.
+func (mvq *MediaVariantQuery) OnlyX(ctx context.Context) *MediaVariant {
+	node, err := mvq.Only(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// OnlyID is like Only, but returns the only MediaVariant ID in the query.
+// Returns a *NotSingularError when more than one MediaVariant ID is found.
+// Returns a *NotFoundError when no entities are found.
+func (mvq *MediaVariantQuery) OnlyID(ctx context.Context) (id int, err error) {
+	var ids []int
+	if ids, err = mvq.Limit(2).IDs(setContextOp(ctx, mvq.ctx, "OnlyID")); err != nil {
+		return
+	}
+	switch len(ids) {
+	case 1:
+		id = ids[0]
+	case 0:
+		err = &NotFoundError{mediavariant.Label}
+	default:
+		err = &NotSingularError{mediavariant.Label}
+	}
+	return
+}
+
+// OnlyIDX is like OnlyID, but panics if an error occurs.
+func (mvq *MediaVariantQuery) OnlyIDX(ctx context.Context) int {
+	id, err := mvq.OnlyID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// All executes the query and returns a list of MediaVariants.
+func (mvq *MediaVariantQuery) All(ctx context.Context) ([]*MediaVariant, error) {
+	ctx = setContextOp(ctx, mvq.ctx, "All")
+	if err := mvq.prepareQuery(ctx); err != nil {
+		return nil, err
+	}
+	qr := querierAll[[]*MediaVariant, *MediaVariantQuery]()
+	return withInterceptors[[]*MediaVariant](ctx, mvq, qr, mvq.inters)
+}
+
+// AllX is like All, but panics if an error occurs.
+func (mvq *MediaVariantQuery) AllX(ctx context.Context) []*MediaVariant {
+	nodes, err := mvq.All(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return nodes
+}
+
+// IDs executes the query and returns a list of MediaVariant IDs.
+func (mvq *MediaVariantQuery) IDs(ctx context.Context) (ids []int, err error) {
+	if mvq.ctx.Unique == nil && mvq.path != nil {
+		mvq.Unique(true)
+	}
+	ctx = setContextOp(ctx, mvq.ctx, "IDs")
+	if err = mvq.Select(mediavariant.FieldID).Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// IDsX is like IDs, but panics if an error occurs.
+func (mvq *MediaVariantQuery) IDsX(ctx context.Context) []int {
+	ids, err := mvq.IDs(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return ids
+}
+
+// Count returns the count of the given query.
+func (mvq *MediaVariantQuery) Count(ctx context.Context) (int, error) {
+	ctx = setContextOp(ctx, mvq.ctx, "Count")
+	if err := mvq.prepareQuery(ctx); err != nil {
+		return 0, err
+	}
+	return withInterceptors[int](ctx, mvq, querierCount[*MediaVariantQuery](), mvq.inters)
+}
+
+// CountX is like Count, but panics if an error occurs.
+func (mvq *MediaVariantQuery) CountX(ctx context.Context) int {
+	count, err := mvq.Count(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return count
+}
+
+// Exist returns true if the query has elements in the graph.
+func (mvq *MediaVariantQuery) Exist(ctx context.Context) (bool, error) {
+	ctx = setContextOp(ctx, mvq.ctx, "Exist")
+	switch _, err := mvq.FirstID(ctx); {
+	case IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("ent: check existence: %w", err)
+	default:
+		return true, nil
+	}
+}
+
+// ExistX is like Exist, but panics if an error occurs.
+func (mvq *MediaVariantQuery) ExistX(ctx context.Context) bool {
+	exist, err := mvq.Exist(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return exist
+}
+
+// Clone returns a duplicate of the MediaVariantQuery builder, including all associated steps. It can be
+// used to prepare common query builders and use them differently after the clone is made.
+func (mvq *MediaVariantQuery) Clone() *MediaVariantQuery {
+	if mvq == nil {
+		return nil
+	}
+	return &MediaVariantQuery{
+		config:     mvq.config,
+		ctx:        mvq.ctx.Clone(),
+		order:      append([]mediavariant.OrderOption{}, mvq.order...),
+		inters:     append([]Interceptor{}, mvq.inters...),
+		predicates: append([]predicate.MediaVariant{}, mvq.predicates...),
+		withMedia:  mvq.withMedia.Clone(),
+		// clone intermediate query.
+		sql:  mvq.sql.Clone(),
+		path: mvq.path,
+	}
+}
+
+// WithMedia tells the query-builder to eager-load the nodes that are connected to
+// the "media" edge. The optional arguments are used to configure the query builder of the edge.
+func (mvq *MediaVariantQuery) WithMedia(opts ...func(*MediaQuery)) *MediaVariantQuery {
+	query := (&MediaClient{config: mvq.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	mvq.withMedia = query
+	return mvq
+}
+
+// GroupBy is used to group vertices by one or more fields/columns.
+// It is often used with aggregate functions, like: count, max, mean, min, sum.
+//
+// Example:
+//
+//	var v []struct {
+//		Name string `json:"name,omitempty"`
+//		Count int `json:"count,omitempty"`
+//	}
+//
+//	client.MediaVariant.Query().
+//		GroupBy(mediavariant.FieldName).
+//		Aggregate(ent.Count()).
+//		Scan(ctx, &v)
+func (mvq *MediaVariantQuery) GroupBy(field string, fields ...string) *MediaVariantGroupBy {
+	mvq.ctx.Fields = append([]string{field}, fields...)
+	grbuild := &MediaVariantGroupBy{build: mvq}
+	grbuild.flds = &mvq.ctx.Fields
+	grbuild.label = mediavariant.Label
+	grbuild.scan = grbuild.Scan
+	return grbuild
+}
+
+// Select allows the selection one or more fields/columns for the given query,
+// instead of selecting all fields in the entity.
+//
+// Example:
+//
+//	var v []struct {
+//		Name string `json:"name,omitempty"`
+//	}
+//
+//	client.MediaVariant.Query().
+//		Select(mediavariant.FieldName).
+//		Scan(ctx, &v)
+func (mvq *MediaVariantQuery) Select(fields ...string) *MediaVariantSelect {
+	mvq.ctx.Fields = append(mvq.ctx.Fields, fields...)
+	sbuild := &MediaVariantSelect{MediaVariantQuery: mvq}
+	sbuild.label = mediavariant.Label
+	sbuild.flds, sbuild.scan = &mvq.ctx.Fields, sbuild.Scan
+	return sbuild
+}
+
+// Aggregate returns a MediaVariantSelect configured with the given aggregations.
+func (mvq *MediaVariantQuery) Aggregate(fns ...AggregateFunc) *MediaVariantSelect {
+	return mvq.Select().Aggregate(fns...)
+}
+
+func (mvq *MediaVariantQuery) prepareQuery(ctx context.Context) error {
+	for _, inter := range mvq.inters {
+		if inter == nil {
+			return fmt.Errorf("ent: uninitialized interceptor (forgotten import ent/runtime?)")
+		}
+		if trv, ok := inter.(Traverser); ok {
+			if err := trv.Traverse(ctx, mvq); err != nil {
+				return err
+			}
+		}
+	}
+	for _, f := range mvq.ctx.Fields {
+		if !mediavariant.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+		}
+	}
+	if mvq.path != nil {
+		prev, err := mvq.path(ctx)
+		if err != nil {
+			return err
+		}
+		mvq.sql = prev
+	}
+	return nil
+}
+
+func (mvq *MediaVariantQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*MediaVariant, error) {
+	var (
+		nodes       = []*MediaVariant{}
+		withFKs     = mvq.withFKs
+		_spec       = mvq.querySpec()
+		loadedTypes = [1]bool{
+			mvq.withMedia != nil,
+		}
+	)
+	if mvq.withMedia != nil {
+		withFKs = true
+	}
+	if withFKs {
+		_spec.Node.Columns = append(_spec.Node.Columns, mediavariant.ForeignKeys...)
+	}
+	_spec.ScanValues = func(columns []string) ([]any, error) {
+		return (*MediaVariant).scanValues(nil, columns)
+	}
+	_spec.Assign = func(columns []string, values []any) error {
+		node := &MediaVariant{config: mvq.config}
+		nodes = append(nodes, node)
+		node.Edges.loadedTypes = loadedTypes
+		return node.assignValues(columns, values)
+	}
+	if len(mvq.modifiers) > 0 {
+		_spec.Modifiers = mvq.modifiers
+	}
+	for i := range hooks {
+		hooks[i](ctx, _spec)
+	}
+	if err := sqlgraph.QueryNodes(ctx, mvq.driver, _spec); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nodes, nil
+	}
+	if query := mvq.withMedia; query != nil {
+		if err := mvq.loadMedia(ctx, query, nodes, nil,
+			func(n *MediaVariant, e *Media) { n.Edges.Media = e }); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+func (mvq *MediaVariantQuery) loadMedia(ctx context.Context, query *MediaQuery, nodes []*MediaVariant, init func(*MediaVariant), assign func(*MediaVariant, *Media)) error {
+	ids := make([]int, 0, len(nodes))
+	nodeids := make(map[int][]*MediaVariant)
+	for i := range nodes {
+		if nodes[i].media_variant_media == nil {
+			continue
+		}
+		fk := *nodes[i].media_variant_media
+		if _, ok := nodeids[fk]; !ok {
+			ids = append(ids, fk)
+		}
+		nodeids[fk] = append(nodeids[fk], nodes[i])
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	query.Where(media.IDIn(ids...))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		nodes, ok := nodeids[n.ID]
+		if !ok {
+			return fmt.Errorf(`unexpected foreign-key "media_variant_media" returned %v`, n.ID)
+		}
+		for i := range nodes {
+			assign(nodes[i], n)
+		}
+	}
+	return nil
+}
+
+func (mvq *MediaVariantQuery) sqlCount(ctx context.Context) (int, error) {
+	_spec := mvq.querySpec()
+	if len(mvq.modifiers) > 0 {
+		_spec.Modifiers = mvq.modifiers
+	}
+	_spec.Node.Columns = mvq.ctx.Fields
+	if len(mvq.ctx.Fields) > 0 {
+		_spec.Unique = mvq.ctx.Unique != nil && *mvq.ctx.Unique
+	}
+	return sqlgraph.CountNodes(ctx, mvq.driver, _spec)
+}
+
+func (mvq *MediaVariantQuery) querySpec() *sqlgraph.QuerySpec {
+	_spec := sqlgraph.NewQuerySpec(mediavariant.Table, mediavariant.Columns, sqlgraph.NewFieldSpec(mediavariant.FieldID, field.TypeInt))
+	_spec.From = mvq.sql
+	if unique := mvq.ctx.Unique; unique != nil {
+		_spec.Unique = *unique
+	} else if mvq.path != nil {
+		_spec.Unique = true
+	}
+	if fields := mvq.ctx.Fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, mediavariant.FieldID)
+		for i := range fields {
+			if fields[i] != mediavariant.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, fields[i])
+			}
+		}
+	}
+	if ps := mvq.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if limit := mvq.ctx.Limit; limit != nil {
+		_spec.Limit = *limit
+	}
+	if offset := mvq.ctx.Offset; offset != nil {
+		_spec.Offset = *offset
+	}
+	if ps := mvq.order; len(ps) > 0 {
+		_spec.Order = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	return _spec
+}
+
+func (mvq *MediaVariantQuery) sqlQuery(ctx context.Context) *sql.Selector {
+	builder := sql.Dialect(mvq.driver.Dialect())
+	t1 := builder.Table(mediavariant.Table)
+	columns := mvq.ctx.Fields
+	if len(columns) == 0 {
+		columns = mediavariant.Columns
+	}
+	selector := builder.Select(t1.Columns(columns...)...).From(t1)
+	if mvq.sql != nil {
+		selector = mvq.sql
+		selector.Select(selector.Columns(columns...)...)
+	}
+	if mvq.ctx.Unique != nil && *mvq.ctx.Unique {
+		selector.Distinct()
+	}
+	for _, m := range mvq.modifiers {
+		m(selector)
+	}
+	for _, p := range mvq.predicates {
+		p(selector)
+	}
+	for _, p := range mvq.order {
+		p(selector)
+	}
+	if offset := mvq.ctx.Offset; offset != nil {
+		// limit is mandatory for offset clause. We start
+		// with default value, and override it below if needed.
+		selector.Offset(*offset).Limit(math.MaxInt32)
+	}
+	if limit := mvq.ctx.Limit; limit != nil {
+		selector.Limit(*limit)
+	}
+	return selector
+}
+
+// ForUpdate locks the selected rows against concurrent updates, and prevent them from being
+// updated, deleted or "selected ... for update" by other sessions, until the transaction is
+// either committed or rolled-back.
+func (mvq *MediaVariantQuery) ForUpdate(opts ...sql.LockOption) *MediaVariantQuery {
+	if mvq.driver.Dialect() == dialect.Postgres {
+		mvq.Unique(false)
+	}
+	mvq.modifiers = append(mvq.modifiers, func(s *sql.Selector) {
+		s.ForUpdate(opts...)
+	})
+	return mvq
+}
+
+// ForShare behaves similarly to ForUpdate, except that it acquires a shared mode lock
+// on any rows that are read. Other sessions can read the rows, but cannot modify them
+// until your transaction commits.
+func (mvq *MediaVariantQuery) ForShare(opts ...sql.LockOption) *MediaVariantQuery {
+	if mvq.driver.Dialect() == dialect.Postgres {
+		mvq.Unique(false)
+	}
+	mvq.modifiers = append(mvq.modifiers, func(s *sql.Selector) {
+		s.ForShare(opts...)
+	})
+	return mvq
+}
+
+// MediaVariantGroupBy is the group-by builder for MediaVariant entities.
+type MediaVariantGroupBy struct {
+	selector
+	build *MediaVariantQuery
+}
+
+// Aggregate adds the given aggregation functions to the group-by query.
+func (mvgb *MediaVariantGroupBy) Aggregate(fns ...AggregateFunc) *MediaVariantGroupBy {
+	mvgb.fns = append(mvgb.fns, fns...)
+	return mvgb
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (mvgb *MediaVariantGroupBy) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, mvgb.build.ctx, "GroupBy")
+	if err := mvgb.build.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*MediaVariantQuery, *MediaVariantGroupBy](ctx, mvgb.build, mvgb, mvgb.build.inters, v)
+}
+
+func (mvgb *MediaVariantGroupBy) sqlScan(ctx context.Context, root *MediaVariantQuery, v any) error {
+	selector := root.sqlQuery(ctx).Select()
+	aggregation := make([]string, 0, len(mvgb.fns))
+	for _, fn := range mvgb.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	if len(selector.SelectedColumns()) == 0 {
+		columns := make([]string, 0, len(*mvgb.flds)+len(mvgb.fns))
+		for _, f := range *mvgb.flds {
+			columns = append(columns, selector.C(f))
+		}
+		columns = append(columns, aggregation...)
+		selector.Select(columns...)
+	}
+	selector.GroupBy(selector.Columns(*mvgb.flds...)...)
+	if err := selector.Err(); err != nil {
+		return err
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := mvgb.build.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+// MediaVariantSelect is the builder for selecting fields of MediaVariant entities.
+type MediaVariantSelect struct {
+	*MediaVariantQuery
+	selector
+}
+
+// Aggregate adds the given aggregation functions to the selector query.
+func (mvs *MediaVariantSelect) Aggregate(fns ...AggregateFunc) *MediaVariantSelect {
+	mvs.fns = append(mvs.fns, fns...)
+	return mvs
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (mvs *MediaVariantSelect) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, mvs.ctx, "Select")
+	if err := mvs.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*MediaVariantQuery, *MediaVariantSelect](ctx, mvs.MediaVariantQuery, mvs, mvs.inters, v)
+}
+
+func (mvs *MediaVariantSelect) sqlScan(ctx context.Context, root *MediaVariantQuery, v any) error {
+	selector := root.sqlQuery(ctx)
+	aggregation := make([]string, 0, len(mvs.fns))
+	for _, fn := range mvs.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	switch n := len(*mvs.selector.flds); {
+	case n == 0 && len(aggregation) > 0:
+		selector.Select(aggregation...)
+	case n != 0 && len(aggregation) > 0:
+		selector.AppendSelect(aggregation...)
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := mvs.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
@@ -0,0 +1,518 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/vovanwin/api-my-site/ent/media"
+	"github.com/vovanwin/api-my-site/ent/mediavariant"
+	"github.com/vovanwin/api-my-site/ent/predicate"
+)
+
+// MediaVariantUpdate is the builder for updating MediaVariant entities.
+type MediaVariantUpdate struct {
+	config
+	hooks    []Hook
+	mutation *MediaVariantMutation
+}
+
+// Where appends a list predicates to the MediaVariantUpdate builder.
+func (mvu *MediaVariantUpdate) Where(ps ...predicate.MediaVariant) *MediaVariantUpdate {
+	mvu.mutation.Where(ps...)
+	return mvu
+}
+
+// SetName sets the "name" field.
+func (mvu *MediaVariantUpdate) SetName(s string) *MediaVariantUpdate {
+	mvu.mutation.SetName(s)
+	return mvu
+}
+
+// SetKey sets the "key" field.
+func (mvu *MediaVariantUpdate) SetKey(s string) *MediaVariantUpdate {
+	mvu.mutation.SetKey(s)
+	return mvu
+}
+
+// SetMimeType sets the "mime_type" field.
+func (mvu *MediaVariantUpdate) SetMimeType(s string) *MediaVariantUpdate {
+	mvu.mutation.SetMimeType(s)
+	return mvu
+}
+
+// SetSize sets the "size" field.
+func (mvu *MediaVariantUpdate) SetSize(i int64) *MediaVariantUpdate {
+	mvu.mutation.ResetSize()
+	mvu.mutation.SetSize(i)
+	return mvu
+}
+
+// AddSize adds i to the "size" field.
+func (mvu *MediaVariantUpdate) AddSize(i int64) *MediaVariantUpdate {
+	mvu.mutation.AddSize(i)
+	return mvu
+}
+
+// SetWidth sets the "width" field.
+func (mvu *MediaVariantUpdate) SetWidth(i int) *MediaVariantUpdate {
+	mvu.mutation.ResetWidth()
+	mvu.mutation.SetWidth(i)
+	return mvu
+}
+
+// AddWidth adds i to the "width" field.
+func (mvu *MediaVariantUpdate) AddWidth(i int) *MediaVariantUpdate {
+	mvu.mutation.AddWidth(i)
+	return mvu
+}
+
+// SetHeight sets the "height" field.
+func (mvu *MediaVariantUpdate) SetHeight(i int) *MediaVariantUpdate {
+	mvu.mutation.ResetHeight()
+	mvu.mutation.SetHeight(i)
+	return mvu
+}
+
+// AddHeight adds i to the "height" field.
+func (mvu *MediaVariantUpdate) AddHeight(i int) *MediaVariantUpdate {
+	mvu.mutation.AddHeight(i)
+	return mvu
+}
+
+// SetMediaID sets the "media" edge to the Media entity by ID.
+func (mvu *MediaVariantUpdate) SetMediaID(id int) *MediaVariantUpdate {
+	mvu.mutation.SetMediaID(id)
+	return mvu
+}
+
+// SetMedia sets the "media" edge to the Media entity.
+func (mvu *MediaVariantUpdate) SetMedia(m *Media) *MediaVariantUpdate {
+	return mvu.SetMediaID(m.ID)
+}
+
+// Mutation returns the MediaVariantMutation object of the builder.
+func (mvu *MediaVariantUpdate) Mutation() *MediaVariantMutation {
+	return mvu.mutation
+}
+
+// ClearMedia clears the "media" edge to the Media entity.
+func (mvu *MediaVariantUpdate) ClearMedia() *MediaVariantUpdate {
+	mvu.mutation.ClearMedia()
+	return mvu
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (mvu *MediaVariantUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks[int, MediaVariantMutation](ctx, mvu.sqlSave, mvu.mutation, mvu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (mvu *MediaVariantUpdate) SaveX(ctx context.Context) int {
+	affected, err := mvu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (mvu *MediaVariantUpdate) Exec(ctx context.Context) error {
+	_, err := mvu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (mvu *MediaVariantUpdate) ExecX(ctx context.Context) {
+	if err := mvu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (mvu *MediaVariantUpdate) check() error {
+	if v, ok := mvu.mutation.Name(); ok {
+		if err := mediavariant.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "MediaVariant.name": %w`, err)}
+		}
+	}
+	if v, ok := mvu.mutation.Key(); ok {
+		if err := mediavariant.KeyValidator(v); err != nil {
+			return &ValidationError{Name: "key", err: fmt.Errorf(`ent: validator failed for field "MediaVariant.key": %w`, err)}
+		}
+	}
+	if v, ok := mvu.mutation.MimeType(); ok {
+		if err := mediavariant.MimeTypeValidator(v); err != nil {
+			return &ValidationError{Name: "mime_type", err: fmt.Errorf(`ent: validator failed for field "MediaVariant.mime_type": %w`, err)}
+		}
+	}
+	if v, ok := mvu.mutation.Size(); ok {
+		if err := mediavariant.SizeValidator(v); err != nil {
+			return &ValidationError{Name: "size", err: fmt.Errorf(`ent: validator failed for field "MediaVariant.size": %w`, err)}
+		}
+	}
+	if v, ok := mvu.mutation.Width(); ok {
+		if err := mediavariant.WidthValidator(v); err != nil {
+			return &ValidationError{Name: "width", err: fmt.Errorf(`ent: validator failed for field "MediaVariant.width": %w`, err)}
+		}
+	}
+	if v, ok := mvu.mutation.Height(); ok {
+		if err := mediavariant.HeightValidator(v); err != nil {
+			return &ValidationError{Name: "height", err: fmt.Errorf(`ent: validator failed for field "MediaVariant.height": %w`, err)}
+		}
+	}
+	if _, ok := mvu.mutation.MediaID(); mvu.mutation.MediaCleared() && !ok {
+		return errors.New(`ent: clearing a required unique edge "MediaVariant.media"`)
+	}
+	return nil
+}
+
+func (mvu *MediaVariantUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := mvu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(mediavariant.Table, mediavariant.Columns, sqlgraph.NewFieldSpec(mediavariant.FieldID, field.TypeInt))
+	if ps := mvu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := mvu.mutation.Name(); ok {
+		_spec.SetField(mediavariant.FieldName, field.TypeString, value)
+	}
+	if value, ok := mvu.mutation.Key(); ok {
+		_spec.SetField(mediavariant.FieldKey, field.TypeString, value)
+	}
+	if value, ok := mvu.mutation.MimeType(); ok {
+		_spec.SetField(mediavariant.FieldMimeType, field.TypeString, value)
+	}
+	if value, ok := mvu.mutation.Size(); ok {
+		_spec.SetField(mediavariant.FieldSize, field.TypeInt64, value)
+	}
+	if value, ok := mvu.mutation.AddedSize(); ok {
+		_spec.AddField(mediavariant.FieldSize, field.TypeInt64, value)
+	}
+	if value, ok := mvu.mutation.Width(); ok {
+		_spec.SetField(mediavariant.FieldWidth, field.TypeInt, value)
+	}
+	if value, ok := mvu.mutation.AddedWidth(); ok {
+		_spec.AddField(mediavariant.FieldWidth, field.TypeInt, value)
+	}
+	if value, ok := mvu.mutation.Height(); ok {
+		_spec.SetField(mediavariant.FieldHeight, field.TypeInt, value)
+	}
+	if value, ok := mvu.mutation.AddedHeight(); ok {
+		_spec.AddField(mediavariant.FieldHeight, field.TypeInt, value)
+	}
+	if mvu.mutation.MediaCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   mediavariant.MediaTable,
+			Columns: []string{mediavariant.MediaColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(media.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := mvu.mutation.MediaIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   mediavariant.MediaTable,
+			Columns: []string{mediavariant.MediaColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(media.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, mvu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{mediavariant.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	mvu.mutation.done = true
+	return n, nil
+}
+
+// MediaVariantUpdateOne is the builder for updating a single MediaVariant entity.
+type MediaVariantUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *MediaVariantMutation
+}
+
+// SetName sets the "name" field.
+func (mvuo *MediaVariantUpdateOne) SetName(s string) *MediaVariantUpdateOne {
+	mvuo.mutation.SetName(s)
+	return mvuo
+}
+
+// SetKey sets the "key" field.
+func (mvuo *MediaVariantUpdateOne) SetKey(s string) *MediaVariantUpdateOne {
+	mvuo.mutation.SetKey(s)
+	return mvuo
+}
+
+// SetMimeType sets the "mime_type" field.
+func (mvuo *MediaVariantUpdateOne) SetMimeType(s string) *MediaVariantUpdateOne {
+	mvuo.mutation.SetMimeType(s)
+	return mvuo
+}
+
+// SetSize sets the "size" field.
+func (mvuo *MediaVariantUpdateOne) SetSize(i int64) *MediaVariantUpdateOne {
+	mvuo.mutation.ResetSize()
+	mvuo.mutation.SetSize(i)
+	return mvuo
+}
+
+// AddSize adds i to the "size" field.
+func (mvuo *MediaVariantUpdateOne) AddSize(i int64) *MediaVariantUpdateOne {
+	mvuo.mutation.AddSize(i)
+	return mvuo
+}
+
+// SetWidth sets the "width" field.
+func (mvuo *MediaVariantUpdateOne) SetWidth(i int) *MediaVariantUpdateOne {
+	mvuo.mutation.ResetWidth()
+	mvuo.mutation.SetWidth(i)
+	return mvuo
+}
+
+// AddWidth adds i to the "width" field.
+func (mvuo *MediaVariantUpdateOne) AddWidth(i int) *MediaVariantUpdateOne {
+	mvuo.mutation.AddWidth(i)
+	return mvuo
+}
+
+// SetHeight sets the "height" field.
+func (mvuo *MediaVariantUpdateOne) SetHeight(i int) *MediaVariantUpdateOne {
+	mvuo.mutation.ResetHeight()
+	mvuo.mutation.SetHeight(i)
+	return mvuo
+}
+
+// AddHeight adds i to the "height" field.
+func (mvuo *MediaVariantUpdateOne) AddHeight(i int) *MediaVariantUpdateOne {
+	mvuo.mutation.AddHeight(i)
+	return mvuo
+}
+
+// SetMediaID sets the "media" edge to the Media entity by ID.
+func (mvuo *MediaVariantUpdateOne) SetMediaID(id int) *MediaVariantUpdateOne {
+	mvuo.mutation.SetMediaID(id)
+	return mvuo
+}
+
+// SetMedia sets the "media" edge to the Media entity.
+func (mvuo *MediaVariantUpdateOne) SetMedia(m *Media) *MediaVariantUpdateOne {
+	return mvuo.SetMediaID(m.ID)
+}
+
+// Mutation returns the MediaVariantMutation object of the builder.
+func (mvuo *MediaVariantUpdateOne) Mutation() *MediaVariantMutation {
+	return mvuo.mutation
+}
+
+// ClearMedia clears the "media" edge to the Media entity.
+func (mvuo *MediaVariantUpdateOne) ClearMedia() *MediaVariantUpdateOne {
+	mvuo.mutation.ClearMedia()
+	return mvuo
+}
+
+// Where appends a list predicates to the MediaVariantUpdate builder.
+func (mvuo *MediaVariantUpdateOne) Where(ps ...predicate.MediaVariant) *MediaVariantUpdateOne {
+	mvuo.mutation.Where(ps...)
+	return mvuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (mvuo *MediaVariantUpdateOne) Select(field string, fields ...string) *MediaVariantUpdateOne {
+	mvuo.fields = append([]string{field}, fields...)
+	return mvuo
+}
+
+// Save executes the query and returns the updated MediaVariant entity.
+func (mvuo *MediaVariantUpdateOne) Save(ctx context.Context) (*MediaVariant, error) {
+	return withHooks[*MediaVariant, MediaVariantMutation](ctx, mvuo.sqlSave, mvuo.mutation, mvuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (mvuo *MediaVariantUpdateOne) SaveX(ctx context.Context) *MediaVariant {
+	node, err := mvuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (mvuo *MediaVariantUpdateOne) Exec(ctx context.Context) error {
+	_, err := mvuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (mvuo *MediaVariantUpdateOne) ExecX(ctx context.Context) {
+	if err := mvuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (mvuo *MediaVariantUpdateOne) check() error {
+	if v, ok := mvuo.mutation.Name(); ok {
+		if err := mediavariant.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "MediaVariant.name": %w`, err)}
+		}
+	}
+	if v, ok := mvuo.mutation.Key(); ok {
+		if err := mediavariant.KeyValidator(v); err != nil {
+			return &ValidationError{Name: "key", err: fmt.Errorf(`ent: validator failed for field "MediaVariant.key": %w`, err)}
+		}
+	}
+	if v, ok := mvuo.mutation.MimeType(); ok {
+		if err := mediavariant.MimeTypeValidator(v); err != nil {
+			return &ValidationError{Name: "mime_type", err: fmt.Errorf(`ent: validator failed for field "MediaVariant.mime_type": %w`, err)}
+		}
+	}
+	if v, ok := mvuo.mutation.Size(); ok {
+		if err := mediavariant.SizeValidator(v); err != nil {
+			return &ValidationError{Name: "size", err: fmt.Errorf(`ent: validator failed for field "MediaVariant.size": %w`, err)}
+		}
+	}
+	if v, ok := mvuo.mutation.Width(); ok {
+		if err := mediavariant.WidthValidator(v); err != nil {
+			return &ValidationError{Name: "width", err: fmt.Errorf(`ent: validator failed for field "MediaVariant.width": %w`, err)}
+		}
+	}
+	if v, ok := mvuo.mutation.Height(); ok {
+		if err := mediavariant.HeightValidator(v); err != nil {
+			return &ValidationError{Name: "height", err: fmt.Errorf(`ent: validator failed for field "MediaVariant.height": %w`, err)}
+		}
+	}
+	if _, ok := mvuo.mutation.MediaID(); mvuo.mutation.MediaCleared() && !ok {
+		return errors.New(`ent: clearing a required unique edge "MediaVariant.media"`)
+	}
+	return nil
+}
+
+func (mvuo *MediaVariantUpdateOne) sqlSave(ctx context.Context) (_node *MediaVariant, err error) {
+	if err := mvuo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(mediavariant.Table, mediavariant.Columns, sqlgraph.NewFieldSpec(mediavariant.FieldID, field.TypeInt))
+	id, ok := mvuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "MediaVariant.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := mvuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, mediavariant.FieldID)
+		for _, f := range fields {
+			if !mediavariant.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != mediavariant.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := mvuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := mvuo.mutation.Name(); ok {
+		_spec.SetField(mediavariant.FieldName, field.TypeString, value)
+	}
+	if value, ok := mvuo.mutation.Key(); ok {
+		_spec.SetField(mediavariant.FieldKey, field.TypeString, value)
+	}
+	if value, ok := mvuo.mutation.MimeType(); ok {
+		_spec.SetField(mediavariant.FieldMimeType, field.TypeString, value)
+	}
+	if value, ok := mvuo.mutation.Size(); ok {
+		_spec.SetField(mediavariant.FieldSize, field.TypeInt64, value)
+	}
+	if value, ok := mvuo.mutation.AddedSize(); ok {
+		_spec.AddField(mediavariant.FieldSize, field.TypeInt64, value)
+	}
+	if value, ok := mvuo.mutation.Width(); ok {
+		_spec.SetField(mediavariant.FieldWidth, field.TypeInt, value)
+	}
+	if value, ok := mvuo.mutation.AddedWidth(); ok {
+		_spec.AddField(mediavariant.FieldWidth, field.TypeInt, value)
+	}
+	if value, ok := mvuo.mutation.Height(); ok {
+		_spec.SetField(mediavariant.FieldHeight, field.TypeInt, value)
+	}
+	if value, ok := mvuo.mutation.AddedHeight(); ok {
+		_spec.AddField(mediavariant.FieldHeight, field.TypeInt, value)
+	}
+	if mvuo.mutation.MediaCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   mediavariant.MediaTable,
+			Columns: []string{mediavariant.MediaColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(media.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := mvuo.mutation.MediaIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   mediavariant.MediaTable,
+			Columns: []string{mediavariant.MediaColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(media.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &MediaVariant{config: mvuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, mvuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{mediavariant.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	mvuo.mutation.done = true
+	return _node, nil
+}
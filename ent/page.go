@@ -0,0 +1,263 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/vovanwin/api-my-site/ent/page"
+)
+
+// Page is the model entity for the Page schema.
+type Page struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// Title holds the value of the "title" field.
+	Title string `json:"title,omitempty"`
+	// Slug holds the value of the "slug" field.
+	Slug string `json:"slug,omitempty"`
+	// Body holds the value of the "body" field.
+	Body string `json:"body,omitempty"`
+	// MenuOrder holds the value of the "menu_order" field.
+	MenuOrder int `json:"menu_order,omitempty"`
+	// ShowInMenu holds the value of the "show_in_menu" field.
+	ShowInMenu bool `json:"show_in_menu,omitempty"`
+	// MetaTitle holds the value of the "meta_title" field.
+	MetaTitle string `json:"meta_title,omitempty"`
+	// MetaDescription holds the value of the "meta_description" field.
+	MetaDescription string `json:"meta_description,omitempty"`
+	// Published holds the value of the "published" field.
+	Published bool `json:"published,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// UpdatedAt holds the value of the "updated_at" field.
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the PageQuery when eager-loading is set.
+	Edges         PageEdges `json:"edges"`
+	page_children *int
+	selectValues  sql.SelectValues
+}
+
+// PageEdges holds the relations/edges for other nodes in the graph.
+type PageEdges struct {
+	// Parent holds the value of the parent edge.
+	Parent *Page `json:"parent,omitempty"`
+	// Children holds the value of the children edge.
+	Children []*Page `json:"children,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [2]bool
+}
+
+// ParentOrErr returns the Parent value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e PageEdges) ParentOrErr() (*Page, error) {
+	if e.loadedTypes[0] {
+		if e.Parent == nil {
+			// Edge was loaded but was not found.
+			return nil, &NotFoundError{label: page.Label}
+		}
+		return e.Parent, nil
+	}
+	return nil, &NotLoadedError{edge: "parent"}
+}
+
+// ChildrenOrErr returns the Children value or an error if the edge
+// was not loaded in eager-loading.
+func (e PageEdges) ChildrenOrErr() ([]*Page, error) {
+	if e.loadedTypes[1] {
+		return e.Children, nil
+	}
+	return nil, &NotLoadedError{edge: "children"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Page) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case page.FieldShowInMenu, page.FieldPublished:
+			values[i] = new(sql.NullBool)
+		case page.FieldID, page.FieldMenuOrder:
+			values[i] = new(sql.NullInt64)
+		case page.FieldTitle, page.FieldSlug, page.FieldBody, page.FieldMetaTitle, page.FieldMetaDescription:
+			values[i] = new(sql.NullString)
+		case page.FieldCreatedAt, page.FieldUpdatedAt:
+			values[i] = new(sql.NullTime)
+		case page.ForeignKeys[0]: // page_children
+			values[i] = new(sql.NullInt64)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Page fields.
+func (pa *Page) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case page.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			pa.ID = int(value.Int64)
+		case page.FieldTitle:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field title", values[i])
+			} else if value.Valid {
+				pa.Title = value.String
+			}
+		case page.FieldSlug:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field slug", values[i])
+			} else if value.Valid {
+				pa.Slug = value.String
+			}
+		case page.FieldBody:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field body", values[i])
+			} else if value.Valid {
+				pa.Body = value.String
+			}
+		case page.FieldMenuOrder:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field menu_order", values[i])
+			} else if value.Valid {
+				pa.MenuOrder = int(value.Int64)
+			}
+		case page.FieldShowInMenu:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field show_in_menu", values[i])
+			} else if value.Valid {
+				pa.ShowInMenu = value.Bool
+			}
+		case page.FieldMetaTitle:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field meta_title", values[i])
+			} else if value.Valid {
+				pa.MetaTitle = value.String
+			}
+		case page.FieldMetaDescription:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field meta_description", values[i])
+			} else if value.Valid {
+				pa.MetaDescription = value.String
+			}
+		case page.FieldPublished:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field published", values[i])
+			} else if value.Valid {
+				pa.Published = value.Bool
+			}
+		case page.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				pa.CreatedAt = value.Time
+			}
+		case page.FieldUpdatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field updated_at", values[i])
+			} else if value.Valid {
+				pa.UpdatedAt = value.Time
+			}
+		case page.ForeignKeys[0]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field page_children", value)
+			} else if value.Valid {
+				pa.page_children = new(int)
+				*pa.page_children = int(value.Int64)
+			}
+		default:
+			pa.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the Page.
+// This includes values selected through modifiers, order, etc.
+func (pa *Page) Value(name string) (ent.Value, error) {
+	return pa.selectValues.Get(name)
+}
+
+// QueryParent queries the "parent" edge of the Page entity.
+func (pa *Page) QueryParent() *PageQuery {
+	return NewPageClient(pa.config).QueryParent(pa)
+}
+
+// QueryChildren queries the "children" edge of the Page entity.
+func (pa *Page) QueryChildren() *PageQuery {
+	return NewPageClient(pa.config).QueryChildren(pa)
+}
+
+// Update returns a builder for updating this Page.
+// Note that you need to call Page.Unwrap() before calling this method if this Page
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (pa *Page) Update() *PageUpdateOne {
+	return NewPageClient(pa.config).UpdateOne(pa)
+}
+
+// Unwrap unwraps the Page entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (pa *Page) Unwrap() *Page {
+	_tx, ok := pa.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: Page is not a transactional entity")
+	}
+	pa.config.driver = _tx.drv
+	return pa
+}
+
+// String implements the fmt.Stringer.
+func (pa *Page) String() string {
+	var builder strings.Builder
+	builder.WriteString("Page(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", pa.ID))
+	builder.WriteString("title=")
+	builder.WriteString(pa.Title)
+	builder.WriteString(", ")
+	builder.WriteString("slug=")
+	builder.WriteString(pa.Slug)
+	builder.WriteString(", ")
+	builder.WriteString("body=")
+	builder.WriteString(pa.Body)
+	builder.WriteString(", ")
+	builder.WriteString("menu_order=")
+	builder.WriteString(fmt.Sprintf("%v", pa.MenuOrder))
+	builder.WriteString(", ")
+	builder.WriteString("show_in_menu=")
+	builder.WriteString(fmt.Sprintf("%v", pa.ShowInMenu))
+	builder.WriteString(", ")
+	builder.WriteString("meta_title=")
+	builder.WriteString(pa.MetaTitle)
+	builder.WriteString(", ")
+	builder.WriteString("meta_description=")
+	builder.WriteString(pa.MetaDescription)
+	builder.WriteString(", ")
+	builder.WriteString("published=")
+	builder.WriteString(fmt.Sprintf("%v", pa.Published))
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(pa.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("updated_at=")
+	builder.WriteString(pa.UpdatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Pages is a parsable slice of Page.
+type Pages []*Page
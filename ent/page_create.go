@@ -0,0 +1,450 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/vovanwin/api-my-site/ent/page"
+)
+
+// PageCreate is the builder for creating a Page entity.
+type PageCreate struct {
+	config
+	mutation *PageMutation
+	hooks    []Hook
+}
+
+// SetTitle sets the "title" field.
+func (pc *PageCreate) SetTitle(s string) *PageCreate {
+	pc.mutation.SetTitle(s)
+	return pc
+}
+
+// SetSlug sets the "slug" field.
+func (pc *PageCreate) SetSlug(s string) *PageCreate {
+	pc.mutation.SetSlug(s)
+	return pc
+}
+
+// SetBody sets the "body" field.
+func (pc *PageCreate) SetBody(s string) *PageCreate {
+	pc.mutation.SetBody(s)
+	return pc
+}
+
+// SetMenuOrder sets the "menu_order" field.
+func (pc *PageCreate) SetMenuOrder(i int) *PageCreate {
+	pc.mutation.SetMenuOrder(i)
+	return pc
+}
+
+// SetNillableMenuOrder sets the "menu_order" field if the given value is not nil.
+func (pc *PageCreate) SetNillableMenuOrder(i *int) *PageCreate {
+	if i != nil {
+		pc.SetMenuOrder(*i)
+	}
+	return pc
+}
+
+// SetShowInMenu sets the "show_in_menu" field.
+func (pc *PageCreate) SetShowInMenu(b bool) *PageCreate {
+	pc.mutation.SetShowInMenu(b)
+	return pc
+}
+
+// SetNillableShowInMenu sets the "show_in_menu" field if the given value is not nil.
+func (pc *PageCreate) SetNillableShowInMenu(b *bool) *PageCreate {
+	if b != nil {
+		pc.SetShowInMenu(*b)
+	}
+	return pc
+}
+
+// SetMetaTitle sets the "meta_title" field.
+func (pc *PageCreate) SetMetaTitle(s string) *PageCreate {
+	pc.mutation.SetMetaTitle(s)
+	return pc
+}
+
+// SetNillableMetaTitle sets the "meta_title" field if the given value is not nil.
+func (pc *PageCreate) SetNillableMetaTitle(s *string) *PageCreate {
+	if s != nil {
+		pc.SetMetaTitle(*s)
+	}
+	return pc
+}
+
+// SetMetaDescription sets the "meta_description" field.
+func (pc *PageCreate) SetMetaDescription(s string) *PageCreate {
+	pc.mutation.SetMetaDescription(s)
+	return pc
+}
+
+// SetNillableMetaDescription sets the "meta_description" field if the given value is not nil.
+func (pc *PageCreate) SetNillableMetaDescription(s *string) *PageCreate {
+	if s != nil {
+		pc.SetMetaDescription(*s)
+	}
+	return pc
+}
+
+// SetPublished sets the "published" field.
+func (pc *PageCreate) SetPublished(b bool) *PageCreate {
+	pc.mutation.SetPublished(b)
+	return pc
+}
+
+// SetNillablePublished sets the "published" field if the given value is not nil.
+func (pc *PageCreate) SetNillablePublished(b *bool) *PageCreate {
+	if b != nil {
+		pc.SetPublished(*b)
+	}
+	return pc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (pc *PageCreate) SetCreatedAt(t time.Time) *PageCreate {
+	pc.mutation.SetCreatedAt(t)
+	return pc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (pc *PageCreate) SetNillableCreatedAt(t *time.Time) *PageCreate {
+	if t != nil {
+		pc.SetCreatedAt(*t)
+	}
+	return pc
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (pc *PageCreate) SetUpdatedAt(t time.Time) *PageCreate {
+	pc.mutation.SetUpdatedAt(t)
+	return pc
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (pc *PageCreate) SetNillableUpdatedAt(t *time.Time) *PageCreate {
+	if t != nil {
+		pc.SetUpdatedAt(*t)
+	}
+	return pc
+}
+
+// SetParentID sets the "parent" edge to the Page entity by ID.
+func (pc *PageCreate) SetParentID(id int) *PageCreate {
+	pc.mutation.SetParentID(id)
+	return pc
+}
+
+// SetNillableParentID sets the "parent" edge to the Page entity by ID if the given value is not nil.
+func (pc *PageCreate) SetNillableParentID(id *int) *PageCreate {
+	if id != nil {
+		pc = pc.SetParentID(*id)
+	}
+	return pc
+}
+
+// SetParent sets the "parent" edge to the Page entity.
+func (pc *PageCreate) SetParent(p *Page) *PageCreate {
+	return pc.SetParentID(p.ID)
+}
+
+// AddChildIDs adds the "children" edge to the Page entity by IDs.
+func (pc *PageCreate) AddChildIDs(ids ...int) *PageCreate {
+	pc.mutation.AddChildIDs(ids...)
+	return pc
+}
+
+// AddChildren adds the "children" edges to the Page entity.
+func (pc *PageCreate) AddChildren(p ...*Page) *PageCreate {
+	ids := make([]int, len(p))
+	for i := range p {
+		ids[i] = p[i].ID
+	}
+	return pc.AddChildIDs(ids...)
+}
+
+// Mutation returns the PageMutation object of the builder.
+func (pc *PageCreate) Mutation() *PageMutation {
+	return pc.mutation
+}
+
+// Save creates the Page in the database.
+func (pc *PageCreate) Save(ctx context.Context) (*Page, error) {
+	pc.defaults()
+	return withHooks[*Page, PageMutation](ctx, pc.sqlSave, pc.mutation, pc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (pc *PageCreate) SaveX(ctx context.Context) *Page {
+	v, err := pc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (pc *PageCreate) Exec(ctx context.Context) error {
+	_, err := pc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (pc *PageCreate) ExecX(ctx context.Context) {
+	if err := pc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (pc *PageCreate) defaults() {
+	if _, ok := pc.mutation.MenuOrder(); !ok {
+		v := page.DefaultMenuOrder
+		pc.mutation.SetMenuOrder(v)
+	}
+	if _, ok := pc.mutation.ShowInMenu(); !ok {
+		v := page.DefaultShowInMenu
+		pc.mutation.SetShowInMenu(v)
+	}
+	if _, ok := pc.mutation.Published(); !ok {
+		v := page.DefaultPublished
+		pc.mutation.SetPublished(v)
+	}
+	if _, ok := pc.mutation.CreatedAt(); !ok {
+		v := page.DefaultCreatedAt()
+		pc.mutation.SetCreatedAt(v)
+	}
+	if _, ok := pc.mutation.UpdatedAt(); !ok {
+		v := page.DefaultUpdatedAt()
+		pc.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (pc *PageCreate) check() error {
+	if _, ok := pc.mutation.Title(); !ok {
+		return &ValidationError{Name: "title", err: errors.New(`ent: missing required field "Page.title"`)}
+	}
+	if v, ok := pc.mutation.Title(); ok {
+		if err := page.TitleValidator(v); err != nil {
+			return &ValidationError{Name: "title", err: fmt.Errorf(`ent: validator failed for field "Page.title": %w`, err)}
+		}
+	}
+	if _, ok := pc.mutation.Slug(); !ok {
+		return &ValidationError{Name: "slug", err: errors.New(`ent: missing required field "Page.slug"`)}
+	}
+	if v, ok := pc.mutation.Slug(); ok {
+		if err := page.SlugValidator(v); err != nil {
+			return &ValidationError{Name: "slug", err: fmt.Errorf(`ent: validator failed for field "Page.slug": %w`, err)}
+		}
+	}
+	if _, ok := pc.mutation.Body(); !ok {
+		return &ValidationError{Name: "body", err: errors.New(`ent: missing required field "Page.body"`)}
+	}
+	if _, ok := pc.mutation.MenuOrder(); !ok {
+		return &ValidationError{Name: "menu_order", err: errors.New(`ent: missing required field "Page.menu_order"`)}
+	}
+	if _, ok := pc.mutation.ShowInMenu(); !ok {
+		return &ValidationError{Name: "show_in_menu", err: errors.New(`ent: missing required field "Page.show_in_menu"`)}
+	}
+	if _, ok := pc.mutation.Published(); !ok {
+		return &ValidationError{Name: "published", err: errors.New(`ent: missing required field "Page.published"`)}
+	}
+	if _, ok := pc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Page.created_at"`)}
+	}
+	if _, ok := pc.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New(`ent: missing required field "Page.updated_at"`)}
+	}
+	return nil
+}
+
+func (pc *PageCreate) sqlSave(ctx context.Context) (*Page, error) {
+	if err := pc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := pc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, pc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	pc.mutation.id = &_node.ID
+	pc.mutation.done = true
+	return _node, nil
+}
+
+func (pc *PageCreate) createSpec() (*Page, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Page{config: pc.config}
+		_spec = sqlgraph.NewCreateSpec(page.Table, sqlgraph.NewFieldSpec(page.FieldID, field.TypeInt))
+	)
+	if value, ok := pc.mutation.Title(); ok {
+		_spec.SetField(page.FieldTitle, field.TypeString, value)
+		_node.Title = value
+	}
+	if value, ok := pc.mutation.Slug(); ok {
+		_spec.SetField(page.FieldSlug, field.TypeString, value)
+		_node.Slug = value
+	}
+	if value, ok := pc.mutation.Body(); ok {
+		_spec.SetField(page.FieldBody, field.TypeString, value)
+		_node.Body = value
+	}
+	if value, ok := pc.mutation.MenuOrder(); ok {
+		_spec.SetField(page.FieldMenuOrder, field.TypeInt, value)
+		_node.MenuOrder = value
+	}
+	if value, ok := pc.mutation.ShowInMenu(); ok {
+		_spec.SetField(page.FieldShowInMenu, field.TypeBool, value)
+		_node.ShowInMenu = value
+	}
+	if value, ok := pc.mutation.MetaTitle(); ok {
+		_spec.SetField(page.FieldMetaTitle, field.TypeString, value)
+		_node.MetaTitle = value
+	}
+	if value, ok := pc.mutation.MetaDescription(); ok {
+		_spec.SetField(page.FieldMetaDescription, field.TypeString, value)
+		_node.MetaDescription = value
+	}
+	if value, ok := pc.mutation.Published(); ok {
+		_spec.SetField(page.FieldPublished, field.TypeBool, value)
+		_node.Published = value
+	}
+	if value, ok := pc.mutation.CreatedAt(); ok {
+		_spec.SetField(page.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := pc.mutation.UpdatedAt(); ok {
+		_spec.SetField(page.FieldUpdatedAt, field.TypeTime, value)
+		_node.UpdatedAt = value
+	}
+	if nodes := pc.mutation.ParentIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   page.ParentTable,
+			Columns: []string{page.ParentColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(page.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.page_children = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := pc.mutation.ChildrenIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   page.ChildrenTable,
+			Columns: []string{page.ChildrenColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(page.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// PageCreateBulk is the builder for creating many Page entities in bulk.
+type PageCreateBulk struct {
+	config
+	builders []*PageCreate
+}
+
+// Save creates the Page entities in the database.
+func (pcb *PageCreateBulk) Save(ctx context.Context) ([]*Page, error) {
+	specs := make([]*sqlgraph.CreateSpec, len(pcb.builders))
+	nodes := make([]*Page, len(pcb.builders))
+	mutators := make([]Mutator, len(pcb.builders))
+	for i := range pcb.builders {
+		func(i int, root context.Context) {
+			builder := pcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*PageMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, pcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, pcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, pcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (pcb *PageCreateBulk) SaveX(ctx context.Context) []*Page {
+	v, err := pcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (pcb *PageCreateBulk) Exec(ctx context.Context) error {
+	_, err := pcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (pcb *PageCreateBulk) ExecX(ctx context.Context) {
+	if err := pcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
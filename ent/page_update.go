@@ -0,0 +1,798 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/vovanwin/api-my-site/ent/page"
+	"github.com/vovanwin/api-my-site/ent/predicate"
+)
+
+// PageUpdate is the builder for updating Page entities.
+type PageUpdate struct {
+	config
+	hooks    []Hook
+	mutation *PageMutation
+}
+
+// Where appends a list predicates to the PageUpdate builder.
+func (pu *PageUpdate) Where(ps ...predicate.Page) *PageUpdate {
+	pu.mutation.Where(ps...)
+	return pu
+}
+
+// SetTitle sets the "title" field.
+func (pu *PageUpdate) SetTitle(s string) *PageUpdate {
+	pu.mutation.SetTitle(s)
+	return pu
+}
+
+// SetSlug sets the "slug" field.
+func (pu *PageUpdate) SetSlug(s string) *PageUpdate {
+	pu.mutation.SetSlug(s)
+	return pu
+}
+
+// SetBody sets the "body" field.
+func (pu *PageUpdate) SetBody(s string) *PageUpdate {
+	pu.mutation.SetBody(s)
+	return pu
+}
+
+// SetMenuOrder sets the "menu_order" field.
+func (pu *PageUpdate) SetMenuOrder(i int) *PageUpdate {
+	pu.mutation.ResetMenuOrder()
+	pu.mutation.SetMenuOrder(i)
+	return pu
+}
+
+// SetNillableMenuOrder sets the "menu_order" field if the given value is not nil.
+func (pu *PageUpdate) SetNillableMenuOrder(i *int) *PageUpdate {
+	if i != nil {
+		pu.SetMenuOrder(*i)
+	}
+	return pu
+}
+
+// AddMenuOrder adds i to the "menu_order" field.
+func (pu *PageUpdate) AddMenuOrder(i int) *PageUpdate {
+	pu.mutation.AddMenuOrder(i)
+	return pu
+}
+
+// SetShowInMenu sets the "show_in_menu" field.
+func (pu *PageUpdate) SetShowInMenu(b bool) *PageUpdate {
+	pu.mutation.SetShowInMenu(b)
+	return pu
+}
+
+// SetNillableShowInMenu sets the "show_in_menu" field if the given value is not nil.
+func (pu *PageUpdate) SetNillableShowInMenu(b *bool) *PageUpdate {
+	if b != nil {
+		pu.SetShowInMenu(*b)
+	}
+	return pu
+}
+
+// SetMetaTitle sets the "meta_title" field.
+func (pu *PageUpdate) SetMetaTitle(s string) *PageUpdate {
+	pu.mutation.SetMetaTitle(s)
+	return pu
+}
+
+// SetNillableMetaTitle sets the "meta_title" field if the given value is not nil.
+func (pu *PageUpdate) SetNillableMetaTitle(s *string) *PageUpdate {
+	if s != nil {
+		pu.SetMetaTitle(*s)
+	}
+	return pu
+}
+
+// ClearMetaTitle clears the value of the "meta_title" field.
+func (pu *PageUpdate) ClearMetaTitle() *PageUpdate {
+	pu.mutation.ClearMetaTitle()
+	return pu
+}
+
+// SetMetaDescription sets the "meta_description" field.
+func (pu *PageUpdate) SetMetaDescription(s string) *PageUpdate {
+	pu.mutation.SetMetaDescription(s)
+	return pu
+}
+
+// SetNillableMetaDescription sets the "meta_description" field if the given value is not nil.
+func (pu *PageUpdate) SetNillableMetaDescription(s *string) *PageUpdate {
+	if s != nil {
+		pu.SetMetaDescription(*s)
+	}
+	return pu
+}
+
+// ClearMetaDescription clears the value of the "meta_description" field.
+func (pu *PageUpdate) ClearMetaDescription() *PageUpdate {
+	pu.mutation.ClearMetaDescription()
+	return pu
+}
+
+// SetPublished sets the "published" field.
+func (pu *PageUpdate) SetPublished(b bool) *PageUpdate {
+	pu.mutation.SetPublished(b)
+	return pu
+}
+
+// SetNillablePublished sets the "published" field if the given value is not nil.
+func (pu *PageUpdate) SetNillablePublished(b *bool) *PageUpdate {
+	if b != nil {
+		pu.SetPublished(*b)
+	}
+	return pu
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (pu *PageUpdate) SetUpdatedAt(t time.Time) *PageUpdate {
+	pu.mutation.SetUpdatedAt(t)
+	return pu
+}
+
+// SetParentID sets the "parent" edge to the Page entity by ID.
+func (pu *PageUpdate) SetParentID(id int) *PageUpdate {
+	pu.mutation.SetParentID(id)
+	return pu
+}
+
+// SetNillableParentID sets the "parent" edge to the Page entity by ID if the given value is not nil.
+func (pu *PageUpdate) SetNillableParentID(id *int) *PageUpdate {
+	if id != nil {
+		pu = pu.SetParentID(*id)
+	}
+	return pu
+}
+
+// SetParent sets the "parent" edge to the Page entity.
+func (pu *PageUpdate) SetParent(p *Page) *PageUpdate {
+	return pu.SetParentID(p.ID)
+}
+
+// AddChildIDs adds the "children" edge to the Page entity by IDs.
+func (pu *PageUpdate) AddChildIDs(ids ...int) *PageUpdate {
+	pu.mutation.AddChildIDs(ids...)
+	return pu
+}
+
+// AddChildren adds the "children" edges to the Page entity.
+func (pu *PageUpdate) AddChildren(p ...*Page) *PageUpdate {
+	ids := make([]int, len(p))
+	for i := range p {
+		ids[i] = p[i].ID
+	}
+	return pu.AddChildIDs(ids...)
+}
+
+// Mutation returns the PageMutation object of the builder.
+func (pu *PageUpdate) Mutation() *PageMutation {
+	return pu.mutation
+}
+
+// ClearParent clears the "parent" edge to the Page entity.
+func (pu *PageUpdate) ClearParent() *PageUpdate {
+	pu.mutation.ClearParent()
+	return pu
+}
+
+// ClearChildren clears all "children" edges to the Page entity.
+func (pu *PageUpdate) ClearChildren() *PageUpdate {
+	pu.mutation.ClearChildren()
+	return pu
+}
+
+// RemoveChildIDs removes the "children" edge to Page entities by IDs.
+func (pu *PageUpdate) RemoveChildIDs(ids ...int) *PageUpdate {
+	pu.mutation.RemoveChildIDs(ids...)
+	return pu
+}
+
+// RemoveChildren removes "children" edges to Page entities.
+func (pu *PageUpdate) RemoveChildren(p ...*Page) *PageUpdate {
+	ids := make([]int, len(p))
+	for i := range p {
+		ids[i] = p[i].ID
+	}
+	return pu.RemoveChildIDs(ids...)
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (pu *PageUpdate) Save(ctx context.Context) (int, error) {
+	pu.defaults()
+	return withHooks[int, PageMutation](ctx, pu.sqlSave, pu.mutation, pu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (pu *PageUpdate) SaveX(ctx context.Context) int {
+	affected, err := pu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (pu *PageUpdate) Exec(ctx context.Context) error {
+	_, err := pu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (pu *PageUpdate) ExecX(ctx context.Context) {
+	if err := pu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (pu *PageUpdate) defaults() {
+	if _, ok := pu.mutation.UpdatedAt(); !ok {
+		v := page.UpdateDefaultUpdatedAt()
+		pu.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (pu *PageUpdate) check() error {
+	if v, ok := pu.mutation.Title(); ok {
+		if err := page.TitleValidator(v); err != nil {
+			return &ValidationError{Name: "title", err: fmt.Errorf(`ent: validator failed for field "Page.title": %w`, err)}
+		}
+	}
+	if v, ok := pu.mutation.Slug(); ok {
+		if err := page.SlugValidator(v); err != nil {
+			return &ValidationError{Name: "slug", err: fmt.Errorf(`ent: validator failed for field "Page.slug": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (pu *PageUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := pu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(page.Table, page.Columns, sqlgraph.NewFieldSpec(page.FieldID, field.TypeInt))
+	if ps := pu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := pu.mutation.Title(); ok {
+		_spec.SetField(page.FieldTitle, field.TypeString, value)
+	}
+	if value, ok := pu.mutation.Slug(); ok {
+		_spec.SetField(page.FieldSlug, field.TypeString, value)
+	}
+	if value, ok := pu.mutation.Body(); ok {
+		_spec.SetField(page.FieldBody, field.TypeString, value)
+	}
+	if value, ok := pu.mutation.MenuOrder(); ok {
+		_spec.SetField(page.FieldMenuOrder, field.TypeInt, value)
+	}
+	if value, ok := pu.mutation.AddedMenuOrder(); ok {
+		_spec.AddField(page.FieldMenuOrder, field.TypeInt, value)
+	}
+	if value, ok := pu.mutation.ShowInMenu(); ok {
+		_spec.SetField(page.FieldShowInMenu, field.TypeBool, value)
+	}
+	if value, ok := pu.mutation.MetaTitle(); ok {
+		_spec.SetField(page.FieldMetaTitle, field.TypeString, value)
+	}
+	if pu.mutation.MetaTitleCleared() {
+		_spec.ClearField(page.FieldMetaTitle, field.TypeString)
+	}
+	if value, ok := pu.mutation.MetaDescription(); ok {
+		_spec.SetField(page.FieldMetaDescription, field.TypeString, value)
+	}
+	if pu.mutation.MetaDescriptionCleared() {
+		_spec.ClearField(page.FieldMetaDescription, field.TypeString)
+	}
+	if value, ok := pu.mutation.Published(); ok {
+		_spec.SetField(page.FieldPublished, field.TypeBool, value)
+	}
+	if value, ok := pu.mutation.UpdatedAt(); ok {
+		_spec.SetField(page.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if pu.mutation.ParentCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   page.ParentTable,
+			Columns: []string{page.ParentColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(page.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := pu.mutation.ParentIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   page.ParentTable,
+			Columns: []string{page.ParentColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(page.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if pu.mutation.ChildrenCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   page.ChildrenTable,
+			Columns: []string{page.ChildrenColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(page.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := pu.mutation.RemovedChildrenIDs(); len(nodes) > 0 && !pu.mutation.ChildrenCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   page.ChildrenTable,
+			Columns: []string{page.ChildrenColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(page.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := pu.mutation.ChildrenIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   page.ChildrenTable,
+			Columns: []string{page.ChildrenColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(page.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, pu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{page.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	pu.mutation.done = true
+	return n, nil
+}
+
+// PageUpdateOne is the builder for updating a single Page entity.
+type PageUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *PageMutation
+}
+
+// SetTitle sets the "title" field.
+func (puo *PageUpdateOne) SetTitle(s string) *PageUpdateOne {
+	puo.mutation.SetTitle(s)
+	return puo
+}
+
+// SetSlug sets the "slug" field.
+func (puo *PageUpdateOne) SetSlug(s string) *PageUpdateOne {
+	puo.mutation.SetSlug(s)
+	return puo
+}
+
+// SetBody sets the "body" field.
+func (puo *PageUpdateOne) SetBody(s string) *PageUpdateOne {
+	puo.mutation.SetBody(s)
+	return puo
+}
+
+// SetMenuOrder sets the "menu_order" field.
+func (puo *PageUpdateOne) SetMenuOrder(i int) *PageUpdateOne {
+	puo.mutation.ResetMenuOrder()
+	puo.mutation.SetMenuOrder(i)
+	return puo
+}
+
+// SetNillableMenuOrder sets the "menu_order" field if the given value is not nil.
+func (puo *PageUpdateOne) SetNillableMenuOrder(i *int) *PageUpdateOne {
+	if i != nil {
+		puo.SetMenuOrder(*i)
+	}
+	return puo
+}
+
+// AddMenuOrder adds i to the "menu_order" field.
+func (puo *PageUpdateOne) AddMenuOrder(i int) *PageUpdateOne {
+	puo.mutation.AddMenuOrder(i)
+	return puo
+}
+
+// SetShowInMenu sets the "show_in_menu" field.
+func (puo *PageUpdateOne) SetShowInMenu(b bool) *PageUpdateOne {
+	puo.mutation.SetShowInMenu(b)
+	return puo
+}
+
+// SetNillableShowInMenu sets the "show_in_menu" field if the given value is not nil.
+func (puo *PageUpdateOne) SetNillableShowInMenu(b *bool) *PageUpdateOne {
+	if b != nil {
+		puo.SetShowInMenu(*b)
+	}
+	return puo
+}
+
+// SetMetaTitle sets the "meta_title" field.
+func (puo *PageUpdateOne) SetMetaTitle(s string) *PageUpdateOne {
+	puo.mutation.SetMetaTitle(s)
+	return puo
+}
+
+// SetNillableMetaTitle sets the "meta_title" field if the given value is not nil.
+func (puo *PageUpdateOne) SetNillableMetaTitle(s *string) *PageUpdateOne {
+	if s != nil {
+		puo.SetMetaTitle(*s)
+	}
+	return puo
+}
+
+// ClearMetaTitle clears the value of the "meta_title" field.
+func (puo *PageUpdateOne) ClearMetaTitle() *PageUpdateOne {
+	puo.mutation.ClearMetaTitle()
+	return puo
+}
+
+// SetMetaDescription sets the "meta_description" field.
+func (puo *PageUpdateOne) SetMetaDescription(s string) *PageUpdateOne {
+	puo.mutation.SetMetaDescription(s)
+	return puo
+}
+
+// SetNillableMetaDescription sets the "meta_description" field if the given value is not nil.
+func (puo *PageUpdateOne) SetNillableMetaDescription(s *string) *PageUpdateOne {
+	if s != nil {
+		puo.SetMetaDescription(*s)
+	}
+	return puo
+}
+
+// ClearMetaDescription clears the value of the "meta_description" field.
+func (puo *PageUpdateOne) ClearMetaDescription() *PageUpdateOne {
+	puo.mutation.ClearMetaDescription()
+	return puo
+}
+
+// SetPublished sets the "published" field.
+func (puo *PageUpdateOne) SetPublished(b bool) *PageUpdateOne {
+	puo.mutation.SetPublished(b)
+	return puo
+}
+
+// SetNillablePublished sets the "published" field if the given value is not nil.
+func (puo *PageUpdateOne) SetNillablePublished(b *bool) *PageUpdateOne {
+	if b != nil {
+		puo.SetPublished(*b)
+	}
+	return puo
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (puo *PageUpdateOne) SetUpdatedAt(t time.Time) *PageUpdateOne {
+	puo.mutation.SetUpdatedAt(t)
+	return puo
+}
+
+// SetParentID sets the "parent" edge to the Page entity by ID.
+func (puo *PageUpdateOne) SetParentID(id int) *PageUpdateOne {
+	puo.mutation.SetParentID(id)
+	return puo
+}
+
+// SetNillableParentID sets the "parent" edge to the Page entity by ID if the given value is not nil.
+func (puo *PageUpdateOne) SetNillableParentID(id *int) *PageUpdateOne {
+	if id != nil {
+		puo = puo.SetParentID(*id)
+	}
+	return puo
+}
+
+// SetParent sets the "parent" edge to the Page entity.
+func (puo *PageUpdateOne) SetParent(p *Page) *PageUpdateOne {
+	return puo.SetParentID(p.ID)
+}
+
+// AddChildIDs adds the "children" edge to the Page entity by IDs.
+func (puo *PageUpdateOne) AddChildIDs(ids ...int) *PageUpdateOne {
+	puo.mutation.AddChildIDs(ids...)
+	return puo
+}
+
+// AddChildren adds the "children" edges to the Page entity.
+func (puo *PageUpdateOne) AddChildren(p ...*Page) *PageUpdateOne {
+	ids := make([]int, len(p))
+	for i := range p {
+		ids[i] = p[i].ID
+	}
+	return puo.AddChildIDs(ids...)
+}
+
+// Mutation returns the PageMutation object of the builder.
+func (puo *PageUpdateOne) Mutation() *PageMutation {
+	return puo.mutation
+}
+
+// ClearParent clears the "parent" edge to the Page entity.
+func (puo *PageUpdateOne) ClearParent() *PageUpdateOne {
+	puo.mutation.ClearParent()
+	return puo
+}
+
+// ClearChildren clears all "children" edges to the Page entity.
+func (puo *PageUpdateOne) ClearChildren() *PageUpdateOne {
+	puo.mutation.ClearChildren()
+	return puo
+}
+
+// RemoveChildIDs removes the "children" edge to Page entities by IDs.
+func (puo *PageUpdateOne) RemoveChildIDs(ids ...int) *PageUpdateOne {
+	puo.mutation.RemoveChildIDs(ids...)
+	return puo
+}
+
+// RemoveChildren removes "children" edges to Page entities.
+func (puo *PageUpdateOne) RemoveChildren(p ...*Page) *PageUpdateOne {
+	ids := make([]int, len(p))
+	for i := range p {
+		ids[i] = p[i].ID
+	}
+	return puo.RemoveChildIDs(ids...)
+}
+
+// Where appends a list predicates to the PageUpdate builder.
+func (puo *PageUpdateOne) Where(ps ...predicate.Page) *PageUpdateOne {
+	puo.mutation.Where(ps...)
+	return puo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (puo *PageUpdateOne) Select(field string, fields ...string) *PageUpdateOne {
+	puo.fields = append([]string{field}, fields...)
+	return puo
+}
+
+// Save executes the query and returns the updated Page entity.
+func (puo *PageUpdateOne) Save(ctx context.Context) (*Page, error) {
+	puo.defaults()
+	return withHooks[*Page, PageMutation](ctx, puo.sqlSave, puo.mutation, puo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (puo *PageUpdateOne) SaveX(ctx context.Context) *Page {
+	node, err := puo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (puo *PageUpdateOne) Exec(ctx context.Context) error {
+	_, err := puo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (puo *PageUpdateOne) ExecX(ctx context.Context) {
+	if err := puo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (puo *PageUpdateOne) defaults() {
+	if _, ok := puo.mutation.UpdatedAt(); !ok {
+		v := page.UpdateDefaultUpdatedAt()
+		puo.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (puo *PageUpdateOne) check() error {
+	if v, ok := puo.mutation.Title(); ok {
+		if err := page.TitleValidator(v); err != nil {
+			return &ValidationError{Name: "title", err: fmt.Errorf(`ent: validator failed for field "Page.title": %w`, err)}
+		}
+	}
+	if v, ok := puo.mutation.Slug(); ok {
+		if err := page.SlugValidator(v); err != nil {
+			return &ValidationError{Name: "slug", err: fmt.Errorf(`ent: validator failed for field "Page.slug": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (puo *PageUpdateOne) sqlSave(ctx context.Context) (_node *Page, err error) {
+	if err := puo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(page.Table, page.Columns, sqlgraph.NewFieldSpec(page.FieldID, field.TypeInt))
+	id, ok := puo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Page.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := puo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, page.FieldID)
+		for _, f := range fields {
+			if !page.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != page.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := puo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := puo.mutation.Title(); ok {
+		_spec.SetField(page.FieldTitle, field.TypeString, value)
+	}
+	if value, ok := puo.mutation.Slug(); ok {
+		_spec.SetField(page.FieldSlug, field.TypeString, value)
+	}
+	if value, ok := puo.mutation.Body(); ok {
+		_spec.SetField(page.FieldBody, field.TypeString, value)
+	}
+	if value, ok := puo.mutation.MenuOrder(); ok {
+		_spec.SetField(page.FieldMenuOrder, field.TypeInt, value)
+	}
+	if value, ok := puo.mutation.AddedMenuOrder(); ok {
+		_spec.AddField(page.FieldMenuOrder, field.TypeInt, value)
+	}
+	if value, ok := puo.mutation.ShowInMenu(); ok {
+		_spec.SetField(page.FieldShowInMenu, field.TypeBool, value)
+	}
+	if value, ok := puo.mutation.MetaTitle(); ok {
+		_spec.SetField(page.FieldMetaTitle, field.TypeString, value)
+	}
+	if puo.mutation.MetaTitleCleared() {
+		_spec.ClearField(page.FieldMetaTitle, field.TypeString)
+	}
+	if value, ok := puo.mutation.MetaDescription(); ok {
+		_spec.SetField(page.FieldMetaDescription, field.TypeString, value)
+	}
+	if puo.mutation.MetaDescriptionCleared() {
+		_spec.ClearField(page.FieldMetaDescription, field.TypeString)
+	}
+	if value, ok := puo.mutation.Published(); ok {
+		_spec.SetField(page.FieldPublished, field.TypeBool, value)
+	}
+	if value, ok := puo.mutation.UpdatedAt(); ok {
+		_spec.SetField(page.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if puo.mutation.ParentCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   page.ParentTable,
+			Columns: []string{page.ParentColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(page.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := puo.mutation.ParentIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   page.ParentTable,
+			Columns: []string{page.ParentColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(page.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if puo.mutation.ChildrenCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   page.ChildrenTable,
+			Columns: []string{page.ChildrenColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(page.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := puo.mutation.RemovedChildrenIDs(); len(nodes) > 0 && !puo.mutation.ChildrenCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   page.ChildrenTable,
+			Columns: []string{page.ChildrenColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(page.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := puo.mutation.ChildrenIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   page.ChildrenTable,
+			Columns: []string{page.ChildrenColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(page.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &Page{config: puo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, puo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{page.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	puo.mutation.done = true
+	return _node, nil
+}
@@ -0,0 +1,651 @@
+// Code generated by ent, DO NOT EDIT.
+
+package project
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/vovanwin/api-my-site/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int) predicate.Project {
+	return predicate.Project(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int) predicate.Project {
+	return predicate.Project(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int) predicate.Project {
+	return predicate.Project(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int) predicate.Project {
+	return predicate.Project(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int) predicate.Project {
+	return predicate.Project(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int) predicate.Project {
+	return predicate.Project(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int) predicate.Project {
+	return predicate.Project(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int) predicate.Project {
+	return predicate.Project(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int) predicate.Project {
+	return predicate.Project(sql.FieldLTE(FieldID, id))
+}
+
+// Title applies equality check predicate on the "title" field. It's identical to TitleEQ.
+func Title(v string) predicate.Project {
+	return predicate.Project(sql.FieldEQ(FieldTitle, v))
+}
+
+// Slug applies equality check predicate on the "slug" field. It's identical to SlugEQ.
+func Slug(v string) predicate.Project {
+	return predicate.Project(sql.FieldEQ(FieldSlug, v))
+}
+
+// Description applies equality check predicate on the "description" field. It's identical to DescriptionEQ.
+func Description(v string) predicate.Project {
+	return predicate.Project(sql.FieldEQ(FieldDescription, v))
+}
+
+// RepositoryURL applies equality check predicate on the "repository_url" field. It's identical to RepositoryURLEQ.
+func RepositoryURL(v string) predicate.Project {
+	return predicate.Project(sql.FieldEQ(FieldRepositoryURL, v))
+}
+
+// DemoURL applies equality check predicate on the "demo_url" field. It's identical to DemoURLEQ.
+func DemoURL(v string) predicate.Project {
+	return predicate.Project(sql.FieldEQ(FieldDemoURL, v))
+}
+
+// Position applies equality check predicate on the "position" field. It's identical to PositionEQ.
+func Position(v int) predicate.Project {
+	return predicate.Project(sql.FieldEQ(FieldPosition, v))
+}
+
+// Featured applies equality check predicate on the "featured" field. It's identical to FeaturedEQ.
+func Featured(v bool) predicate.Project {
+	return predicate.Project(sql.FieldEQ(FieldFeatured, v))
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.Project {
+	return predicate.Project(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// UpdatedAt applies equality check predicate on the "updated_at" field. It's identical to UpdatedAtEQ.
+func UpdatedAt(v time.Time) predicate.Project {
+	return predicate.Project(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// TitleEQ applies the EQ predicate on the "title" field.
+func TitleEQ(v string) predicate.Project {
+	return predicate.Project(sql.FieldEQ(FieldTitle, v))
+}
+
+// TitleNEQ applies the NEQ predicate on the "title" field.
+func TitleNEQ(v string) predicate.Project {
+	return predicate.Project(sql.FieldNEQ(FieldTitle, v))
+}
+
+// TitleIn applies the In predicate on the "title" field.
+func TitleIn(vs ...string) predicate.Project {
+	return predicate.Project(sql.FieldIn(FieldTitle, vs...))
+}
+
+// TitleNotIn applies the NotIn predicate on the "title" field.
+func TitleNotIn(vs ...string) predicate.Project {
+	return predicate.Project(sql.FieldNotIn(FieldTitle, vs...))
+}
+
+// TitleGT applies the GT predicate on the "title" field.
+func TitleGT(v string) predicate.Project {
+	return predicate.Project(sql.FieldGT(FieldTitle, v))
+}
+
+// TitleGTE applies the GTE predicate on the "title" field.
+func TitleGTE(v string) predicate.Project {
+	return predicate.Project(sql.FieldGTE(FieldTitle, v))
+}
+
+// TitleLT applies the LT predicate on the "title" field.
+func TitleLT(v string) predicate.Project {
+	return predicate.Project(sql.FieldLT(FieldTitle, v))
+}
+
+// TitleLTE applies the LTE predicate on the "title" field.
+func TitleLTE(v string) predicate.Project {
+	return predicate.Project(sql.FieldLTE(FieldTitle, v))
+}
+
+// TitleContains applies the Contains predicate on the "title" field.
+func TitleContains(v string) predicate.Project {
+	return predicate.Project(sql.FieldContains(FieldTitle, v))
+}
+
+// TitleHasPrefix applies the HasPrefix predicate on the "title" field.
+func TitleHasPrefix(v string) predicate.Project {
+	return predicate.Project(sql.FieldHasPrefix(FieldTitle, v))
+}
+
+// TitleHasSuffix applies the HasSuffix predicate on the "title" field.
+func TitleHasSuffix(v string) predicate.Project {
+	return predicate.Project(sql.FieldHasSuffix(FieldTitle, v))
+}
+
+// TitleEqualFold applies the EqualFold predicate on the "title" field.
+func TitleEqualFold(v string) predicate.Project {
+	return predicate.Project(sql.FieldEqualFold(FieldTitle, v))
+}
+
+// TitleContainsFold applies the ContainsFold predicate on the "title" field.
+func TitleContainsFold(v string) predicate.Project {
+	return predicate.Project(sql.FieldContainsFold(FieldTitle, v))
+}
+
+// SlugEQ applies the EQ predicate on the "slug" field.
+func SlugEQ(v string) predicate.Project {
+	return predicate.Project(sql.FieldEQ(FieldSlug, v))
+}
+
+// SlugNEQ applies the NEQ predicate on the "slug" field.
+func SlugNEQ(v string) predicate.Project {
+	return predicate.Project(sql.FieldNEQ(FieldSlug, v))
+}
+
+// SlugIn applies the In predicate on the "slug" field.
+func SlugIn(vs ...string) predicate.Project {
+	return predicate.Project(sql.FieldIn(FieldSlug, vs...))
+}
+
+// SlugNotIn applies the NotIn predicate on the "slug" field.
+func SlugNotIn(vs ...string) predicate.Project {
+	return predicate.Project(sql.FieldNotIn(FieldSlug, vs...))
+}
+
+// SlugGT applies the GT predicate on the "slug" field.
+func SlugGT(v string) predicate.Project {
+	return predicate.Project(sql.FieldGT(FieldSlug, v))
+}
+
+// SlugGTE applies the GTE predicate on the "slug" field.
+func SlugGTE(v string) predicate.Project {
+	return predicate.Project(sql.FieldGTE(FieldSlug, v))
+}
+
+// SlugLT applies the LT predicate on the "slug" field.
+func SlugLT(v string) predicate.Project {
+	return predicate.Project(sql.FieldLT(FieldSlug, v))
+}
+
+// SlugLTE applies the LTE predicate on the "slug" field.
+func SlugLTE(v string) predicate.Project {
+	return predicate.Project(sql.FieldLTE(FieldSlug, v))
+}
+
+// SlugContains applies the Contains predicate on the "slug" field.
+func SlugContains(v string) predicate.Project {
+	return predicate.Project(sql.FieldContains(FieldSlug, v))
+}
+
+// SlugHasPrefix applies the HasPrefix predicate on the "slug" field.
+func SlugHasPrefix(v string) predicate.Project {
+	return predicate.Project(sql.FieldHasPrefix(FieldSlug, v))
+}
+
+// SlugHasSuffix applies the HasSuffix predicate on the "slug" field.
+func SlugHasSuffix(v string) predicate.Project {
+	return predicate.Project(sql.FieldHasSuffix(FieldSlug, v))
+}
+
+// SlugEqualFold applies the EqualFold predicate on the "slug" field.
+func SlugEqualFold(v string) predicate.Project {
+	return predicate.Project(sql.FieldEqualFold(FieldSlug, v))
+}
+
+// SlugContainsFold applies the ContainsFold predicate on the "slug" field.
+func SlugContainsFold(v string) predicate.Project {
+	return predicate.Project(sql.FieldContainsFold(FieldSlug, v))
+}
+
+// DescriptionEQ applies the EQ predicate on the "description" field.
+func DescriptionEQ(v string) predicate.Project {
+	return predicate.Project(sql.FieldEQ(FieldDescription, v))
+}
+
+// DescriptionNEQ applies the NEQ predicate on the "description" field.
+func DescriptionNEQ(v string) predicate.Project {
+	return predicate.Project(sql.FieldNEQ(FieldDescription, v))
+}
+
+// DescriptionIn applies the In predicate on the "description" field.
+func DescriptionIn(vs ...string) predicate.Project {
+	return predicate.Project(sql.FieldIn(FieldDescription, vs...))
+}
+
+// DescriptionNotIn applies the NotIn predicate on the "description" field.
+func DescriptionNotIn(vs ...string) predicate.Project {
+	return predicate.Project(sql.FieldNotIn(FieldDescription, vs...))
+}
+
+// DescriptionGT applies the GT predicate on the "description" field.
+func DescriptionGT(v string) predicate.Project {
+	return predicate.Project(sql.FieldGT(FieldDescription, v))
+}
+
+// DescriptionGTE applies the GTE predicate on the "description" field.
+func DescriptionGTE(v string) predicate.Project {
+	return predicate.Project(sql.FieldGTE(FieldDescription, v))
+}
+
+// DescriptionLT applies the LT predicate on the "description" field.
+func DescriptionLT(v string) predicate.Project {
+	return predicate.Project(sql.FieldLT(FieldDescription, v))
+}
+
+// DescriptionLTE applies the LTE predicate on the "description" field.
+func DescriptionLTE(v string) predicate.Project {
+	return predicate.Project(sql.FieldLTE(FieldDescription, v))
+}
+
+// DescriptionContains applies the Contains predicate on the "description" field.
+func DescriptionContains(v string) predicate.Project {
+	return predicate.Project(sql.FieldContains(FieldDescription, v))
+}
+
+// DescriptionHasPrefix applies the HasPrefix predicate on the "description" field.
+func DescriptionHasPrefix(v string) predicate.Project {
+	return predicate.Project(sql.FieldHasPrefix(FieldDescription, v))
+}
+
+// DescriptionHasSuffix applies the HasSuffix predicate on the "description" field.
+func DescriptionHasSuffix(v string) predicate.Project {
+	return predicate.Project(sql.FieldHasSuffix(FieldDescription, v))
+}
+
+// DescriptionIsNil applies the IsNil predicate on the "description" field.
+func DescriptionIsNil() predicate.Project {
+	return predicate.Project(sql.FieldIsNull(FieldDescription))
+}
+
+// DescriptionNotNil applies the NotNil predicate on the "description" field.
+func DescriptionNotNil() predicate.Project {
+	return predicate.Project(sql.FieldNotNull(FieldDescription))
+}
+
+// DescriptionEqualFold applies the EqualFold predicate on the "description" field.
+func DescriptionEqualFold(v string) predicate.Project {
+	return predicate.Project(sql.FieldEqualFold(FieldDescription, v))
+}
+
+// DescriptionContainsFold applies the ContainsFold predicate on the "description" field.
+func DescriptionContainsFold(v string) predicate.Project {
+	return predicate.Project(sql.FieldContainsFold(FieldDescription, v))
+}
+
+// StackIsNil applies the IsNil predicate on the "stack" field.
+func StackIsNil() predicate.Project {
+	return predicate.Project(sql.FieldIsNull(FieldStack))
+}
+
+// StackNotNil applies the NotNil predicate on the "stack" field.
+func StackNotNil() predicate.Project {
+	return predicate.Project(sql.FieldNotNull(FieldStack))
+}
+
+// RepositoryURLEQ applies the EQ predicate on the "repository_url" field.
+func RepositoryURLEQ(v string) predicate.Project {
+	return predicate.Project(sql.FieldEQ(FieldRepositoryURL, v))
+}
+
+// RepositoryURLNEQ applies the NEQ predicate on the "repository_url" field.
+func RepositoryURLNEQ(v string) predicate.Project {
+	return predicate.Project(sql.FieldNEQ(FieldRepositoryURL, v))
+}
+
+// RepositoryURLIn applies the In predicate on the "repository_url" field.
+func RepositoryURLIn(vs ...string) predicate.Project {
+	return predicate.Project(sql.FieldIn(FieldRepositoryURL, vs...))
+}
+
+// RepositoryURLNotIn applies the NotIn predicate on the "repository_url" field.
+func RepositoryURLNotIn(vs ...string) predicate.Project {
+	return predicate.Project(sql.FieldNotIn(FieldRepositoryURL, vs...))
+}
+
+// RepositoryURLGT applies the GT predicate on the "repository_url" field.
+func RepositoryURLGT(v string) predicate.Project {
+	return predicate.Project(sql.FieldGT(FieldRepositoryURL, v))
+}
+
+// RepositoryURLGTE applies the GTE predicate on the "repository_url" field.
+func RepositoryURLGTE(v string) predicate.Project {
+	return predicate.Project(sql.FieldGTE(FieldRepositoryURL, v))
+}
+
+// RepositoryURLLT applies the LT predicate on the "repository_url" field.
+func RepositoryURLLT(v string) predicate.Project {
+	return predicate.Project(sql.FieldLT(FieldRepositoryURL, v))
+}
+
+// RepositoryURLLTE applies the LTE predicate on the "repository_url" field.
+func RepositoryURLLTE(v string) predicate.Project {
+	return predicate.Project(sql.FieldLTE(FieldRepositoryURL, v))
+}
+
+// RepositoryURLContains applies the Contains predicate on the "repository_url" field.
+func RepositoryURLContains(v string) predicate.Project {
+	return predicate.Project(sql.FieldContains(FieldRepositoryURL, v))
+}
+
+// RepositoryURLHasPrefix applies the HasPrefix predicate on the "repository_url" field.
+func RepositoryURLHasPrefix(v string) predicate.Project {
+	return predicate.Project(sql.FieldHasPrefix(FieldRepositoryURL, v))
+}
+
+// RepositoryURLHasSuffix applies the HasSuffix predicate on the "repository_url" field.
+func RepositoryURLHasSuffix(v string) predicate.Project {
+	return predicate.Project(sql.FieldHasSuffix(FieldRepositoryURL, v))
+}
+
+// RepositoryURLIsNil applies the IsNil predicate on the "repository_url" field.
+func RepositoryURLIsNil() predicate.Project {
+	return predicate.Project(sql.FieldIsNull(FieldRepositoryURL))
+}
+
+// RepositoryURLNotNil applies the NotNil predicate on the "repository_url" field.
+func RepositoryURLNotNil() predicate.Project {
+	return predicate.Project(sql.FieldNotNull(FieldRepositoryURL))
+}
+
+// RepositoryURLEqualFold applies the EqualFold predicate on the "repository_url" field.
+func RepositoryURLEqualFold(v string) predicate.Project {
+	return predicate.Project(sql.FieldEqualFold(FieldRepositoryURL, v))
+}
+
+// RepositoryURLContainsFold applies the ContainsFold predicate on the "repository_url" field.
+func RepositoryURLContainsFold(v string) predicate.Project {
+	return predicate.Project(sql.FieldContainsFold(FieldRepositoryURL, v))
+}
+
+// DemoURLEQ applies the EQ predicate on the "demo_url" field.
+func DemoURLEQ(v string) predicate.Project {
+	return predicate.Project(sql.FieldEQ(FieldDemoURL, v))
+}
+
+// DemoURLNEQ applies the NEQ predicate on the "demo_url" field.
+func DemoURLNEQ(v string) predicate.Project {
+	return predicate.Project(sql.FieldNEQ(FieldDemoURL, v))
+}
+
+// DemoURLIn applies the In predicate on the "demo_url" field.
+func DemoURLIn(vs ...string) predicate.Project {
+	return predicate.Project(sql.FieldIn(FieldDemoURL, vs...))
+}
+
+// DemoURLNotIn applies the NotIn predicate on the "demo_url" field.
+func DemoURLNotIn(vs ...string) predicate.Project {
+	return predicate.Project(sql.FieldNotIn(FieldDemoURL, vs...))
+}
+
+// DemoURLGT applies the GT predicate on the "demo_url" field.
+func DemoURLGT(v string) predicate.Project {
+	return predicate.Project(sql.FieldGT(FieldDemoURL, v))
+}
+
+// DemoURLGTE applies the GTE predicate on the "demo_url" field.
+func DemoURLGTE(v string) predicate.Project {
+	return predicate.Project(sql.FieldGTE(FieldDemoURL, v))
+}
+
+// DemoURLLT applies the LT predicate on the "demo_url" field.
+func DemoURLLT(v string) predicate.Project {
+	return predicate.Project(sql.FieldLT(FieldDemoURL, v))
+}
+
+// DemoURLLTE applies the LTE predicate on the "demo_url" field.
+func DemoURLLTE(v string) predicate.Project {
+	return predicate.Project(sql.FieldLTE(FieldDemoURL, v))
+}
+
+// DemoURLContains applies the Contains predicate on the "demo_url" field.
+func DemoURLContains(v string) predicate.Project {
+	return predicate.Project(sql.FieldContains(FieldDemoURL, v))
+}
+
+// DemoURLHasPrefix applies the HasPrefix predicate on the "demo_url" field.
+func DemoURLHasPrefix(v string) predicate.Project {
+	return predicate.Project(sql.FieldHasPrefix(FieldDemoURL, v))
+}
+
+// DemoURLHasSuffix applies the HasSuffix predicate on the "demo_url" field.
+func DemoURLHasSuffix(v string) predicate.Project {
+	return predicate.Project(sql.FieldHasSuffix(FieldDemoURL, v))
+}
+
+// DemoURLIsNil applies the IsNil predicate on the "demo_url" field.
+func DemoURLIsNil() predicate.Project {
+	return predicate.Project(sql.FieldIsNull(FieldDemoURL))
+}
+
+// DemoURLNotNil applies the NotNil predicate on the "demo_url" field.
+func DemoURLNotNil() predicate.Project {
+	return predicate.Project(sql.FieldNotNull(FieldDemoURL))
+}
+
+// DemoURLEqualFold applies the EqualFold predicate on the "demo_url" field.
+func DemoURLEqualFold(v string) predicate.Project {
+	return predicate.Project(sql.FieldEqualFold(FieldDemoURL, v))
+}
+
+// DemoURLContainsFold applies the ContainsFold predicate on the "demo_url" field.
+func DemoURLContainsFold(v string) predicate.Project {
+	return predicate.Project(sql.FieldContainsFold(FieldDemoURL, v))
+}
+
+// PositionEQ applies the EQ predicate on the "position" field.
+func PositionEQ(v int) predicate.Project {
+	return predicate.Project(sql.FieldEQ(FieldPosition, v))
+}
+
+// PositionNEQ applies the NEQ predicate on the "position" field.
+func PositionNEQ(v int) predicate.Project {
+	return predicate.Project(sql.FieldNEQ(FieldPosition, v))
+}
+
+// PositionIn applies the In predicate on the "position" field.
+func PositionIn(vs ...int) predicate.Project {
+	return predicate.Project(sql.FieldIn(FieldPosition, vs...))
+}
+
+// PositionNotIn applies the NotIn predicate on the "position" field.
+func PositionNotIn(vs ...int) predicate.Project {
+	return predicate.Project(sql.FieldNotIn(FieldPosition, vs...))
+}
+
+// PositionGT applies the GT predicate on the "position" field.
+func PositionGT(v int) predicate.Project {
+	return predicate.Project(sql.FieldGT(FieldPosition, v))
+}
+
+// PositionGTE applies the GTE predicate on the "position" field.
+func PositionGTE(v int) predicate.Project {
+	return predicate.Project(sql.FieldGTE(FieldPosition, v))
+}
+
+// PositionLT applies the LT predicate on the "position" field.
+func PositionLT(v int) predicate.Project {
+	return predicate.Project(sql.FieldLT(FieldPosition, v))
+}
+
+// PositionLTE applies the LTE predicate on the "position" field.
+func PositionLTE(v int) predicate.Project {
+	return predicate.Project(sql.FieldLTE(FieldPosition, v))
+}
+
+// FeaturedEQ applies the EQ predicate on the "featured" field.
+func FeaturedEQ(v bool) predicate.Project {
+	return predicate.Project(sql.FieldEQ(FieldFeatured, v))
+}
+
+// FeaturedNEQ applies the NEQ predicate on the "featured" field.
+func FeaturedNEQ(v bool) predicate.Project {
+	return predicate.Project(sql.FieldNEQ(FieldFeatured, v))
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v time.Time) predicate.Project {
+	return predicate.Project(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v time.Time) predicate.Project {
+	return predicate.Project(sql.FieldNEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...time.Time) predicate.Project {
+	return predicate.Project(sql.FieldIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.Project {
+	return predicate.Project(sql.FieldNotIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v time.Time) predicate.Project {
+	return predicate.Project(sql.FieldGT(FieldCreatedAt, v))
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v time.Time) predicate.Project {
+	return predicate.Project(sql.FieldGTE(FieldCreatedAt, v))
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v time.Time) predicate.Project {
+	return predicate.Project(sql.FieldLT(FieldCreatedAt, v))
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v time.Time) predicate.Project {
+	return predicate.Project(sql.FieldLTE(FieldCreatedAt, v))
+}
+
+// UpdatedAtEQ applies the EQ predicate on the "updated_at" field.
+func UpdatedAtEQ(v time.Time) predicate.Project {
+	return predicate.Project(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtNEQ applies the NEQ predicate on the "updated_at" field.
+func UpdatedAtNEQ(v time.Time) predicate.Project {
+	return predicate.Project(sql.FieldNEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtIn applies the In predicate on the "updated_at" field.
+func UpdatedAtIn(vs ...time.Time) predicate.Project {
+	return predicate.Project(sql.FieldIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtNotIn applies the NotIn predicate on the "updated_at" field.
+func UpdatedAtNotIn(vs ...time.Time) predicate.Project {
+	return predicate.Project(sql.FieldNotIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtGT applies the GT predicate on the "updated_at" field.
+func UpdatedAtGT(v time.Time) predicate.Project {
+	return predicate.Project(sql.FieldGT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtGTE applies the GTE predicate on the "updated_at" field.
+func UpdatedAtGTE(v time.Time) predicate.Project {
+	return predicate.Project(sql.FieldGTE(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLT applies the LT predicate on the "updated_at" field.
+func UpdatedAtLT(v time.Time) predicate.Project {
+	return predicate.Project(sql.FieldLT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLTE applies the LTE predicate on the "updated_at" field.
+func UpdatedAtLTE(v time.Time) predicate.Project {
+	return predicate.Project(sql.FieldLTE(FieldUpdatedAt, v))
+}
+
+// HasCover applies the HasEdge predicate on the "cover" edge.
+func HasCover() predicate.Project {
+	return predicate.Project(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, false, CoverTable, CoverColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasCoverWith applies the HasEdge predicate on the "cover" edge with a given conditions (other predicates).
+func HasCoverWith(preds ...predicate.Media) predicate.Project {
+	return predicate.Project(func(s *sql.Selector) {
+		step := newCoverStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.Project) predicate.Project {
+	return predicate.Project(func(s *sql.Selector) {
+		s1 := s.Clone().SetP(nil)
+		for _, p := range predicates {
+			p(s1)
+		}
+		s.Where(s1.P())
+	})
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.Project) predicate.Project {
+	return predicate.Project(func(s *sql.Selector) {
+		s1 := s.Clone().SetP(nil)
+		for i, p := range predicates {
+			if i > 0 {
+				s1.Or()
+			}
+			p(s1)
+		}
+		s.Where(s1.P())
+	})
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.Project) predicate.Project {
+	return predicate.Project(func(s *sql.Selector) {
+		p(s.Not())
+	})
+}
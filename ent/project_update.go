@@ -0,0 +1,696 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/dialect/sql/sqljson"
+	"entgo.io/ent/schema/field"
+	"github.com/vovanwin/api-my-site/ent/media"
+	"github.com/vovanwin/api-my-site/ent/predicate"
+	"github.com/vovanwin/api-my-site/ent/project"
+)
+
+// ProjectUpdate is the builder for updating Project entities.
+type ProjectUpdate struct {
+	config
+	hooks    []Hook
+	mutation *ProjectMutation
+}
+
+// Where appends a list predicates to the ProjectUpdate builder.
+func (pu *ProjectUpdate) Where(ps ...predicate.Project) *ProjectUpdate {
+	pu.mutation.Where(ps...)
+	return pu
+}
+
+// SetTitle sets the "title" field.
+func (pu *ProjectUpdate) SetTitle(s string) *ProjectUpdate {
+	pu.mutation.SetTitle(s)
+	return pu
+}
+
+// SetSlug sets the "slug" field.
+func (pu *ProjectUpdate) SetSlug(s string) *ProjectUpdate {
+	pu.mutation.SetSlug(s)
+	return pu
+}
+
+// SetDescription sets the "description" field.
+func (pu *ProjectUpdate) SetDescription(s string) *ProjectUpdate {
+	pu.mutation.SetDescription(s)
+	return pu
+}
+
+// SetNillableDescription sets the "description" field if the given value is not nil.
+func (pu *ProjectUpdate) SetNillableDescription(s *string) *ProjectUpdate {
+	if s != nil {
+		pu.SetDescription(*s)
+	}
+	return pu
+}
+
+// ClearDescription clears the value of the "description" field.
+func (pu *ProjectUpdate) ClearDescription() *ProjectUpdate {
+	pu.mutation.ClearDescription()
+	return pu
+}
+
+// SetStack sets the "stack" field.
+func (pu *ProjectUpdate) SetStack(s []string) *ProjectUpdate {
+	pu.mutation.SetStack(s)
+	return pu
+}
+
+// AppendStack appends s to the "stack" field.
+func (pu *ProjectUpdate) AppendStack(s []string) *ProjectUpdate {
+	pu.mutation.AppendStack(s)
+	return pu
+}
+
+// ClearStack clears the value of the "stack" field.
+func (pu *ProjectUpdate) ClearStack() *ProjectUpdate {
+	pu.mutation.ClearStack()
+	return pu
+}
+
+// SetRepositoryURL sets the "repository_url" field.
+func (pu *ProjectUpdate) SetRepositoryURL(s string) *ProjectUpdate {
+	pu.mutation.SetRepositoryURL(s)
+	return pu
+}
+
+// SetNillableRepositoryURL sets the "repository_url" field if the given value is not nil.
+func (pu *ProjectUpdate) SetNillableRepositoryURL(s *string) *ProjectUpdate {
+	if s != nil {
+		pu.SetRepositoryURL(*s)
+	}
+	return pu
+}
+
+// ClearRepositoryURL clears the value of the "repository_url" field.
+func (pu *ProjectUpdate) ClearRepositoryURL() *ProjectUpdate {
+	pu.mutation.ClearRepositoryURL()
+	return pu
+}
+
+// SetDemoURL sets the "demo_url" field.
+func (pu *ProjectUpdate) SetDemoURL(s string) *ProjectUpdate {
+	pu.mutation.SetDemoURL(s)
+	return pu
+}
+
+// SetNillableDemoURL sets the "demo_url" field if the given value is not nil.
+func (pu *ProjectUpdate) SetNillableDemoURL(s *string) *ProjectUpdate {
+	if s != nil {
+		pu.SetDemoURL(*s)
+	}
+	return pu
+}
+
+// ClearDemoURL clears the value of the "demo_url" field.
+func (pu *ProjectUpdate) ClearDemoURL() *ProjectUpdate {
+	pu.mutation.ClearDemoURL()
+	return pu
+}
+
+// SetPosition sets the "position" field.
+func (pu *ProjectUpdate) SetPosition(i int) *ProjectUpdate {
+	pu.mutation.ResetPosition()
+	pu.mutation.SetPosition(i)
+	return pu
+}
+
+// SetNillablePosition sets the "position" field if the given value is not nil.
+func (pu *ProjectUpdate) SetNillablePosition(i *int) *ProjectUpdate {
+	if i != nil {
+		pu.SetPosition(*i)
+	}
+	return pu
+}
+
+// AddPosition adds i to the "position" field.
+func (pu *ProjectUpdate) AddPosition(i int) *ProjectUpdate {
+	pu.mutation.AddPosition(i)
+	return pu
+}
+
+// SetFeatured sets the "featured" field.
+func (pu *ProjectUpdate) SetFeatured(b bool) *ProjectUpdate {
+	pu.mutation.SetFeatured(b)
+	return pu
+}
+
+// SetNillableFeatured sets the "featured" field if the given value is not nil.
+func (pu *ProjectUpdate) SetNillableFeatured(b *bool) *ProjectUpdate {
+	if b != nil {
+		pu.SetFeatured(*b)
+	}
+	return pu
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (pu *ProjectUpdate) SetUpdatedAt(t time.Time) *ProjectUpdate {
+	pu.mutation.SetUpdatedAt(t)
+	return pu
+}
+
+// SetCoverID sets the "cover" edge to the Media entity by ID.
+func (pu *ProjectUpdate) SetCoverID(id int) *ProjectUpdate {
+	pu.mutation.SetCoverID(id)
+	return pu
+}
+
+// SetNillableCoverID sets the "cover" edge to the Media entity by ID if the given value is not nil.
+func (pu *ProjectUpdate) SetNillableCoverID(id *int) *ProjectUpdate {
+	if id != nil {
+		pu = pu.SetCoverID(*id)
+	}
+	return pu
+}
+
+// SetCover sets the "cover" edge to the Media entity.
+func (pu *ProjectUpdate) SetCover(m *Media) *ProjectUpdate {
+	return pu.SetCoverID(m.ID)
+}
+
+// Mutation returns the ProjectMutation object of the builder.
+func (pu *ProjectUpdate) Mutation() *ProjectMutation {
+	return pu.mutation
+}
+
+// ClearCover clears the "cover" edge to the Media entity.
+func (pu *ProjectUpdate) ClearCover() *ProjectUpdate {
+	pu.mutation.ClearCover()
+	return pu
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (pu *ProjectUpdate) Save(ctx context.Context) (int, error) {
+	pu.defaults()
+	return withHooks[int, ProjectMutation](ctx, pu.sqlSave, pu.mutation, pu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (pu *ProjectUpdate) SaveX(ctx context.Context) int {
+	affected, err := pu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (pu *ProjectUpdate) Exec(ctx context.Context) error {
+	_, err := pu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (pu *ProjectUpdate) ExecX(ctx context.Context) {
+	if err := pu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (pu *ProjectUpdate) defaults() {
+	if _, ok := pu.mutation.UpdatedAt(); !ok {
+		v := project.UpdateDefaultUpdatedAt()
+		pu.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (pu *ProjectUpdate) check() error {
+	if v, ok := pu.mutation.Title(); ok {
+		if err := project.TitleValidator(v); err != nil {
+			return &ValidationError{Name: "title", err: fmt.Errorf(`ent: validator failed for field "Project.title": %w`, err)}
+		}
+	}
+	if v, ok := pu.mutation.Slug(); ok {
+		if err := project.SlugValidator(v); err != nil {
+			return &ValidationError{Name: "slug", err: fmt.Errorf(`ent: validator failed for field "Project.slug": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (pu *ProjectUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := pu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(project.Table, project.Columns, sqlgraph.NewFieldSpec(project.FieldID, field.TypeInt))
+	if ps := pu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := pu.mutation.Title(); ok {
+		_spec.SetField(project.FieldTitle, field.TypeString, value)
+	}
+	if value, ok := pu.mutation.Slug(); ok {
+		_spec.SetField(project.FieldSlug, field.TypeString, value)
+	}
+	if value, ok := pu.mutation.Description(); ok {
+		_spec.SetField(project.FieldDescription, field.TypeString, value)
+	}
+	if pu.mutation.DescriptionCleared() {
+		_spec.ClearField(project.FieldDescription, field.TypeString)
+	}
+	if value, ok := pu.mutation.Stack(); ok {
+		_spec.SetField(project.FieldStack, field.TypeJSON, value)
+	}
+	if value, ok := pu.mutation.AppendedStack(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, project.FieldStack, value)
+		})
+	}
+	if pu.mutation.StackCleared() {
+		_spec.ClearField(project.FieldStack, field.TypeJSON)
+	}
+	if value, ok := pu.mutation.RepositoryURL(); ok {
+		_spec.SetField(project.FieldRepositoryURL, field.TypeString, value)
+	}
+	if pu.mutation.RepositoryURLCleared() {
+		_spec.ClearField(project.FieldRepositoryURL, field.TypeString)
+	}
+	if value, ok := pu.mutation.DemoURL(); ok {
+		_spec.SetField(project.FieldDemoURL, field.TypeString, value)
+	}
+	if pu.mutation.DemoURLCleared() {
+		_spec.ClearField(project.FieldDemoURL, field.TypeString)
+	}
+	if value, ok := pu.mutation.Position(); ok {
+		_spec.SetField(project.FieldPosition, field.TypeInt, value)
+	}
+	if value, ok := pu.mutation.AddedPosition(); ok {
+		_spec.AddField(project.FieldPosition, field.TypeInt, value)
+	}
+	if value, ok := pu.mutation.Featured(); ok {
+		_spec.SetField(project.FieldFeatured, field.TypeBool, value)
+	}
+	if value, ok := pu.mutation.UpdatedAt(); ok {
+		_spec.SetField(project.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if pu.mutation.CoverCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   project.CoverTable,
+			Columns: []string{project.CoverColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(media.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := pu.mutation.CoverIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   project.CoverTable,
+			Columns: []string{project.CoverColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(media.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, pu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{project.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	pu.mutation.done = true
+	return n, nil
+}
+
+// ProjectUpdateOne is the builder for updating a single Project entity.
+type ProjectUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *ProjectMutation
+}
+
+// SetTitle sets the "title" field.
+func (puo *ProjectUpdateOne) SetTitle(s string) *ProjectUpdateOne {
+	puo.mutation.SetTitle(s)
+	return puo
+}
+
+// SetSlug sets the "slug" field.
+func (puo *ProjectUpdateOne) SetSlug(s string) *ProjectUpdateOne {
+	puo.mutation.SetSlug(s)
+	return puo
+}
+
+// SetDescription sets the "description" field.
+func (puo *ProjectUpdateOne) SetDescription(s string) *ProjectUpdateOne {
+	puo.mutation.SetDescription(s)
+	return puo
+}
+
+// SetNillableDescription sets the "description" field if the given value is not nil.
+func (puo *ProjectUpdateOne) SetNillableDescription(s *string) *ProjectUpdateOne {
+	if s != nil {
+		puo.SetDescription(*s)
+	}
+	return puo
+}
+
+// ClearDescription clears the value of the "description" field.
+func (puo *ProjectUpdateOne) ClearDescription() *ProjectUpdateOne {
+	puo.mutation.ClearDescription()
+	return puo
+}
+
+// SetStack sets the "stack" field.
+func (puo *ProjectUpdateOne) SetStack(s []string) *ProjectUpdateOne {
+	puo.mutation.SetStack(s)
+	return puo
+}
+
+// AppendStack appends s to the "stack" field.
+func (puo *ProjectUpdateOne) AppendStack(s []string) *ProjectUpdateOne {
+	puo.mutation.AppendStack(s)
+	return puo
+}
+
+// ClearStack clears the value of the "stack" field.
+func (puo *ProjectUpdateOne) ClearStack() *ProjectUpdateOne {
+	puo.mutation.ClearStack()
+	return puo
+}
+
+// SetRepositoryURL sets the "repository_url" field.
+func (puo *ProjectUpdateOne) SetRepositoryURL(s string) *ProjectUpdateOne {
+	puo.mutation.SetRepositoryURL(s)
+	return puo
+}
+
+// SetNillableRepositoryURL sets the "repository_url" field if the given value is not nil.
+func (puo *ProjectUpdateOne) SetNillableRepositoryURL(s *string) *ProjectUpdateOne {
+	if s != nil {
+		puo.SetRepositoryURL(*s)
+	}
+	return puo
+}
+
+// ClearRepositoryURL clears the value of the "repository_url" field.
+func (puo *ProjectUpdateOne) ClearRepositoryURL() *ProjectUpdateOne {
+	puo.mutation.ClearRepositoryURL()
+	return puo
+}
+
+// SetDemoURL sets the "demo_url" field.
+func (puo *ProjectUpdateOne) SetDemoURL(s string) *ProjectUpdateOne {
+	puo.mutation.SetDemoURL(s)
+	return puo
+}
+
+// SetNillableDemoURL sets the "demo_url" field if the given value is not nil.
+func (puo *ProjectUpdateOne) SetNillableDemoURL(s *string) *ProjectUpdateOne {
+	if s != nil {
+		puo.SetDemoURL(*s)
+	}
+	return puo
+}
+
+// ClearDemoURL clears the value of the "demo_url" field.
+func (puo *ProjectUpdateOne) ClearDemoURL() *ProjectUpdateOne {
+	puo.mutation.ClearDemoURL()
+	return puo
+}
+
+// SetPosition sets the "position" field.
+func (puo *ProjectUpdateOne) SetPosition(i int) *ProjectUpdateOne {
+	puo.mutation.ResetPosition()
+	puo.mutation.SetPosition(i)
+	return puo
+}
+
+// SetNillablePosition sets the "position" field if the given value is not nil.
+func (puo *ProjectUpdateOne) SetNillablePosition(i *int) *ProjectUpdateOne {
+	if i != nil {
+		puo.SetPosition(*i)
+	}
+	return puo
+}
+
+// AddPosition adds i to the "position" field.
+func (puo *ProjectUpdateOne) AddPosition(i int) *ProjectUpdateOne {
+	puo.mutation.AddPosition(i)
+	return puo
+}
+
+// SetFeatured sets the "featured" field.
+func (puo *ProjectUpdateOne) SetFeatured(b bool) *ProjectUpdateOne {
+	puo.mutation.SetFeatured(b)
+	return puo
+}
+
+// SetNillableFeatured sets the "featured" field if the given value is not nil.
+func (puo *ProjectUpdateOne) SetNillableFeatured(b *bool) *ProjectUpdateOne {
+	if b != nil {
+		puo.SetFeatured(*b)
+	}
+	return puo
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (puo *ProjectUpdateOne) SetUpdatedAt(t time.Time) *ProjectUpdateOne {
+	puo.mutation.SetUpdatedAt(t)
+	return puo
+}
+
+// SetCoverID sets the "cover" edge to the Media entity by ID.
+func (puo *ProjectUpdateOne) SetCoverID(id int) *ProjectUpdateOne {
+	puo.mutation.SetCoverID(id)
+	return puo
+}
+
+// SetNillableCoverID sets the "cover" edge to the Media entity by ID if the given value is not nil.
+func (puo *ProjectUpdateOne) SetNillableCoverID(id *int) *ProjectUpdateOne {
+	if id != nil {
+		puo = puo.SetCoverID(*id)
+	}
+	return puo
+}
+
+// SetCover sets the "cover" edge to the Media entity.
+func (puo *ProjectUpdateOne) SetCover(m *Media) *ProjectUpdateOne {
+	return puo.SetCoverID(m.ID)
+}
+
+// Mutation returns the ProjectMutation object of the builder.
+func (puo *ProjectUpdateOne) Mutation() *ProjectMutation {
+	return puo.mutation
+}
+
+// ClearCover clears the "cover" edge to the Media entity.
+func (puo *ProjectUpdateOne) ClearCover() *ProjectUpdateOne {
+	puo.mutation.ClearCover()
+	return puo
+}
+
+// Where appends a list predicates to the ProjectUpdate builder.
+func (puo *ProjectUpdateOne) Where(ps ...predicate.Project) *ProjectUpdateOne {
+	puo.mutation.Where(ps...)
+	return puo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (puo *ProjectUpdateOne) Select(field string, fields ...string) *ProjectUpdateOne {
+	puo.fields = append([]string{field}, fields...)
+	return puo
+}
+
+// Save executes the query and returns the updated Project entity.
+func (puo *ProjectUpdateOne) Save(ctx context.Context) (*Project, error) {
+	puo.defaults()
+	return withHooks[*Project, ProjectMutation](ctx, puo.sqlSave, puo.mutation, puo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (puo *ProjectUpdateOne) SaveX(ctx context.Context) *Project {
+	node, err := puo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (puo *ProjectUpdateOne) Exec(ctx context.Context) error {
+	_, err := puo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (puo *ProjectUpdateOne) ExecX(ctx context.Context) {
+	if err := puo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (puo *ProjectUpdateOne) defaults() {
+	if _, ok := puo.mutation.UpdatedAt(); !ok {
+		v := project.UpdateDefaultUpdatedAt()
+		puo.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (puo *ProjectUpdateOne) check() error {
+	if v, ok := puo.mutation.Title(); ok {
+		if err := project.TitleValidator(v); err != nil {
+			return &ValidationError{Name: "title", err: fmt.Errorf(`ent: validator failed for field "Project.title": %w`, err)}
+		}
+	}
+	if v, ok := puo.mutation.Slug(); ok {
+		if err := project.SlugValidator(v); err != nil {
+			return &ValidationError{Name: "slug", err: fmt.Errorf(`ent: validator failed for field "Project.slug": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (puo *ProjectUpdateOne) sqlSave(ctx context.Context) (_node *Project, err error) {
+	if err := puo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(project.Table, project.Columns, sqlgraph.NewFieldSpec(project.FieldID, field.TypeInt))
+	id, ok := puo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Project.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := puo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, project.FieldID)
+		for _, f := range fields {
+			if !project.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != project.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := puo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := puo.mutation.Title(); ok {
+		_spec.SetField(project.FieldTitle, field.TypeString, value)
+	}
+	if value, ok := puo.mutation.Slug(); ok {
+		_spec.SetField(project.FieldSlug, field.TypeString, value)
+	}
+	if value, ok := puo.mutation.Description(); ok {
+		_spec.SetField(project.FieldDescription, field.TypeString, value)
+	}
+	if puo.mutation.DescriptionCleared() {
+		_spec.ClearField(project.FieldDescription, field.TypeString)
+	}
+	if value, ok := puo.mutation.Stack(); ok {
+		_spec.SetField(project.FieldStack, field.TypeJSON, value)
+	}
+	if value, ok := puo.mutation.AppendedStack(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, project.FieldStack, value)
+		})
+	}
+	if puo.mutation.StackCleared() {
+		_spec.ClearField(project.FieldStack, field.TypeJSON)
+	}
+	if value, ok := puo.mutation.RepositoryURL(); ok {
+		_spec.SetField(project.FieldRepositoryURL, field.TypeString, value)
+	}
+	if puo.mutation.RepositoryURLCleared() {
+		_spec.ClearField(project.FieldRepositoryURL, field.TypeString)
+	}
+	if value, ok := puo.mutation.DemoURL(); ok {
+		_spec.SetField(project.FieldDemoURL, field.TypeString, value)
+	}
+	if puo.mutation.DemoURLCleared() {
+		_spec.ClearField(project.FieldDemoURL, field.TypeString)
+	}
+	if value, ok := puo.mutation.Position(); ok {
+		_spec.SetField(project.FieldPosition, field.TypeInt, value)
+	}
+	if value, ok := puo.mutation.AddedPosition(); ok {
+		_spec.AddField(project.FieldPosition, field.TypeInt, value)
+	}
+	if value, ok := puo.mutation.Featured(); ok {
+		_spec.SetField(project.FieldFeatured, field.TypeBool, value)
+	}
+	if value, ok := puo.mutation.UpdatedAt(); ok {
+		_spec.SetField(project.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if puo.mutation.CoverCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   project.CoverTable,
+			Columns: []string{project.CoverColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(media.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := puo.mutation.CoverIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   project.CoverTable,
+			Columns: []string{project.CoverColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(media.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &Project{config: puo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, puo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{project.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	puo.mutation.done = true
+	return _node, nil
+}
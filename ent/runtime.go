@@ -5,8 +5,18 @@ package ent
 import (
 	"time"
 
+	"github.com/vovanwin/api-my-site/ent/auditevent"
+	"github.com/vovanwin/api-my-site/ent/category"
+	"github.com/vovanwin/api-my-site/ent/contactmessage"
+	"github.com/vovanwin/api-my-site/ent/featureflag"
+	"github.com/vovanwin/api-my-site/ent/media"
+	"github.com/vovanwin/api-my-site/ent/mediavariant"
+	"github.com/vovanwin/api-my-site/ent/page"
 	"github.com/vovanwin/api-my-site/ent/passwordtoken"
+	"github.com/vovanwin/api-my-site/ent/post"
+	"github.com/vovanwin/api-my-site/ent/project"
 	"github.com/vovanwin/api-my-site/ent/schema"
+	"github.com/vovanwin/api-my-site/ent/tag"
 	"github.com/vovanwin/api-my-site/ent/user"
 )
 
@@ -14,6 +24,158 @@ import (
 // (default values, validators, hooks and policies) and stitches it
 // to their package variables.
 func init() {
+	auditeventFields := schema.AuditEvent{}.Fields()
+	_ = auditeventFields
+	// auditeventDescAction is the schema descriptor for action field.
+	auditeventDescAction := auditeventFields[2].Descriptor()
+	// auditevent.ActionValidator is a validator for the "action" field. It is called by the builders before save.
+	auditevent.ActionValidator = auditeventDescAction.Validators[0].(func(string) error)
+	// auditeventDescCreatedAt is the schema descriptor for created_at field.
+	auditeventDescCreatedAt := auditeventFields[10].Descriptor()
+	// auditevent.DefaultCreatedAt holds the default value on creation for the created_at field.
+	auditevent.DefaultCreatedAt = auditeventDescCreatedAt.Default.(func() time.Time)
+	categoryFields := schema.Category{}.Fields()
+	_ = categoryFields
+	// categoryDescName is the schema descriptor for name field.
+	categoryDescName := categoryFields[0].Descriptor()
+	// category.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	category.NameValidator = categoryDescName.Validators[0].(func(string) error)
+	// categoryDescSlug is the schema descriptor for slug field.
+	categoryDescSlug := categoryFields[1].Descriptor()
+	// category.SlugValidator is a validator for the "slug" field. It is called by the builders before save.
+	category.SlugValidator = categoryDescSlug.Validators[0].(func(string) error)
+	contactmessageFields := schema.ContactMessage{}.Fields()
+	_ = contactmessageFields
+	// contactmessageDescName is the schema descriptor for name field.
+	contactmessageDescName := contactmessageFields[0].Descriptor()
+	// contactmessage.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	contactmessage.NameValidator = contactmessageDescName.Validators[0].(func(string) error)
+	// contactmessageDescEmail is the schema descriptor for email field.
+	contactmessageDescEmail := contactmessageFields[1].Descriptor()
+	// contactmessage.EmailValidator is a validator for the "email" field. It is called by the builders before save.
+	contactmessage.EmailValidator = contactmessageDescEmail.Validators[0].(func(string) error)
+	// contactmessageDescCreatedAt is the schema descriptor for created_at field.
+	contactmessageDescCreatedAt := contactmessageFields[8].Descriptor()
+	// contactmessage.DefaultCreatedAt holds the default value on creation for the created_at field.
+	contactmessage.DefaultCreatedAt = contactmessageDescCreatedAt.Default.(func() time.Time)
+	featureflagFields := schema.FeatureFlag{}.Fields()
+	_ = featureflagFields
+	// featureflagDescKey is the schema descriptor for key field.
+	featureflagDescKey := featureflagFields[0].Descriptor()
+	// featureflag.KeyValidator is a validator for the "key" field. It is called by the builders before save.
+	featureflag.KeyValidator = featureflagDescKey.Validators[0].(func(string) error)
+	// featureflagDescEnabled is the schema descriptor for enabled field.
+	featureflagDescEnabled := featureflagFields[2].Descriptor()
+	// featureflag.DefaultEnabled holds the default value on creation for the enabled field.
+	featureflag.DefaultEnabled = featureflagDescEnabled.Default.(bool)
+	// featureflagDescRollout is the schema descriptor for rollout field.
+	featureflagDescRollout := featureflagFields[3].Descriptor()
+	// featureflag.DefaultRollout holds the default value on creation for the rollout field.
+	featureflag.DefaultRollout = featureflagDescRollout.Default.(int)
+	// featureflag.RolloutValidator is a validator for the "rollout" field. It is called by the builders before save.
+	featureflag.RolloutValidator = featureflagDescRollout.Validators[0].(func(int) error)
+	// featureflagDescCreatedAt is the schema descriptor for created_at field.
+	featureflagDescCreatedAt := featureflagFields[6].Descriptor()
+	// featureflag.DefaultCreatedAt holds the default value on creation for the created_at field.
+	featureflag.DefaultCreatedAt = featureflagDescCreatedAt.Default.(func() time.Time)
+	// featureflagDescUpdatedAt is the schema descriptor for updated_at field.
+	featureflagDescUpdatedAt := featureflagFields[7].Descriptor()
+	// featureflag.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	featureflag.DefaultUpdatedAt = featureflagDescUpdatedAt.Default.(func() time.Time)
+	// featureflag.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	featureflag.UpdateDefaultUpdatedAt = featureflagDescUpdatedAt.UpdateDefault.(func() time.Time)
+	mediaFields := schema.Media{}.Fields()
+	_ = mediaFields
+	// mediaDescKey is the schema descriptor for key field.
+	mediaDescKey := mediaFields[0].Descriptor()
+	// media.KeyValidator is a validator for the "key" field. It is called by the builders before save.
+	media.KeyValidator = mediaDescKey.Validators[0].(func(string) error)
+	// mediaDescFilename is the schema descriptor for filename field.
+	mediaDescFilename := mediaFields[1].Descriptor()
+	// media.FilenameValidator is a validator for the "filename" field. It is called by the builders before save.
+	media.FilenameValidator = mediaDescFilename.Validators[0].(func(string) error)
+	// mediaDescMimeType is the schema descriptor for mime_type field.
+	mediaDescMimeType := mediaFields[2].Descriptor()
+	// media.MimeTypeValidator is a validator for the "mime_type" field. It is called by the builders before save.
+	media.MimeTypeValidator = mediaDescMimeType.Validators[0].(func(string) error)
+	// mediaDescSize is the schema descriptor for size field.
+	mediaDescSize := mediaFields[3].Descriptor()
+	// media.SizeValidator is a validator for the "size" field. It is called by the builders before save.
+	media.SizeValidator = mediaDescSize.Validators[0].(func(int64) error)
+	// mediaDescChecksum is the schema descriptor for checksum field.
+	mediaDescChecksum := mediaFields[4].Descriptor()
+	// media.ChecksumValidator is a validator for the "checksum" field. It is called by the builders before save.
+	media.ChecksumValidator = mediaDescChecksum.Validators[0].(func(string) error)
+	// mediaDescPrivate is the schema descriptor for private field.
+	mediaDescPrivate := mediaFields[7].Descriptor()
+	// media.DefaultPrivate holds the default value on creation for the private field.
+	media.DefaultPrivate = mediaDescPrivate.Default.(bool)
+	// mediaDescCreatedAt is the schema descriptor for created_at field.
+	mediaDescCreatedAt := mediaFields[9].Descriptor()
+	// media.DefaultCreatedAt holds the default value on creation for the created_at field.
+	media.DefaultCreatedAt = mediaDescCreatedAt.Default.(func() time.Time)
+	mediavariantFields := schema.MediaVariant{}.Fields()
+	_ = mediavariantFields
+	// mediavariantDescName is the schema descriptor for name field.
+	mediavariantDescName := mediavariantFields[0].Descriptor()
+	// mediavariant.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	mediavariant.NameValidator = mediavariantDescName.Validators[0].(func(string) error)
+	// mediavariantDescKey is the schema descriptor for key field.
+	mediavariantDescKey := mediavariantFields[1].Descriptor()
+	// mediavariant.KeyValidator is a validator for the "key" field. It is called by the builders before save.
+	mediavariant.KeyValidator = mediavariantDescKey.Validators[0].(func(string) error)
+	// mediavariantDescMimeType is the schema descriptor for mime_type field.
+	mediavariantDescMimeType := mediavariantFields[2].Descriptor()
+	// mediavariant.MimeTypeValidator is a validator for the "mime_type" field. It is called by the builders before save.
+	mediavariant.MimeTypeValidator = mediavariantDescMimeType.Validators[0].(func(string) error)
+	// mediavariantDescSize is the schema descriptor for size field.
+	mediavariantDescSize := mediavariantFields[3].Descriptor()
+	// mediavariant.SizeValidator is a validator for the "size" field. It is called by the builders before save.
+	mediavariant.SizeValidator = mediavariantDescSize.Validators[0].(func(int64) error)
+	// mediavariantDescWidth is the schema descriptor for width field.
+	mediavariantDescWidth := mediavariantFields[4].Descriptor()
+	// mediavariant.WidthValidator is a validator for the "width" field. It is called by the builders before save.
+	mediavariant.WidthValidator = mediavariantDescWidth.Validators[0].(func(int) error)
+	// mediavariantDescHeight is the schema descriptor for height field.
+	mediavariantDescHeight := mediavariantFields[5].Descriptor()
+	// mediavariant.HeightValidator is a validator for the "height" field. It is called by the builders before save.
+	mediavariant.HeightValidator = mediavariantDescHeight.Validators[0].(func(int) error)
+	// mediavariantDescCreatedAt is the schema descriptor for created_at field.
+	mediavariantDescCreatedAt := mediavariantFields[6].Descriptor()
+	// mediavariant.DefaultCreatedAt holds the default value on creation for the created_at field.
+	mediavariant.DefaultCreatedAt = mediavariantDescCreatedAt.Default.(func() time.Time)
+	pageFields := schema.Page{}.Fields()
+	_ = pageFields
+	// pageDescTitle is the schema descriptor for title field.
+	pageDescTitle := pageFields[0].Descriptor()
+	// page.TitleValidator is a validator for the "title" field. It is called by the builders before save.
+	page.TitleValidator = pageDescTitle.Validators[0].(func(string) error)
+	// pageDescSlug is the schema descriptor for slug field.
+	pageDescSlug := pageFields[1].Descriptor()
+	// page.SlugValidator is a validator for the "slug" field. It is called by the builders before save.
+	page.SlugValidator = pageDescSlug.Validators[0].(func(string) error)
+	// pageDescMenuOrder is the schema descriptor for menu_order field.
+	pageDescMenuOrder := pageFields[3].Descriptor()
+	// page.DefaultMenuOrder holds the default value on creation for the menu_order field.
+	page.DefaultMenuOrder = pageDescMenuOrder.Default.(int)
+	// pageDescShowInMenu is the schema descriptor for show_in_menu field.
+	pageDescShowInMenu := pageFields[4].Descriptor()
+	// page.DefaultShowInMenu holds the default value on creation for the show_in_menu field.
+	page.DefaultShowInMenu = pageDescShowInMenu.Default.(bool)
+	// pageDescPublished is the schema descriptor for published field.
+	pageDescPublished := pageFields[7].Descriptor()
+	// page.DefaultPublished holds the default value on creation for the published field.
+	page.DefaultPublished = pageDescPublished.Default.(bool)
+	// pageDescCreatedAt is the schema descriptor for created_at field.
+	pageDescCreatedAt := pageFields[8].Descriptor()
+	// page.DefaultCreatedAt holds the default value on creation for the created_at field.
+	page.DefaultCreatedAt = pageDescCreatedAt.Default.(func() time.Time)
+	// pageDescUpdatedAt is the schema descriptor for updated_at field.
+	pageDescUpdatedAt := pageFields[9].Descriptor()
+	// page.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	page.DefaultUpdatedAt = pageDescUpdatedAt.Default.(func() time.Time)
+	// page.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	page.UpdateDefaultUpdatedAt = pageDescUpdatedAt.UpdateDefault.(func() time.Time)
 	passwordtokenFields := schema.PasswordToken{}.Fields()
 	_ = passwordtokenFields
 	// passwordtokenDescHash is the schema descriptor for hash field.
@@ -24,6 +186,64 @@ func init() {
 	passwordtokenDescCreatedAt := passwordtokenFields[1].Descriptor()
 	// passwordtoken.DefaultCreatedAt holds the default value on creation for the created_at field.
 	passwordtoken.DefaultCreatedAt = passwordtokenDescCreatedAt.Default.(func() time.Time)
+	postFields := schema.Post{}.Fields()
+	_ = postFields
+	// postDescTitle is the schema descriptor for title field.
+	postDescTitle := postFields[0].Descriptor()
+	// post.TitleValidator is a validator for the "title" field. It is called by the builders before save.
+	post.TitleValidator = postDescTitle.Validators[0].(func(string) error)
+	// postDescSlug is the schema descriptor for slug field.
+	postDescSlug := postFields[1].Descriptor()
+	// post.SlugValidator is a validator for the "slug" field. It is called by the builders before save.
+	post.SlugValidator = postDescSlug.Validators[0].(func(string) error)
+	// postDescCreatedAt is the schema descriptor for created_at field.
+	postDescCreatedAt := postFields[5].Descriptor()
+	// post.DefaultCreatedAt holds the default value on creation for the created_at field.
+	post.DefaultCreatedAt = postDescCreatedAt.Default.(func() time.Time)
+	// postDescUpdatedAt is the schema descriptor for updated_at field.
+	postDescUpdatedAt := postFields[6].Descriptor()
+	// post.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	post.DefaultUpdatedAt = postDescUpdatedAt.Default.(func() time.Time)
+	// post.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	post.UpdateDefaultUpdatedAt = postDescUpdatedAt.UpdateDefault.(func() time.Time)
+	projectFields := schema.Project{}.Fields()
+	_ = projectFields
+	// projectDescTitle is the schema descriptor for title field.
+	projectDescTitle := projectFields[0].Descriptor()
+	// project.TitleValidator is a validator for the "title" field. It is called by the builders before save.
+	project.TitleValidator = projectDescTitle.Validators[0].(func(string) error)
+	// projectDescSlug is the schema descriptor for slug field.
+	projectDescSlug := projectFields[1].Descriptor()
+	// project.SlugValidator is a validator for the "slug" field. It is called by the builders before save.
+	project.SlugValidator = projectDescSlug.Validators[0].(func(string) error)
+	// projectDescPosition is the schema descriptor for position field.
+	projectDescPosition := projectFields[6].Descriptor()
+	// project.DefaultPosition holds the default value on creation for the position field.
+	project.DefaultPosition = projectDescPosition.Default.(int)
+	// projectDescFeatured is the schema descriptor for featured field.
+	projectDescFeatured := projectFields[7].Descriptor()
+	// project.DefaultFeatured holds the default value on creation for the featured field.
+	project.DefaultFeatured = projectDescFeatured.Default.(bool)
+	// projectDescCreatedAt is the schema descriptor for created_at field.
+	projectDescCreatedAt := projectFields[8].Descriptor()
+	// project.DefaultCreatedAt holds the default value on creation for the created_at field.
+	project.DefaultCreatedAt = projectDescCreatedAt.Default.(func() time.Time)
+	// projectDescUpdatedAt is the schema descriptor for updated_at field.
+	projectDescUpdatedAt := projectFields[9].Descriptor()
+	// project.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	project.DefaultUpdatedAt = projectDescUpdatedAt.Default.(func() time.Time)
+	// project.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	project.UpdateDefaultUpdatedAt = projectDescUpdatedAt.UpdateDefault.(func() time.Time)
+	tagFields := schema.Tag{}.Fields()
+	_ = tagFields
+	// tagDescName is the schema descriptor for name field.
+	tagDescName := tagFields[0].Descriptor()
+	// tag.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	tag.NameValidator = tagDescName.Validators[0].(func(string) error)
+	// tagDescSlug is the schema descriptor for slug field.
+	tagDescSlug := tagFields[1].Descriptor()
+	// tag.SlugValidator is a validator for the "slug" field. It is called by the builders before save.
+	tag.SlugValidator = tagDescSlug.Validators[0].(func(string) error)
 	userFields := schema.User{}.Fields()
 	_ = userFields
 	// userDescName is the schema descriptor for name field.
@@ -42,8 +262,12 @@ func init() {
 	userDescVerified := userFields[3].Descriptor()
 	// user.DefaultVerified holds the default value on creation for the verified field.
 	user.DefaultVerified = userDescVerified.Default.(bool)
+	// userDescTokenVersion is the schema descriptor for token_version field.
+	userDescTokenVersion := userFields[8].Descriptor()
+	// user.DefaultTokenVersion holds the default value on creation for the token_version field.
+	user.DefaultTokenVersion = userDescTokenVersion.Default.(int)
 	// userDescCreatedAt is the schema descriptor for created_at field.
-	userDescCreatedAt := userFields[4].Descriptor()
+	userDescCreatedAt := userFields[11].Descriptor()
 	// user.DefaultCreatedAt holds the default value on creation for the created_at field.
 	user.DefaultCreatedAt = userDescCreatedAt.Default.(func() time.Time)
 }
@@ -0,0 +1,56 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// AuditEvent holds the schema definition for the AuditEvent entity.
+type AuditEvent struct {
+	ent.Schema
+}
+
+// Fields of the AuditEvent.
+func (AuditEvent) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int("actor_id").
+			Optional().
+			Nillable(),
+		field.Int("impersonator_id").
+			Optional().
+			Nillable(),
+		field.String("action").
+			NotEmpty(),
+		field.String("target_type").
+			Optional(),
+		field.Int("target_id").
+			Optional().
+			Nillable(),
+		field.String("ip").
+			Optional(),
+		field.String("user_agent").
+			Optional(),
+		field.String("request_id").
+			Optional(),
+		field.JSON("before", map[string]interface{}{}).
+			Optional(),
+		field.JSON("after", map[string]interface{}{}).
+			Optional(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Indexes of the AuditEvent.
+func (AuditEvent) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("created_at"),
+		index.Fields("action"),
+		index.Fields("actor_id"),
+		index.Fields("target_type", "target_id"),
+	}
+}
@@ -0,0 +1,47 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// ContactMessage holds the schema definition for the ContactMessage entity.
+type ContactMessage struct {
+	ent.Schema
+}
+
+// Fields of the ContactMessage.
+func (ContactMessage) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("name").
+			NotEmpty(),
+		field.String("email").
+			NotEmpty(),
+		field.String("subject").
+			Optional(),
+		field.Text("message"),
+		field.String("ip").
+			Optional(),
+		field.String("user_agent").
+			Optional(),
+		field.Time("forwarded_at").
+			Optional().
+			Nillable(),
+		field.Time("handled_at").
+			Optional().
+			Nillable(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Indexes of the ContactMessage.
+func (ContactMessage) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("handled_at", "created_at"),
+	}
+}
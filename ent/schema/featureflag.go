@@ -0,0 +1,40 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+)
+
+// FeatureFlag holds the schema definition for the FeatureFlag entity.
+type FeatureFlag struct {
+	ent.Schema
+}
+
+// Fields of the FeatureFlag.
+func (FeatureFlag) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("key").
+			NotEmpty().
+			Unique().
+			Immutable(),
+		field.String("description").
+			Optional(),
+		field.Bool("enabled").
+			Default(false),
+		field.Int("rollout").
+			Range(0, 100).
+			Default(100),
+		field.JSON("user_ids", []int{}).
+			Optional(),
+		field.JSON("roles", []string{}).
+			Optional(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now),
+	}
+}
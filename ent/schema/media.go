@@ -0,0 +1,55 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+)
+
+// Media holds the schema definition for the Media entity.
+type Media struct {
+	ent.Schema
+}
+
+// Fields of the Media.
+func (Media) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("key").
+			NotEmpty().
+			Unique().
+			Immutable(),
+		field.String("filename").
+			NotEmpty(),
+		field.String("mime_type").
+			NotEmpty(),
+		field.Int64("size").
+			NonNegative(),
+		field.String("checksum").
+			NotEmpty(),
+		field.Int("width").
+			Optional(),
+		field.Int("height").
+			Optional(),
+		field.Bool("private").
+			Default(false),
+		field.Time("processed_at").
+			Optional().
+			Nillable(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the Media.
+func (Media) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("owner", User.Type).
+			Required().
+			Unique(),
+		edge.From("variants", MediaVariant.Type).
+			Ref("media"),
+	}
+}
@@ -0,0 +1,55 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// MediaVariant holds the schema definition for the MediaVariant entity.
+type MediaVariant struct {
+	ent.Schema
+}
+
+// Fields of the MediaVariant.
+func (MediaVariant) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("name").
+			NotEmpty(),
+		field.String("key").
+			NotEmpty().
+			Unique(),
+		field.String("mime_type").
+			NotEmpty(),
+		field.Int64("size").
+			NonNegative(),
+		field.Int("width").
+			Positive(),
+		field.Int("height").
+			Positive(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the MediaVariant.
+func (MediaVariant) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("media", Media.Type).
+			Required().
+			Unique(),
+	}
+}
+
+// Indexes of the MediaVariant.
+func (MediaVariant) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("name").
+			Edges("media").
+			Unique(),
+	}
+}
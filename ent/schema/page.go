@@ -0,0 +1,51 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+)
+
+// Page holds the schema definition for the Page entity.
+type Page struct {
+	ent.Schema
+}
+
+// Fields of the Page.
+func (Page) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("title").
+			NotEmpty(),
+		field.String("slug").
+			NotEmpty().
+			Unique(),
+		field.Text("body"),
+		field.Int("menu_order").
+			Default(0),
+		field.Bool("show_in_menu").
+			Default(false),
+		field.String("meta_title").
+			Optional(),
+		field.String("meta_description").
+			Optional(),
+		field.Bool("published").
+			Default(false),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now),
+	}
+}
+
+// Edges of the Page.
+func (Page) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("children", Page.Type).
+			From("parent").
+			Unique(),
+	}
+}
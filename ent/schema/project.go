@@ -0,0 +1,51 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+)
+
+// Project holds the schema definition for the Project entity.
+type Project struct {
+	ent.Schema
+}
+
+// Fields of the Project.
+func (Project) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("title").
+			NotEmpty(),
+		field.String("slug").
+			NotEmpty().
+			Unique(),
+		field.Text("description").
+			Optional(),
+		field.Strings("stack").
+			Optional(),
+		field.String("repository_url").
+			Optional(),
+		field.String("demo_url").
+			Optional(),
+		field.Int("position").
+			Default(0),
+		field.Bool("featured").
+			Default(false),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now),
+	}
+}
+
+// Edges of the Project.
+func (Project) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("cover", Media.Type).
+			Unique(),
+	}
+}
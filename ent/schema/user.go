@@ -26,6 +26,23 @@ func (User) Fields() []ent.Field {
 			NotEmpty(),
 		field.Bool("verified").
 			Default(false),
+		field.Enum("role").
+			Values("user", "admin").
+			Default("user"),
+		field.Text("bio").
+			Optional(),
+		field.String("locale").
+			Optional(),
+		field.String("timezone").
+			Optional(),
+		field.Int("token_version").
+			Default(0),
+		field.Time("delete_at").
+			Optional().
+			Nillable(),
+		field.Time("blocked_at").
+			Optional().
+			Nillable(),
 		field.Time("created_at").
 			Default(time.Now).
 			Immutable(),
@@ -37,5 +54,11 @@ func (User) Edges() []ent.Edge {
 	return []ent.Edge{
 		edge.From("owner", PasswordToken.Type).
 			Ref("user"),
+		edge.From("media", Media.Type).
+			Ref("owner"),
+		edge.From("posts", Post.Type).
+			Ref("author"),
+		edge.To("avatar", Media.Type).
+			Unique(),
 	}
 }
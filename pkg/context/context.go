@@ -9,6 +9,10 @@ const (
 	// AuthenticatedUserKey является ли значение ключа используемым для хранения аутентифицированного пользователя в контексте
 	AuthenticatedUserKey = "auth_user"
 
+	// ImpersonatorKey является ли значение ключа используемым для хранения идентификатора администратора,
+	// действующего от имени аутентифицированного пользователя, в контексте
+	ImpersonatorKey = "impersonator"
+
 	// UserKey является ли значение ключа используемым для хранения пользователя в контексте
 	UserKey = "user"
 
@@ -17,6 +21,33 @@ const (
 
 	// PasswordTokenKey является ли значение ключа используемым для хранения токена пароля в контексте
 	PasswordTokenKey = "password_token"
+
+	// MediaKey является ли значение ключа используемым для хранения медиафайла в контексте
+	MediaKey = "media"
+
+	// PageKey является ли значение ключа используемым для хранения страницы в контексте
+	PageKey = "page"
+
+	// ProjectKey является ли значение ключа используемым для хранения проекта в контексте
+	ProjectKey = "project"
+
+	// ContactMessageKey является ли значение ключа используемым для хранения сообщения с формы обратной связи в контексте
+	ContactMessageKey = "contact_message"
+
+	// FeatureFlagKey является ли значение ключа используемым для хранения флага функциональности в контексте
+	FeatureFlagKey = "feature_flag"
+
+	// LocaleKey является ли значение ключа используемым для хранения языка запроса в контексте
+	LocaleKey = "locale"
+
+	// RequestIDKey является ли значение ключа используемым для хранения идентификатора запроса в контексте
+	RequestIDKey = "request_id"
+
+	// CachedPageKey является ли значение ключа используемым для хранения ключа кэша страницы запроса в контексте
+	CachedPageKey = "cached_page"
+
+	// APIVersionKey является ли значение ключа используемым для хранения версии API запроса в контексте
+	APIVersionKey = "api_version"
 )
 
 // IsCanceledError определяет, вызвана ли ошибка отменой контекста
@@ -1,11 +1,22 @@
 package controller
 
 import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/golang-jwt/jwt/v4"
 	echojwt "github.com/labstack/echo-jwt/v4"
 	"net/http"
+	"net/url"
+	"time"
 
+	"github.com/vovanwin/api-my-site/pkg/context"
+	"github.com/vovanwin/api-my-site/pkg/i18n"
+	"github.com/vovanwin/api-my-site/pkg/logging"
+	"github.com/vovanwin/api-my-site/pkg/middleware"
+	"github.com/vovanwin/api-my-site/pkg/paging"
+	"github.com/vovanwin/api-my-site/pkg/problem"
 	"github.com/vovanwin/api-my-site/pkg/services"
 
 	"github.com/labstack/echo/v4"
@@ -26,9 +37,10 @@ func NewController(c *services.Container) Controller {
 	}
 }
 
-// Fail является помощником для сбоя запроса, возвращая ошибку 500 и регистрируя ошибку
+// Fail является помощником для сбоя запроса, возвращая ошибку 500
+// Сама ошибка записывается в журнал обработчиком ошибок и не раскрывается клиенту
 func (c *Controller) Fail(err error, log string) error {
-	return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("%s: %v", log, err))
+	return problem.Internal(fmt.Errorf("%s: %w", log, err))
 }
 
 func (c *Controller) AuthMiddleware() echo.MiddlewareFunc {
@@ -39,3 +51,107 @@ func (c *Controller) AuthMiddleware() echo.MiddlewareFunc {
 		SigningKey: []byte(c.Container.Config.App.EncryptionKey),
 	})
 }
+
+// RenderCached отправляет тело ответа и сохраняет его в кэше страниц, чтобы последующие запросы
+// обслуживались middleware.ServeCachedPage без вызова обработчика.
+// Ответ снабжается заголовками ETag и Last-Modified, а условные GET запросы получают 304 Not Modified.
+// Кэшируются только ответы маршрутов с middleware.ServeCachedPage, и не кэшируются ответы аутентифицированным
+// пользователям.
+func (c *Controller) RenderCached(ctx echo.Context, contentType string, body []byte, lastModified time.Time, tags ...string) error {
+	return c.RenderCachedUntil(ctx, contentType, body, lastModified, time.Time{}, tags...)
+}
+
+// RenderCachedUntil отправляет тело ответа так же, как RenderCached, но хранит его в кэше не дольше заданного
+// времени, когда ответ устареет, например при публикации запланированной записи. Нулевое время не ограничивает срок
+func (c *Controller) RenderCachedUntil(ctx echo.Context, contentType string, body []byte, lastModified, until time.Time, tags ...string) error {
+	sum := sha256.Sum256(body)
+	headers := map[string]string{
+		echo.HeaderContentType: contentType,
+		"ETag":                 fmt.Sprintf(`"%x"`, sum[:16]),
+	}
+	if !lastModified.IsZero() {
+		headers[echo.HeaderLastModified] = lastModified.UTC().Format(http.TimeFormat)
+	}
+
+	expiration := c.Container.Settings.Current().Cache.Expiration.Page
+	if !until.IsZero() && time.Until(until) < expiration {
+		expiration = time.Until(until)
+	}
+
+	key := middleware.CachedPageKeyOf(ctx)
+	if key != "" && expiration > 0 && ctx.Get(context.AuthenticatedUserKey) == nil {
+		page := middleware.CachedPage{
+			URL:        key,
+			HTML:       body,
+			StatusCode: http.StatusOK,
+			Headers:    headers,
+		}
+
+		err := c.Container.Cache.
+			Set().
+			Group(middleware.CachedPageGroup).
+			Key(key).
+			Data(page).
+			Expiration(expiration).
+			Tags(tags...).
+			Save(ctx.Request().Context())
+
+		if err != nil {
+			logging.Errorf(ctx.Request().Context(), "failed to cache page: %v", err)
+		}
+	}
+
+	for k, v := range headers {
+		ctx.Response().Header().Set(k, v)
+	}
+
+	if middleware.IsNotModified(ctx.Request(), headers["ETag"], headers[echo.HeaderLastModified]) {
+		return ctx.NoContent(http.StatusNotModified)
+	}
+
+	return ctx.Blob(http.StatusOK, contentType, body)
+}
+
+// RenderCachedJSON кодирует значение в JSON и отправляет его так же, как RenderCached
+func (c *Controller) RenderCachedJSON(ctx echo.Context, v interface{}, lastModified time.Time, tags ...string) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return c.Fail(err, "не удается закодировать ответ")
+	}
+
+	return c.RenderCached(ctx, echo.MIMEApplicationJSONCharsetUTF8, body, lastModified, tags...)
+}
+
+// Paginate разбирает параметры постраничного вывода, сортировки и фильтрации списка ресурса.
+// Курсоры подписываются ключом шифрования приложения, а ошибки в параметрах возвращаются как 400 Bad Request
+func (c *Controller) Paginate(ctx echo.Context, r paging.Resource) (*paging.Params, error) {
+	p, err := paging.Parse(ctx.QueryParams(), r, []byte(c.Container.Config.App.EncryptionKey))
+	if err == nil {
+		return p, nil
+	}
+
+	if errors.Is(err, paging.ErrInvalidCursor) {
+		return nil, problem.New(http.StatusBadRequest, problem.CodeInvalidCursor, "").WithInternal(err)
+	}
+
+	var pe *paging.ParamError
+	if errors.As(err, &pe) {
+		return nil, problem.New(
+			http.StatusBadRequest,
+			problem.CodeBadRequest,
+			i18n.Ctx(ctx, "request.invalid_param", i18n.Params{"param": pe.Param}),
+		).WithInternal(err)
+	}
+
+	return nil, c.Fail(err, "не удается разобрать параметры списка")
+}
+
+// RenderPage отправляет страницу списка с заголовком Link, указывающим на первую и следующую страницы
+// Ссылки строятся по исходному адресу запроса, чтобы сохранить указанную в пути версию API
+func (c *Controller) RenderPage(ctx echo.Context, page interface{ Link(*url.URL) string }) error {
+	if u, err := url.ParseRequestURI(ctx.Request().RequestURI); err == nil {
+		ctx.Response().Header().Set("Link", page.Link(u))
+	}
+
+	return ctx.JSON(http.StatusOK, page)
+}
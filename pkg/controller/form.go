@@ -1,8 +1,13 @@
 package controller
 
 import (
+	"context"
+	"reflect"
+
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
+
+	"github.com/vovanwin/api-my-site/pkg/i18n"
 )
 
 // FormSubmission представляет состояние отправки формы, не включая саму форму
@@ -10,18 +15,30 @@ type FormSubmission struct {
 	// IsSubmitted указывает, была ли отправлена форма
 	IsSubmitted bool
 
-	// Errors сохраняет фрагмент строк сообщения об ошибке с ключом, заданным именем поля структуры формы
+	// Errors сохраняет фрагмент строк сообщения об ошибке с ключом, заданным именем поля в запросе
 	Errors map[string][]string
 }
 
+// contextValidator проверяет структуры с учетом контекста запроса, как services.Validator
+type contextValidator interface {
+	ValidateCtx(ctx context.Context, i interface{}) error
+}
+
 // Process обрабатывает отправку формы
+// Сообщения об ошибках проверки формируются на языке запроса
 func (f *FormSubmission) Process(ctx echo.Context, form interface{}) error {
 	f.Errors = make(map[string][]string)
 	f.IsSubmitted = true
 
 	// Валидация формы
-	if err := ctx.Validate(form); err != nil {
-		f.setErrorMessages(err)
+	var err error
+	if v, ok := ctx.Echo().Validator.(contextValidator); ok {
+		err = v.ValidateCtx(ctx.Request().Context(), form)
+	} else {
+		err = ctx.Validate(form)
+	}
+	if err != nil {
+		f.setErrorMessages(i18n.Locale(ctx), form, err)
 	}
 
 	return nil
@@ -68,7 +85,8 @@ func (f FormSubmission) IsDone() bool {
 }
 
 // setErrorMessages устанавливает сообщения об ошибках при отправке для всех полей, проверка которых не удалась
-func (f *FormSubmission) setErrorMessages(err error) {
+// Названия полей в сообщениях берутся из каталога по ключу, указанному в теге label поля формы
+func (f *FormSubmission) setErrorMessages(locale string, form interface{}, err error) {
 	// Прямо сейчас поддерживается только это
 	ves, ok := err.(validator.ValidationErrors)
 	if !ok {
@@ -76,22 +94,37 @@ func (f *FormSubmission) setErrorMessages(err error) {
 	}
 
 	for _, ve := range ves {
-		var message string
+		params := i18n.Params{
+			"field": fieldLabel(locale, form, ve.StructField()),
+			"param": ve.Param(),
+		}
 
-		// Предоставлять лучшие сообщения об ошибках в зависимости от тега неудачной проверки
-		// Это должно быть расширено по мере использования дополнительных тегов в вашей проверке
+		// Параметры этих тегов ссылаются на другое поле формы
 		switch ve.Tag() {
-		case "required":
-			message = "Это поле является обязательным."
-		case "email":
-			message = "Введите действительный адрес электронной почты."
-		case "eqfield":
-			message = "Не соответствует."
-		default:
-			message = "Недопустимое значение."
+		case "eqfield", "nefield", "gtfield", "ltfield":
+			params["param"] = fieldLabel(locale, form, ve.Param())
 		}
 
-		// Add the error
-		f.SetFieldError(ve.Field(), message)
+		f.SetFieldError(ve.Field(), i18n.Validation(locale, ve.Tag(), ve.Kind(), params))
+	}
+}
+
+// fieldLabel возвращает переведенное название поля формы, либо имя поля, если тег label не задан
+func fieldLabel(locale string, form interface{}, name string) string {
+	t := reflect.TypeOf(form)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return name
+	}
+
+	field, ok := t.FieldByName(name)
+	if !ok {
+		return name
+	}
+	if key := field.Tag.Get("label"); key != "" {
+		return i18n.T(locale, key)
 	}
+	return name
 }
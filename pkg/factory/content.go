@@ -0,0 +1,322 @@
+package factory
+
+import (
+	"context"
+	"time"
+
+	"github.com/vovanwin/api-my-site/ent"
+)
+
+// CategoryBuilder builds a category
+type CategoryBuilder struct {
+	create *ent.CategoryCreate
+}
+
+// Category starts building a category
+func (f *Factory) Category() *CategoryBuilder {
+	name := f.pick(samples[f.lang()].categories)
+	return &CategoryBuilder{
+		create: f.orm.Category.
+			Create().
+			SetName(name).
+			SetSlug(f.slug(name)),
+	}
+}
+
+// Name sets the name
+func (b *CategoryBuilder) Name(name string) *CategoryBuilder {
+	b.create.SetName(name)
+	return b
+}
+
+// Slug sets the slug
+func (b *CategoryBuilder) Slug(slug string) *CategoryBuilder {
+	b.create.SetSlug(slug)
+	return b
+}
+
+// Mutate applies overrides which have no dedicated method
+func (b *CategoryBuilder) Mutate(fn func(*ent.CategoryCreate)) *CategoryBuilder {
+	fn(b.create)
+	return b
+}
+
+// Create creates the category
+func (b *CategoryBuilder) Create(ctx context.Context) (*ent.Category, error) {
+	return b.create.Save(ctx)
+}
+
+// TagBuilder builds a tag
+type TagBuilder struct {
+	create *ent.TagCreate
+}
+
+// Tag starts building a tag
+func (f *Factory) Tag() *TagBuilder {
+	name := f.pick(samples[f.lang()].tags)
+	return &TagBuilder{
+		create: f.orm.Tag.
+			Create().
+			SetName(name).
+			SetSlug(f.slug(name)),
+	}
+}
+
+// Name sets the name
+func (b *TagBuilder) Name(name string) *TagBuilder {
+	b.create.SetName(name)
+	return b
+}
+
+// Slug sets the slug
+func (b *TagBuilder) Slug(slug string) *TagBuilder {
+	b.create.SetSlug(slug)
+	return b
+}
+
+// Mutate applies overrides which have no dedicated method
+func (b *TagBuilder) Mutate(fn func(*ent.TagCreate)) *TagBuilder {
+	fn(b.create)
+	return b
+}
+
+// Create creates the tag
+func (b *TagBuilder) Create(ctx context.Context) (*ent.Tag, error) {
+	return b.create.Save(ctx)
+}
+
+// PostBuilder builds a post
+// The post is a draft by a new user, without a category or tags, unless they're set
+type PostBuilder struct {
+	f      *Factory
+	create *ent.PostCreate
+	author *ent.User
+}
+
+// Post starts building a post
+func (f *Factory) Post() *PostBuilder {
+	lang := f.lang()
+	title := f.pick(samples[lang].titles)
+	created := f.past(180 * 24 * time.Hour)
+
+	return &PostBuilder{
+		f: f,
+		create: f.orm.Post.
+			Create().
+			SetTitle(title).
+			SetSlug(f.slug(title)).
+			SetSummary(f.sentences(lang, 2)).
+			SetBody(f.paragraphs(lang, 3+f.intn(4))).
+			SetCreatedAt(created).
+			SetUpdatedAt(created),
+	}
+}
+
+// Title sets the title
+func (b *PostBuilder) Title(title string) *PostBuilder {
+	b.create.SetTitle(title)
+	return b
+}
+
+// Slug sets the slug
+func (b *PostBuilder) Slug(slug string) *PostBuilder {
+	b.create.SetSlug(slug)
+	return b
+}
+
+// Body sets the body
+func (b *PostBuilder) Body(body string) *PostBuilder {
+	b.create.SetBody(body)
+	return b
+}
+
+// Author sets the author
+func (b *PostBuilder) Author(u *ent.User) *PostBuilder {
+	b.author = u
+	return b
+}
+
+// Category sets the category
+func (b *PostBuilder) Category(c *ent.Category) *PostBuilder {
+	b.create.SetCategory(c)
+	return b
+}
+
+// Tags adds the tags
+func (b *PostBuilder) Tags(tags ...*ent.Tag) *PostBuilder {
+	b.create.AddTags(tags...)
+	return b
+}
+
+// Published publishes the post at a random time within the last month
+func (b *PostBuilder) Published() *PostBuilder {
+	return b.PublishedAt(b.f.past(30 * 24 * time.Hour))
+}
+
+// PublishedAt publishes the post at the time
+func (b *PostBuilder) PublishedAt(at time.Time) *PostBuilder {
+	b.create.SetPublishedAt(at)
+	return b
+}
+
+// Mutate applies overrides which have no dedicated method
+func (b *PostBuilder) Mutate(fn func(*ent.PostCreate)) *PostBuilder {
+	fn(b.create)
+	return b
+}
+
+// Create creates the post
+func (b *PostBuilder) Create(ctx context.Context) (*ent.Post, error) {
+	if b.author == nil {
+		u, err := b.f.User().Create(ctx)
+		if err != nil {
+			return nil, err
+		}
+		b.author = u
+	}
+
+	return b.create.
+		SetAuthor(b.author).
+		Save(ctx)
+}
+
+// PageBuilder builds a page
+// The page is an unpublished top-level page which isn't shown in the menu, unless they're set
+type PageBuilder struct {
+	create *ent.PageCreate
+}
+
+// Page starts building a page
+func (f *Factory) Page() *PageBuilder {
+	lang := f.lang()
+	title := f.pick(samples[lang].pages)
+
+	return &PageBuilder{
+		create: f.orm.Page.
+			Create().
+			SetTitle(title).
+			SetSlug(f.slug(title)).
+			SetBody(f.paragraphs(lang, 2+f.intn(3))).
+			SetMetaDescription(f.sentences(lang, 1)),
+	}
+}
+
+// Title sets the title
+func (b *PageBuilder) Title(title string) *PageBuilder {
+	b.create.SetTitle(title)
+	return b
+}
+
+// Slug sets the slug
+func (b *PageBuilder) Slug(slug string) *PageBuilder {
+	b.create.SetSlug(slug)
+	return b
+}
+
+// Body sets the body
+func (b *PageBuilder) Body(body string) *PageBuilder {
+	b.create.SetBody(body)
+	return b
+}
+
+// Published publishes the page
+func (b *PageBuilder) Published() *PageBuilder {
+	b.create.SetPublished(true)
+	return b
+}
+
+// InMenu shows the page in the menu at the position
+func (b *PageBuilder) InMenu(order int) *PageBuilder {
+	b.create.
+		SetShowInMenu(true).
+		SetMenuOrder(order)
+	return b
+}
+
+// Parent sets the parent page
+func (b *PageBuilder) Parent(p *ent.Page) *PageBuilder {
+	b.create.SetParent(p)
+	return b
+}
+
+// Mutate applies overrides which have no dedicated method
+func (b *PageBuilder) Mutate(fn func(*ent.PageCreate)) *PageBuilder {
+	fn(b.create)
+	return b
+}
+
+// Create creates the page
+func (b *PageBuilder) Create(ctx context.Context) (*ent.Page, error) {
+	return b.create.Save(ctx)
+}
+
+// ProjectBuilder builds a project
+// The project isn't featured and has no cover unless they're set
+type ProjectBuilder struct {
+	create *ent.ProjectCreate
+}
+
+// Project starts building a project
+func (f *Factory) Project() *ProjectBuilder {
+	lang := f.lang()
+	title := f.pick(samples[lang].projects)
+	slug := f.slug(title)
+
+	return &ProjectBuilder{
+		create: f.orm.Project.
+			Create().
+			SetTitle(title).
+			SetSlug(slug).
+			SetDescription(f.sentences(lang, 2+f.intn(2))).
+			SetStack(stacks[f.intn(len(stacks))]).
+			SetRepositoryURL("https://github.com/example/" + slug).
+			SetPosition(f.intn(100)),
+	}
+}
+
+// Title sets the title
+func (b *ProjectBuilder) Title(title string) *ProjectBuilder {
+	b.create.SetTitle(title)
+	return b
+}
+
+// Slug sets the slug
+func (b *ProjectBuilder) Slug(slug string) *ProjectBuilder {
+	b.create.SetSlug(slug)
+	return b
+}
+
+// Stack sets the technology stack
+func (b *ProjectBuilder) Stack(stack ...string) *ProjectBuilder {
+	b.create.SetStack(stack)
+	return b
+}
+
+// Featured features the project
+func (b *ProjectBuilder) Featured() *ProjectBuilder {
+	b.create.SetFeatured(true)
+	return b
+}
+
+// Position sets the position in the project list
+func (b *ProjectBuilder) Position(position int) *ProjectBuilder {
+	b.create.SetPosition(position)
+	return b
+}
+
+// Cover sets the cover image
+func (b *ProjectBuilder) Cover(m *ent.Media) *ProjectBuilder {
+	b.create.SetCover(m)
+	return b
+}
+
+// Mutate applies overrides which have no dedicated method
+func (b *ProjectBuilder) Mutate(fn func(*ent.ProjectCreate)) *ProjectBuilder {
+	fn(b.create)
+	return b
+}
+
+// Create creates the project
+func (b *ProjectBuilder) Create(ctx context.Context) (*ent.Project, error) {
+	return b.create.Save(ctx)
+}
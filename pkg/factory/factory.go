@@ -0,0 +1,161 @@
+// Package factory builds entities with random but realistic defaults for tests and seeding
+//
+// Every entity has a builder whose defaults can be overridden before it's created:
+//
+//	f := factory.New(orm, 42)
+//	admin, err := f.User().Verified().WithRole("admin").Create(ctx)
+//	post, err := f.Post().Author(admin).Published().Create(ctx)
+//
+// The defaults are drawn from the seeded source, so a factory created with the same seed builds the same
+// entities in the same order, apart from the timestamps which are relative to the current time. Factories
+// sharing a database therefore need different seeds, or their unique values collide.
+// Required edges which aren't set are satisfied by creating the related entity.
+package factory
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vovanwin/api-my-site/ent"
+)
+
+const (
+	// DefaultPassword is the password of the users built unless another one is set
+	DefaultPassword = "password"
+
+	// langRU identifies the Russian sample content
+	langRU = "ru"
+
+	// langEN identifies the English sample content
+	langEN = "en"
+)
+
+// Factory builds entities
+// It's safe for concurrent use, but the values are only deterministic when used from a single goroutine
+type Factory struct {
+	// orm stores the client the entities are created with
+	orm *ent.Client
+
+	// mu guards rand and seq
+	mu sync.Mutex
+
+	// rand stores the seeded source of the default values
+	rand *rand.Rand
+
+	// seq stores the number of unique values generated so far
+	seq int
+
+	// now stores the time the generated timestamps are relative to
+	now time.Time
+}
+
+// New creates a new Factory whose defaults are drawn from the seed
+func New(orm *ent.Client, seed int64) *Factory {
+	return &Factory{
+		orm:  orm,
+		rand: rand.New(rand.NewSource(seed)),
+		now:  time.Now(),
+	}
+}
+
+// intn returns a random number in [0, n)
+func (f *Factory) intn(n int) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.rand.Intn(n)
+}
+
+// pick returns a random element of the values
+func (f *Factory) pick(values []string) string {
+	return values[f.intn(len(values))]
+}
+
+// chance returns true with the probability of percent / 100
+func (f *Factory) chance(percent int) bool {
+	return f.intn(100) < percent
+}
+
+// lang returns the language of the sample content of an entity
+func (f *Factory) lang() string {
+	if f.chance(50) {
+		return langRU
+	}
+	return langEN
+}
+
+// unique returns a value which is unique among the values generated by the factory and unlikely to collide
+// with the values generated by other factories
+func (f *Factory) unique() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.seq++
+	return fmt.Sprintf("%d%04x", f.seq, f.rand.Intn(1<<16))
+}
+
+// hex returns a random hex string of the length
+func (f *Factory) hex(length int) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	const digits = "0123456789abcdef"
+	b := make([]byte, length)
+	for i := range b {
+		b[i] = digits[f.rand.Intn(len(digits))]
+	}
+	return string(b)
+}
+
+// past returns a random time within the duration before the factory was created
+func (f *Factory) past(within time.Duration) time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now.Add(-time.Duration(f.rand.Int63n(int64(within)))).Truncate(time.Second)
+}
+
+// sentences returns n random sample sentences joined into a paragraph
+func (f *Factory) sentences(lang string, n int) string {
+	out := make([]string, n)
+	for i := range out {
+		out[i] = f.pick(samples[lang].sentences)
+	}
+	return strings.Join(out, " ")
+}
+
+// paragraphs returns n random sample paragraphs separated by blank lines
+func (f *Factory) paragraphs(lang string, n int) string {
+	out := make([]string, n)
+	for i := range out {
+		out[i] = f.sentences(lang, 3+f.intn(3))
+	}
+	return strings.Join(out, "\n\n")
+}
+
+// slug returns a unique slug for the title, transliterating Cyrillic letters
+func (f *Factory) slug(title string) string {
+	var b strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(title) {
+		latin, cyrillic := translit[r]
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+			hyphen = false
+		case cyrillic:
+			b.WriteString(latin)
+			hyphen = hyphen && latin == ""
+		default:
+			if !hyphen && b.Len() > 0 {
+				b.WriteByte('-')
+				hyphen = true
+			}
+		}
+	}
+
+	slug := strings.TrimSuffix(b.String(), "-")
+	if slug == "" {
+		return f.unique()
+	}
+	return slug + "-" + f.unique()
+}
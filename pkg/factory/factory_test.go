@@ -0,0 +1,77 @@
+package factory
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vovanwin/api-my-site/ent"
+)
+
+func TestFactory_Deterministic(t *testing.T) {
+	// Nothing is saved, so the client doesn't need a driver
+	orm := ent.NewClient()
+
+	build := func(seed int64) []string {
+		f := New(orm, seed)
+		u := f.User().create.Mutation()
+		p := f.Post().create.Mutation()
+		name, _ := u.Name()
+		email, _ := u.Email()
+		title, _ := p.Title()
+		slug, _ := p.Slug()
+		body, _ := p.Body()
+		return []string{name, email, title, slug, body}
+	}
+
+	assert.Equal(t, build(42), build(42))
+	assert.NotEqual(t, build(42), build(43))
+}
+
+func TestFactory_Overrides(t *testing.T) {
+	f := New(ent.NewClient(), 1)
+
+	m := f.User().
+		Name("Test").
+		Email("test@localhost").
+		Verified().
+		WithRole("admin").
+		Mutate(func(c *ent.UserCreate) {
+			c.SetTimezone("Europe/Moscow")
+		}).
+		create.Mutation()
+
+	name, _ := m.Name()
+	email, _ := m.Email()
+	verified, _ := m.Verified()
+	role, _ := m.Role()
+	timezone, _ := m.Timezone()
+	assert.Equal(t, "Test", name)
+	assert.Equal(t, "test@localhost", email)
+	assert.True(t, verified)
+	assert.Equal(t, "admin", string(role))
+	assert.Equal(t, "Europe/Moscow", timezone)
+}
+
+func TestFactory_Slug(t *testing.T) {
+	f := New(ent.NewClient(), 1)
+	valid := regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+	tests := map[string]string{
+		"Как мы перевели сервис на Go": "kak-my-pereveli-servis-na-go-",
+		"Объект — «съезд»":             "obekt-sezd-",
+		"Testing HTTP handlers":        "testing-http-handlers-",
+		"Щука, ёж и я":                 "shchuka-ezh-i-ya-",
+	}
+	for title, prefix := range tests {
+		slug := f.slug(title)
+		assert.True(t, strings.HasPrefix(slug, prefix), slug)
+		assert.Regexp(t, valid, slug)
+	}
+
+	require.NotEqual(t, f.slug("Go"), f.slug("Go"))
+	assert.Regexp(t, valid, f.slug("—"))
+}
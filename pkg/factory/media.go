@@ -0,0 +1,181 @@
+package factory
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/vovanwin/api-my-site/ent"
+)
+
+// MediaBuilder builds a media entity
+// The media is a public, processed JPEG image owned by a new user, unless they're set
+// Only the entity is created, nothing is written to the storage
+type MediaBuilder struct {
+	f           *Factory
+	create      *ent.MediaCreate
+	name        string
+	key         string
+	owner       *ent.User
+	unprocessed bool
+}
+
+// Media starts building a media entity
+func (f *Factory) Media() *MediaBuilder {
+	width := 640 + 160*f.intn(10)
+
+	return &MediaBuilder{
+		f:    f,
+		name: f.hex(32),
+		create: f.orm.Media.
+			Create().
+			SetFilename(fmt.Sprintf("IMG_%04d.jpg", f.intn(10000))).
+			SetMimeType("image/jpeg").
+			SetSize(int64(50_000 + f.intn(2_000_000))).
+			SetChecksum(f.hex(64)).
+			SetWidth(width).
+			SetHeight(width * 3 / 4).
+			SetCreatedAt(f.past(90 * 24 * time.Hour)),
+	}
+}
+
+// Key sets the storage key
+func (b *MediaBuilder) Key(key string) *MediaBuilder {
+	b.key = key
+	return b
+}
+
+// Filename sets the original filename
+func (b *MediaBuilder) Filename(filename string) *MediaBuilder {
+	b.create.SetFilename(filename)
+	return b
+}
+
+// MimeType sets the MIME type
+func (b *MediaBuilder) MimeType(mimeType string) *MediaBuilder {
+	b.create.SetMimeType(mimeType)
+	return b
+}
+
+// Size sets the size in bytes
+func (b *MediaBuilder) Size(size int64) *MediaBuilder {
+	b.create.SetSize(size)
+	return b
+}
+
+// Owner sets the owner
+func (b *MediaBuilder) Owner(u *ent.User) *MediaBuilder {
+	b.owner = u
+	return b
+}
+
+// Private makes the media private
+func (b *MediaBuilder) Private() *MediaBuilder {
+	b.create.SetPrivate(true)
+	return b
+}
+
+// Unprocessed marks the media as waiting for its variants
+func (b *MediaBuilder) Unprocessed() *MediaBuilder {
+	b.unprocessed = true
+	return b
+}
+
+// Mutate applies overrides which have no dedicated method
+func (b *MediaBuilder) Mutate(fn func(*ent.MediaCreate)) *MediaBuilder {
+	fn(b.create)
+	return b
+}
+
+// Create creates the media entity
+// Unless it's set, the key follows the layout of uploaded media, which is prefixed with the owner ID
+func (b *MediaBuilder) Create(ctx context.Context) (*ent.Media, error) {
+	if b.owner == nil {
+		u, err := b.f.User().Create(ctx)
+		if err != nil {
+			return nil, err
+		}
+		b.owner = u
+	}
+
+	if b.key == "" {
+		b.key = fmt.Sprintf("%d/%s.jpg", b.owner.ID, b.name)
+	}
+	if !b.unprocessed {
+		b.create.SetProcessedAt(b.f.now)
+	}
+
+	return b.create.
+		SetKey(b.key).
+		SetOwner(b.owner).
+		Save(ctx)
+}
+
+// MediaVariantBuilder builds a media variant
+// The variant is a thumbnail of new media unless they're set; a media entity has one variant per name
+type MediaVariantBuilder struct {
+	f      *Factory
+	create *ent.MediaVariantCreate
+	name   string
+	media  *ent.Media
+}
+
+// MediaVariant starts building a media variant
+func (f *Factory) MediaVariant() *MediaVariantBuilder {
+	return &MediaVariantBuilder{
+		f:    f,
+		name: "thumb",
+		create: f.orm.MediaVariant.
+			Create().
+			SetMimeType("image/jpeg").
+			SetSize(int64(5_000 + f.intn(50_000))).
+			SetWidth(320).
+			SetHeight(320),
+	}
+}
+
+// Name sets the name
+func (b *MediaVariantBuilder) Name(name string) *MediaVariantBuilder {
+	b.name = name
+	return b
+}
+
+// Size sets the dimensions
+func (b *MediaVariantBuilder) Size(width, height int) *MediaVariantBuilder {
+	b.create.
+		SetWidth(width).
+		SetHeight(height)
+	return b
+}
+
+// Media sets the media the variant belongs to
+func (b *MediaVariantBuilder) Media(m *ent.Media) *MediaVariantBuilder {
+	b.media = m
+	return b
+}
+
+// Mutate applies overrides which have no dedicated method
+func (b *MediaVariantBuilder) Mutate(fn func(*ent.MediaVariantCreate)) *MediaVariantBuilder {
+	fn(b.create)
+	return b
+}
+
+// Create creates the media variant, keyed after the media like the generated variants
+func (b *MediaVariantBuilder) Create(ctx context.Context) (*ent.MediaVariant, error) {
+	if b.media == nil {
+		m, err := b.f.Media().Create(ctx)
+		if err != nil {
+			return nil, err
+		}
+		b.media = m
+	}
+
+	base := strings.TrimSuffix(b.media.Key, path.Ext(b.media.Key))
+	return b.create.
+		SetName(b.name).
+		SetKey(base + "_" + b.name + ".jpg").
+		SetMedia(b.media).
+		Save(ctx)
+}
@@ -0,0 +1,189 @@
+package factory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vovanwin/api-my-site/ent"
+)
+
+// ContactMessageBuilder builds a contact form message
+// The message is neither forwarded nor handled unless they're set
+type ContactMessageBuilder struct {
+	f      *Factory
+	create *ent.ContactMessageCreate
+}
+
+// ContactMessage starts building a contact form message
+func (f *Factory) ContactMessage() *ContactMessageBuilder {
+	lang := f.lang()
+	first, last := f.pick(samples[lang].firstNames), f.pick(samples[lang].lastNames)
+
+	return &ContactMessageBuilder{
+		f: f,
+		create: f.orm.ContactMessage.
+			Create().
+			SetName(first + " " + last).
+			SetEmail(fmt.Sprintf("%s@example.org", f.slug(first+" "+last))).
+			SetSubject(f.pick(samples[lang].subjects)).
+			SetMessage(f.sentences(lang, 2+f.intn(4))).
+			SetIP(fmt.Sprintf("192.0.2.%d", 1+f.intn(254))).
+			SetUserAgent("Mozilla/5.0").
+			SetCreatedAt(f.past(30 * 24 * time.Hour)),
+	}
+}
+
+// Email sets the email address of the sender
+func (b *ContactMessageBuilder) Email(email string) *ContactMessageBuilder {
+	b.create.SetEmail(email)
+	return b
+}
+
+// Message sets the message
+func (b *ContactMessageBuilder) Message(message string) *ContactMessageBuilder {
+	b.create.SetMessage(message)
+	return b
+}
+
+// Forwarded marks the message as forwarded
+func (b *ContactMessageBuilder) Forwarded() *ContactMessageBuilder {
+	b.create.SetForwardedAt(b.f.now)
+	return b
+}
+
+// Handled marks the message as handled
+func (b *ContactMessageBuilder) Handled() *ContactMessageBuilder {
+	b.create.SetHandledAt(b.f.now)
+	return b
+}
+
+// Mutate applies overrides which have no dedicated method
+func (b *ContactMessageBuilder) Mutate(fn func(*ent.ContactMessageCreate)) *ContactMessageBuilder {
+	fn(b.create)
+	return b
+}
+
+// Create creates the message
+func (b *ContactMessageBuilder) Create(ctx context.Context) (*ent.ContactMessage, error) {
+	return b.create.Save(ctx)
+}
+
+// AuditEventBuilder builds an audit event
+// The event is a user update without an actor unless they're set
+type AuditEventBuilder struct {
+	create *ent.AuditEventCreate
+}
+
+// AuditEvent starts building an audit event
+func (f *Factory) AuditEvent() *AuditEventBuilder {
+	return &AuditEventBuilder{
+		create: f.orm.AuditEvent.
+			Create().
+			SetAction("user.update").
+			SetTargetType("user").
+			SetIP(fmt.Sprintf("192.0.2.%d", 1+f.intn(254))).
+			SetUserAgent("Mozilla/5.0").
+			SetRequestID(f.hex(32)).
+			SetCreatedAt(f.past(30 * 24 * time.Hour)),
+	}
+}
+
+// Action sets the action
+func (b *AuditEventBuilder) Action(action string) *AuditEventBuilder {
+	b.create.SetAction(action)
+	return b
+}
+
+// Actor sets the user who performed the action
+func (b *AuditEventBuilder) Actor(u *ent.User) *AuditEventBuilder {
+	b.create.SetActorID(u.ID)
+	return b
+}
+
+// Target sets the entity the action was performed on
+func (b *AuditEventBuilder) Target(targetType string, id int) *AuditEventBuilder {
+	b.create.
+		SetTargetType(targetType).
+		SetTargetID(id)
+	return b
+}
+
+// At sets the time of the event
+func (b *AuditEventBuilder) At(at time.Time) *AuditEventBuilder {
+	b.create.SetCreatedAt(at)
+	return b
+}
+
+// Mutate applies overrides which have no dedicated method
+func (b *AuditEventBuilder) Mutate(fn func(*ent.AuditEventCreate)) *AuditEventBuilder {
+	fn(b.create)
+	return b
+}
+
+// Create creates the audit event
+func (b *AuditEventBuilder) Create(ctx context.Context) (*ent.AuditEvent, error) {
+	return b.create.Save(ctx)
+}
+
+// FeatureFlagBuilder builds a feature flag
+// The flag is disabled, with a full rollout and no allowlists, unless they're set
+type FeatureFlagBuilder struct {
+	create *ent.FeatureFlagCreate
+}
+
+// FeatureFlag starts building a feature flag
+func (f *Factory) FeatureFlag() *FeatureFlagBuilder {
+	lang := f.lang()
+	name := f.pick(samples[lang].titles)
+
+	return &FeatureFlagBuilder{
+		create: f.orm.FeatureFlag.
+			Create().
+			SetKey(strings.SplitN(f.slug(name), "-", 2)[0] + "-" + f.unique()).
+			SetDescription(name),
+	}
+}
+
+// Key sets the key
+func (b *FeatureFlagBuilder) Key(key string) *FeatureFlagBuilder {
+	b.create.SetKey(key)
+	return b
+}
+
+// Enabled enables the flag
+func (b *FeatureFlagBuilder) Enabled() *FeatureFlagBuilder {
+	b.create.SetEnabled(true)
+	return b
+}
+
+// Rollout sets the percentage of the users the flag is enabled for
+func (b *FeatureFlagBuilder) Rollout(percent int) *FeatureFlagBuilder {
+	b.create.SetRollout(percent)
+	return b
+}
+
+// Users sets the IDs of the users the flag is always enabled for
+func (b *FeatureFlagBuilder) Users(ids ...int) *FeatureFlagBuilder {
+	b.create.SetUserIds(ids)
+	return b
+}
+
+// Roles sets the roles the flag is always enabled for
+func (b *FeatureFlagBuilder) Roles(roles ...string) *FeatureFlagBuilder {
+	b.create.SetRoles(roles)
+	return b
+}
+
+// Mutate applies overrides which have no dedicated method
+func (b *FeatureFlagBuilder) Mutate(fn func(*ent.FeatureFlagCreate)) *FeatureFlagBuilder {
+	fn(b.create)
+	return b
+}
+
+// Create creates the feature flag
+// Flags are cached by the feature flag store, so flags created in tests should be read through a fresh store
+func (b *FeatureFlagBuilder) Create(ctx context.Context) (*ent.FeatureFlag, error) {
+	return b.create.Save(ctx)
+}
@@ -0,0 +1,105 @@
+package factory
+
+// sampleSet stores the sample content of a language
+type sampleSet struct {
+	firstNames []string
+	lastNames  []string
+	titles     []string
+	sentences  []string
+	categories []string
+	tags       []string
+	projects   []string
+	pages      []string
+	subjects   []string
+}
+
+// samples stores the sample content by language
+var samples = map[string]sampleSet{
+	langRU: {
+		firstNames: []string{"Алексей", "Мария", "Дмитрий", "Анна", "Иван", "Екатерина", "Сергей", "Ольга", "Никита", "Татьяна"},
+		lastNames:  []string{"Иванов", "Смирнова", "Кузнецов", "Попова", "Соколов", "Лебедева", "Новиков", "Морозова", "Волков", "Павлова"},
+		titles: []string{
+			"Как мы перевели сервис на Go",
+			"Заметки о миграциях базы данных",
+			"Пять ошибок при работе с очередями",
+			"Почему я выбрал Postgres",
+			"Кэширование без боли",
+			"Структурные логи на практике",
+			"Фоновые задачи и повторные попытки",
+			"Тестирование HTTP-обработчиков",
+			"Конфигурация для нескольких окружений",
+			"Что я узнал за год разработки",
+		},
+		sentences: []string{
+			"Начнём с простого примера и постепенно его усложним.",
+			"В этом подходе нет ничего нового, но он хорошо работает.",
+			"Главное — не пытаться решить все проблемы сразу.",
+			"Код из статьи доступен в репозитории проекта.",
+			"Такое решение проще поддерживать, чем кажется на первый взгляд.",
+			"На практике это заняло меньше недели.",
+			"Стоит заранее подумать о том, как вы будете это тестировать.",
+			"Метрики показали, что время ответа сократилось почти вдвое.",
+			"Ниже я разберу каждый шаг подробнее.",
+			"Если у вас есть вопросы, напишите мне через форму обратной связи.",
+			"Документация по этой теме, к сожалению, довольно скудная.",
+			"В итоге мы оставили самый скучный и надёжный вариант.",
+		},
+		categories: []string{"Разработка", "Инфраструктура", "Базы данных", "Заметки", "Карьера"},
+		tags:       []string{"go", "postgres", "redis", "docker", "тестирование", "архитектура", "производительность", "очереди"},
+		projects:   []string{"Личный сайт", "Трекер привычек", "Бот для заметок", "Каталог книг", "Планировщик задач"},
+		pages:      []string{"Обо мне", "Контакты", "Чем я пользуюсь", "Услуги"},
+		subjects:   []string{"Вопрос по проекту", "Предложение о сотрудничестве", "Ошибка на сайте", "Приглашение на конференцию"},
+	},
+	langEN: {
+		firstNames: []string{"Alice", "Bob", "Carol", "David", "Emma", "Frank", "Grace", "Henry", "Isla", "Jack"},
+		lastNames:  []string{"Smith", "Johnson", "Brown", "Taylor", "Wilson", "Davies", "Evans", "Thomas", "Walker", "Wright"},
+		titles: []string{
+			"Moving a service to Go",
+			"Notes on database migrations",
+			"Five mistakes with task queues",
+			"Why I picked Postgres",
+			"Caching without the pain",
+			"Structured logging in practice",
+			"Background jobs and retries",
+			"Testing HTTP handlers",
+			"Configuration for many environments",
+			"What a year of side projects taught me",
+		},
+		sentences: []string{
+			"Let's start with a simple example and build on it.",
+			"There's nothing new in this approach, but it works well.",
+			"The key is not to solve every problem at once.",
+			"The code from this post is available in the repository.",
+			"This is easier to maintain than it looks at first.",
+			"In practice it took less than a week.",
+			"Think early about how you are going to test it.",
+			"The metrics showed the response time was almost halved.",
+			"Below I go through each step in more detail.",
+			"If you have questions, reach out through the contact form.",
+			"The documentation on this topic is sadly rather thin.",
+			"In the end we kept the most boring and reliable option.",
+		},
+		categories: []string{"Development", "Infrastructure", "Databases", "Notes", "Career"},
+		tags:       []string{"go", "postgres", "redis", "docker", "testing", "architecture", "performance", "queues"},
+		projects:   []string{"Personal site", "Habit tracker", "Note taking bot", "Book catalog", "Task planner"},
+		pages:      []string{"About", "Contact", "Uses", "Services"},
+		subjects:   []string{"Question about a project", "Collaboration proposal", "Bug on the site", "Conference invitation"},
+	},
+}
+
+// stacks stores sample project technology stacks
+var stacks = [][]string{
+	{"go", "postgres", "redis"},
+	{"go", "echo", "ent"},
+	{"typescript", "react", "vite"},
+	{"python", "fastapi", "sqlite"},
+	{"go", "asynq", "docker"},
+}
+
+// translit maps lowercase Cyrillic letters to their Latin transliteration
+var translit = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh", 'з': "z", 'и': "i",
+	'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t",
+	'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "",
+	'э': "e", 'ю': "yu", 'я': "ya",
+}
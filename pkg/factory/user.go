@@ -0,0 +1,166 @@
+package factory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/vovanwin/api-my-site/ent"
+	"github.com/vovanwin/api-my-site/ent/user"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// UserBuilder builds a user
+// The user is unverified, has the user role and the password DefaultPassword unless they're overridden
+type UserBuilder struct {
+	f        *Factory
+	create   *ent.UserCreate
+	password string
+}
+
+// User starts building a user
+func (f *Factory) User() *UserBuilder {
+	lang := f.lang()
+	first, last := f.pick(samples[lang].firstNames), f.pick(samples[lang].lastNames)
+
+	return &UserBuilder{
+		f:        f,
+		password: DefaultPassword,
+		create: f.orm.User.
+			Create().
+			SetName(first + " " + last).
+			SetEmail(fmt.Sprintf("%s@example.com", f.slug(first+" "+last))).
+			SetLocale(lang).
+			SetCreatedAt(f.past(365 * 24 * time.Hour)),
+	}
+}
+
+// Name sets the name
+func (b *UserBuilder) Name(name string) *UserBuilder {
+	b.create.SetName(name)
+	return b
+}
+
+// Email sets the email address
+func (b *UserBuilder) Email(email string) *UserBuilder {
+	b.create.SetEmail(email)
+	return b
+}
+
+// Password sets the password, which is hashed when the user is created
+func (b *UserBuilder) Password(password string) *UserBuilder {
+	b.password = password
+	return b
+}
+
+// Verified marks the email address as verified
+func (b *UserBuilder) Verified() *UserBuilder {
+	b.create.SetVerified(true)
+	return b
+}
+
+// WithRole sets the role
+func (b *UserBuilder) WithRole(role string) *UserBuilder {
+	b.create.SetRole(user.Role(role))
+	return b
+}
+
+// Admin gives the user the admin role
+func (b *UserBuilder) Admin() *UserBuilder {
+	return b.WithRole(string(user.RoleAdmin))
+}
+
+// Locale sets the locale
+func (b *UserBuilder) Locale(locale string) *UserBuilder {
+	b.create.SetLocale(locale)
+	return b
+}
+
+// Blocked blocks the user
+func (b *UserBuilder) Blocked() *UserBuilder {
+	b.create.SetBlockedAt(b.f.now)
+	return b
+}
+
+// Mutate applies overrides which have no dedicated method
+func (b *UserBuilder) Mutate(fn func(*ent.UserCreate)) *UserBuilder {
+	fn(b.create)
+	return b
+}
+
+// Create creates the user
+// The password is hashed at the minimum cost to keep tests fast, which the password checks accept all the same
+func (b *UserBuilder) Create(ctx context.Context) (*ent.User, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(b.password), bcrypt.MinCost)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.create.
+		SetPassword(string(hash)).
+		Save(ctx)
+}
+
+// PasswordTokenBuilder builds a password reset token
+// The token belongs to a new user unless another one is set
+type PasswordTokenBuilder struct {
+	f      *Factory
+	create *ent.PasswordTokenCreate
+	token  string
+	user   *ent.User
+}
+
+// PasswordToken starts building a password reset token
+func (f *Factory) PasswordToken() *PasswordTokenBuilder {
+	return &PasswordTokenBuilder{
+		f:      f,
+		token:  f.hex(64),
+		create: f.orm.PasswordToken.Create(),
+	}
+}
+
+// Token sets the token, which is hashed when the token is created
+func (b *PasswordTokenBuilder) Token(token string) *PasswordTokenBuilder {
+	b.token = token
+	return b
+}
+
+// User sets the user the token belongs to
+func (b *PasswordTokenBuilder) User(u *ent.User) *PasswordTokenBuilder {
+	b.user = u
+	return b
+}
+
+// Expired backdates the token by the duration
+func (b *PasswordTokenBuilder) Expired(by time.Duration) *PasswordTokenBuilder {
+	b.create.SetCreatedAt(b.f.now.Add(-by))
+	return b
+}
+
+// Mutate applies overrides which have no dedicated method
+func (b *PasswordTokenBuilder) Mutate(fn func(*ent.PasswordTokenCreate)) *PasswordTokenBuilder {
+	fn(b.create)
+	return b
+}
+
+// Create creates the token and returns it along with the token itself, since only its hash is stored
+func (b *PasswordTokenBuilder) Create(ctx context.Context) (*ent.PasswordToken, string, error) {
+	if b.user == nil {
+		u, err := b.f.User().Create(ctx)
+		if err != nil {
+			return nil, "", err
+		}
+		b.user = u
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(b.token), bcrypt.MinCost)
+	if err != nil {
+		return nil, "", err
+	}
+
+	pt, err := b.create.
+		SetHash(string(hash)).
+		SetUser(b.user).
+		Save(ctx)
+	return pt, b.token, err
+}
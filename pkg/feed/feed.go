@@ -0,0 +1,299 @@
+package feed
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"time"
+)
+
+const (
+	// ContentTypeRSS stores the content type of RSS feeds
+	ContentTypeRSS = "application/rss+xml; charset=utf-8"
+
+	// ContentTypeAtom stores the content type of Atom feeds
+	ContentTypeAtom = "application/atom+xml; charset=utf-8"
+
+	// ContentTypeJSON stores the content type of JSON feeds
+	ContentTypeJSON = "application/feed+json; charset=utf-8"
+)
+
+type (
+	// Feed is a format-independent syndication feed
+	Feed struct {
+		// Title stores the title of the feed
+		Title string
+
+		// Description stores the description of the feed
+		Description string
+
+		// Language stores the language code of the feed
+		Language string
+
+		// Link stores the URL of the page the feed is about
+		Link string
+
+		// FeedURL stores the URL the feed itself is served from
+		FeedURL string
+
+		// Updated stores when the feed content last changed
+		Updated time.Time
+
+		// Items stores the items of the feed, newest first
+		Items []Item
+	}
+
+	// Item is an entry of a syndication feed
+	Item struct {
+		// ID stores a permanent unique identifier of the item
+		ID string
+
+		// Title stores the title of the item
+		Title string
+
+		// Link stores the URL of the item
+		Link string
+
+		// Summary stores a plain text summary of the item
+		Summary string
+
+		// Content stores the HTML content of the item
+		Content string
+
+		// Author stores the name of the author
+		Author string
+
+		// Tags stores the names of the categories and tags of the item
+		Tags []string
+
+		// Published stores when the item was published
+		Published time.Time
+
+		// Updated stores when the item last changed
+		Updated time.Time
+	}
+)
+
+// RSS renders the feed as RSS 2.0
+func (f Feed) RSS() ([]byte, error) {
+	type (
+		rssGUID struct {
+			IsPermaLink bool   `xml:"isPermaLink,attr"`
+			Value       string `xml:",chardata"`
+		}
+		rssItem struct {
+			Title       string   `xml:"title"`
+			Link        string   `xml:"link"`
+			GUID        rssGUID  `xml:"guid"`
+			PubDate     string   `xml:"pubDate"`
+			Description string   `xml:"description,omitempty"`
+			Categories  []string `xml:"category"`
+		}
+		atomLink struct {
+			Href string `xml:"href,attr"`
+			Rel  string `xml:"rel,attr"`
+			Type string `xml:"type,attr"`
+		}
+		rssChannel struct {
+			Title         string    `xml:"title"`
+			Link          string    `xml:"link"`
+			Description   string    `xml:"description"`
+			Language      string    `xml:"language,omitempty"`
+			LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+			Self          atomLink  `xml:"atom:link"`
+			Items         []rssItem `xml:"item"`
+		}
+		rss struct {
+			XMLName xml.Name   `xml:"rss"`
+			Version string     `xml:"version,attr"`
+			Atom    string     `xml:"xmlns:atom,attr"`
+			Channel rssChannel `xml:"channel"`
+		}
+	)
+
+	doc := rss{
+		Version: "2.0",
+		Atom:    "http://www.w3.org/2005/Atom",
+		Channel: rssChannel{
+			Title:       f.Title,
+			Link:        f.Link,
+			Description: f.Description,
+			Language:    f.Language,
+			Self:        atomLink{Href: f.FeedURL, Rel: "self", Type: "application/rss+xml"},
+			Items:       make([]rssItem, 0, len(f.Items)),
+		},
+	}
+
+	if !f.Updated.IsZero() {
+		doc.Channel.LastBuildDate = f.Updated.UTC().Format(time.RFC1123Z)
+	}
+
+	for _, item := range f.Items {
+		description := item.Summary
+		if description == "" {
+			description = item.Content
+		}
+		doc.Channel.Items = append(doc.Channel.Items, rssItem{
+			Title:       item.Title,
+			Link:        item.Link,
+			GUID:        rssGUID{IsPermaLink: item.ID == item.Link, Value: item.ID},
+			PubDate:     item.Published.UTC().Format(time.RFC1123Z),
+			Description: description,
+			Categories:  item.Tags,
+		})
+	}
+
+	return marshalXML(doc)
+}
+
+// Atom renders the feed as Atom 1.0
+func (f Feed) Atom() ([]byte, error) {
+	type (
+		atomLink struct {
+			Href string `xml:"href,attr"`
+			Rel  string `xml:"rel,attr,omitempty"`
+			Type string `xml:"type,attr,omitempty"`
+		}
+		atomText struct {
+			Type  string `xml:"type,attr,omitempty"`
+			Value string `xml:",chardata"`
+		}
+		atomPerson struct {
+			Name string `xml:"name"`
+		}
+		atomCategory struct {
+			Term string `xml:"term,attr"`
+		}
+		atomEntry struct {
+			Title      string         `xml:"title"`
+			ID         string         `xml:"id"`
+			Link       atomLink       `xml:"link"`
+			Published  string         `xml:"published"`
+			Updated    string         `xml:"updated"`
+			Author     *atomPerson    `xml:"author,omitempty"`
+			Summary    *atomText      `xml:"summary,omitempty"`
+			Content    *atomText      `xml:"content,omitempty"`
+			Categories []atomCategory `xml:"category"`
+		}
+		atom struct {
+			XMLName  xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+			Lang     string      `xml:"xml:lang,attr,omitempty"`
+			Title    string      `xml:"title"`
+			Subtitle string      `xml:"subtitle,omitempty"`
+			ID       string      `xml:"id"`
+			Updated  string      `xml:"updated"`
+			Links    []atomLink  `xml:"link"`
+			Entries  []atomEntry `xml:"entry"`
+		}
+	)
+
+	// Atom requires the update time, so an empty feed is considered to never have been updated
+	updated := f.Updated
+	if updated.IsZero() {
+		updated = time.Unix(0, 0)
+	}
+
+	doc := atom{
+		Lang:     f.Language,
+		Title:    f.Title,
+		Subtitle: f.Description,
+		ID:       f.FeedURL,
+		Updated:  updated.UTC().Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: f.FeedURL, Rel: "self", Type: "application/atom+xml"},
+			{Href: f.Link, Rel: "alternate", Type: "text/html"},
+		},
+		Entries: make([]atomEntry, 0, len(f.Items)),
+	}
+
+	for _, item := range f.Items {
+		entry := atomEntry{
+			Title:     item.Title,
+			ID:        item.ID,
+			Link:      atomLink{Href: item.Link, Rel: "alternate"},
+			Published: item.Published.UTC().Format(time.RFC3339),
+			Updated:   item.Updated.UTC().Format(time.RFC3339),
+		}
+		if item.Author != "" {
+			entry.Author = &atomPerson{Name: item.Author}
+		}
+		if item.Summary != "" {
+			entry.Summary = &atomText{Type: "text", Value: item.Summary}
+		}
+		if item.Content != "" {
+			entry.Content = &atomText{Type: "html", Value: item.Content}
+		}
+		for _, tag := range item.Tags {
+			entry.Categories = append(entry.Categories, atomCategory{Term: tag})
+		}
+		doc.Entries = append(doc.Entries, entry)
+	}
+
+	return marshalXML(doc)
+}
+
+// JSON renders the feed as JSON Feed 1.1
+func (f Feed) JSON() ([]byte, error) {
+	type (
+		jsonAuthor struct {
+			Name string `json:"name"`
+		}
+		jsonItem struct {
+			ID            string       `json:"id"`
+			URL           string       `json:"url"`
+			Title         string       `json:"title"`
+			ContentHTML   string       `json:"content_html"`
+			Summary       string       `json:"summary,omitempty"`
+			DatePublished string       `json:"date_published"`
+			DateModified  string       `json:"date_modified"`
+			Authors       []jsonAuthor `json:"authors,omitempty"`
+			Tags          []string     `json:"tags,omitempty"`
+		}
+		jsonFeed struct {
+			Version     string     `json:"version"`
+			Title       string     `json:"title"`
+			HomePageURL string     `json:"home_page_url"`
+			FeedURL     string     `json:"feed_url"`
+			Description string     `json:"description,omitempty"`
+			Language    string     `json:"language,omitempty"`
+			Items       []jsonItem `json:"items"`
+		}
+	)
+
+	doc := jsonFeed{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       f.Title,
+		HomePageURL: f.Link,
+		FeedURL:     f.FeedURL,
+		Description: f.Description,
+		Language:    f.Language,
+		Items:       make([]jsonItem, 0, len(f.Items)),
+	}
+
+	for _, item := range f.Items {
+		ji := jsonItem{
+			ID:            item.ID,
+			URL:           item.Link,
+			Title:         item.Title,
+			ContentHTML:   item.Content,
+			Summary:       item.Summary,
+			DatePublished: item.Published.UTC().Format(time.RFC3339),
+			DateModified:  item.Updated.UTC().Format(time.RFC3339),
+			Tags:          item.Tags,
+		}
+		if item.Author != "" {
+			ji.Authors = []jsonAuthor{{Name: item.Author}}
+		}
+		doc.Items = append(doc.Items, ji)
+	}
+
+	return json.Marshal(doc)
+}
+
+// marshalXML marshals a document including the XML declaration
+func marshalXML(v interface{}) ([]byte, error) {
+	b, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), b...), nil
+}
@@ -0,0 +1,124 @@
+package feed
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testFeed() Feed {
+	published := time.Date(2023, 4, 1, 10, 0, 0, 0, time.UTC)
+	return Feed{
+		Title:       "Site",
+		Description: "Description",
+		Language:    "ru",
+		Link:        "http://localhost/",
+		FeedURL:     "http://localhost/feed.xml",
+		Updated:     published.Add(time.Hour),
+		Items: []Item{
+			{
+				ID:        "http://localhost/posts/a",
+				Title:     "A & B",
+				Link:      "http://localhost/posts/a",
+				Summary:   "Summary",
+				Content:   "<p>Content</p>",
+				Author:    "Author",
+				Tags:      []string{"go", "web"},
+				Published: published,
+				Updated:   published.Add(time.Hour),
+			},
+		},
+	}
+}
+
+func TestFeed_RSS(t *testing.T) {
+	b, err := testFeed().RSS()
+	require.NoError(t, err)
+
+	var doc struct {
+		Version string `xml:"version,attr"`
+		Channel struct {
+			Title string `xml:"title"`
+			Items []struct {
+				Title      string   `xml:"title"`
+				PubDate    string   `xml:"pubDate"`
+				Categories []string `xml:"category"`
+			} `xml:"item"`
+		} `xml:"channel"`
+	}
+	require.NoError(t, xml.Unmarshal(b, &doc))
+	assert.Equal(t, "2.0", doc.Version)
+	assert.Equal(t, "Site", doc.Channel.Title)
+	require.Len(t, doc.Channel.Items, 1)
+	assert.Equal(t, "A & B", doc.Channel.Items[0].Title)
+	assert.Equal(t, "Sat, 01 Apr 2023 10:00:00 +0000", doc.Channel.Items[0].PubDate)
+	assert.Equal(t, []string{"go", "web"}, doc.Channel.Items[0].Categories)
+	assert.Contains(t, string(b), `<atom:link href="http://localhost/feed.xml" rel="self"`)
+}
+
+func TestFeed_Atom(t *testing.T) {
+	b, err := testFeed().Atom()
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(b), xml.Header))
+
+	var doc struct {
+		XMLName xml.Name `xml:"http://www.w3.org/2005/Atom feed"`
+		Updated string   `xml:"updated"`
+		Entries []struct {
+			ID      string `xml:"id"`
+			Content struct {
+				Type  string `xml:"type,attr"`
+				Value string `xml:",chardata"`
+			} `xml:"content"`
+		} `xml:"entry"`
+	}
+	require.NoError(t, xml.Unmarshal(b, &doc))
+	assert.Equal(t, "2023-04-01T11:00:00Z", doc.Updated)
+	require.Len(t, doc.Entries, 1)
+	assert.Equal(t, "http://localhost/posts/a", doc.Entries[0].ID)
+	assert.Equal(t, "html", doc.Entries[0].Content.Type)
+	assert.Equal(t, "<p>Content</p>", doc.Entries[0].Content.Value)
+}
+
+func TestFeed_JSON(t *testing.T) {
+	b, err := testFeed().JSON()
+	require.NoError(t, err)
+
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal(b, &doc))
+	assert.Equal(t, "https://jsonfeed.org/version/1.1", doc["version"])
+	assert.Equal(t, "http://localhost/feed.xml", doc["feed_url"])
+	items := doc["items"].([]interface{})
+	require.Len(t, items, 1)
+	item := items[0].(map[string]interface{})
+	assert.Equal(t, "<p>Content</p>", item["content_html"])
+	assert.Equal(t, "2023-04-01T10:00:00Z", item["date_published"])
+}
+
+func TestSitemap(t *testing.T) {
+	b, err := Sitemap([]SitemapURL{
+		{Loc: "http://localhost/"},
+		{Loc: "http://localhost/posts/a", LastMod: time.Date(2023, 4, 1, 10, 0, 0, 0, time.UTC)},
+	})
+	require.NoError(t, err)
+	s := string(b)
+	assert.Contains(t, s, `<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`)
+	assert.Contains(t, s, "<url>\n    <loc>http://localhost/</loc>\n  </url>")
+	assert.Contains(t, s, "<lastmod>2023-04-01T10:00:00Z</lastmod>")
+
+	b, err = SitemapIndex([]SitemapURL{{Loc: "http://localhost/sitemap/1.xml"}})
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`)
+}
+
+func TestSitemapPages(t *testing.T) {
+	assert.Equal(t, 1, SitemapPages(0))
+	assert.Equal(t, 1, SitemapPages(SitemapMaxURLs))
+	assert.Equal(t, 2, SitemapPages(SitemapMaxURLs+1))
+	assert.Equal(t, 3, SitemapPages(SitemapMaxURLs*2+1))
+}
@@ -0,0 +1,93 @@
+package feed
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+const (
+	// ContentTypeSitemap stores the content type of sitemaps
+	ContentTypeSitemap = "application/xml; charset=utf-8"
+
+	// SitemapMaxURLs stores the maximum amount of URLs a single sitemap may contain
+	SitemapMaxURLs = 50000
+
+	// sitemapNamespace stores the XML namespace of sitemaps and sitemap indexes
+	sitemapNamespace = "http://www.sitemaps.org/schemas/sitemap/0.9"
+)
+
+// SitemapURL is a URL listed in a sitemap
+type SitemapURL struct {
+	// Loc stores the absolute URL
+	Loc string
+
+	// LastMod stores when the page last changed, if known
+	LastMod time.Time
+}
+
+// SitemapPages returns the amount of sitemaps needed to list a given amount of URLs
+// A single sitemap is used up to SitemapMaxURLs, after which a sitemap index should be served instead
+func SitemapPages(urls int) int {
+	if urls <= SitemapMaxURLs {
+		return 1
+	}
+	return (urls + SitemapMaxURLs - 1) / SitemapMaxURLs
+}
+
+// Sitemap renders a sitemap listing the given URLs
+func Sitemap(urls []SitemapURL) ([]byte, error) {
+	type (
+		url struct {
+			Loc     string `xml:"loc"`
+			LastMod string `xml:"lastmod,omitempty"`
+		}
+		urlset struct {
+			XMLName xml.Name `xml:"urlset"`
+			XMLNS   string   `xml:"xmlns,attr"`
+			URLs    []url    `xml:"url"`
+		}
+	)
+
+	doc := urlset{
+		XMLNS: sitemapNamespace,
+		URLs:  make([]url, 0, len(urls)),
+	}
+	for _, u := range urls {
+		doc.URLs = append(doc.URLs, url{Loc: u.Loc, LastMod: formatLastMod(u.LastMod)})
+	}
+
+	return marshalXML(doc)
+}
+
+// SitemapIndex renders a sitemap index listing the given sitemaps
+func SitemapIndex(sitemaps []SitemapURL) ([]byte, error) {
+	type (
+		sitemap struct {
+			Loc     string `xml:"loc"`
+			LastMod string `xml:"lastmod,omitempty"`
+		}
+		index struct {
+			XMLName  xml.Name  `xml:"sitemapindex"`
+			XMLNS    string    `xml:"xmlns,attr"`
+			Sitemaps []sitemap `xml:"sitemap"`
+		}
+	)
+
+	doc := index{
+		XMLNS:    sitemapNamespace,
+		Sitemaps: make([]sitemap, 0, len(sitemaps)),
+	}
+	for _, s := range sitemaps {
+		doc.Sitemaps = append(doc.Sitemaps, sitemap{Loc: s.Loc, LastMod: formatLastMod(s.LastMod)})
+	}
+
+	return marshalXML(doc)
+}
+
+// formatLastMod formats a modification time in the W3C datetime format, or returns an empty string if unknown
+func formatLastMod(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
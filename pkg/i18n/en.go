@@ -0,0 +1,131 @@
+package i18n
+
+// en stores the English messages
+var en = map[string]string{
+	// Errors by problem code
+	"error.bad_request":             "The request is invalid.",
+	"error.unauthorized":            "Authentication is required.",
+	"error.forbidden":               "Access is denied.",
+	"error.not_found":               "The resource was not found.",
+	"error.method_not_allowed":      "The method is not allowed.",
+	"error.conflict":                "The request conflicts with the current state of the resource.",
+	"error.payload_too_large":       "The request body is too large.",
+	"error.unsupported_media_type":  "The content type is not supported.",
+	"error.validation_failed":       "Please check the submitted fields.",
+	"error.too_many_requests":       "Too many requests. Please try again later.",
+	"error.internal_error":          "Internal server error.",
+	"error.service_unavailable":     "The service is temporarily unavailable.",
+	"error.timeout":                 "The request timed out.",
+	"error.invalid_credentials":     "Invalid credentials.",
+	"error.invalid_token":           "The token is invalid or expired.",
+	"error.quota_exceeded":          "The quota has been exceeded.",
+	"error.unsupported_api_version": "The API version is not supported.",
+	"error.invalid_cursor":          "The page cursor is invalid. Request the list from the first page.",
+	"error.account_blocked":         "Your account has been blocked.",
+
+	// Requests
+	"request.malformed":           "The request body could not be parsed.",
+	"request.unknown_field":       "Unknown field {field}.",
+	"request.invalid_field":       "The {field} field has an invalid type.",
+	"request.unsupported_version": "API version {version} is not supported.",
+	"request.invalid_param":       "The {param} parameter is invalid.",
+
+	// Authentication
+	"auth.invalid_credentials": "Invalid credentials. Please try again.",
+	"auth.user_exists":         "A user with this email address already exists. Please log in.",
+	"auth.account_created":     "Your account has been created.",
+	"auth.reset_token_invalid": "The password reset link is invalid or has expired.",
+
+	// Profile
+	"me.invalid_password":      "The current password is incorrect.",
+	"me.avatar_not_found":      "The media file was not found.",
+	"me.avatar_not_image":      "The avatar must be an image.",
+	"me.email_change_sent":     "We have sent a confirmation link to the new email address.",
+	"me.email_change_invalid":  "The email change link is invalid or has expired.",
+	"me.email_change_subject":  "Confirm your new email address",
+	"me.email_change_body":     "Follow the link to confirm your new email address: {url}",
+	"me.email_changed_subject": "Your email address has been changed",
+	"me.email_changed_body":    "The email address of your account has been changed to {email}. If this wasn't you, please contact us.",
+
+	// Users
+	"users.self":                   "This action can't be applied to your own account.",
+	"users.impersonate_admin":      "Administrators can't be impersonated.",
+	"users.impersonate_blocked":    "Blocked users can't be impersonated.",
+	"users.unblock_deleting":       "The user is queued for deletion and can't be unblocked.",
+	"users.password_reset_sent":    "The password has been reset and the user has been sent a link to set a new one.",
+	"users.password_reset_subject": "Set a new password",
+	"users.password_reset_body":    "The password of your account has been reset by an administrator. Follow the link to set a new password: {url}",
+
+	// Media
+	"media.file_missing":     "No file was submitted or it exceeds the maximum size.",
+	"media.too_large":        "The file exceeds the maximum size.",
+	"media.too_many_pixels":  "The image exceeds the maximum resolution.",
+	"media.unsupported_type": "This file type is not supported.",
+	"media.quota_exceeded":   "The file storage quota has been exceeded.",
+
+	// Pages and projects
+	"page.slug_taken":         "A page with this address already exists.",
+	"page.parent_invalid":     "The parent page is invalid.",
+	"project.slug_taken":      "A project with this address already exists.",
+	"project.cover_private":   "The cover must be a public media file.",
+	"project.cover_not_found": "The media file was not found.",
+
+	// Feature flags
+	"flag.key_taken": "A flag with this key already exists.",
+
+	// Contact form
+	"contact.token_expired": "The form has expired. Please reload the page and try again.",
+	"contact.accepted":      "Thank you! Your message has been sent.",
+
+	// Validation, keyed by the validator tag and optionally the kind of the value
+	"validation.default":      "The value is invalid.",
+	"validation.required":     "The {field} field is required.",
+	"validation.email":        "Enter a valid email address.",
+	"validation.url":          "Enter a valid URL.",
+	"validation.eqfield":      "The value does not match the {param} field.",
+	"validation.oneof":        "Allowed values: {param}.",
+	"validation.max.string":   "The {field} field must be at most {param} characters long.",
+	"validation.max.number":   "The {field} field must be at most {param}.",
+	"validation.max.items":    "The {field} field must contain at most {param} items.",
+	"validation.min.string":   "The {field} field must be at least {param} characters long.",
+	"validation.min.number":   "The {field} field must be at least {param}.",
+	"validation.min.items":    "The {field} field must contain at least {param} items.",
+	"validation.password":     "The password must be at least 8 characters long, contain at least three of lowercase letters, uppercase letters, digits and symbols, and must not be a common password.",
+	"validation.slug":         "The {field} field may only contain lowercase latin letters and digits separated by hyphens.",
+	"validation.phone":        "Enter a phone number in the international format, such as +15551234567.",
+	"validation.unique_email": "A user with this email address already exists.",
+	"validation.locale":       "This language is not supported.",
+	"validation.timezone":     "Enter a time zone name, such as Europe/London.",
+
+	// Field names
+	"field.name":             "name",
+	"field.email":            "email",
+	"field.password":         "password",
+	"field.password_confirm": "password confirmation",
+	"field.title":            "title",
+	"field.slug":             "address",
+	"field.body":             "body",
+	"field.parent":           "parent page",
+	"field.menu_order":       "menu order",
+	"field.meta_title":       "SEO title",
+	"field.meta_description": "SEO description",
+	"field.description":      "description",
+	"field.stack":            "technologies",
+	"field.repository_url":   "repository",
+	"field.demo_url":         "demo",
+	"field.cover":            "cover",
+	"field.position":         "position",
+	"field.subject":          "subject",
+	"field.message":          "message",
+	"field.avatar":           "avatar",
+	"field.bio":              "bio",
+	"field.locale":           "language",
+	"field.timezone":         "time zone",
+	"field.current_password": "current password",
+	"field.token":            "token",
+	"field.user":             "user",
+	"field.key":              "key",
+	"field.rollout":          "rollout",
+	"field.user_ids":         "users",
+	"field.roles":            "roles",
+}
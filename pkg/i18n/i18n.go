@@ -0,0 +1,124 @@
+package i18n
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/text/language"
+
+	"github.com/vovanwin/api-my-site/pkg/context"
+)
+
+const (
+	// LocaleRU stores the Russian locale, which is the default
+	LocaleRU = "ru"
+
+	// LocaleEN stores the English locale
+	LocaleEN = "en"
+
+	// DefaultLocale stores the locale used when none of the requested locales are supported
+	DefaultLocale = LocaleRU
+)
+
+// Params stores the values substituted into the {placeholders} of a message
+type Params map[string]string
+
+// catalogs stores the messages of every supported locale keyed by the message key
+var catalogs = map[string]map[string]string{
+	LocaleRU: ru,
+	LocaleEN: en,
+}
+
+// matcher matches requested languages against the supported locales, the first being the default
+var matcher = language.NewMatcher([]language.Tag{
+	language.Russian,
+	language.English,
+})
+
+// Locales returns the supported locales
+func Locales() []string {
+	return []string{LocaleRU, LocaleEN}
+}
+
+// Negotiate returns the supported locale which best matches an Accept-Language header value
+func Negotiate(acceptLanguage string) string {
+	tag, _ := language.MatchStrings(matcher, acceptLanguage)
+	base, _ := tag.Base()
+	if _, ok := catalogs[base.String()]; ok {
+		return base.String()
+	}
+	return DefaultLocale
+}
+
+// Locale returns the locale of the request stored in the context, or the default locale if there is none
+func Locale(ctx echo.Context) string {
+	if locale, ok := ctx.Get(context.LocaleKey).(string); ok {
+		return locale
+	}
+	return DefaultLocale
+}
+
+// Lookup returns the message of a given key in a given locale, falling back to the default locale
+func Lookup(locale, key string, params ...Params) (string, bool) {
+	msg, ok := catalogs[locale][key]
+	if !ok {
+		msg, ok = catalogs[DefaultLocale][key]
+	}
+	if !ok {
+		return "", false
+	}
+
+	for _, p := range params {
+		for k, v := range p {
+			msg = strings.ReplaceAll(msg, "{"+k+"}", v)
+		}
+	}
+
+	return msg, true
+}
+
+// T returns the message of a given key in a given locale, or the key itself if the message doesn't exist
+func T(locale, key string, params ...Params) string {
+	if msg, ok := Lookup(locale, key, params...); ok {
+		return msg
+	}
+	return key
+}
+
+// Ctx returns the message of a given key in the locale of the request
+func Ctx(ctx echo.Context, key string, params ...Params) string {
+	return T(Locale(ctx), key, params...)
+}
+
+// Validation returns the message of a failed validation tag
+// Messages specific to the kind of the value, such as "validation.max.string", take precedence over
+// the message of the tag, and "validation.default" is used for tags without a message
+func Validation(locale, tag string, kind reflect.Kind, params Params) string {
+	keys := []string{"validation." + tag, "validation.default"}
+	if k := kindName(kind); k != "" {
+		keys = append([]string{"validation." + tag + "." + k}, keys...)
+	}
+
+	for _, key := range keys {
+		if msg, ok := Lookup(locale, key, params); ok {
+			return msg
+		}
+	}
+	return "validation.default"
+}
+
+// kindName returns the name of a kind of value used in validation message keys
+func kindName(kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return "string"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return "items"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	}
+	return ""
+}
@@ -0,0 +1,197 @@
+package i18n
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vovanwin/api-my-site/pkg/context"
+)
+
+var placeholder = regexp.MustCompile(`\{\w+\}`)
+
+func TestCatalogs_Complete(t *testing.T) {
+	keys := make(map[string]struct{})
+	for _, catalog := range catalogs {
+		for key := range catalog {
+			keys[key] = struct{}{}
+		}
+	}
+
+	for _, locale := range Locales() {
+		catalog, ok := catalogs[locale]
+		require.True(t, ok, "locale %s has no catalog", locale)
+
+		for key := range keys {
+			msg, ok := catalog[key]
+			if assert.True(t, ok, "key %s is missing in locale %s", key, locale) {
+				assert.NotEmpty(t, msg, "key %s is empty in locale %s", key, locale)
+			}
+		}
+	}
+}
+
+func TestCatalogs_Placeholders(t *testing.T) {
+	for key, msg := range catalogs[DefaultLocale] {
+		want := placeholders(msg)
+		for _, locale := range Locales() {
+			assert.Equal(t, want, placeholders(catalogs[locale][key]),
+				"placeholders of key %s differ in locale %s", key, locale)
+		}
+	}
+}
+
+// TestCatalogs_Used checks that every key referenced in the source code exists in every locale,
+// so a message added to one of the handlers can't be forgotten in the catalogs
+func TestCatalogs_Used(t *testing.T) {
+	keys := usedKeys(t, "../routes", "../middleware", "../controller", "../services")
+	require.NotEmpty(t, keys)
+
+	for _, key := range keys {
+		for _, locale := range Locales() {
+			_, ok := catalogs[locale][key]
+			assert.True(t, ok, "key %s is missing in locale %s", key, locale)
+		}
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	cases := map[string]string{
+		"":                               LocaleRU,
+		"ru":                             LocaleRU,
+		"ru-RU,ru;q=0.9":                 LocaleRU,
+		"en":                             LocaleEN,
+		"en-US,en;q=0.9,ru;q=0.8":        LocaleEN,
+		"de-DE,en;q=0.5":                 LocaleEN,
+		"fr":                             DefaultLocale,
+		"ru;q=0.3,en;q=0.7":              LocaleEN,
+		"not a language header at all;;": DefaultLocale,
+	}
+
+	for header, want := range cases {
+		assert.Equal(t, want, Negotiate(header), header)
+	}
+}
+
+func TestLocale(t *testing.T) {
+	ctx := echo.New().NewContext(nil, nil)
+	assert.Equal(t, DefaultLocale, Locale(ctx))
+
+	ctx.Set(context.LocaleKey, LocaleEN)
+	assert.Equal(t, LocaleEN, Locale(ctx))
+}
+
+func TestT(t *testing.T) {
+	assert.Equal(t, "Invalid credentials.", T(LocaleEN, "error.invalid_credentials"))
+	assert.Equal(t, catalogs[DefaultLocale]["error.not_found"], T("de", "error.not_found"))
+	assert.Equal(t, "missing.key", T(LocaleEN, "missing.key"))
+}
+
+func TestValidation(t *testing.T) {
+	params := Params{"field": "Name", "param": "10"}
+
+	assert.Equal(t,
+		T(LocaleEN, "validation.max.string", params),
+		Validation(LocaleEN, "max", reflect.String, params),
+	)
+	assert.Equal(t,
+		T(LocaleEN, "validation.max.number", params),
+		Validation(LocaleEN, "max", reflect.Int, params),
+	)
+	assert.Equal(t,
+		T(LocaleEN, "validation.required", params),
+		Validation(LocaleEN, "required", reflect.String, params),
+	)
+	assert.Equal(t,
+		T(LocaleEN, "validation.default", params),
+		Validation(LocaleEN, "unknown", reflect.String, params),
+	)
+	assert.Contains(t, Validation(LocaleEN, "required", reflect.String, params), "Name")
+}
+
+// placeholders returns the sorted placeholders of a message
+func placeholders(msg string) []string {
+	p := placeholder.FindAllString(msg, -1)
+	sort.Strings(p)
+	return p
+}
+
+// usedKeys returns the message keys passed as literals to T and Ctx, and the keys of label struct tags,
+// found in the Go files of the given directories
+func usedKeys(t *testing.T, dirs ...string) []string {
+	found := make(map[string]struct{})
+	fset := token.NewFileSet()
+
+	for _, dir := range dirs {
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+				return err
+			}
+
+			f, err := parser.ParseFile(fset, path, nil, 0)
+			if err != nil {
+				return err
+			}
+
+			ast.Inspect(f, func(n ast.Node) bool {
+				switch n := n.(type) {
+				case *ast.CallExpr:
+					sel, ok := n.Fun.(*ast.SelectorExpr)
+					if !ok || len(n.Args) < 2 {
+						return true
+					}
+					if pkg, ok := sel.X.(*ast.Ident); !ok || pkg.Name != "i18n" {
+						return true
+					}
+					if sel.Sel.Name != "T" && sel.Sel.Name != "Ctx" {
+						return true
+					}
+					if key, ok := stringLit(n.Args[1]); ok {
+						found[key] = struct{}{}
+					}
+				case *ast.Field:
+					if n.Tag == nil {
+						return true
+					}
+					if tag, err := strconv.Unquote(n.Tag.Value); err == nil {
+						if key := reflect.StructTag(tag).Get("label"); key != "" {
+							found[key] = struct{}{}
+						}
+					}
+				}
+				return true
+			})
+			return nil
+		})
+		require.NoError(t, err)
+	}
+
+	keys := make([]string, 0, len(found))
+	for key := range found {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// stringLit returns the value of a string literal expression
+func stringLit(expr ast.Expr) (string, bool) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	s, err := strconv.Unquote(lit.Value)
+	return s, err == nil
+}
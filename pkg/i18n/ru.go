@@ -0,0 +1,131 @@
+package i18n
+
+// ru stores the Russian messages
+var ru = map[string]string{
+	// Errors by problem code
+	"error.bad_request":             "Некорректный запрос.",
+	"error.unauthorized":            "Требуется аутентификация.",
+	"error.forbidden":               "Доступ запрещен.",
+	"error.not_found":               "Ресурс не найден.",
+	"error.method_not_allowed":      "Метод не поддерживается.",
+	"error.conflict":                "Конфликт с текущим состоянием ресурса.",
+	"error.payload_too_large":       "Тело запроса слишком велико.",
+	"error.unsupported_media_type":  "Тип содержимого не поддерживается.",
+	"error.validation_failed":       "Проверьте правильность заполнения полей.",
+	"error.too_many_requests":       "Слишком много запросов. Пожалуйста, попробуйте позже.",
+	"error.internal_error":          "Внутренняя ошибка сервера.",
+	"error.service_unavailable":     "Сервис временно недоступен.",
+	"error.timeout":                 "Превышено время ожидания ответа.",
+	"error.invalid_credentials":     "Неверные учетные данные.",
+	"error.invalid_token":           "Недействительный или просроченный токен.",
+	"error.quota_exceeded":          "Превышена квота.",
+	"error.unsupported_api_version": "Версия API не поддерживается.",
+	"error.invalid_cursor":          "Курсор страницы недействителен. Запросите список с первой страницы.",
+	"error.account_blocked":         "Ваша учетная запись заблокирована.",
+
+	// Requests
+	"request.malformed":           "Не удается разобрать тело запроса.",
+	"request.unknown_field":       "Неизвестное поле «{field}».",
+	"request.invalid_field":       "Недопустимый тип значения поля «{field}».",
+	"request.unsupported_version": "Версия API «{version}» не поддерживается.",
+	"request.invalid_param":       "Недопустимое значение параметра «{param}».",
+
+	// Authentication
+	"auth.invalid_credentials": "Неверные учетные данные. Пожалуйста, попробуйте снова.",
+	"auth.user_exists":         "Пользователь с этим адресом электронной почты уже существует. Пожалуйста, войдите в систему.",
+	"auth.account_created":     "Ваша учетная запись была создана.",
+	"auth.reset_token_invalid": "Ссылка для сброса пароля недействительна или устарела.",
+
+	// Профиль
+	"me.invalid_password":      "Неверный текущий пароль.",
+	"me.avatar_not_found":      "Медиафайл не найден.",
+	"me.avatar_not_image":      "Аватар должен быть изображением.",
+	"me.email_change_sent":     "Мы отправили ссылку для подтверждения на новый адрес электронной почты.",
+	"me.email_change_invalid":  "Ссылка для смены адреса электронной почты недействительна или устарела.",
+	"me.email_change_subject":  "Подтвердите новый адрес электронной почты",
+	"me.email_change_body":     "Перейдите по ссылке, чтобы подтвердить новый адрес электронной почты: {url}",
+	"me.email_changed_subject": "Адрес электронной почты изменен",
+	"me.email_changed_body":    "Адрес электронной почты вашей учетной записи изменен на {email}. Если это были не вы, свяжитесь с нами.",
+
+	// Пользователи
+	"users.self":                   "Это действие нельзя применить к собственной учетной записи.",
+	"users.impersonate_admin":      "Нельзя действовать от имени администратора.",
+	"users.impersonate_blocked":    "Нельзя действовать от имени заблокированного пользователя.",
+	"users.unblock_deleting":       "Пользователь ожидает удаления, его нельзя разблокировать.",
+	"users.password_reset_sent":    "Пароль сброшен, пользователю отправлена ссылка для установки нового пароля.",
+	"users.password_reset_subject": "Установите новый пароль",
+	"users.password_reset_body":    "Пароль вашей учетной записи был сброшен администратором. Перейдите по ссылке, чтобы установить новый пароль: {url}",
+
+	// Media
+	"media.file_missing":     "Файл не передан или превышает допустимый размер.",
+	"media.too_large":        "Файл превышает допустимый размер.",
+	"media.too_many_pixels":  "Изображение превышает допустимое разрешение.",
+	"media.unsupported_type": "Этот тип файла не поддерживается.",
+	"media.quota_exceeded":   "Превышена квота на хранение файлов.",
+
+	// Pages and projects
+	"page.slug_taken":         "Страница с таким адресом уже существует.",
+	"page.parent_invalid":     "Недопустимая родительская страница.",
+	"project.slug_taken":      "Проект с таким адресом уже существует.",
+	"project.cover_private":   "Обложка должна быть общедоступным медиафайлом.",
+	"project.cover_not_found": "Медиафайл не найден.",
+
+	// Feature flags
+	"flag.key_taken": "Флаг с таким ключом уже существует.",
+
+	// Contact form
+	"contact.token_expired": "Срок действия формы истек. Обновите страницу и попробуйте снова.",
+	"contact.accepted":      "Спасибо! Ваше сообщение отправлено.",
+
+	// Validation, keyed by the validator tag and optionally the kind of the value
+	"validation.default":      "Недопустимое значение.",
+	"validation.required":     "Поле «{field}» является обязательным.",
+	"validation.email":        "Введите действительный адрес электронной почты.",
+	"validation.url":          "Введите действительный URL-адрес.",
+	"validation.eqfield":      "Значение не совпадает с полем «{param}».",
+	"validation.oneof":        "Допустимые значения: {param}.",
+	"validation.max.string":   "Поле «{field}» должно содержать не более {param} символов.",
+	"validation.max.number":   "Значение поля «{field}» должно быть не больше {param}.",
+	"validation.max.items":    "Поле «{field}» должно содержать не более {param} элементов.",
+	"validation.min.string":   "Поле «{field}» должно содержать не менее {param} символов.",
+	"validation.min.number":   "Значение поля «{field}» должно быть не меньше {param}.",
+	"validation.min.items":    "Поле «{field}» должно содержать не менее {param} элементов.",
+	"validation.password":     "Пароль должен содержать не менее 8 символов, включая хотя бы три вида символов из строчных и заглавных букв, цифр и спецсимволов, и не должен быть распространенным.",
+	"validation.slug":         "Поле «{field}» может содержать только строчные латинские буквы, цифры и дефисы между ними.",
+	"validation.phone":        "Введите номер телефона в международном формате, например +79991234567.",
+	"validation.unique_email": "Пользователь с этим адресом электронной почты уже существует.",
+	"validation.locale":       "Этот язык не поддерживается.",
+	"validation.timezone":     "Введите название часового пояса, например Europe/Moscow.",
+
+	// Field names
+	"field.name":             "Имя",
+	"field.email":            "Электронная почта",
+	"field.password":         "Пароль",
+	"field.password_confirm": "Подтверждение пароля",
+	"field.title":            "Заголовок",
+	"field.slug":             "Адрес",
+	"field.body":             "Текст",
+	"field.parent":           "Родительская страница",
+	"field.menu_order":       "Порядок в меню",
+	"field.meta_title":       "SEO заголовок",
+	"field.meta_description": "SEO описание",
+	"field.description":      "Описание",
+	"field.stack":            "Технологии",
+	"field.repository_url":   "Репозиторий",
+	"field.demo_url":         "Демо",
+	"field.cover":            "Обложка",
+	"field.position":         "Позиция",
+	"field.subject":          "Тема",
+	"field.message":          "Сообщение",
+	"field.avatar":           "Аватар",
+	"field.bio":              "О себе",
+	"field.locale":           "Язык",
+	"field.timezone":         "Часовой пояс",
+	"field.current_password": "Текущий пароль",
+	"field.token":            "Токен",
+	"field.user":             "Пользователь",
+	"field.key":              "Ключ",
+	"field.rollout":          "Доля пользователей",
+	"field.user_ids":         "Пользователи",
+	"field.roles":            "Роли",
+}
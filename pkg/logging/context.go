@@ -1,6 +1,10 @@
 package logging
 
-import "context"
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
 
 type ctxLogger struct{}
 
@@ -9,9 +13,19 @@ func ContextWithLogger(ctx context.Context, l Logger) context.Context {
 	return context.WithValue(ctx, ctxLogger{}, l)
 }
 
-// LoggerFromContext returns logger from context
+// ContextWithFields adds a logger to context which adds the fields to every entry logged by the logger already in it
+func ContextWithFields(ctx context.Context, fields logrus.Fields) context.Context {
+	return ContextWithLogger(ctx, &entryLogger{Entry: loggerFromContext(ctx).WithFields(fields)})
+}
+
+// FromContext returns logger from context, or the default logger if there's none
+func FromContext(ctx context.Context) Logger {
+	return loggerFromContext(ctx)
+}
+
+// loggerFromContext returns logger from context
 func loggerFromContext(ctx context.Context) Logger {
-	if l, ok := ctx.Value(ctxLogger{}).(*logger); ok {
+	if l, ok := ctx.Value(ctxLogger{}).(Logger); ok {
 		return l
 	}
 	return defLogger
@@ -3,14 +3,21 @@ package logging
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"path"
 	"runtime"
+	"strings"
 	"time"
 
 	"github.com/sirupsen/logrus"
 )
 
+const (
+	logrusPackage  = "github.com/sirupsen/logrus"
+	loggingPackage = "github.com/vovanwin/api-my-site/pkg/logging"
+)
+
 var defLogger = NewLogger()
 
 type logger struct {
@@ -51,22 +58,52 @@ func NewLogger() Logger {
 	logrusLogger := logrus.New()
 	logrusLogger.SetLevel(logrus.InfoLevel)
 	logrusLogger.Formatter = &logrus.TextFormatter{
-		CallerPrettyfier: func(f *runtime.Frame) (string, string) {
-			filename := path.Base(f.File)
-			return fmt.Sprintf("%s:%d", filename, f.Line), fmt.Sprintf("%s()", f.Function)
-		},
-		DisableColors: false,
-		FullTimestamp: true,
+		CallerPrettyfier: callerPrettyfier,
+		DisableColors:    false,
+		FullTimestamp:    true,
 	}
 	logrusLogger.SetOutput(os.Stdout)
-	// TODO не работает, починить
 	logrusLogger.SetReportCaller(true)
+	logrusLogger.AddHook(callerHook{})
+	logrusLogger.AddHook(redactHook{})
 
 	return &logger{
 		Logger: logrusLogger,
 	}
 }
 
+// SetJSONFormat switches the default logger to JSON output, which log collectors can parse
+func SetJSONFormat() {
+	if l, ok := defLogger.(*logger); ok {
+		l.Logger.SetFormatter(&logrus.JSONFormatter{
+			CallerPrettyfier: callerPrettyfier,
+		})
+	}
+}
+
+// SetOutput sets where the default logger writes the entries to, which is stdout by default
+func SetOutput(w io.Writer) {
+	if l, ok := defLogger.(*logger); ok {
+		l.Logger.SetOutput(w)
+	}
+}
+
+// SetLevel sets the minimum level of the entries written by the default logger, such as "debug" or "warn"
+func SetLevel(level string) error {
+	l, err := logrus.ParseLevel(level)
+	if err != nil {
+		return err
+	}
+	defLogger.SetLevel(l)
+	return nil
+}
+
+// callerPrettyfier shortens the reported caller to the file name and line
+func callerPrettyfier(f *runtime.Frame) (string, string) {
+	filename := path.Base(f.File)
+	return fmt.Sprintf("%s()", f.Function), fmt.Sprintf("%s:%d", filename, f.Line)
+}
+
 func (l *logger) SetLevel(level logrus.Level) {
 	l.Logger.SetLevel(level)
 }
@@ -75,6 +112,54 @@ func (l *logger) GetLevel() logrus.Level {
 	return l.Logger.GetLevel()
 }
 
+// callerHook reports the code which called the package functions, such as Infof, as the caller of an entry,
+// rather than the package functions themselves
+type callerHook struct{}
+
+func (callerHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (callerHook) Fire(e *logrus.Entry) error {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		f, more := frames.Next()
+		if pkg := packageName(f.Function); pkg != logrusPackage && pkg != loggingPackage {
+			e.Caller = &f
+			return nil
+		}
+		if !more {
+			return nil
+		}
+	}
+}
+
+// packageName returns the package of a fully qualified function name
+func packageName(function string) string {
+	for {
+		period := strings.LastIndex(function, ".")
+		slash := strings.LastIndex(function, "/")
+		if period <= slash {
+			return function
+		}
+		function = function[:period]
+	}
+}
+
+// entryLogger is a logger which adds a set of fields to every entry it logs
+type entryLogger struct {
+	*logrus.Entry
+}
+
+func (l *entryLogger) SetLevel(level logrus.Level) {
+	l.Entry.Logger.SetLevel(level)
+}
+
+func (l *entryLogger) GetLevel() logrus.Level {
+	return l.Entry.Logger.GetLevel()
+}
+
 func WithField(ctx context.Context, key string, value interface{}) *logrus.Entry {
 	return loggerFromContext(ctx).WithField(key, value)
 }
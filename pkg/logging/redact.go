@@ -0,0 +1,209 @@
+package logging
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync/atomic"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Redacted replaces sensitive values
+const Redacted = "[redacted]"
+
+var (
+	// DefaultRedactKeys contains the names of the fields, parameters and headers whose values are redacted by default
+	DefaultRedactKeys = []string{"password", "token", "secret", "authorization", "cookie"}
+
+	// DefaultPartialRedactKeys contains the names of the fields whose values are partially masked by default
+	DefaultPartialRedactKeys = []string{"email"}
+
+	// emailPattern matches email addresses, including URL encoded ones
+	emailPattern = regexp.MustCompile(`([A-Za-z0-9._%+-])[A-Za-z0-9._%+-]*((?:@|%40)[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,})`)
+
+	// jwtPattern matches JSON Web Tokens
+	jwtPattern = regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+
+	// credentialsPattern matches the credentials of an Authorization header value
+	credentialsPattern = regexp.MustCompile(`(?i)\b(bearer|basic)\s+[A-Za-z0-9._~+/=-]+`)
+
+	// redactor stores the redactor applied to the entries of the loggers created by NewLogger
+	redactor atomic.Value
+)
+
+func init() {
+	redactor.Store(NewRedactor(DefaultRedactKeys, DefaultPartialRedactKeys))
+}
+
+// Redactor removes sensitive data, such as passwords, tokens and email addresses, from log entries and
+// error messages. Values of fields, query parameters and headers whose names contain one of the keys are
+// replaced, values of those whose names contain one of the partial keys are masked, and secrets found in
+// any other text are replaced or masked as well
+type Redactor struct {
+	// keys contains the lowercase key patterns whose values are replaced
+	keys []string
+
+	// partialKeys contains the lowercase key patterns whose values are masked
+	partialKeys []string
+
+	// pairs matches key=value and key: value pairs in text whose key contains one of the keys
+	pairs *regexp.Regexp
+}
+
+// NewRedactor creates a new Redactor
+func NewRedactor(keys, partialKeys []string) *Redactor {
+	r := &Redactor{
+		keys:        lower(keys),
+		partialKeys: lower(partialKeys),
+	}
+
+	if len(r.keys) > 0 {
+		quoted := make([]string, len(r.keys))
+		for i, key := range r.keys {
+			quoted[i] = regexp.QuoteMeta(key)
+		}
+		r.pairs = regexp.MustCompile(
+			`(?i)([\w.-]*(?:` + strings.Join(quoted, "|") + `)[\w.-]*"?)(\s*[=:]\s*)("[^"]*"|[^\s&,;"]+)`,
+		)
+	}
+
+	return r
+}
+
+// SetRedactor sets the redactor applied to the entries of the loggers created by NewLogger
+func SetRedactor(r *Redactor) {
+	redactor.Store(r)
+}
+
+// Redact removes sensitive data from text, such as an error message or a URI
+func Redact(s string) string {
+	return redactor.Load().(*Redactor).String(s)
+}
+
+// RedactHeader returns a copy of the HTTP headers with sensitive values removed
+func RedactHeader(h http.Header) http.Header {
+	return redactor.Load().(*Redactor).Header(h)
+}
+
+// String removes sensitive data from text
+func (r *Redactor) String(s string) string {
+	s = jwtPattern.ReplaceAllString(s, Redacted)
+	s = credentialsPattern.ReplaceAllString(s, "$1 "+Redacted)
+	if r.pairs != nil {
+		s = r.pairs.ReplaceAllString(s, "${1}${2}"+Redacted)
+	}
+	return emailPattern.ReplaceAllString(s, "${1}***${2}")
+}
+
+// Field removes sensitive data from the value of a field with the given name
+func (r *Redactor) Field(key string, value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+
+	switch {
+	case r.matches(r.keys, key):
+		return Redacted
+	case r.matches(r.partialKeys, key):
+		return mask(fmt.Sprint(value))
+	}
+
+	switch v := value.(type) {
+	case string:
+		return r.String(v)
+	case error:
+		if s := r.String(v.Error()); s != v.Error() {
+			return s
+		}
+		return v
+	case http.Header:
+		return r.Header(v)
+	case url.Values:
+		return url.Values(r.Header(http.Header(v)))
+	case []string:
+		redacted := make([]string, len(v))
+		for i, s := range v {
+			redacted[i] = r.String(s)
+		}
+		return redacted
+	case map[string]interface{}:
+		return map[string]interface{}(r.Fields(v))
+	case logrus.Fields:
+		return r.Fields(v)
+	default:
+		return v
+	}
+}
+
+// Fields returns a copy of the fields with sensitive values removed
+func (r *Redactor) Fields(fields logrus.Fields) logrus.Fields {
+	redacted := make(logrus.Fields, len(fields))
+	for key, value := range fields {
+		redacted[key] = r.Field(key, value)
+	}
+	return redacted
+}
+
+// Header returns a copy of the HTTP headers with sensitive values removed
+func (r *Redactor) Header(h http.Header) http.Header {
+	redacted := make(http.Header, len(h))
+	for key, values := range h {
+		redacted[key] = make([]string, len(values))
+		for i, value := range values {
+			redacted[key][i] = fmt.Sprint(r.Field(key, value))
+		}
+	}
+	return redacted
+}
+
+// matches returns whether the name contains one of the key patterns
+func (r *Redactor) matches(patterns []string, name string) bool {
+	name = strings.ToLower(name)
+	for _, pattern := range patterns {
+		if strings.Contains(name, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactHook removes sensitive data from the message and fields of every entry
+type redactHook struct{}
+
+func (redactHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (redactHook) Fire(e *logrus.Entry) error {
+	r := redactor.Load().(*Redactor)
+	e.Message = r.String(e.Message)
+	e.Data = r.Fields(e.Data)
+	return nil
+}
+
+// mask keeps the first character of a value, and the domain if it's an email address
+func mask(value string) string {
+	if value == "" {
+		return value
+	}
+
+	if masked := emailPattern.ReplaceAllString(value, "${1}***${2}"); masked != value {
+		return masked
+	}
+
+	return string([]rune(value)[:1]) + "***"
+}
+
+// lower returns the lowercase copies of the values
+func lower(values []string) []string {
+	lowered := make([]string, 0, len(values))
+	for _, v := range values {
+		if v = strings.ToLower(strings.TrimSpace(v)); v != "" {
+			lowered = append(lowered, v)
+		}
+	}
+	return lowered
+}
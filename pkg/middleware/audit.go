@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/vovanwin/api-my-site/ent"
+	"github.com/vovanwin/api-my-site/pkg/context"
+	"github.com/vovanwin/api-my-site/pkg/services"
+)
+
+// AuditActor сохраняет в контексте запроса сведения о том, кто и откуда выполняет запрос, чтобы записи журнала
+// аудита, в том числе создаваемые хуками ORM, относились к аутентифицированному пользователю и администратору,
+// действующему от его имени
+// Должен выполняться после LoadAuthenticatedUser, а идентификатор запроса берется из контекста по ключу RequestIDKey
+func AuditActor() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			actor := services.AuditActor{
+				IP:        c.RealIP(),
+				UserAgent: c.Request().UserAgent(),
+			}
+
+			if id, ok := c.Get(context.RequestIDKey).(string); ok {
+				actor.RequestID = id
+			}
+
+			if u, ok := c.Get(context.AuthenticatedUserKey).(*ent.User); ok {
+				actor.UserID = &u.ID
+			}
+
+			if adminID, ok := c.Get(context.ImpersonatorKey).(int); ok {
+				actor.ImpersonatorID = &adminID
+			}
+
+			req := c.Request()
+			c.SetRequest(req.WithContext(services.WithAuditActor(req.Context(), actor)))
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vovanwin/api-my-site/pkg/context"
+	"github.com/vovanwin/api-my-site/pkg/services"
+	"github.com/vovanwin/api-my-site/pkg/tests"
+)
+
+func TestAuditActor(t *testing.T) {
+	ctx, _ := tests.NewContext(c.Web, "/")
+	ctx.Request().Header.Set("User-Agent", "test")
+	ctx.Set(context.RequestIDKey, "abc")
+	ctx.Set(context.AuthenticatedUserKey, usr)
+	ctx.Set(context.ImpersonatorKey, 99)
+	require.NoError(t, tests.ExecuteMiddleware(ctx, AuditActor()))
+
+	actor, ok := services.GetAuditActor(ctx.Request().Context())
+	require.True(t, ok)
+	require.NotNil(t, actor.UserID)
+	require.NotNil(t, actor.ImpersonatorID)
+	assert.Equal(t, usr.ID, *actor.UserID)
+	assert.Equal(t, 99, *actor.ImpersonatorID)
+	assert.Equal(t, "test", actor.UserAgent)
+	assert.Equal(t, "abc", actor.RequestID)
+	assert.Equal(t, ctx.RealIP(), actor.IP)
+}
@@ -4,15 +4,20 @@ import (
 	"fmt"
 	"github.com/sirupsen/logrus"
 	"github.com/vovanwin/api-my-site/ent"
+	"github.com/vovanwin/api-my-site/ent/user"
 	"net/http"
 
 	"github.com/vovanwin/api-my-site/pkg/context"
+	"github.com/vovanwin/api-my-site/pkg/logging"
+	"github.com/vovanwin/api-my-site/pkg/problem"
 	"github.com/vovanwin/api-my-site/pkg/services"
 
 	"github.com/labstack/echo/v4"
 )
 
 // LoadAuthenticatedUser загружает аутентифицированного пользователя, если таковой имеется, и сохраняет в контексте
+// Заблокированным пользователям отказывается в доступе, а запросы администратора от имени пользователя
+// отмечаются в контексте и журнале
 func LoadAuthenticatedUser(authClient *services.AuthClient) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
@@ -20,16 +25,26 @@ func LoadAuthenticatedUser(authClient *services.AuthClient) echo.MiddlewareFunc
 
 			switch err.(type) {
 			case *ent.NotFoundError:
-				logrus.Warn("авторизованный пользователь не найден")
+				logging.Warning(c.Request().Context(), "авторизованный пользователь не найден")
 			case services.NotAuthenticatedError:
 			case nil:
+				if u.BlockedAt != nil {
+					return problem.New(http.StatusForbidden, problem.CodeAccountBlocked, "")
+				}
 				c.Set(context.AuthenticatedUserKey, u)
-				logrus.Infof("авторизованный пользователь, загруженный в контекст: %d", u.ID)
+				logging.Infof(c.Request().Context(), "авторизованный пользователь, загруженный в контекст: %d", u.ID)
+
+				if adminID, ok := authClient.GetImpersonatorID(c); ok {
+					c.Set(context.ImpersonatorKey, adminID)
+					logging.WithFields(c.Request().Context(), logrus.Fields{
+						"impersonator_id": adminID,
+						"user_id":         u.ID,
+						"method":          c.Request().Method,
+						"uri":             c.Request().RequestURI,
+					}).Warn("запрос администратора от имени пользователя")
+				}
 			default:
-				return echo.NewHTTPError(
-					http.StatusInternalServerError,
-					fmt.Sprintf("ошибка при запросе аутентифицированного пользователя: %v", err),
-				)
+				return problem.Internal(fmt.Errorf("ошибка при запросе аутентифицированного пользователя: %w", err))
 			}
 
 			return next(c)
@@ -50,6 +65,24 @@ func RequireAuthentication() echo.MiddlewareFunc {
 	}
 }
 
+// RequireAdmin требуется, чтобы аутентифицированный пользователь был администратором для продолжения
+func RequireAdmin() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			u, ok := c.Get(context.AuthenticatedUserKey).(*ent.User)
+			if !ok {
+				return echo.NewHTTPError(http.StatusUnauthorized)
+			}
+
+			if u.Role != user.RoleAdmin {
+				return echo.NewHTTPError(http.StatusForbidden)
+			}
+
+			return next(c)
+		}
+	}
+}
+
 // RequireNoAuthentication требуется, чтобы пользователь не проходил аутентификацию для продолжения
 func RequireNoAuthentication() echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
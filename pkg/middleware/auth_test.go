@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/vovanwin/api-my-site/ent"
+	"github.com/vovanwin/api-my-site/ent/user"
+	"github.com/vovanwin/api-my-site/pkg/context"
+	"github.com/vovanwin/api-my-site/pkg/problem"
+	"github.com/vovanwin/api-my-site/pkg/services"
+	"github.com/vovanwin/api-my-site/pkg/tests"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withToken returns a context carrying a parsed token, as set by the JWT middleware
+func withToken(t *testing.T, token string) echo.Context {
+	claims := new(services.JwtCustomClaims)
+	parsed, err := jwt.ParseWithClaims(token, claims, func(*jwt.Token) (interface{}, error) {
+		return []byte(c.Config.App.EncryptionKey), nil
+	})
+	require.NoError(t, err)
+
+	ctx, _ := tests.NewContext(c.Web, "/")
+	ctx.Set("user", parsed)
+	return ctx
+}
+
+func TestLoadAuthenticatedUser(t *testing.T) {
+	u, err := tests.CreateUser(c.ORM)
+	require.NoError(t, err)
+	admin, err := tests.CreateUser(c.ORM)
+	require.NoError(t, err)
+
+	token, err := c.Auth.Login(nil, u)
+	require.NoError(t, err)
+	ctx := withToken(t, token)
+	require.NoError(t, tests.ExecuteMiddleware(ctx, LoadAuthenticatedUser(c.Auth)))
+	assert.Equal(t, u.ID, ctx.Get(context.AuthenticatedUserKey).(*ent.User).ID)
+	assert.Nil(t, ctx.Get(context.ImpersonatorKey))
+
+	// Impersonated requests are marked
+	token, err = c.Auth.Impersonate(nil, admin, u)
+	require.NoError(t, err)
+	ctx = withToken(t, token)
+	require.NoError(t, tests.ExecuteMiddleware(ctx, LoadAuthenticatedUser(c.Auth)))
+	assert.Equal(t, admin.ID, ctx.Get(context.ImpersonatorKey))
+
+	// Blocked users are denied
+	_, err = u.Update().SetBlockedAt(time.Now()).Save(ctx.Request().Context())
+	require.NoError(t, err)
+	ctx = withToken(t, token)
+	err = tests.ExecuteMiddleware(ctx, LoadAuthenticatedUser(c.Auth))
+	var pe *problem.Error
+	if assert.True(t, errors.As(err, &pe)) {
+		assert.Equal(t, problem.CodeAccountBlocked, pe.Code)
+	}
+	assert.Nil(t, ctx.Get(context.AuthenticatedUserKey))
+}
+
+func TestRequireAdmin(t *testing.T) {
+	ctx, _ := tests.NewContext(c.Web, "/")
+	err := tests.ExecuteMiddleware(ctx, RequireAdmin())
+	tests.AssertHTTPErrorCode(t, err, http.StatusUnauthorized)
+
+	ctx.Set(context.AuthenticatedUserKey, &ent.User{Role: user.RoleUser})
+	err = tests.ExecuteMiddleware(ctx, RequireAdmin())
+	tests.AssertHTTPErrorCode(t, err, http.StatusForbidden)
+
+	ctx.Set(context.AuthenticatedUserKey, &ent.User{Role: user.RoleAdmin})
+	err = tests.ExecuteMiddleware(ctx, RequireAdmin())
+	assert.Nil(t, err)
+}
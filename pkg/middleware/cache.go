@@ -3,9 +3,12 @@ package middleware
 import (
 	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/vovanwin/api-my-site/pkg/context"
+	"github.com/vovanwin/api-my-site/pkg/logging"
 	"github.com/vovanwin/api-my-site/pkg/services"
 
 	"github.com/go-redis/redis/v8"
@@ -20,7 +23,8 @@ type CachedPage struct {
 	// URL stores the URL of the requested page
 	URL string
 
-	// HTML stores the complete HTML of the rendered Page
+	// HTML stores the complete body of the rendered Page, which is not necessarily HTML
+	// The Content-Type header, if included in Headers, dictates what is served
 	HTML []byte
 
 	// StatusCode stores the HTTP status code
@@ -30,10 +34,12 @@ type CachedPage struct {
 	Headers map[string]string
 }
 
-// ServeCachedPage пытается загрузить страницу из кэша путем сопоставления полного URL-адреса запроса
-// Если страница кэшируется по запрошенному URL, она будет отправлена здесь, и запрос завершится.
-// Любой запрос, сделанный аутентифицированным пользователем или не являющийся получением, будет пропущен.
-func ServeCachedPage(ch *services.CacheClient) echo.MiddlewareFunc {
+// ServeCachedPage пытается загрузить страницу из кэша по версии API, пути запроса и заданным параметрам запроса,
+// которые читает обработчик. Остальные параметры не влияют на ключ, чтобы ими нельзя было обойти кэш.
+// Если страница кэширована, она будет отправлена здесь, и запрос завершится, иначе ключ сохраняется в контексте
+// для controller.RenderCached. Любой запрос, сделанный аутентифицированным пользователем или не являющийся
+// получением, будет пропущен.
+func ServeCachedPage(ch *services.CacheClient, query ...string) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			// Skip non GET requests
@@ -46,22 +52,25 @@ func ServeCachedPage(ch *services.CacheClient) echo.MiddlewareFunc {
 				return next(c)
 			}
 
+			key := cachedPageKey(c, query)
+			c.Set(context.CachedPageKey, key)
+
 			// Attempt to load from cache
 			res, err := ch.
 				Get().
 				Group(CachedPageGroup).
-				Key(c.Request().URL.String()).
+				Key(key).
 				Type(new(CachedPage)).
 				Fetch(c.Request().Context())
 
 			if err != nil {
 				switch {
 				case err == redis.Nil:
-					c.Logger().Info("no cached page found")
+					logging.Info(c.Request().Context(), "no cached page found")
 				case context.IsCanceledError(err):
 					return nil
 				default:
-					c.Logger().Errorf("failed getting cached page: %v", err)
+					logging.Errorf(c.Request().Context(), "failed getting cached page: %v", err)
 				}
 
 				return next(c)
@@ -69,7 +78,7 @@ func ServeCachedPage(ch *services.CacheClient) echo.MiddlewareFunc {
 
 			page, ok := res.(*CachedPage)
 			if !ok {
-				c.Logger().Errorf("failed casting cached page")
+				logging.Errorf(c.Request().Context(), "failed casting cached page")
 				return next(c)
 			}
 
@@ -80,19 +89,56 @@ func ServeCachedPage(ch *services.CacheClient) echo.MiddlewareFunc {
 				}
 			}
 
-			c.Logger().Info("serving cached page")
+			if IsNotModified(c.Request(), page.Headers["ETag"], page.Headers[echo.HeaderLastModified]) {
+				return c.NoContent(http.StatusNotModified)
+			}
+
+			logging.Info(c.Request().Context(), "serving cached page")
 
-			return c.HTMLBlob(page.StatusCode, page.HTML)
+			contentType := page.Headers[echo.HeaderContentType]
+			if contentType == "" {
+				contentType = echo.MIMETextHTMLCharsetUTF8
+			}
+
+			return c.Blob(page.StatusCode, contentType, page.HTML)
 		}
 	}
 }
 
-// CacheControl sets a Cache-Control header with a given max age
-func CacheControl(maxAge time.Duration) echo.MiddlewareFunc {
+// CachedPageKeyOf возвращает ключ кэша страницы, определенный middleware ServeCachedPage,
+// либо пустую строку, если страница запроса не кэшируется
+func CachedPageKeyOf(c echo.Context) string {
+	key, _ := c.Get(context.CachedPageKey).(string)
+	return key
+}
+
+// cachedPageKey builds the cache key of a page from the API version, the path and the given query parameters
+// The version is part of the key since it's removed from the path, and may not be in the path at all
+func cachedPageKey(c echo.Context, query []string) string {
+	values := make(url.Values, len(query))
+	for _, name := range query {
+		if v, ok := c.QueryParams()[name]; ok {
+			values[name] = v
+		}
+	}
+
+	key := c.Request().URL.EscapedPath()
+	if len(values) > 0 {
+		key += "?" + values.Encode()
+	}
+	if v := APIVersionOf(c); v != "" {
+		key = v + ":" + key
+	}
+	return key
+}
+
+// CacheControl sets a Cache-Control header with the max age returned by the given function
+// The max age is read on every request, so it can be changed while the application is running
+func CacheControl(maxAge func() time.Duration) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			v := "no-cache, no-store"
-			if maxAge > 0 {
+			if maxAge := maxAge(); maxAge > 0 {
 				v = fmt.Sprintf("public, max-age=%.0f", maxAge.Seconds())
 			}
 			c.Response().Header().Set("Cache-Control", v)
@@ -100,3 +146,34 @@ func CacheControl(maxAge time.Duration) echo.MiddlewareFunc {
 		}
 	}
 }
+
+// IsNotModified determines if a conditional GET request can be answered with 304 Not Modified given the
+// ETag and Last-Modified values of the current representation
+// As per RFC 7232, If-None-Match takes precedence over If-Modified-Since
+func IsNotModified(r *http.Request, etag, lastModified string) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		if etag == "" {
+			return false
+		}
+		for _, t := range strings.Split(inm, ",") {
+			if t = strings.TrimSpace(t); t == "*" || strings.TrimPrefix(t, "W/") == strings.TrimPrefix(etag, "W/") {
+				return true
+			}
+		}
+		return false
+	}
+
+	if ims := r.Header.Get("If-Modified-Since"); ims != "" && lastModified != "" {
+		since, err := http.ParseTime(ims)
+		if err != nil {
+			return false
+		}
+		modified, err := http.ParseTime(lastModified)
+		if err != nil {
+			return false
+		}
+		return !modified.After(since)
+	}
+
+	return false
+}
@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/vovanwin/api-my-site/pkg/tests"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServeCachedPage(t *testing.T) {
+	// Cache a page
+	cp := CachedPage{
+		URL:        "/cache",
+		HTML:       []byte("<rss></rss>"),
+		Headers:    make(map[string]string),
+		StatusCode: http.StatusCreated,
+	}
+	cp.Headers[echo.HeaderContentType] = "application/rss+xml"
+	cp.Headers["ETag"] = `"abc"`
+	err := c.Cache.
+		Set().
+		Group(CachedPageGroup).
+		Key(cp.URL).
+		Data(cp).
+		Save(context.Background())
+	require.NoError(t, err)
+
+	// Request the URL of the cached page
+	ctx, rec := tests.NewContext(c.Web, cp.URL)
+	err = tests.ExecuteMiddleware(ctx, ServeCachedPage(c.Cache))
+	assert.NoError(t, err)
+	assert.Equal(t, cp.StatusCode, ctx.Response().Status)
+	assert.Equal(t, "application/rss+xml", rec.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, cp.HTML, rec.Body.Bytes())
+
+	// A conditional request should not receive the body
+	ctx, rec = tests.NewContext(c.Web, cp.URL)
+	ctx.Request().Header.Set("If-None-Match", `"abc"`)
+	err = tests.ExecuteMiddleware(ctx, ServeCachedPage(c.Cache))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotModified, ctx.Response().Status)
+	assert.Empty(t, rec.Body.Bytes())
+}
+
+func TestServeCachedPage_Key(t *testing.T) {
+	cp := CachedPage{
+		URL:        "/cache/key?featured=true",
+		HTML:       []byte("[]"),
+		StatusCode: http.StatusOK,
+	}
+	err := c.Cache.
+		Set().
+		Group(CachedPageGroup).
+		Key(cp.URL).
+		Data(cp).
+		Save(context.Background())
+	require.NoError(t, err)
+
+	// Parameters the handler doesn't read are left out of the key
+	ctx, rec := tests.NewContext(c.Web, "/cache/key?utm_source=x&featured=true")
+	err = tests.ExecuteMiddleware(ctx, ServeCachedPage(c.Cache, "featured"))
+	assert.NoError(t, err)
+	assert.Equal(t, cp.HTML, rec.Body.Bytes())
+
+	// A miss leaves the key for the handler to cache the page under
+	ctx, _ = tests.NewContext(c.Web, "/cache/key?utm_source=x")
+	err = tests.ExecuteMiddleware(ctx, ServeCachedPage(c.Cache, "featured"))
+	assert.NoError(t, err)
+	assert.Equal(t, "/cache/key", CachedPageKeyOf(ctx))
+
+	// Pages of different API versions are cached separately
+	ctx, rec = tests.NewContext(c.Web, "/api/v2/cache/key?featured=true")
+	require.NoError(t, tests.ExecuteMiddleware(ctx, APIVersion("/api", "vnd.test", "v1", "v2")))
+	err = tests.ExecuteMiddleware(ctx, ServeCachedPage(c.Cache, "featured"))
+	assert.NoError(t, err)
+	assert.Empty(t, rec.Body.Bytes())
+	assert.Equal(t, "v2:/api/cache/key?featured=true", CachedPageKeyOf(ctx))
+}
+
+func TestIsNotModified(t *testing.T) {
+	lastModified := time.Date(2023, 4, 1, 10, 0, 0, 0, time.UTC).Format(http.TimeFormat)
+
+	cases := []struct {
+		header   string
+		value    string
+		expected bool
+	}{
+		{"If-None-Match", `"abc"`, true},
+		{"If-None-Match", `"def", W/"abc"`, true},
+		{"If-None-Match", "*", true},
+		{"If-None-Match", `"def"`, false},
+		{"If-Modified-Since", lastModified, true},
+		{"If-Modified-Since", time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC).Format(http.TimeFormat), false},
+		{"If-Modified-Since", "invalid", false},
+		{"", "", false},
+	}
+
+	for _, test := range cases {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		if test.header != "" {
+			r.Header.Set(test.header, test.value)
+		}
+		assert.Equal(t, test.expected, IsNotModified(r, `"abc"`, lastModified), test.value)
+	}
+}
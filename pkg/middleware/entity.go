@@ -5,8 +5,14 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/vovanwin/api-my-site/ent"
+	"github.com/vovanwin/api-my-site/ent/contactmessage"
+	"github.com/vovanwin/api-my-site/ent/media"
+	"github.com/vovanwin/api-my-site/ent/page"
+	"github.com/vovanwin/api-my-site/ent/project"
 	"github.com/vovanwin/api-my-site/ent/user"
 	"github.com/vovanwin/api-my-site/pkg/context"
+	"github.com/vovanwin/api-my-site/pkg/problem"
 
 	"github.com/labstack/echo/v4"
 )
@@ -32,10 +38,121 @@ func LoadUser(orm *ent.Client) echo.MiddlewareFunc {
 			case *ent.NotFoundError:
 				return echo.NewHTTPError(http.StatusNotFound)
 			default:
-				return echo.NewHTTPError(
-					http.StatusInternalServerError,
-					fmt.Sprintf("error querying user: %v", err),
-				)
+				return problem.Internal(fmt.Errorf("error querying user: %w", err))
+			}
+		}
+	}
+}
+
+// LoadMedia loads the media based on the ID provided as a path parameter
+func LoadMedia(orm *ent.Client) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			mediaID, err := strconv.Atoi(c.Param("media"))
+			if err != nil {
+				return echo.NewHTTPError(http.StatusNotFound)
+			}
+
+			m, err := orm.Media.
+				Query().
+				Where(media.ID(mediaID)).
+				WithOwner().
+				WithVariants().
+				Only(c.Request().Context())
+
+			switch err.(type) {
+			case nil:
+				c.Set(context.MediaKey, m)
+				return next(c)
+			case *ent.NotFoundError:
+				return echo.NewHTTPError(http.StatusNotFound)
+			default:
+				return problem.Internal(fmt.Errorf("error querying media: %w", err))
+			}
+		}
+	}
+}
+
+// LoadPage loads the page based on the ID provided as a path parameter
+func LoadPage(orm *ent.Client) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			pageID, err := strconv.Atoi(c.Param("page"))
+			if err != nil {
+				return echo.NewHTTPError(http.StatusNotFound)
+			}
+
+			p, err := orm.Page.
+				Query().
+				Where(page.ID(pageID)).
+				WithParent().
+				Only(c.Request().Context())
+
+			switch err.(type) {
+			case nil:
+				c.Set(context.PageKey, p)
+				return next(c)
+			case *ent.NotFoundError:
+				return echo.NewHTTPError(http.StatusNotFound)
+			default:
+				return problem.Internal(fmt.Errorf("error querying page: %w", err))
+			}
+		}
+	}
+}
+
+// LoadProject loads the project based on the ID provided as a path parameter
+func LoadProject(orm *ent.Client) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			projectID, err := strconv.Atoi(c.Param("project"))
+			if err != nil {
+				return echo.NewHTTPError(http.StatusNotFound)
+			}
+
+			p, err := orm.Project.
+				Query().
+				Where(project.ID(projectID)).
+				WithCover(func(q *ent.MediaQuery) {
+					q.WithVariants()
+				}).
+				Only(c.Request().Context())
+
+			switch err.(type) {
+			case nil:
+				c.Set(context.ProjectKey, p)
+				return next(c)
+			case *ent.NotFoundError:
+				return echo.NewHTTPError(http.StatusNotFound)
+			default:
+				return problem.Internal(fmt.Errorf("error querying project: %w", err))
+			}
+		}
+	}
+}
+
+// LoadContactMessage loads the contact message based on the ID provided as a path parameter
+func LoadContactMessage(orm *ent.Client) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			messageID, err := strconv.Atoi(c.Param("message"))
+			if err != nil {
+				return echo.NewHTTPError(http.StatusNotFound)
+			}
+
+			m, err := orm.ContactMessage.
+				Query().
+				Where(contactmessage.ID(messageID)).
+				Only(c.Request().Context())
+
+			switch err.(type) {
+			case nil:
+				c.Set(context.ContactMessageKey, m)
+				return next(c)
+			case *ent.NotFoundError:
+				return echo.NewHTTPError(http.StatusNotFound)
+			default:
+				return problem.Internal(fmt.Errorf("error querying contact message: %w", err))
 			}
 		}
 	}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/vovanwin/api-my-site/ent"
 	"github.com/vovanwin/api-my-site/pkg/context"
 	"github.com/vovanwin/api-my-site/pkg/tests"
 
@@ -20,3 +21,43 @@ func TestLoadUser(t *testing.T) {
 	require.True(t, ok)
 	assert.Equal(t, usr.ID, ctxUsr.ID)
 }
+
+func TestLoadMedia(t *testing.T) {
+	ctx, _ := tests.NewContext(c.Web, "/")
+	m, err := c.ORM.Media.
+		Create().
+		SetKey(fmt.Sprintf("%d/test.png", usr.ID)).
+		SetFilename("test.png").
+		SetMimeType("image/png").
+		SetSize(1).
+		SetChecksum("abc").
+		SetOwner(usr).
+		Save(ctx.Request().Context())
+	require.NoError(t, err)
+
+	ctx.SetParamNames("media")
+	ctx.SetParamValues(fmt.Sprintf("%d", m.ID))
+	_ = tests.ExecuteMiddleware(ctx, LoadMedia(c.ORM))
+	ctxMedia, ok := ctx.Get(context.MediaKey).(*ent.Media)
+	require.True(t, ok)
+	assert.Equal(t, m.ID, ctxMedia.ID)
+	assert.Equal(t, usr.ID, ctxMedia.Edges.Owner.ID)
+}
+
+func TestLoadPage(t *testing.T) {
+	ctx, _ := tests.NewContext(c.Web, "/")
+	p, err := c.ORM.Page.
+		Create().
+		SetTitle("About").
+		SetSlug("about").
+		SetBody("Body").
+		Save(ctx.Request().Context())
+	require.NoError(t, err)
+
+	ctx.SetParamNames("page")
+	ctx.SetParamValues(fmt.Sprintf("%d", p.ID))
+	_ = tests.ExecuteMiddleware(ctx, LoadPage(c.ORM))
+	ctxPage, ok := ctx.Get(context.PageKey).(*ent.Page)
+	require.True(t, ok)
+	assert.Equal(t, p.ID, ctxPage.ID)
+}
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/vovanwin/api-my-site/ent"
+	"github.com/vovanwin/api-my-site/pkg/context"
+	"github.com/vovanwin/api-my-site/pkg/logging"
+	"github.com/vovanwin/api-my-site/pkg/problem"
+	"github.com/vovanwin/api-my-site/pkg/services"
+)
+
+// RequireFlag скрывает маршрут, пока флаг функциональности выключен для текущего пользователя
+// Запросы к скрытому маршруту получают 404, как если бы маршрута не было. Если флаги не удается загрузить,
+// маршрут также скрывается, чтобы незаконченная функциональность не стала доступна всем
+func RequireFlag(flags *services.FeatureFlagClient, key string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			u, _ := c.Get(context.AuthenticatedUserKey).(*ent.User)
+
+			on, err := flags.Enabled(c.Request().Context(), key, u)
+			if err != nil {
+				logging.Errorf(c.Request().Context(), "не удается проверить флаг функциональности %s: %v", key, err)
+			}
+
+			if !on {
+				return echo.NewHTTPError(http.StatusNotFound)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// LoadFeatureFlag загружает флаг функциональности по ключу, указанному в параметре пути
+func LoadFeatureFlag(flags *services.FeatureFlagClient) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			flag, err := flags.Get(c.Request().Context(), c.Param("flag"))
+
+			switch {
+			case err == nil:
+				c.Set(context.FeatureFlagKey, flag)
+				return next(c)
+			case errors.Is(err, services.ErrFeatureFlagNotFound):
+				return echo.NewHTTPError(http.StatusNotFound)
+			default:
+				return problem.Internal(fmt.Errorf("error querying feature flag: %w", err))
+			}
+		}
+	}
+}
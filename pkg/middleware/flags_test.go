@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vovanwin/api-my-site/config"
+	"github.com/vovanwin/api-my-site/ent"
+	"github.com/vovanwin/api-my-site/ent/user"
+	"github.com/vovanwin/api-my-site/pkg/context"
+	"github.com/vovanwin/api-my-site/pkg/services"
+	"github.com/vovanwin/api-my-site/pkg/tests"
+)
+
+func TestRequireFlag(t *testing.T) {
+	cfg, err := config.GetConfig()
+	require.NoError(t, err)
+
+	flags := services.NewFeatureFlagClient(services.NewMemoryFeatureFlagStore(
+		services.FeatureFlag{Key: "beta", Enabled: true, UserIDs: []int{1}},
+	), services.NewSettingsClient(&cfg))
+
+	ctx, _ := tests.NewContext(echo.New(), "/")
+	err = tests.ExecuteMiddleware(ctx, RequireFlag(flags, "beta"))
+	tests.AssertHTTPErrorCode(t, err, http.StatusNotFound)
+
+	ctx.Set(context.AuthenticatedUserKey, &ent.User{ID: 1, Role: user.RoleUser})
+	assert.NoError(t, tests.ExecuteMiddleware(ctx, RequireFlag(flags, "beta")))
+
+	err = tests.ExecuteMiddleware(ctx, RequireFlag(flags, "missing"))
+	tests.AssertHTTPErrorCode(t, err, http.StatusNotFound)
+}
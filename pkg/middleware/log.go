@@ -1,19 +1,39 @@
 package middleware
 
 import (
-	"fmt"
-
 	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/vovanwin/api-my-site/ent"
+	"github.com/vovanwin/api-my-site/pkg/context"
+	"github.com/vovanwin/api-my-site/pkg/logging"
 )
 
-// LogRequestID includes the request ID in all logs for the given request
-// This requires that middleware that includes the request ID first execute
-func LogRequestID() echo.MiddlewareFunc {
+// Logger сохраняет в контексте запроса журнал, добавляющий к каждой записи идентификатор запроса, имя маршрута,
+// идентификатор пользователя и идентификатор трассировки. Записи делаются через logging.Infof(ctx, ...) и подобные
+// Должен выполняться после middleware, определяющих идентификатор запроса и аутентифицированного пользователя
+func Logger() echo.MiddlewareFunc {
+	route := routeNames()
+
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			rID := c.Response().Header().Get(echo.HeaderXRequestID)
-			format := `{"time":"${time_rfc3339_nano}","id":"%s","level":"${level}","prefix":"${prefix}","file":"${short_file}","line":"${line}"}`
-			c.Logger().SetHeader(fmt.Sprintf(format, rID))
+			req := c.Request()
+			fields := logrus.Fields{
+				"route": route(c),
+			}
+
+			if id, ok := c.Get(context.RequestIDKey).(string); ok {
+				fields["request_id"] = id
+			}
+			if u, ok := c.Get(context.AuthenticatedUserKey).(*ent.User); ok {
+				fields["user_id"] = u.ID
+			}
+			if sc := trace.SpanContextFromContext(req.Context()); sc.IsValid() {
+				fields["trace_id"] = sc.TraceID().String()
+			}
+
+			c.SetRequest(req.WithContext(logging.ContextWithFields(req.Context(), fields)))
 			return next(c)
 		}
 	}
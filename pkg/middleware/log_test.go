@@ -1,28 +1,28 @@
 package middleware
 
 import (
-	"bytes"
-	"fmt"
-	"github.com/sirupsen/logrus"
 	"testing"
 
-	"github.com/vovanwin/api-my-site/pkg/tests"
-
 	"github.com/labstack/echo/v4"
-
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
-	echomw "github.com/labstack/echo/v4/middleware"
+	"github.com/vovanwin/api-my-site/ent"
+	"github.com/vovanwin/api-my-site/pkg/context"
+	"github.com/vovanwin/api-my-site/pkg/logging"
+	"github.com/vovanwin/api-my-site/pkg/tests"
 )
 
-func TestLogRequestID(t *testing.T) {
-	ctx, _ := tests.NewContext(c.Web, "/")
-	_ = tests.ExecuteMiddleware(ctx, echomw.RequestID())
-	_ = tests.ExecuteMiddleware(ctx, LogRequestID())
+func TestLogger(t *testing.T) {
+	ctx, _ := tests.NewContext(echo.New(), "/")
+	ctx.Set(context.RequestIDKey, "request-id")
+	ctx.Set(context.AuthenticatedUserKey, &ent.User{ID: 7})
+	require.NoError(t, tests.ExecuteMiddleware(ctx, Logger()))
 
-	var buf bytes.Buffer
-	logrus.SetOutput(&buf)
-	logrus.Info("test")
-	rID := ctx.Response().Header().Get(echo.HeaderXRequestID)
-	assert.Contains(t, buf.String(), fmt.Sprintf(`id":"%s"`, rID))
+	fields := logging.WithField(ctx.Request().Context(), "test", true).Data
+	assert.Equal(t, "request-id", fields["request_id"])
+	assert.Equal(t, unmatchedRoute, fields["route"])
+	assert.Equal(t, 7, fields["user_id"])
+	assert.Equal(t, true, fields["test"])
+	assert.NotContains(t, fields, "trace_id")
 }
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/vovanwin/api-my-site/pkg/problem"
+	"github.com/vovanwin/api-my-site/pkg/services"
+)
+
+// Metrics записывает в метрики количество и длительность обработки запросов по имени маршрута, методу и статусу
+// Статус ошибки определяется так же, как обработчиком ошибок, поэтому должен выполняться раньше остального
+// промежуточного ПО группы
+func Metrics(m *services.MetricsClient) echo.MiddlewareFunc {
+	route := routeNames()
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			err := next(c)
+
+			status := c.Response().Status
+			if err != nil {
+				status = problem.FromError(err).Status
+			}
+
+			m.ObserveRequest(route(c), c.Request().Method, status, time.Since(start))
+			return err
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vovanwin/api-my-site/pkg/problem"
+	"github.com/vovanwin/api-my-site/pkg/services"
+)
+
+func TestMetrics(t *testing.T) {
+	m := services.NewMetricsClient()
+	e := echo.New()
+	g := e.Group("/api", Metrics(m))
+	g.GET("/ok", func(ctx echo.Context) error {
+		return ctx.NoContent(http.StatusOK)
+	}).Name = "ok"
+	g.GET("/fail", func(ctx echo.Context) error {
+		return problem.New(http.StatusConflict, problem.CodeConflict, "")
+	}).Name = "fail"
+
+	for _, path := range []string{"/api/ok", "/api/ok", "/api/fail", "/api/missing"} {
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	expected := `
+		# HELP app_http_requests_total The number of handled HTTP requests by route name, method and status.
+		# TYPE app_http_requests_total counter
+		app_http_requests_total{method="GET",route="fail",status="409"} 1
+		app_http_requests_total{method="GET",route="ok",status="200"} 2
+		app_http_requests_total{method="GET",route="unmatched",status="404"} 1
+	`
+	assert.NoError(t, testutil.GatherAndCompare(m.Registry, strings.NewReader(expected), "app_http_requests_total"))
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/vovanwin/api-my-site/config"
+	"github.com/vovanwin/api-my-site/ent"
 	"github.com/vovanwin/api-my-site/pkg/services"
 	"github.com/vovanwin/api-my-site/pkg/tests"
 )
@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	echomw "github.com/labstack/echo/v4/middleware"
+	"golang.org/x/time/rate"
+
+	"github.com/vovanwin/api-my-site/pkg/i18n"
+	"github.com/vovanwin/api-my-site/pkg/problem"
+)
+
+// RateLimitPolicy описывает допустимое количество запросов за период
+type RateLimitPolicy struct {
+	Requests int
+	Window   time.Duration
+}
+
+// RateLimit ограничивает количество запросов с одного IP адреса политикой, которую возвращает функция
+// Политика читается при каждом запросе, поэтому её можно изменить без перезапуска приложения
+// Состояние хранится в памяти процесса, поэтому ограничение действует для каждого экземпляра приложения отдельно
+func RateLimit(policy func() RateLimitPolicy) echo.MiddlewareFunc {
+	return echomw.RateLimiterWithConfig(echomw.RateLimiterConfig{
+		Store: &rateLimitStore{policy: policy},
+		IdentifierExtractor: func(c echo.Context) (string, error) {
+			return c.RealIP(), nil
+		},
+		ErrorHandler: func(c echo.Context, err error) error {
+			return echo.NewHTTPError(http.StatusForbidden)
+		},
+		DenyHandler: func(c echo.Context, identifier string, err error) error {
+			return problem.New(
+				http.StatusTooManyRequests,
+				problem.CodeTooManyRequests,
+				i18n.Ctx(c, "error.too_many_requests"),
+			)
+		},
+	})
+}
+
+// rateLimitStore учитывает запросы по текущей политике
+// При изменении политики учёт начинается заново
+type rateLimitStore struct {
+	policy func() RateLimitPolicy
+
+	mu     sync.Mutex
+	active RateLimitPolicy
+	store  echomw.RateLimiterStore
+}
+
+// Allow сообщает, разрешён ли запрос с данным идентификатором
+func (s *rateLimitStore) Allow(identifier string) (bool, error) {
+	s.mu.Lock()
+	if p := s.policy(); s.store == nil || p != s.active {
+		s.active = p
+		s.store = echomw.NewRateLimiterMemoryStoreWithConfig(echomw.RateLimiterMemoryStoreConfig{
+			Rate:      rate.Limit(float64(p.Requests) / p.Window.Seconds()),
+			Burst:     p.Requests,
+			ExpiresIn: p.Window,
+		})
+	}
+	store := s.store
+	s.mu.Unlock()
+
+	return store.Allow(identifier)
+}
@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vovanwin/api-my-site/pkg/problem"
+	"github.com/vovanwin/api-my-site/pkg/tests"
+)
+
+func TestRateLimit(t *testing.T) {
+	policy := RateLimitPolicy{Requests: 1, Window: time.Hour}
+	mw := RateLimit(func() RateLimitPolicy {
+		return policy
+	})
+
+	// The middleware passes denials to the error handler
+	var denied error
+	e := echo.New()
+	e.HTTPErrorHandler = func(err error, c echo.Context) {
+		denied = err
+	}
+
+	allowed := func() bool {
+		denied = nil
+		ctx, _ := tests.NewContext(e, "/")
+		require.NoError(t, tests.ExecuteMiddleware(ctx, mw))
+		return denied == nil
+	}
+
+	assert.True(t, allowed())
+	assert.False(t, allowed())
+	assert.Equal(t, http.StatusTooManyRequests, problem.FromError(denied).Status)
+
+	// A new policy starts counting over
+	policy.Requests = 2
+	assert.True(t, allowed())
+	assert.True(t, allowed())
+	assert.False(t, allowed())
+}
+
+func TestRateLimit_SpoofedForwardedFor(t *testing.T) {
+	mw := RateLimit(func() RateLimitPolicy {
+		return RateLimitPolicy{Requests: 1, Window: time.Hour}
+	})
+
+	var denied error
+	e := echo.New()
+	e.IPExtractor = c.Web.IPExtractor
+	e.HTTPErrorHandler = func(err error, c echo.Context) {
+		denied = err
+	}
+
+	allowed := func(forwardedFor string) bool {
+		denied = nil
+		ctx, _ := tests.NewContext(e, "/")
+		ctx.Request().RemoteAddr = "192.0.2.1:1234"
+		ctx.Request().Header.Set(echo.HeaderXForwardedFor, forwardedFor)
+		ctx.Request().Header.Set(echo.HeaderXRealIP, forwardedFor)
+		require.NoError(t, tests.ExecuteMiddleware(ctx, mw))
+		return denied == nil
+	}
+
+	// A fresh forwarded address on every request doesn't reset the limit
+	assert.True(t, allowed("203.0.113.1"))
+	assert.False(t, allowed("203.0.113.2"))
+	assert.False(t, allowed("203.0.113.3"))
+}
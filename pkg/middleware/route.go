@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"reflect"
+	"runtime"
+	"sync"
+
+	"github.com/labstack/echo/v4"
+)
+
+// unmatchedRoute заменяет имя маршрута запросов, которым не соответствует ни один именованный маршрут,
+// чтобы произвольные пути не попадали в метрики и трассировки
+const unmatchedRoute = "unmatched"
+
+// routeNames возвращает функцию, определяющую имя маршрута, которому соответствует запрос
+func routeNames() func(c echo.Context) string {
+	var once sync.Once
+	var names map[string]string
+
+	return func(c echo.Context) string {
+		// Маршруты регистрируются до обработки первого запроса.
+		// Маршруты, которые echo добавляет группам для ответа 404, названы по имени обработчика и пропускаются
+		once.Do(func() {
+			notFound := runtime.FuncForPC(reflect.ValueOf(echo.NotFoundHandler).Pointer()).Name()
+			names = make(map[string]string)
+			for _, r := range c.Echo().Routes() {
+				if r.Name != notFound {
+					names[r.Method+" "+r.Path] = r.Name
+				}
+			}
+		})
+
+		if name, ok := names[c.Request().Method+" "+c.Path()]; ok && name != "" {
+			return name
+		}
+		return unmatchedRoute
+	}
+}
@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/vovanwin/api-my-site/pkg/context"
+	"github.com/vovanwin/api-my-site/pkg/logging"
+	"github.com/vovanwin/api-my-site/pkg/problem"
+	"github.com/vovanwin/api-my-site/pkg/services"
+)
+
+// Tracing начинает трассировку запроса, продолжая трассировку клиента из заголовка traceparent, если он передан
+// Трассировка передается дальше через контекст запроса, а идентификатор трассировки возвращается в заголовке traceparent
+func Tracing(t *services.TracingClient) echo.MiddlewareFunc {
+	route := routeNames()
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			propagator := otel.GetTextMapPropagator()
+			ctx := propagator.Extract(req.Context(), propagation.HeaderCarrier(req.Header))
+
+			name := route(c)
+			ctx, span := t.Tracer().Start(ctx, req.Method+" "+name,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					semconv.HTTPMethod(req.Method),
+					semconv.HTTPRoute(name),
+					attribute.String("http.target", logging.Redact(req.RequestURI)),
+					attribute.String("http.client_ip", c.RealIP()),
+					attribute.String("http.user_agent", req.UserAgent()),
+				),
+			)
+			defer span.End()
+
+			c.SetRequest(req.WithContext(ctx))
+			propagator.Inject(ctx, propagation.HeaderCarrier(c.Response().Header()))
+
+			err := next(c)
+
+			status := c.Response().Status
+			if err != nil {
+				status = problem.FromError(err).Status
+				span.RecordError(err)
+			}
+			span.SetAttributes(semconv.HTTPStatusCode(status))
+			if status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(status))
+			}
+
+			if id, ok := c.Get(context.RequestIDKey).(string); ok {
+				span.SetAttributes(attribute.String("http.request_id", id))
+			}
+
+			return err
+		}
+	}
+}
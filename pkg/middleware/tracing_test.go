@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/vovanwin/api-my-site/config"
+	"github.com/vovanwin/api-my-site/pkg/problem"
+	"github.com/vovanwin/api-my-site/pkg/services"
+)
+
+func TestTracing(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.App.Environment = config.EnvTest
+	client, err := services.NewTracingClient(cfg)
+	require.NoError(t, err)
+
+	var handled trace.SpanContext
+	e := echo.New()
+	g := e.Group("/api", Tracing(client))
+	g.GET("/fail", func(ctx echo.Context) error {
+		handled = trace.SpanContextFromContext(ctx.Request().Context())
+		return problem.New(http.StatusConflict, problem.CodeConflict, "")
+	}).Name = "fail"
+
+	traceparent := "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"
+	req := httptest.NewRequest(http.MethodGet, "/api/fail", nil)
+	req.Header.Set("traceparent", traceparent)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, "0af7651916cd43dd8448eb211c80319c", handled.TraceID().String())
+	assert.Contains(t, rec.Header().Get("traceparent"), handled.SpanID().String())
+
+	spans := client.Recorded.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "GET fail", spans[0].Name)
+	assert.Equal(t, "b7ad6b7169203331", spans[0].Parent.SpanID().String())
+	assert.Contains(t, spans[0].Attributes, semconv.HTTPStatusCode(http.StatusConflict))
+}
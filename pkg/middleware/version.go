@@ -0,0 +1,176 @@
+package middleware
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+
+	"github.com/vovanwin/api-my-site/pkg/context"
+	"github.com/vovanwin/api-my-site/pkg/i18n"
+	"github.com/vovanwin/api-my-site/pkg/logging"
+	"github.com/vovanwin/api-my-site/pkg/problem"
+)
+
+// versionSegment соответствует сегменту пути с версией API, например v2
+var versionSegment = regexp.MustCompile(`^v\d+$`)
+
+// Deprecation описывает вывод версии маршрута из эксплуатации
+type Deprecation struct {
+	// Version содержит устаревшую версию API
+	Version string
+
+	// Date содержит дату, с которой версия считается устаревшей
+	Date time.Time
+
+	// Sunset содержит дату, после которой версия перестанет отвечать, если она известна
+	Sunset time.Time
+
+	// Link содержит адрес описания перехода на новую версию, если оно есть
+	Link string
+}
+
+// APIVersion определяет версию API запросов с заданным префиксом пути и сохраняет ее в контексте.
+// Версия указывается сегментом пути после префикса (/api/v2/pages), который удаляется, чтобы все версии
+// обслуживались одними маршрутами, либо типом application/<vendor>.v2+json в заголовке Accept.
+// Без указания версии используется первая из поддерживаемых, чтобы существующие клиенты не сломались.
+// Middleware должно быть подключено через Echo.Pre, так как изменяет путь до выбора маршрута
+func APIVersion(prefix, vendor string, versions ...string) echo.MiddlewareFunc {
+	supported := make(map[string]struct{}, len(versions))
+	for _, v := range versions {
+		supported[v] = struct{}{}
+	}
+	prefix = strings.TrimSuffix(prefix, "/")
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			if !strings.HasPrefix(req.URL.Path, prefix+"/") {
+				return next(c)
+			}
+
+			version := versions[0]
+			segment, _, _ := strings.Cut(strings.TrimPrefix(req.URL.Path, prefix+"/"), "/")
+			if versionSegment.MatchString(segment) {
+				if _, ok := supported[segment]; !ok {
+					return unsupportedVersion(c, http.StatusNotFound, segment)
+				}
+				version = segment
+				req.URL.Path = withoutSegment(req.URL.Path, prefix, segment)
+				if req.URL.RawPath != "" {
+					req.URL.RawPath = withoutSegment(req.URL.RawPath, prefix, segment)
+				}
+			} else {
+				c.Response().Header().Add(echo.HeaderVary, "Accept")
+				if v := acceptedVersion(req.Header.Get(echo.HeaderAccept), vendor); v != "" {
+					if _, ok := supported[v]; !ok {
+						return unsupportedVersion(c, http.StatusNotAcceptable, v)
+					}
+					version = v
+				}
+			}
+
+			c.Set(context.APIVersionKey, version)
+			c.Response().Header().Set("API-Version", version)
+			return next(c)
+		}
+	}
+}
+
+// Versioned оборачивает обработчик маршрута адаптером, соответствующим версии API запроса,
+// что позволяет разным версиям использовать общий обработчик. Запросы других версий обрабатываются без изменений
+func Versioned(adapters map[string]echo.MiddlewareFunc) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		wrapped := make(map[string]echo.HandlerFunc, len(adapters))
+		for v, adapter := range adapters {
+			wrapped[v] = adapter(next)
+		}
+
+		return func(c echo.Context) error {
+			if h, ok := wrapped[APIVersionOf(c)]; ok {
+				return h(c)
+			}
+			return next(c)
+		}
+	}
+}
+
+// Deprecated отмечает маршрут устаревшим в заданных версиях API.
+// Ответы на запросы этих версий получают заголовки Deprecation, Sunset и Link, а каждый такой запрос
+// записывается в журнал, чтобы было видно, какие клиенты еще не перешли на новую версию
+func Deprecated(deprecations ...Deprecation) echo.MiddlewareFunc {
+	byVersion := make(map[string]Deprecation, len(deprecations))
+	for _, d := range deprecations {
+		byVersion[d.Version] = d
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			d, ok := byVersion[APIVersionOf(c)]
+			if !ok {
+				return next(c)
+			}
+
+			h := c.Response().Header()
+			h.Set("Deprecation", fmt.Sprintf("@%d", d.Date.Unix()))
+			if !d.Sunset.IsZero() {
+				h.Set("Sunset", d.Sunset.UTC().Format(http.TimeFormat))
+			}
+			if d.Link != "" {
+				h.Add("Link", fmt.Sprintf(`<%s>; rel="deprecation"`, d.Link))
+			}
+
+			logging.WithFields(c.Request().Context(), logrus.Fields{
+				"version":    d.Version,
+				"method":     c.Request().Method,
+				"route":      c.Path(),
+				"sunset":     d.Sunset,
+				"user_agent": c.Request().UserAgent(),
+				"ip":         c.RealIP(),
+			}).Warn("запрос к устаревшей версии API")
+
+			return next(c)
+		}
+	}
+}
+
+// APIVersionOf возвращает версию API запроса, определенную middleware APIVersion
+func APIVersionOf(c echo.Context) string {
+	v, _ := c.Get(context.APIVersionKey).(string)
+	return v
+}
+
+// acceptedVersion возвращает версию API из типа application/<vendor>.<version>+json заголовка Accept
+func acceptedVersion(accept, vendor string) string {
+	for _, part := range strings.Split(accept, ",") {
+		mt, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		if v := strings.TrimPrefix(mt, "application/"+vendor+"."); v != mt {
+			return strings.TrimSuffix(v, "+json")
+		}
+	}
+	return ""
+}
+
+// withoutSegment удаляет сегмент с версией, следующий за префиксом пути
+func withoutSegment(path, prefix, segment string) string {
+	return prefix + strings.TrimPrefix(path, prefix+"/"+segment)
+}
+
+// unsupportedVersion возвращает ошибку запроса неподдерживаемой версии API
+// Язык определяется здесь же, так как middleware выполняется до определения языка запроса
+func unsupportedVersion(c echo.Context, status int, version string) error {
+	locale := i18n.Negotiate(c.Request().Header.Get("Accept-Language"))
+	return problem.New(
+		status,
+		problem.CodeUnsupportedVersion,
+		i18n.T(locale, "request.unsupported_version", i18n.Params{"version": version}),
+	)
+}
@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vovanwin/api-my-site/pkg/context"
+	"github.com/vovanwin/api-my-site/pkg/problem"
+	"github.com/vovanwin/api-my-site/pkg/tests"
+)
+
+func TestAPIVersion(t *testing.T) {
+	mw := APIVersion("/api", "vnd.test", "v1", "v2")
+
+	cases := []struct {
+		url, accept, path, version string
+	}{
+		{"/api/pages", "", "/api/pages", "v1"},
+		{"/api/v2/pages/about", "", "/api/pages/about", "v2"},
+		{"/api/v1", "", "/api", "v1"},
+		{"/api/pages", "application/vnd.test.v2+json", "/api/pages", "v2"},
+		{"/api/pages", "text/html, application/vnd.test.v2+json;q=0.9", "/api/pages", "v2"},
+		{"/api/v1/pages", "application/vnd.test.v2+json", "/api/pages", "v1"},
+		{"/api/pages", "application/json", "/api/pages", "v1"},
+		{"/feed.xml", "", "/feed.xml", ""},
+	}
+
+	for _, tc := range cases {
+		ctx, rec := tests.NewContext(echo.New(), tc.url)
+		ctx.Request().Header.Set(echo.HeaderAccept, tc.accept)
+
+		require.NoError(t, tests.ExecuteMiddleware(ctx, mw), tc.url)
+		assert.Equal(t, tc.path, ctx.Request().URL.Path, tc.url)
+		assert.Equal(t, tc.version, APIVersionOf(ctx), tc.url)
+		assert.Equal(t, tc.version, rec.Header().Get("API-Version"), tc.url)
+	}
+}
+
+func TestAPIVersion_Unsupported(t *testing.T) {
+	mw := APIVersion("/api", "vnd.test", "v1")
+
+	ctx, _ := tests.NewContext(echo.New(), "/api/v3/pages")
+	err := tests.ExecuteMiddleware(ctx, mw)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, problem.FromError(err).Status)
+	assert.Equal(t, problem.CodeUnsupportedVersion, problem.FromError(err).Code)
+	assert.Contains(t, problem.FromError(err).Detail, "v3")
+
+	ctx, _ = tests.NewContext(echo.New(), "/api/pages")
+	ctx.Request().Header.Set(echo.HeaderAccept, "application/vnd.test.v3+json")
+	err = tests.ExecuteMiddleware(ctx, mw)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusNotAcceptable, problem.FromError(err).Status)
+}
+
+func TestVersioned(t *testing.T) {
+	adapter := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set("adapted", true)
+			return next(c)
+		}
+	}
+	mw := Versioned(map[string]echo.MiddlewareFunc{"v2": adapter})
+
+	for version, adapted := range map[string]bool{"v1": false, "v2": true} {
+		ctx, _ := tests.NewContext(echo.New(), "/")
+		ctx.Set(context.APIVersionKey, version)
+		require.NoError(t, tests.ExecuteMiddleware(ctx, mw))
+		assert.Equal(t, adapted, ctx.Get("adapted") != nil, version)
+	}
+}
+
+func TestDeprecated(t *testing.T) {
+	d := Deprecation{
+		Version: "v1",
+		Date:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Sunset:  time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+		Link:    "https://example.com/migration",
+	}
+	mw := Deprecated(d)
+
+	ctx, rec := tests.NewContext(echo.New(), "/")
+	ctx.Set(context.APIVersionKey, "v1")
+	require.NoError(t, tests.ExecuteMiddleware(ctx, mw))
+	assert.Equal(t, "@1704067200", rec.Header().Get("Deprecation"))
+	assert.Equal(t, "Mon, 01 Jul 2024 00:00:00 GMT", rec.Header().Get("Sunset"))
+	assert.Equal(t, `<https://example.com/migration>; rel="deprecation"`, rec.Header().Get("Link"))
+
+	ctx, rec = tests.NewContext(echo.New(), "/")
+	ctx.Set(context.APIVersionKey, "v2")
+	require.NoError(t, tests.ExecuteMiddleware(ctx, mw))
+	assert.Empty(t, rec.Header().Get("Deprecation"))
+	assert.Empty(t, rec.Header().Get("Sunset"))
+}
@@ -0,0 +1,324 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/vovanwin/api-my-site/pkg/problem"
+)
+
+// Version stores the version of the OpenAPI specification the documents conform to
+const Version = "3.1.0"
+
+const (
+	// mimeJSON stores the content type of JSON bodies
+	mimeJSON = echo.MIMEApplicationJSON
+
+	// securityScheme stores the name of the bearer token security scheme
+	securityScheme = "bearerAuth"
+)
+
+var (
+	// pathParam matches the parameters of echo route paths
+	pathParam = regexp.MustCompile(`:(\w+)`)
+
+	// notFound stores the route name echo gives to the catch-all routes groups register for their middleware
+	notFound = runtime.FuncForPC(reflect.ValueOf(echo.NotFoundHandler).Pointer()).Name()
+)
+
+type (
+	// Document is an OpenAPI document
+	Document struct {
+		OpenAPI    string              `json:"openapi"`
+		Info       Info                `json:"info"`
+		Paths      map[string]PathItem `json:"paths"`
+		Components Components          `json:"components"`
+		Tags       []map[string]string `json:"tags,omitempty"`
+	}
+
+	// Info stores the metadata of the API
+	Info struct {
+		Title       string `json:"title"`
+		Version     string `json:"version"`
+		Description string `json:"description,omitempty"`
+	}
+
+	// PathItem stores the operations of a path keyed by the lowercased HTTP method
+	PathItem map[string]*operationObject
+
+	// Components stores the reusable schemas and security schemes
+	Components struct {
+		Schemas         map[string]*Schema        `json:"schemas"`
+		SecuritySchemes map[string]securityObject `json:"securitySchemes"`
+	}
+
+	// Parameter describes a path or query parameter
+	Parameter struct {
+		Name        string  `json:"name"`
+		In          string  `json:"in"`
+		Description string  `json:"description,omitempty"`
+		Required    bool    `json:"required,omitempty"`
+		Schema      *Schema `json:"schema"`
+
+		// value stores a value of the type of the parameter
+		value interface{}
+	}
+
+	// Operation describes a route
+	Operation struct {
+		// Summary stores a short summary of what the route does
+		Summary string
+
+		// Description stores a longer explanation of the route
+		Description string
+
+		// Tags stores the tags grouping the route
+		Tags []string
+
+		// Auth indicates that the route requires a bearer token
+		Auth bool
+
+		// Params describes the path and query parameters
+		// Path parameters which aren't described are documented as strings
+		Params []Parameter
+
+		// Request stores a value of the type of the request body
+		Request interface{}
+
+		// Consumes stores the content types of the request body, which default to JSON and forms
+		Consumes []string
+
+		// Responses stores values of the types of the successful responses keyed by the status code,
+		// with nil values for responses without a body
+		Responses map[int]interface{}
+
+		// Produces stores the content type of the successful responses, which defaults to JSON
+		Produces string
+
+		// Errors stores the status codes of the problem responses besides those implied by the operation
+		Errors []int
+	}
+
+	// File is used as the type of uploaded or downloaded files
+	File struct{}
+
+	// Rule modifies the schema of a field according to a validation tag and its parameter
+	Rule func(s *Schema, param string)
+
+	operationObject struct {
+		OperationID string                    `json:"operationId"`
+		Summary     string                    `json:"summary,omitempty"`
+		Description string                    `json:"description,omitempty"`
+		Tags        []string                  `json:"tags,omitempty"`
+		Parameters  []Parameter               `json:"parameters,omitempty"`
+		RequestBody *requestBodyObject        `json:"requestBody,omitempty"`
+		Responses   map[string]responseObject `json:"responses"`
+		Security    []map[string][]string     `json:"security,omitempty"`
+	}
+
+	requestBodyObject struct {
+		Required bool                   `json:"required"`
+		Content  map[string]mediaObject `json:"content"`
+	}
+
+	responseObject struct {
+		Description string                 `json:"description"`
+		Content     map[string]mediaObject `json:"content,omitempty"`
+	}
+
+	mediaObject struct {
+		Schema *Schema `json:"schema"`
+	}
+
+	securityObject struct {
+		Type         string `json:"type"`
+		Scheme       string `json:"scheme"`
+		BearerFormat string `json:"bearerFormat,omitempty"`
+	}
+)
+
+// PathParam describes a path parameter of the type of a given value
+func PathParam(name, description string, value interface{}) Parameter {
+	return Parameter{Name: name, In: "path", Description: description, Required: true, value: value}
+}
+
+// QueryParam describes an optional query parameter of the type of a given value
+func QueryParam(name, description string, value interface{}) Parameter {
+	return Parameter{Name: name, In: "query", Description: description, value: value}
+}
+
+// Builder builds the document of the documented routes
+type Builder struct {
+	info       Info
+	operations map[string]Operation
+	gen        *generator
+}
+
+// NewBuilder creates a new Builder
+func NewBuilder(info Info) *Builder {
+	return &Builder{
+		info:       info,
+		operations: make(map[string]Operation),
+		gen:        newGenerator(),
+	}
+}
+
+// Rule registers how a custom validation tag is reflected in the schemas
+func (b *Builder) Rule(tag string, rule Rule) *Builder {
+	b.gen.rules[tag] = rule
+	return b
+}
+
+// Operation documents the route with a given name
+func (b *Builder) Operation(name string, op Operation) *Builder {
+	b.operations[name] = op
+	return b
+}
+
+// Build builds the document of the routes whose path starts with a given prefix
+// An error is returned if any of the routes isn't documented, so the document can't silently fall behind
+func (b *Builder) Build(routes []*echo.Route, prefix string) (*Document, error) {
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    b.info,
+		Paths:   make(map[string]PathItem),
+		Components: Components{
+			Schemas: b.gen.schemas,
+			SecuritySchemes: map[string]securityObject{
+				securityScheme: {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+			},
+		},
+	}
+
+	var missing []string
+	tags := make(map[string]struct{})
+	for _, r := range routes {
+		if !Documented(r, prefix) {
+			continue
+		}
+
+		op, ok := b.operations[r.Name]
+		if !ok {
+			missing = append(missing, fmt.Sprintf("%s %s (%s)", r.Method, r.Path, r.Name))
+			continue
+		}
+
+		path := pathParam.ReplaceAllString(r.Path, "{$1}")
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = make(PathItem)
+		}
+		doc.Paths[path][strings.ToLower(r.Method)] = b.operation(r, op)
+
+		for _, t := range op.Tags {
+			tags[t] = struct{}{}
+		}
+	}
+
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("routes missing from the specification: %s", strings.Join(missing, ", "))
+	}
+
+	for t := range tags {
+		doc.Tags = append(doc.Tags, map[string]string{"name": t})
+	}
+	sort.Slice(doc.Tags, func(i, j int) bool {
+		return doc.Tags[i]["name"] < doc.Tags[j]["name"]
+	})
+
+	return doc, nil
+}
+
+// Documented returns whether a route whose path starts with a given prefix belongs in the document,
+// which excludes the routes echo registers to answer unmatched requests
+func Documented(r *echo.Route, prefix string) bool {
+	return strings.HasPrefix(r.Path, prefix) && r.Method != echo.RouteNotFound && r.Name != notFound
+}
+
+// operation builds the operation object of a route
+func (b *Builder) operation(r *echo.Route, op Operation) *operationObject {
+	o := &operationObject{
+		OperationID: r.Name,
+		Summary:     op.Summary,
+		Description: op.Description,
+		Tags:        op.Tags,
+		Responses:   make(map[string]responseObject),
+	}
+
+	// Parameters
+	described := make(map[string]struct{})
+	for _, p := range op.Params {
+		if p.Schema == nil {
+			p.Schema = b.gen.schema(reflect.TypeOf(p.value))
+		}
+		described[p.Name] = struct{}{}
+		o.Parameters = append(o.Parameters, p)
+	}
+	for _, m := range pathParam.FindAllStringSubmatch(r.Path, -1) {
+		if _, ok := described[m[1]]; !ok {
+			o.Parameters = append(o.Parameters, Parameter{
+				Name:     m[1],
+				In:       "path",
+				Required: true,
+				Schema:   &Schema{Type: SchemaType{"string"}},
+			})
+		}
+	}
+
+	errs := append([]int(nil), op.Errors...)
+
+	// Request body
+	if op.Request != nil {
+		consumes := op.Consumes
+		if len(consumes) == 0 {
+			consumes = []string{mimeJSON, echo.MIMEApplicationForm}
+		}
+		schema := b.gen.schema(reflect.TypeOf(op.Request))
+		o.RequestBody = &requestBodyObject{Required: true, Content: make(map[string]mediaObject)}
+		for _, ct := range consumes {
+			o.RequestBody.Content[ct] = mediaObject{Schema: schema}
+		}
+		errs = append(errs, http.StatusBadRequest, http.StatusUnprocessableEntity)
+	}
+
+	// Security
+	if op.Auth {
+		o.Security = []map[string][]string{{securityScheme: {}}}
+		errs = append(errs, http.StatusUnauthorized)
+	}
+
+	// Responses
+	produces := op.Produces
+	if produces == "" {
+		produces = mimeJSON
+	}
+	for status, v := range op.Responses {
+		res := responseObject{Description: http.StatusText(status)}
+		if v != nil {
+			res.Content = map[string]mediaObject{
+				produces: {Schema: b.gen.schema(reflect.TypeOf(v))},
+			}
+		}
+		o.Responses[strconv.Itoa(status)] = res
+	}
+
+	problemSchema := b.gen.schema(reflect.TypeOf(problem.Problem{}))
+	for _, status := range errs {
+		o.Responses[strconv.Itoa(status)] = responseObject{
+			Description: http.StatusText(status),
+			Content: map[string]mediaObject{
+				problem.ContentType: {Schema: problemSchema},
+			},
+		}
+	}
+
+	return o
+}
@@ -0,0 +1,158 @@
+package openapi
+
+import (
+	"encoding/json"
+	"io/fs"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	testForm struct {
+		Email    string   `json:"email" validate:"required,email"`
+		Name     string   `json:"name" validate:"omitempty,max=100"`
+		Age      int      `json:"age" validate:"min=18"`
+		Tags     []string `json:"tags" validate:"max=5,dive,required"`
+		Kind     string   `json:"kind" validate:"oneof=a b"`
+		Slug     string   `json:"slug" validate:"required,slug"`
+		Internal string   `json:"-"`
+		internal string
+	}
+
+	testPage[T any] struct {
+		Data []T `json:"data"`
+	}
+
+	testResponse struct {
+		ID        int64         `json:"id"`
+		ParentID  *int          `json:"parent_id"`
+		Parent    *testResponse `json:"parent"`
+		CreatedAt time.Time     `json:"created_at"`
+	}
+)
+
+func testRoutes() []*echo.Route {
+	e := echo.New()
+	h := func(echo.Context) error { return nil }
+	e.POST("/api/items", h).Name = "items.create"
+	e.GET("/api/items/:item", h).Name = "items.get"
+	e.GET("/api/items", h).Name = "items.list"
+	e.GET("/feed.xml", h).Name = "feed"
+	e.Group("/api/admin", func(next echo.HandlerFunc) echo.HandlerFunc { return next })
+	return e.Routes()
+}
+
+func testBuilder() *Builder {
+	return NewBuilder(Info{Title: "Test", Version: "1"}).
+		Rule("slug", func(s *Schema, _ string) { s.Pattern = "^[a-z]+$" }).
+		Operation("items.create", Operation{
+			Auth:      true,
+			Request:   testForm{},
+			Responses: map[int]interface{}{http.StatusCreated: testResponse{}},
+			Errors:    []int{http.StatusConflict},
+		}).
+		Operation("items.get", Operation{
+			Params:    []Parameter{PathParam("item", "Item", 0)},
+			Responses: map[int]interface{}{http.StatusOK: testResponse{}},
+		}).
+		Operation("items.list", Operation{
+			Responses: map[int]interface{}{http.StatusOK: testPage[testResponse]{}},
+		})
+}
+
+func TestBuilder_Build(t *testing.T) {
+	doc, err := testBuilder().Build(testRoutes(), "/api/")
+	require.NoError(t, err)
+
+	assert.Equal(t, Version, doc.OpenAPI)
+	assert.Len(t, doc.Paths, 2)
+	assert.Len(t, doc.Paths["/api/items"], 2)
+
+	create := doc.Paths["/api/items"]["post"]
+	require.NotNil(t, create)
+	assert.Equal(t, "items.create", create.OperationID)
+	assert.Equal(t, []map[string][]string{{securityScheme: {}}}, create.Security)
+	assert.Contains(t, create.RequestBody.Content, echo.MIMEApplicationJSON)
+	assert.Contains(t, create.RequestBody.Content, echo.MIMEApplicationForm)
+	for _, status := range []string{"201", "400", "401", "409", "422"} {
+		assert.Contains(t, create.Responses, status)
+	}
+	assert.Contains(t, create.Responses["409"].Content, "application/problem+json")
+
+	get := doc.Paths["/api/items/{item}"]["get"]
+	require.NotNil(t, get)
+	require.Len(t, get.Parameters, 1)
+	assert.Equal(t, "path", get.Parameters[0].In)
+	assert.Equal(t, SchemaType{"integer"}, get.Parameters[0].Schema.Type)
+	assert.Nil(t, get.Security)
+}
+
+func TestBuilder_BuildMissing(t *testing.T) {
+	_, err := NewBuilder(Info{}).
+		Operation("items.get", Operation{}).
+		Build(testRoutes(), "/api/")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "items.create")
+	assert.NotContains(t, err.Error(), "items.get")
+	assert.NotContains(t, err.Error(), "feed")
+	assert.NotContains(t, err.Error(), "/api/admin")
+}
+
+func TestBuilder_Schemas(t *testing.T) {
+	doc, err := testBuilder().Build(testRoutes(), "/api/")
+	require.NoError(t, err)
+
+	form := doc.Components.Schemas["TestForm"]
+	require.NotNil(t, form)
+	assert.Equal(t, []string{"email", "slug"}, form.Required)
+	assert.Equal(t, "email", form.Properties["email"].Format)
+	assert.Equal(t, 100, *form.Properties["name"].MaxLength)
+	assert.Equal(t, float64(18), *form.Properties["age"].Minimum)
+	assert.Equal(t, 5, *form.Properties["tags"].MaxItems)
+	assert.Equal(t, []string{"a", "b"}, form.Properties["kind"].Enum)
+	assert.Equal(t, "^[a-z]+$", form.Properties["slug"].Pattern)
+	assert.NotContains(t, form.Properties, "Internal")
+	assert.NotContains(t, form.Properties, "internal")
+
+	res := doc.Components.Schemas["TestResponse"]
+	require.NotNil(t, res)
+	assert.Equal(t, "int64", res.Properties["id"].Format)
+	assert.Equal(t, SchemaType{"integer", "null"}, res.Properties["parent_id"].Type)
+	assert.Equal(t, "#/components/schemas/TestResponse", res.Properties["parent"].AnyOf[0].Ref)
+	assert.Equal(t, "date-time", res.Properties["created_at"].Format)
+
+	assert.Contains(t, doc.Components.Schemas, "Problem")
+	assert.Contains(t, doc.Components.Schemas, "TestPageTestResponse")
+
+	// The document must be encodable despite the recursive schema
+	b, err := json.Marshal(doc)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"type":["integer","null"]`)
+}
+
+func TestSwaggerUI(t *testing.T) {
+	page, err := SwaggerUI("Test", "/api/openapi.json", "/api/docs/")
+	require.NoError(t, err)
+	assert.Contains(t, string(page), `url: "/api/openapi.json"`)
+
+	// The assets are served by the application rather than a CDN
+	assert.Contains(t, string(page), `href="/api/docs/swagger-ui.css"`)
+	assert.Contains(t, string(page), `src="/api/docs/swagger-ui-bundle.js"`)
+	assert.NotContains(t, string(page), "https://")
+}
+
+func TestSwaggerAsset(t *testing.T) {
+	for _, name := range []string{"", "../ui.go", "swagger-ui/README.md", ".", "missing.js"} {
+		_, err := SwaggerAsset(name)
+		assert.ErrorIs(t, err, fs.ErrNotExist, name)
+	}
+
+	_, err := SwaggerAsset("README.md")
+	assert.NoError(t, err)
+}
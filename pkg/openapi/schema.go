@@ -0,0 +1,262 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Schema is a JSON schema describing a value
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 SchemaType         `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	ContentMediaType     string             `json:"contentMediaType,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+}
+
+// SchemaType stores the types allowed by a schema, which is a single type unless the value is nullable
+type SchemaType []string
+
+// MarshalJSON encodes a single type as a string and multiple types as an array
+func (t SchemaType) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+	fileType = reflect.TypeOf(File{})
+)
+
+// generator generates the schemas of Go types, collecting the schemas of named structs as components
+type generator struct {
+	// schemas stores the component schemas keyed by their name
+	schemas map[string]*Schema
+
+	// names stores the component names of the generated struct types
+	names map[reflect.Type]string
+
+	// rules stores how validation tags are reflected in the schemas
+	rules map[string]Rule
+}
+
+// newGenerator creates a new generator with the rules of the built-in validation tags
+func newGenerator() *generator {
+	g := &generator{
+		schemas: make(map[string]*Schema),
+		names:   make(map[reflect.Type]string),
+		rules:   make(map[string]Rule),
+	}
+
+	g.rules["email"] = func(s *Schema, _ string) { s.Format = "email" }
+	g.rules["url"] = func(s *Schema, _ string) { s.Format = "uri" }
+	g.rules["oneof"] = func(s *Schema, param string) { s.Enum = strings.Fields(param) }
+	g.rules["min"] = func(s *Schema, param string) { bound(s, param, true) }
+	g.rules["max"] = func(s *Schema, param string) { bound(s, param, false) }
+	g.rules["len"] = func(s *Schema, param string) {
+		bound(s, param, true)
+		bound(s, param, false)
+	}
+
+	return g
+}
+
+// schema returns the schema of a type
+func (g *generator) schema(t reflect.Type) *Schema {
+	switch {
+	case t == nil:
+		return &Schema{}
+	case t == timeType:
+		return &Schema{Type: SchemaType{"string"}, Format: "date-time"}
+	case t == fileType:
+		return &Schema{Type: SchemaType{"string"}, ContentMediaType: "application/octet-stream"}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		s := g.schema(t.Elem())
+		if s.Ref != "" {
+			return &Schema{AnyOf: []*Schema{s, {Type: SchemaType{"null"}}}}
+		}
+		s.Type = append(s.Type, "null")
+		return s
+	case reflect.Bool:
+		return &Schema{Type: SchemaType{"boolean"}}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: SchemaType{"integer"}}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: SchemaType{"integer"}, Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: SchemaType{"number"}}
+	case reflect.String:
+		return &Schema{Type: SchemaType{"string"}}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: SchemaType{"string"}, Format: "byte"}
+		}
+		return &Schema{Type: SchemaType{"array"}, Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: SchemaType{"object"}, AdditionalProperties: g.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.object(t)
+		}
+		return g.ref(t)
+	}
+
+	// Interfaces and other kinds allow any value
+	return &Schema{}
+}
+
+// ref returns a reference to the component schema of a named struct, generating it if needed
+func (g *generator) ref(t reflect.Type) *Schema {
+	name, ok := g.names[t]
+	if !ok {
+		name = componentName(t)
+		for i := 2; g.schemas[name] != nil; i++ {
+			name = componentName(t) + strconv.Itoa(i)
+		}
+		g.names[t] = name
+
+		// Register the name before generating, so recursive types refer to themselves
+		g.schemas[name] = &Schema{}
+		*g.schemas[name] = *g.object(t)
+	}
+
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// object returns the schema of the fields of a struct
+func (g *generator) object(t reflect.Type) *Schema {
+	s := &Schema{
+		Type:       SchemaType{"object"},
+		Properties: make(map[string]*Schema),
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		// Embedded structs contribute their fields
+		if f.Anonymous && f.Type.Kind() == reflect.Struct && f.Tag.Get("json") == "" {
+			embedded := g.object(f.Type)
+			for name, p := range embedded.Properties {
+				s.Properties[name] = p
+			}
+			s.Required = append(s.Required, embedded.Required...)
+			continue
+		}
+
+		name := strings.SplitN(f.Tag.Get("json"), ",", 2)[0]
+		if !f.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		p := g.schema(f.Type)
+		if g.validate(p, f.Tag.Get("validate")) {
+			s.Required = append(s.Required, name)
+		}
+		s.Properties[name] = p
+	}
+
+	sort.Strings(s.Required)
+	return s
+}
+
+// validate applies the rules of the tags of a validate struct tag to a schema, returning whether the
+// field is required
+// Rules following dive apply to the elements, which isn't reflected in the schema
+func (g *generator) validate(s *Schema, tag string) (required bool) {
+	if tag == "" {
+		return false
+	}
+
+	for _, r := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(r, "=")
+		switch name {
+		case "dive":
+			return required
+		case "required":
+			required = true
+		}
+
+		// Rules of references would modify the component itself
+		if rule, ok := g.rules[name]; ok && s.Ref == "" {
+			rule(s, param)
+		}
+	}
+
+	return required
+}
+
+// bound sets the minimum or maximum of a schema, which depends on the type of the value
+func bound(s *Schema, param string, min bool) {
+	n, err := strconv.Atoi(param)
+	if err != nil || len(s.Type) == 0 {
+		return
+	}
+
+	switch s.Type[0] {
+	case "string":
+		if min {
+			s.MinLength = &n
+		} else {
+			s.MaxLength = &n
+		}
+	case "array", "object":
+		if min {
+			s.MinItems = &n
+		} else {
+			s.MaxItems = &n
+		}
+	case "integer", "number":
+		f := float64(n)
+		if min {
+			s.Minimum = &f
+		} else {
+			s.Maximum = &f
+		}
+	}
+}
+
+// componentName returns the component name of a named type, which is exported
+// The type arguments of generic types are appended without their packages, so Page[pkg.item] is PageItem
+func componentName(t reflect.Type) string {
+	name, args, _ := strings.Cut(strings.TrimSuffix(t.Name(), "]"), "[")
+
+	parts := []string{name}
+	if args != "" {
+		for _, arg := range strings.Split(args, ",") {
+			parts = append(parts, arg[strings.LastIndex(arg, ".")+1:])
+		}
+	}
+
+	for i, part := range parts {
+		r := []rune(part)
+		r[0] = unicode.ToUpper(r[0])
+		parts[i] = string(r)
+	}
+	return strings.Join(parts, "")
+}
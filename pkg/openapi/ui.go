@@ -0,0 +1,47 @@
+package openapi
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"strings"
+)
+
+var (
+	//go:embed swagger.html
+	swaggerHTML string
+
+	// swaggerAssets stores the Swagger UI assets, which are downloaded with make swagger-ui
+	//go:embed swagger-ui
+	swaggerAssets embed.FS
+)
+
+// swaggerTemplate renders the Swagger UI page
+var swaggerTemplate = template.Must(template.New("swagger").Parse(swaggerHTML))
+
+// SwaggerUI renders a Swagger UI page displaying the document served at a given URL
+// The page loads the embedded assets from the given URL, under which SwaggerAsset is served
+func SwaggerUI(title, specURL, assetsURL string) ([]byte, error) {
+	var buf bytes.Buffer
+	err := swaggerTemplate.Execute(&buf, struct {
+		Title     string
+		SpecURL   string
+		AssetsURL string
+	}{
+		Title:     title,
+		SpecURL:   specURL,
+		AssetsURL: strings.TrimSuffix(assetsURL, "/"),
+	})
+	return buf.Bytes(), err
+}
+
+// SwaggerAsset returns the embedded Swagger UI asset with a given file name
+// The returned error wraps fs.ErrNotExist if there is no such asset
+func SwaggerAsset(name string) ([]byte, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+		return nil, fmt.Errorf("invalid asset name %q: %w", name, fs.ErrNotExist)
+	}
+	return swaggerAssets.ReadFile("swagger-ui/" + name)
+}
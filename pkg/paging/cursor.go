@@ -0,0 +1,138 @@
+package paging
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// ErrInvalidCursor is returned when a cursor is malformed, forged or belongs to a differently sorted list
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// cursor is the position of the last item of a page, which is opaque to the clients
+type cursor struct {
+	// Sort stores the sort parameter of the list, since the position is meaningless in another order
+	Sort string `json:"s"`
+
+	// Values stores the sorted field values of the item followed by its ID
+	Values []interface{} `json:"v"`
+}
+
+// encodeCursor returns the signed cursor of the position of an ent entity
+func (p *Params) encodeCursor(item interface{}) (string, error) {
+	c := cursor{Sort: p.sort}
+	for _, o := range p.orders() {
+		v, err := fieldValue(item, o.Field.Column)
+		if err != nil {
+			return "", err
+		}
+		c.Values = append(c.Values, v)
+	}
+
+	payload, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString(payload) + "." + enc.EncodeToString(p.sign(payload)), nil
+}
+
+// decodeCursor verifies a cursor and decodes the values of its position according to the sort
+func (p *Params) decodeCursor(s string) (*cursor, error) {
+	enc := base64.RawURLEncoding
+	data, sig, ok := strings.Cut(s, ".")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+	payload, err := enc.DecodeString(data)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	mac, err := enc.DecodeString(sig)
+	if err != nil || !hmac.Equal(mac, p.sign(payload)) {
+		return nil, ErrInvalidCursor
+	}
+
+	var c cursor
+	dec := json.NewDecoder(bytes.NewReader(payload))
+	dec.UseNumber()
+	if err := dec.Decode(&c); err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	orders := p.orders()
+	if c.Sort != p.sort || len(c.Values) != len(orders) {
+		return nil, ErrInvalidCursor
+	}
+	for i, o := range orders {
+		if c.Values[i], err = cursorValue(o.Field.Kind, c.Values[i]); err != nil {
+			return nil, ErrInvalidCursor
+		}
+	}
+
+	return &c, nil
+}
+
+// sign returns the signature of a cursor payload
+func (p *Params) sign(payload []byte) []byte {
+	h := hmac.New(sha256.New, p.key)
+	h.Write([]byte("cursor:"))
+	h.Write(payload)
+	return h.Sum(nil)
+}
+
+// cursorValue converts a JSON decoded cursor value to the type of a field
+func cursorValue(kind Kind, v interface{}) (interface{}, error) {
+	switch kind {
+	case Int:
+		if n, ok := v.(json.Number); ok {
+			return n.Int64()
+		}
+	case Bool:
+		if b, ok := v.(bool); ok {
+			return b, nil
+		}
+	case Time:
+		if s, ok := v.(string); ok {
+			return time.Parse(time.RFC3339Nano, s)
+		}
+	default:
+		if s, ok := v.(string); ok {
+			return s, nil
+		}
+	}
+	return nil, fmt.Errorf("unexpected cursor value %v", v)
+}
+
+// fieldValue returns the value of the field of an ent entity with a given column, which is the JSON name
+// of the field
+func fieldValue(item interface{}, column string) (interface{}, error) {
+	v := reflect.Indirect(reflect.ValueOf(item))
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%T is not an entity", item)
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != column {
+			continue
+		}
+
+		f := reflect.Indirect(v.Field(i))
+		if !f.IsValid() {
+			return nil, fmt.Errorf("field %s of %T is null", column, item)
+		}
+		return f.Interface(), nil
+	}
+
+	return nil, fmt.Errorf("%T has no field %s", item, column)
+}
@@ -0,0 +1,61 @@
+package paging
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Page is the envelope of a page of a list
+type Page[T any] struct {
+	// Data stores the items of the page
+	Data []T `json:"data"`
+
+	// NextCursor stores the cursor of the next page, which is null on the last page
+	NextCursor *string `json:"next_cursor"`
+
+	// Total stores the total number of the matching items, if it was requested
+	Total *int `json:"total,omitempty"`
+}
+
+// NewPage creates the page of the items queried with the limit of Params.Fetch, converting them with a
+// given function. The extra item only tells there's a next page, whose cursor points to the last item
+func NewPage[E any, T any](p *Params, items []E, convert func(E) T) (*Page[T], error) {
+	page := &Page[T]{Data: make([]T, 0, len(items))}
+
+	if len(items) > p.Limit {
+		items = items[:p.Limit]
+		next, err := p.encodeCursor(items[len(items)-1])
+		if err != nil {
+			return nil, err
+		}
+		page.NextCursor = &next
+	}
+
+	for _, item := range items {
+		page.Data = append(page.Data, convert(item))
+	}
+
+	return page, nil
+}
+
+// Link returns the value of the Link header of the page, which points to the first and the next pages of
+// the list at a given URL
+func (pg *Page[T]) Link(u *url.URL) string {
+	link := func(cursor, rel string) string {
+		q := u.Query()
+		q.Del("cursor")
+		if cursor != "" {
+			q.Set("cursor", cursor)
+		}
+		next := *u
+		next.RawQuery = q.Encode()
+		return fmt.Sprintf(`<%s>; rel="%s"`, next.String(), rel)
+	}
+
+	links := []string{link("", "first")}
+	if pg.NextCursor != nil {
+		links = append(links, link(*pg.NextCursor, "next"))
+	}
+	return strings.Join(links, ", ")
+}
@@ -0,0 +1,374 @@
+package paging
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+)
+
+const (
+	// DefaultLimit stores the number of items of a page when the limit isn't given
+	DefaultLimit = 20
+
+	// MaxLimit stores the largest number of items of a page, larger limits are lowered to it
+	MaxLimit = 100
+
+	// idColumn stores the column of the entity IDs, which breaks ties between equal sort values
+	idColumn = "id"
+)
+
+// Kind is the type of the values of a field
+type Kind int
+
+const (
+	String Kind = iota
+	Int
+	Bool
+	Time
+)
+
+// Op is a filter operator
+type Op string
+
+const (
+	Eq       Op = "eq"
+	Ne       Op = "ne"
+	Lt       Op = "lt"
+	Lte      Op = "lte"
+	Gt       Op = "gt"
+	Gte      Op = "gte"
+	In       Op = "in"
+	Contains Op = "contains"
+)
+
+var (
+	// Comparable stores the operators of fields whose values are ordered, such as numbers and times
+	Comparable = []Op{Eq, Ne, Lt, Lte, Gt, Gte}
+
+	// operators stores all the operators, which can prefix the filter values
+	operators = []Op{Eq, Ne, Lt, Lte, Gt, Gte, In, Contains}
+)
+
+type (
+	// Field describes a field of an entity which lists can be sorted or filtered by
+	Field struct {
+		// Column stores the database column of the field, which is also its JSON name on the ent entity
+		Column string
+
+		// Kind stores the type of the values of the field
+		Kind Kind
+
+		// Sort indicates that lists can be sorted by the field, which must not be nullable
+		Sort bool
+
+		// Filter stores the operators lists can be filtered by the field with
+		Filter []Op
+
+		// Nullable indicates that the field can be filtered by the null value with eq and ne
+		Nullable bool
+	}
+
+	// Resource describes the allowed fields of the lists of an entity keyed by their name in the query
+	Resource struct {
+		Fields map[string]Field
+
+		// Sort stores the sorting used when the query doesn't give one, such as -created_at
+		Sort string
+	}
+
+	// Order is the sorting by a field
+	Order struct {
+		Field Field
+		Desc  bool
+	}
+
+	// Filter is the filtering by a field
+	Filter struct {
+		Field  Field
+		Op     Op
+		Values []interface{}
+	}
+
+	// Params stores the parsed paging, sorting and filtering query parameters of a list
+	Params struct {
+		// Limit stores the number of items of a page
+		Limit int
+
+		// Total indicates that the total number of the matching items was requested
+		Total bool
+
+		// Sort stores the sorting of the list, which is followed by the ID
+		Sort []Order
+
+		// Filters stores the filters of the list
+		Filters []Filter
+
+		// cursor stores the position following which the page starts
+		cursor *cursor
+
+		// sort stores the normalized sort parameter which the cursors are bound to
+		sort string
+
+		// key stores the key signing the cursors
+		key []byte
+	}
+
+	// ParamError is returned when a query parameter is invalid
+	ParamError struct {
+		Param string
+		Err   error
+	}
+)
+
+func (e *ParamError) Error() string {
+	return fmt.Sprintf("invalid query parameter %s: %v", e.Param, e.Err)
+}
+
+func (e *ParamError) Unwrap() error {
+	return e.Err
+}
+
+// Parse parses the limit, cursor, sort, total and filter[field]=op:value query parameters of a list of a
+// resource, where the operator defaults to eq and in takes comma separated values.
+// The key signs the cursors, so clients can't forge positions in lists
+func Parse(q url.Values, r Resource, key []byte) (*Params, error) {
+	p := &Params{Limit: DefaultLimit, key: key}
+
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return nil, &ParamError{Param: "limit", Err: fmt.Errorf("%q is not a positive number", v)}
+		}
+		if n > MaxLimit {
+			n = MaxLimit
+		}
+		p.Limit = n
+	}
+
+	if v := q.Get("total"); v != "" {
+		total, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, &ParamError{Param: "total", Err: err}
+		}
+		p.Total = total
+	}
+
+	sort := q.Get("sort")
+	if sort == "" {
+		sort = r.Sort
+	}
+	if err := p.parseSort(sort, r); err != nil {
+		return nil, &ParamError{Param: "sort", Err: err}
+	}
+
+	for param, values := range q {
+		name := strings.TrimPrefix(param, "filter[")
+		if name == param || !strings.HasSuffix(name, "]") {
+			continue
+		}
+		name = strings.TrimSuffix(name, "]")
+
+		for _, v := range values {
+			f, err := parseFilter(name, v, r)
+			if err != nil {
+				return nil, &ParamError{Param: param, Err: err}
+			}
+			p.Filters = append(p.Filters, f)
+		}
+	}
+
+	if v := q.Get("cursor"); v != "" {
+		c, err := p.decodeCursor(v)
+		if err != nil {
+			return nil, &ParamError{Param: "cursor", Err: err}
+		}
+		p.cursor = c
+	}
+
+	return p, nil
+}
+
+// parseSort parses a comma separated list of fields, which are prefixed with - for descending order
+func (p *Params) parseSort(sort string, r Resource) error {
+	var names []string
+	for _, name := range strings.Split(sort, ",") {
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+
+		o := Order{Desc: strings.HasPrefix(name, "-")}
+		name = strings.TrimPrefix(name, "-")
+		f, ok := r.Fields[name]
+		if !ok || !f.Sort {
+			return fmt.Errorf("lists can't be sorted by %q", name)
+		}
+		o.Field = f
+		p.Sort = append(p.Sort, o)
+
+		if o.Desc {
+			name = "-" + name
+		}
+		names = append(names, name)
+	}
+
+	p.sort = strings.Join(names, ",")
+	return nil
+}
+
+// parseFilter parses the op:value filter of a field
+func parseFilter(name, v string, r Resource) (Filter, error) {
+	f, ok := r.Fields[name]
+	if !ok {
+		return Filter{}, fmt.Errorf("lists can't be filtered by %q", name)
+	}
+
+	op, value := Eq, v
+	if o, rest, ok := strings.Cut(v, ":"); ok && allowed(operators, Op(o)) {
+		op, value = Op(o), rest
+	}
+	if !allowed(f.Filter, op) {
+		return Filter{}, fmt.Errorf("%q can't be filtered with %s", name, op)
+	}
+
+	filter := Filter{Field: f, Op: op}
+	if value == "null" && f.Nullable && (op == Eq || op == Ne) {
+		filter.Values = []interface{}{nil}
+		return filter, nil
+	}
+
+	raw := []string{value}
+	if op == In {
+		raw = strings.Split(value, ",")
+	}
+	for _, s := range raw {
+		val, err := parseValue(f.Kind, s)
+		if err != nil {
+			return Filter{}, err
+		}
+		filter.Values = append(filter.Values, val)
+	}
+
+	return filter, nil
+}
+
+// parseValue parses a query value of a given kind
+func parseValue(kind Kind, s string) (interface{}, error) {
+	switch kind {
+	case Int:
+		return strconv.ParseInt(s, 10, 64)
+	case Bool:
+		return strconv.ParseBool(s)
+	case Time:
+		return time.Parse(time.RFC3339, s)
+	}
+	return s, nil
+}
+
+// allowed returns whether an operator is among the given operators
+func allowed(ops []Op, op Op) bool {
+	for _, o := range ops {
+		if o == op {
+			return true
+		}
+	}
+	return false
+}
+
+// Where returns a predicate applying the filters, which can be converted to the predicate type of any
+// entity, such as predicate.User(p.Where())
+func (p *Params) Where() func(*sql.Selector) {
+	return func(s *sql.Selector) {
+		for _, f := range p.Filters {
+			s.Where(f.predicate(s))
+		}
+	}
+}
+
+// Seek returns a predicate skipping the items up to the cursor, which is used instead of an offset so pages
+// stay consistent while items are added
+func (p *Params) Seek() func(*sql.Selector) {
+	return func(s *sql.Selector) {
+		if p.cursor == nil {
+			return
+		}
+
+		// The items following (a, b, id) are those with a greater a, or an equal a and a greater b, and so on
+		var or []*sql.Predicate
+		var eq []*sql.Predicate
+		for i, o := range p.orders() {
+			col := s.C(o.Field.Column)
+			value := p.cursor.Values[i]
+			cmp := sql.GT(col, value)
+			if o.Desc {
+				cmp = sql.LT(col, value)
+			}
+			or = append(or, sql.And(append(eq[:len(eq):len(eq)], cmp)...))
+			eq = append(eq, sql.EQ(col, value))
+		}
+		s.Where(sql.Or(or...))
+	}
+}
+
+// Order returns an ordering applying the sort followed by the ID, which can be converted to the order
+// option type of any entity, such as user.OrderOption(p.Order())
+func (p *Params) Order() func(*sql.Selector) {
+	return func(s *sql.Selector) {
+		for _, o := range p.orders() {
+			if o.Desc {
+				s.OrderBy(sql.Desc(s.C(o.Field.Column)))
+			} else {
+				s.OrderBy(sql.Asc(s.C(o.Field.Column)))
+			}
+		}
+	}
+}
+
+// Fetch returns the number of items to query, which is one more than the limit to tell whether there's
+// a next page
+func (p *Params) Fetch() int {
+	return p.Limit + 1
+}
+
+// orders returns the sort followed by the ID in the direction of the last sorted field
+func (p *Params) orders() []Order {
+	id := Order{Field: Field{Column: idColumn, Kind: Int}}
+	if len(p.Sort) > 0 {
+		id.Desc = p.Sort[len(p.Sort)-1].Desc
+	}
+	return append(p.Sort[:len(p.Sort):len(p.Sort)], id)
+}
+
+// predicate returns the predicate of a filter
+func (f Filter) predicate(s *sql.Selector) *sql.Predicate {
+	col := s.C(f.Field.Column)
+	v := f.Values[0]
+
+	switch f.Op {
+	case Eq:
+		if v == nil {
+			return sql.IsNull(col)
+		}
+		return sql.EQ(col, v)
+	case Ne:
+		if v == nil {
+			return sql.NotNull(col)
+		}
+		return sql.NEQ(col, v)
+	case Lt:
+		return sql.LT(col, v)
+	case Lte:
+		return sql.LTE(col, v)
+	case Gt:
+		return sql.GT(col, v)
+	case Gte:
+		return sql.GTE(col, v)
+	case In:
+		return sql.In(col, f.Values...)
+	default:
+		return sql.ContainsFold(col, fmt.Sprint(v))
+	}
+}
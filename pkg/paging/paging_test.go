@@ -0,0 +1,184 @@
+package paging
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testKey = []byte("secret")
+
+	testResource = Resource{
+		Sort: "-created_at",
+		Fields: map[string]Field{
+			"created_at": {Column: "created_at", Kind: Time, Sort: true, Filter: Comparable},
+			"name":       {Column: "name", Kind: String, Sort: true, Filter: []Op{Eq, In, Contains}},
+			"rank":       {Column: "rank", Kind: Int, Filter: Comparable},
+			"deleted_at": {Column: "deleted_at", Kind: Time, Filter: []Op{Eq, Ne}, Nullable: true},
+		},
+	}
+)
+
+type testEntity struct {
+	ID        int       `json:"id,omitempty"`
+	Name      string    `json:"name,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+}
+
+func parse(t *testing.T, query string) *Params {
+	q, err := url.ParseQuery(query)
+	require.NoError(t, err)
+	p, err := Parse(q, testResource, testKey)
+	require.NoError(t, err, query)
+	return p
+}
+
+func selector(apply ...func(*sql.Selector)) (string, []interface{}) {
+	s := sql.Select("*").From(sql.Table("items"))
+	for _, f := range apply {
+		f(s)
+	}
+	return s.Query()
+}
+
+func TestParse(t *testing.T) {
+	p := parse(t, "")
+	assert.Equal(t, DefaultLimit, p.Limit)
+	assert.False(t, p.Total)
+	require.Len(t, p.Sort, 1)
+	assert.Equal(t, "created_at", p.Sort[0].Field.Column)
+	assert.True(t, p.Sort[0].Desc)
+	assert.Empty(t, p.Filters)
+
+	p = parse(t, "limit=5&total=true&sort=name,-created_at&filter[rank]=gte:3&filter[name]=in:a,b&foo=bar")
+	assert.Equal(t, 5, p.Limit)
+	assert.Equal(t, 6, p.Fetch())
+	assert.True(t, p.Total)
+	assert.Equal(t, "name,-created_at", p.sort)
+	require.Len(t, p.Filters, 2)
+
+	assert.Equal(t, MaxLimit, parse(t, "limit=1000").Limit)
+
+	p = parse(t, "filter[created_at]=2024-01-02T03:04:05Z")
+	require.Len(t, p.Filters, 1)
+	assert.Equal(t, Eq, p.Filters[0].Op)
+	assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), p.Filters[0].Values[0])
+
+	p = parse(t, "filter[deleted_at]=null")
+	require.Len(t, p.Filters, 1)
+	assert.Nil(t, p.Filters[0].Values[0])
+}
+
+func TestParse_Errors(t *testing.T) {
+	cases := map[string]string{
+		"limit=0":                  "limit",
+		"limit=abc":                "limit",
+		"total=maybe":              "total",
+		"sort=rank":                "sort",
+		"sort=unknown":             "sort",
+		"filter[unknown]=1":        "filter[unknown]",
+		"filter[name]=gt:a":        "filter[name]",
+		"filter[rank]=abc":         "filter[rank]",
+		"filter[created_at]=today": "filter[created_at]",
+		"filter[rank]=null":        "filter[rank]",
+		"cursor=abc":               "cursor",
+	}
+
+	for query, param := range cases {
+		q, err := url.ParseQuery(query)
+		require.NoError(t, err)
+		_, err = Parse(q, testResource, testKey)
+
+		var pe *ParamError
+		if assert.True(t, errors.As(err, &pe), query) {
+			assert.Equal(t, param, pe.Param, query)
+		}
+	}
+}
+
+func TestParams_Where(t *testing.T) {
+	p := parse(t, "filter[rank]=gte:3&filter[deleted_at]=null")
+	query, args := selector(p.Where())
+	assert.Contains(t, query, "`items`.`rank` >= ?")
+	assert.Contains(t, query, "`items`.`deleted_at` IS NULL")
+	assert.Equal(t, []interface{}{int64(3)}, args)
+
+	query, args = selector(parse(t, "filter[name]=in:a,b").Where())
+	assert.Contains(t, query, "`items`.`name` IN (?, ?)")
+	assert.Equal(t, []interface{}{"a", "b"}, args)
+}
+
+func TestParams_Order(t *testing.T) {
+	query, _ := selector(parse(t, "sort=name,-created_at").Order())
+	assert.Contains(t, query, "ORDER BY `items`.`name` ASC, `items`.`created_at` DESC, `items`.`id` DESC")
+
+	query, _ = selector(parse(t, "sort=name").Order())
+	assert.Contains(t, query, "ORDER BY `items`.`name` ASC, `items`.`id` ASC")
+}
+
+func TestPage(t *testing.T) {
+	now := time.Now().UTC()
+	items := make([]*testEntity, 0, 4)
+	for i := 4; i > 0; i-- {
+		items = append(items, &testEntity{ID: i, Name: strconv.Itoa(i), CreatedAt: now.Add(time.Duration(i) * time.Minute)})
+	}
+	convert := func(e *testEntity) string { return e.Name }
+
+	// A page with more items than the limit has a next page starting after its last item
+	p := parse(t, "limit=3")
+	page, err := NewPage(p, items, convert)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"4", "3", "2"}, page.Data)
+	require.NotNil(t, page.NextCursor)
+
+	next := parse(t, "limit=3&cursor="+url.QueryEscape(*page.NextCursor))
+	require.NotNil(t, next.cursor)
+	assert.Equal(t, []interface{}{items[2].CreatedAt, int64(2)}, next.cursor.Values)
+
+	query, args := selector(next.Seek())
+	assert.Contains(t, query, "`items`.`created_at` < ? OR (`items`.`created_at` = ? AND `items`.`id` < ?)")
+	assert.Equal(t, []interface{}{items[2].CreatedAt, items[2].CreatedAt, int64(2)}, args)
+
+	u, _ := url.Parse("/api/v1/items?limit=3&cursor=old")
+	assert.Equal(t,
+		`</api/v1/items?limit=3>; rel="first", </api/v1/items?cursor=`+url.QueryEscape(*page.NextCursor)+`&limit=3>; rel="next"`,
+		page.Link(u),
+	)
+
+	// The last page has no next page
+	page, err = NewPage(p, items[:3], convert)
+	require.NoError(t, err)
+	assert.Len(t, page.Data, 3)
+	assert.Nil(t, page.NextCursor)
+	assert.Equal(t, `</api/v1/items?limit=3>; rel="first"`, page.Link(u))
+}
+
+func TestCursor_Invalid(t *testing.T) {
+	items := []*testEntity{{ID: 2, CreatedAt: time.Now()}, {ID: 1, CreatedAt: time.Now()}}
+	page, err := NewPage(parse(t, "limit=1"), items, func(e *testEntity) int { return e.ID })
+	require.NoError(t, err)
+	cursor := *page.NextCursor
+
+	try := func(query string, key []byte) error {
+		q, err := url.ParseQuery(query)
+		require.NoError(t, err)
+		_, err = Parse(q, testResource, key)
+		return err
+	}
+
+	assert.NoError(t, try("cursor="+url.QueryEscape(cursor), testKey))
+
+	// Cursors are signed
+	assert.ErrorIs(t, try("cursor="+url.QueryEscape(cursor), []byte("other")), ErrInvalidCursor)
+	assert.ErrorIs(t, try("cursor=x"+url.QueryEscape(cursor), testKey), ErrInvalidCursor)
+
+	// Cursors are bound to the sort of the list
+	assert.ErrorIs(t, try("sort=name&cursor="+url.QueryEscape(cursor), testKey), ErrInvalidCursor)
+}
@@ -0,0 +1,223 @@
+package problem
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ContentType stores the content type of problem details responses, as defined by RFC 7807
+const ContentType = "application/problem+json"
+
+// Stable error codes, which clients can rely on instead of parsing the detail messages
+const (
+	CodeBadRequest           = "bad_request"
+	CodeUnauthorized         = "unauthorized"
+	CodeForbidden            = "forbidden"
+	CodeNotFound             = "not_found"
+	CodeMethodNotAllowed     = "method_not_allowed"
+	CodeConflict             = "conflict"
+	CodePayloadTooLarge      = "payload_too_large"
+	CodeUnsupportedMediaType = "unsupported_media_type"
+	CodeValidationFailed     = "validation_failed"
+	CodeTooManyRequests      = "too_many_requests"
+	CodeInternal             = "internal_error"
+	CodeUnavailable          = "service_unavailable"
+	CodeTimeout              = "timeout"
+	CodeInvalidCredentials   = "invalid_credentials"
+	CodeInvalidToken         = "invalid_token"
+	CodeQuotaExceeded        = "quota_exceeded"
+	CodeUnsupportedVersion   = "unsupported_api_version"
+	CodeInvalidCursor        = "invalid_cursor"
+	CodeAccountBlocked       = "account_blocked"
+)
+
+// Error is an application error which is rendered as a problem details response
+type Error struct {
+	// Status stores the HTTP status code
+	Status int
+
+	// Code stores the stable error code
+	Code string
+
+	// Detail stores a human readable explanation which is safe to show to the client
+	Detail string
+
+	// Fields stores the validation error messages keyed by the field name
+	Fields map[string][]string
+
+	// Internal stores the underlying error, which is logged but never shown in production
+	Internal error
+}
+
+// Problem is the body of a problem details response
+type Problem struct {
+	Type      string              `json:"type"`
+	Title     string              `json:"title"`
+	Status    int                 `json:"status"`
+	Code      string              `json:"code"`
+	Detail    string              `json:"detail,omitempty"`
+	Instance  string              `json:"instance,omitempty"`
+	RequestID string              `json:"request_id,omitempty"`
+	Errors    map[string][]string `json:"errors,omitempty"`
+}
+
+// New creates a new Error
+func New(status int, code, detail string) *Error {
+	return &Error{
+		Status: status,
+		Code:   code,
+		Detail: detail,
+	}
+}
+
+// Validation creates an Error for a request which failed validation
+func Validation(fields map[string][]string) *Error {
+	return &Error{
+		Status: http.StatusUnprocessableEntity,
+		Code:   CodeValidationFailed,
+		Fields: fields,
+	}
+}
+
+// Internal creates an Error for an unexpected failure, hiding the underlying error from the client
+func Internal(err error) *Error {
+	return &Error{
+		Status:   http.StatusInternalServerError,
+		Code:     CodeInternal,
+		Internal: err,
+	}
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("%d %s", e.Status, e.Code)
+	if e.Detail != "" {
+		msg += ": " + e.Detail
+	}
+	if e.Internal != nil {
+		msg += ": " + e.Internal.Error()
+	}
+	return msg
+}
+
+// Unwrap returns the underlying error
+func (e *Error) Unwrap() error {
+	return e.Internal
+}
+
+// WithInternal returns a copy of the error with the underlying error set
+func (e *Error) WithInternal(err error) *Error {
+	c := *e
+	c.Internal = err
+	return &c
+}
+
+// FromError converts any error to an Error
+// Errors returned by echo, such as from the router or middleware, keep their status code and message,
+// while all other errors are considered internal
+func FromError(err error) *Error {
+	var pe *Error
+	if errors.As(err, &pe) {
+		return pe
+	}
+
+	var he *echo.HTTPError
+	if errors.As(err, &he) {
+		// Some middleware wrap the actual HTTP error, such as the JWT middleware
+		if inner, ok := he.Internal.(*echo.HTTPError); ok {
+			he = inner
+		}
+
+		e := &Error{
+			Status:   he.Code,
+			Code:     CodeForStatus(he.Code),
+			Internal: he.Internal,
+		}
+		if msg, ok := he.Message.(string); ok && msg != http.StatusText(he.Code) {
+			e.Detail = msg
+		}
+		return e
+	}
+
+	return Internal(err)
+}
+
+// CodeForStatus returns the default error code of an HTTP status code
+func CodeForStatus(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return CodeBadRequest
+	case http.StatusUnauthorized:
+		return CodeUnauthorized
+	case http.StatusForbidden:
+		return CodeForbidden
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusMethodNotAllowed:
+		return CodeMethodNotAllowed
+	case http.StatusConflict:
+		return CodeConflict
+	case http.StatusRequestEntityTooLarge:
+		return CodePayloadTooLarge
+	case http.StatusUnsupportedMediaType:
+		return CodeUnsupportedMediaType
+	case http.StatusUnprocessableEntity:
+		return CodeValidationFailed
+	case http.StatusTooManyRequests:
+		return CodeTooManyRequests
+	case http.StatusServiceUnavailable:
+		return CodeUnavailable
+	case http.StatusGatewayTimeout:
+		return CodeTimeout
+	}
+
+	if status >= http.StatusInternalServerError {
+		return CodeInternal
+	}
+	return CodeBadRequest
+}
+
+// Problem builds the response body of the error
+// The underlying error is only included in the detail of server errors when expose is true,
+// which should never be the case in production
+func (e *Error) Problem(instance, requestID string, expose bool) Problem {
+	p := Problem{
+		Type:      "about:blank",
+		Title:     http.StatusText(e.Status),
+		Status:    e.Status,
+		Code:      e.Code,
+		Detail:    e.Detail,
+		Instance:  instance,
+		RequestID: requestID,
+		Errors:    e.Fields,
+	}
+
+	if e.Status >= http.StatusInternalServerError && e.Internal != nil && expose {
+		p.Detail = e.Internal.Error()
+	}
+
+	return p
+}
+
+// Redact returns a copy of the response body with its texts passed through redact, so that sensitive data
+// included in them, such as email addresses or tokens taken from the request, isn't sent back
+func (p Problem) Redact(redact func(string) string) Problem {
+	p.Detail = redact(p.Detail)
+	p.Instance = redact(p.Instance)
+
+	if p.Errors != nil {
+		errs := make(map[string][]string, len(p.Errors))
+		for field, messages := range p.Errors {
+			errs[field] = make([]string, len(messages))
+			for i, message := range messages {
+				errs[field][i] = redact(message)
+			}
+		}
+		p.Errors = errs
+	}
+
+	return p
+}
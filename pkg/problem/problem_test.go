@@ -0,0 +1,116 @@
+package problem
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vovanwin/api-my-site/pkg/logging"
+)
+
+func TestFromError(t *testing.T) {
+	internal := errors.New("connection refused")
+
+	cases := []struct {
+		name     string
+		err      error
+		status   int
+		code     string
+		detail   string
+		internal error
+	}{
+		{
+			name:   "problem",
+			err:    New(http.StatusConflict, CodeConflict, "exists"),
+			status: http.StatusConflict,
+			code:   CodeConflict,
+			detail: "exists",
+		},
+		{
+			name:     "wrapped problem",
+			err:      fmt.Errorf("wrapped: %w", Internal(internal)),
+			status:   http.StatusInternalServerError,
+			code:     CodeInternal,
+			internal: internal,
+		},
+		{
+			name:   "http error",
+			err:    echo.NewHTTPError(http.StatusNotFound),
+			status: http.StatusNotFound,
+			code:   CodeNotFound,
+		},
+		{
+			name:   "http error with message",
+			err:    echo.NewHTTPError(http.StatusBadRequest, "bad input"),
+			status: http.StatusBadRequest,
+			code:   CodeBadRequest,
+			detail: "bad input",
+		},
+		{
+			name:   "nested http error",
+			err:    echo.ErrBadRequest.WithInternal(echo.NewHTTPError(http.StatusUnauthorized)),
+			status: http.StatusUnauthorized,
+			code:   CodeUnauthorized,
+		},
+		{
+			name:     "other error",
+			err:      internal,
+			status:   http.StatusInternalServerError,
+			code:     CodeInternal,
+			internal: internal,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := FromError(tc.err)
+			assert.Equal(t, tc.status, e.Status)
+			assert.Equal(t, tc.code, e.Code)
+			assert.Equal(t, tc.detail, e.Detail)
+			assert.Equal(t, tc.internal, e.Internal)
+		})
+	}
+}
+
+func TestError_Problem(t *testing.T) {
+	e := Internal(errors.New("secret"))
+
+	p := e.Problem("/api/x", "abc", false)
+	assert.Equal(t, http.StatusInternalServerError, p.Status)
+	assert.Equal(t, "Internal Server Error", p.Title)
+	assert.Equal(t, CodeInternal, p.Code)
+	assert.Empty(t, p.Detail)
+	assert.Equal(t, "/api/x", p.Instance)
+	assert.Equal(t, "abc", p.RequestID)
+
+	p = e.Problem("/api/x", "abc", true)
+	assert.Equal(t, "secret", p.Detail)
+
+	p = Validation(map[string][]string{"Email": {"required"}}).Problem("/", "", true)
+	assert.Equal(t, http.StatusUnprocessableEntity, p.Status)
+	assert.Equal(t, CodeValidationFailed, p.Code)
+	assert.Equal(t, []string{"required"}, p.Errors["Email"])
+
+	// Client errors never expose the underlying error
+	p = New(http.StatusBadRequest, CodeBadRequest, "bad").WithInternal(errors.New("secret")).Problem("/", "", true)
+	assert.Equal(t, "bad", p.Detail)
+}
+
+func TestProblem_Redact(t *testing.T) {
+	e := New(http.StatusBadRequest, CodeBadRequest, "no account for alice@example.com")
+	e.Fields = map[string][]string{"Email": {"bob@example.org is taken"}}
+
+	p := e.Problem("/api/auth/email/confirm?token=abc123", "", false)
+	redacted := p.Redact(logging.Redact)
+
+	assert.Equal(t, "no account for a***@example.com", redacted.Detail)
+	assert.Equal(t, "/api/auth/email/confirm?token=[redacted]", redacted.Instance)
+	assert.Equal(t, []string{"b***@example.org is taken"}, redacted.Errors["Email"])
+
+	// The original body is left untouched
+	assert.Equal(t, []string{"bob@example.org is taken"}, p.Errors["Email"])
+}
@@ -0,0 +1,144 @@
+package routes
+
+import (
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/vovanwin/api-my-site/ent"
+	"github.com/vovanwin/api-my-site/ent/auditevent"
+	"github.com/vovanwin/api-my-site/ent/predicate"
+	"github.com/vovanwin/api-my-site/pkg/controller"
+	"github.com/vovanwin/api-my-site/pkg/paging"
+)
+
+type (
+	audit struct {
+		controller.Controller
+	}
+
+	auditEventResponse struct {
+		ID             int                    `json:"id"`
+		Action         string                 `json:"action"`
+		ActorID        *int                   `json:"actor_id"`
+		ImpersonatorID *int                   `json:"impersonator_id"`
+		TargetType     string                 `json:"target_type"`
+		TargetID       *int                   `json:"target_id"`
+		IP             string                 `json:"ip"`
+		UserAgent      string                 `json:"user_agent"`
+		RequestID      string                 `json:"request_id"`
+		Before         map[string]interface{} `json:"before"`
+		After          map[string]interface{} `json:"after"`
+		CreatedAt      time.Time              `json:"created_at"`
+	}
+
+	// auditEventPage содержит страницу событий журнала аудита
+	auditEventPage = paging.Page[auditEventResponse]
+)
+
+// auditEventList описывает поля, по которым можно сортировать и фильтровать события журнала аудита
+var auditEventList = paging.Resource{
+	Sort: "-created_at",
+	Fields: map[string]paging.Field{
+		"created_at": {
+			Column: auditevent.FieldCreatedAt,
+			Kind:   paging.Time,
+			Sort:   true,
+			Filter: paging.Comparable,
+		},
+		"action": {
+			Column: auditevent.FieldAction,
+			Kind:   paging.String,
+			Filter: []paging.Op{paging.Eq, paging.In, paging.Contains},
+		},
+		"actor_id": {
+			Column:   auditevent.FieldActorID,
+			Kind:     paging.Int,
+			Filter:   []paging.Op{paging.Eq, paging.In},
+			Nullable: true,
+		},
+		"impersonator_id": {
+			Column:   auditevent.FieldImpersonatorID,
+			Kind:     paging.Int,
+			Filter:   []paging.Op{paging.Eq},
+			Nullable: true,
+		},
+		"target_type": {
+			Column: auditevent.FieldTargetType,
+			Kind:   paging.String,
+			Filter: []paging.Op{paging.Eq, paging.In},
+		},
+		"target_id": {
+			Column:   auditevent.FieldTargetID,
+			Kind:     paging.Int,
+			Filter:   []paging.Op{paging.Eq, paging.In},
+			Nullable: true,
+		},
+		"ip": {
+			Column: auditevent.FieldIP,
+			Kind:   paging.String,
+			Filter: []paging.Op{paging.Eq},
+		},
+		"request_id": {
+			Column: auditevent.FieldRequestID,
+			Kind:   paging.String,
+			Filter: []paging.Op{paging.Eq},
+		},
+	},
+}
+
+// Index возвращает страницу событий журнала аудита, по умолчанию новые первыми
+func (c *audit) Index(ctx echo.Context) error {
+	p, err := c.Paginate(ctx, auditEventList)
+	if err != nil {
+		return err
+	}
+
+	query := c.Container.ORM.AuditEvent.
+		Query().
+		Where(predicate.AuditEvent(p.Where()))
+
+	var total int
+	if p.Total {
+		if total, err = query.Clone().Count(ctx.Request().Context()); err != nil {
+			return c.Fail(err, "не удается подсчитать события журнала аудита")
+		}
+	}
+
+	events, err := query.
+		Where(predicate.AuditEvent(p.Seek())).
+		Order(auditevent.OrderOption(p.Order())).
+		Limit(p.Fetch()).
+		All(ctx.Request().Context())
+
+	if err != nil {
+		return c.Fail(err, "не удается загрузить события журнала аудита")
+	}
+
+	page, err := paging.NewPage(p, events, newAuditEventResponse)
+	if err != nil {
+		return c.Fail(err, "не удается сформировать страницу событий журнала аудита")
+	}
+	if p.Total {
+		page.Total = &total
+	}
+
+	return c.RenderPage(ctx, page)
+}
+
+func newAuditEventResponse(e *ent.AuditEvent) auditEventResponse {
+	return auditEventResponse{
+		ID:             e.ID,
+		Action:         e.Action,
+		ActorID:        e.ActorID,
+		ImpersonatorID: e.ImpersonatorID,
+		TargetType:     e.TargetType,
+		TargetID:       e.TargetID,
+		IP:             e.IP,
+		UserAgent:      e.UserAgent,
+		RequestID:      e.RequestID,
+		Before:         e.Before,
+		After:          e.After,
+		CreatedAt:      e.CreatedAt,
+	}
+}
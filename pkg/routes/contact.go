@@ -0,0 +1,285 @@
+package routes
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/vovanwin/api-my-site/ent"
+	"github.com/vovanwin/api-my-site/ent/contactmessage"
+	"github.com/vovanwin/api-my-site/ent/predicate"
+	"github.com/vovanwin/api-my-site/pkg/context"
+	"github.com/vovanwin/api-my-site/pkg/controller"
+	"github.com/vovanwin/api-my-site/pkg/i18n"
+	"github.com/vovanwin/api-my-site/pkg/logging"
+	"github.com/vovanwin/api-my-site/pkg/paging"
+	"github.com/vovanwin/api-my-site/pkg/problem"
+	"github.com/vovanwin/api-my-site/pkg/services"
+	"github.com/vovanwin/api-my-site/pkg/tasks"
+)
+
+type (
+	contact struct {
+		controller.Controller
+	}
+
+	contactForm struct {
+		Name    string `form:"name" json:"name" validate:"required,max=100" label:"field.name"`
+		Email   string `form:"email" json:"email" normalize:"lower" validate:"required,email" label:"field.email"`
+		Subject string `form:"subject" json:"subject" validate:"max=200" label:"field.subject"`
+		Message string `form:"message" json:"message" validate:"required,max=5000" label:"field.message"`
+		// Website is a honeypot field which is hidden from visitors, so only bots fill it in
+		Website    string                    `form:"website" json:"website"`
+		Token      string                    `form:"token" json:"token"`
+		Submission controller.FormSubmission `form:"-" json:"-"`
+	}
+
+	contactMessageResponse struct {
+		ID          int        `json:"id"`
+		Name        string     `json:"name"`
+		Email       string     `json:"email"`
+		Subject     string     `json:"subject"`
+		Message     string     `json:"message"`
+		IP          string     `json:"ip"`
+		UserAgent   string     `json:"user_agent"`
+		ForwardedAt *time.Time `json:"forwarded_at"`
+		HandledAt   *time.Time `json:"handled_at"`
+		CreatedAt   time.Time  `json:"created_at"`
+	}
+
+	// contactMessagePage содержит страницу сообщений с формы обратной связи
+	contactMessagePage = paging.Page[contactMessageResponse]
+
+	// messageResponse содержит сообщение для пользователя
+	messageResponse struct {
+		Message string `json:"message"`
+	}
+)
+
+// contactMessageList описывает поля, по которым можно сортировать и фильтровать сообщения
+var contactMessageList = paging.Resource{
+	Sort: "-created_at",
+	Fields: map[string]paging.Field{
+		"created_at": {
+			Column: contactmessage.FieldCreatedAt,
+			Kind:   paging.Time,
+			Sort:   true,
+			Filter: paging.Comparable,
+		},
+		"handled_at": {
+			Column:   contactmessage.FieldHandledAt,
+			Kind:     paging.Time,
+			Filter:   paging.Comparable,
+			Nullable: true,
+		},
+		"forwarded_at": {
+			Column:   contactmessage.FieldForwardedAt,
+			Kind:     paging.Time,
+			Filter:   paging.Comparable,
+			Nullable: true,
+		},
+		"email": {
+			Column: contactmessage.FieldEmail,
+			Kind:   paging.String,
+			Sort:   true,
+			Filter: []paging.Op{paging.Eq, paging.In, paging.Contains},
+		},
+		"name": {
+			Column: contactmessage.FieldName,
+			Kind:   paging.String,
+			Sort:   true,
+			Filter: []paging.Op{paging.Eq, paging.Contains},
+		},
+	},
+}
+
+// Get возвращает токен, который необходимо передать вместе с формой обратной связи
+func (c *contact) Get(ctx echo.Context) error {
+	token, err := c.Container.Contact.GenerateToken()
+	if err != nil {
+		return c.Fail(err, "не удалось создать токен формы обратной связи")
+	}
+
+	ctx.Response().Header().Set("Cache-Control", "no-store")
+	return ctx.JSON(http.StatusOK, tokenResponse{Token: token})
+}
+
+// Post принимает сообщение с формы обратной связи и ставит в очередь его отправку по электронной почте
+func (c *contact) Post(ctx echo.Context) error {
+	var form contactForm
+
+	if err := ctx.Bind(&form); err != nil {
+		return err
+	}
+
+	// Bots are told their message was accepted, so they have no reason to adapt
+	if form.Website != "" {
+		logging.Infof(ctx.Request().Context(), "отклонено сообщение с заполненной ловушкой: %s", ctx.RealIP())
+		return c.accepted(ctx)
+	}
+
+	switch c.Container.Contact.ValidateToken(form.Token).(type) {
+	case nil:
+	case services.ContactSubmittedTooFastError:
+		logging.Infof(ctx.Request().Context(), "отклонено слишком быстро отправленное сообщение: %s", ctx.RealIP())
+		return c.accepted(ctx)
+	default:
+		return problem.New(
+			http.StatusBadRequest,
+			problem.CodeInvalidToken,
+			i18n.Ctx(ctx, "contact.token_expired"),
+		)
+	}
+
+	if err := form.Submission.Process(ctx, &form); err != nil {
+		return c.Fail(err, "не удается обработать отправку формы")
+	}
+
+	if form.Submission.HasErrors() {
+		return problem.Validation(form.Submission.GetAllFieldErrors())
+	}
+
+	// Токен расходуется только после проверки формы, чтобы исправленную форму можно было отправить снова
+	switch err := c.Container.Contact.UseToken(ctx.Request().Context(), form.Token).(type) {
+	case nil:
+	case services.InvalidContactTokenError:
+		logging.Infof(ctx.Request().Context(), "отклонено сообщение с повторно использованным токеном: %s", ctx.RealIP())
+		return problem.New(
+			http.StatusBadRequest,
+			problem.CodeInvalidToken,
+			i18n.Ctx(ctx, "contact.token_expired"),
+		)
+	default:
+		return c.Fail(err, "не удается проверить токен формы обратной связи")
+	}
+
+	msg, err := c.Container.ORM.ContactMessage.
+		Create().
+		SetName(form.Name).
+		SetEmail(form.Email).
+		SetSubject(form.Subject).
+		SetMessage(form.Message).
+		SetIP(ctx.RealIP()).
+		SetUserAgent(ctx.Request().UserAgent()).
+		Save(ctx.Request().Context())
+
+	if err != nil {
+		return c.Fail(err, "не удается сохранить сообщение")
+	}
+
+	logging.Infof(ctx.Request().Context(), "получено сообщение с формы обратной связи: %d", msg.ID)
+
+	// Queue forwarding of the message by email
+	err = c.Container.Tasks.
+		New(tasks.TypeContactForward).
+		Context(ctx.Request().Context()).
+		Payload(tasks.ContactForwardPayload{MessageID: msg.ID}).
+		MaxRetries(5).
+		Save()
+	if err != nil {
+		logging.Errorf(ctx.Request().Context(), "не удается поставить в очередь отправку сообщения %d: %v", msg.ID, err)
+	}
+
+	return c.accepted(ctx)
+}
+
+// Index возвращает страницу сообщений с формы обратной связи, по умолчанию новые первыми
+// Параметр handled=true или handled=false ограничивает список обработанными или необработанными сообщениями
+func (c *contact) Index(ctx echo.Context) error {
+	p, err := c.Paginate(ctx, contactMessageList)
+	if err != nil {
+		return err
+	}
+
+	where := []predicate.ContactMessage{predicate.ContactMessage(p.Where())}
+	switch ctx.QueryParam("handled") {
+	case "true":
+		where = append(where, contactmessage.HandledAtNotNil())
+	case "false":
+		where = append(where, contactmessage.HandledAtIsNil())
+	}
+
+	query := c.Container.ORM.ContactMessage.
+		Query().
+		Where(where...)
+
+	var total int
+	if p.Total {
+		if total, err = query.Clone().Count(ctx.Request().Context()); err != nil {
+			return c.Fail(err, "не удается подсчитать сообщения")
+		}
+	}
+
+	msgs, err := query.
+		Where(predicate.ContactMessage(p.Seek())).
+		Order(contactmessage.OrderOption(p.Order())).
+		Limit(p.Fetch()).
+		All(ctx.Request().Context())
+
+	if err != nil {
+		return c.Fail(err, "не удается загрузить сообщения")
+	}
+
+	page, err := paging.NewPage(p, msgs, newContactMessageResponse)
+	if err != nil {
+		return c.Fail(err, "не удается сформировать страницу сообщений")
+	}
+	if p.Total {
+		page.Total = &total
+	}
+
+	return c.RenderPage(ctx, page)
+}
+
+// Handle отмечает сообщение как обработанное
+func (c *contact) Handle(ctx echo.Context) error {
+	m := ctx.Get(context.ContactMessageKey).(*ent.ContactMessage)
+
+	if m.HandledAt == nil {
+		var err error
+		m, err = m.Update().
+			SetHandledAt(time.Now()).
+			Save(ctx.Request().Context())
+		if err != nil {
+			return c.Fail(err, "не удается обновить сообщение")
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, newContactMessageResponse(m))
+}
+
+// Unhandle снимает с сообщения отметку об обработке
+func (c *contact) Unhandle(ctx echo.Context) error {
+	m := ctx.Get(context.ContactMessageKey).(*ent.ContactMessage)
+
+	m, err := m.Update().
+		ClearHandledAt().
+		Save(ctx.Request().Context())
+	if err != nil {
+		return c.Fail(err, "не удается обновить сообщение")
+	}
+
+	return ctx.JSON(http.StatusOK, newContactMessageResponse(m))
+}
+
+// accepted отвечает, что сообщение принято
+func (c *contact) accepted(ctx echo.Context) error {
+	return ctx.JSON(http.StatusAccepted, messageResponse{Message: i18n.Ctx(ctx, "contact.accepted")})
+}
+
+// newContactMessageResponse формирует ответ с описанием сообщения
+func newContactMessageResponse(m *ent.ContactMessage) contactMessageResponse {
+	return contactMessageResponse{
+		ID:          m.ID,
+		Name:        m.Name,
+		Email:       m.Email,
+		Subject:     m.Subject,
+		Message:     m.Message,
+		IP:          m.IP,
+		UserAgent:   m.UserAgent,
+		ForwardedAt: m.ForwardedAt,
+		HandledAt:   m.HandledAt,
+		CreatedAt:   m.CreatedAt,
+	}
+}
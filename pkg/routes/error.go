@@ -0,0 +1,78 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+
+	"github.com/vovanwin/api-my-site/config"
+	"github.com/vovanwin/api-my-site/pkg/context"
+	"github.com/vovanwin/api-my-site/pkg/controller"
+	"github.com/vovanwin/api-my-site/pkg/i18n"
+	"github.com/vovanwin/api-my-site/pkg/logging"
+	"github.com/vovanwin/api-my-site/pkg/problem"
+)
+
+type errorHandler struct {
+	controller.Controller
+}
+
+// Get отвечает на любую ошибку, возвращенную обработчиком или middleware, в формате application/problem+json
+// Внутренние ошибки записываются в журнал, но не раскрываются клиенту в production окружении, где из ответа
+// также удаляются конфиденциальные данные.
+// Ошибки без пояснения получают переведенное пояснение, соответствующее коду ошибки
+func (e *errorHandler) Get(err error, ctx echo.Context) {
+	if ctx.Response().Committed || context.IsCanceledError(err) {
+		return
+	}
+
+	pe := problem.FromError(err)
+	requestID := ctx.Response().Header().Get(echo.HeaderXRequestID)
+
+	entry := logging.WithFields(ctx.Request().Context(), logrus.Fields{
+		"status":     pe.Status,
+		"code":       pe.Code,
+		"method":     ctx.Request().Method,
+		"uri":        ctx.Request().RequestURI,
+		"request_id": requestID,
+	})
+	if pe.Internal != nil {
+		entry = entry.WithError(pe.Internal)
+	}
+	if pe.Status >= http.StatusInternalServerError {
+		entry.Error("ошибка обработки запроса")
+	} else {
+		entry.Info("запрос отклонен")
+	}
+
+	if ctx.Request().Method == http.MethodHead {
+		if err := ctx.NoContent(pe.Status); err != nil {
+			logging.Errorf(ctx.Request().Context(), "не удается отправить ответ с ошибкой: %v", err)
+		}
+		return
+	}
+
+	p := pe.Problem(
+		ctx.Request().URL.Path,
+		requestID,
+		e.Container.Config.App.Environment != config.EnvProduction,
+	)
+	if p.Detail == "" {
+		p.Detail, _ = i18n.Lookup(i18n.Locale(ctx), "error."+p.Code)
+	}
+	if e.Container.Config.App.Environment == config.EnvProduction {
+		p = p.Redact(logging.Redact)
+	}
+
+	body, err := json.Marshal(p)
+	if err != nil {
+		logging.Errorf(ctx.Request().Context(), "не удается закодировать ответ с ошибкой: %v", err)
+		return
+	}
+
+	if err := ctx.Blob(pe.Status, problem.ContentType, body); err != nil {
+		logging.Errorf(ctx.Request().Context(), "не удается отправить ответ с ошибкой: %v", err)
+	}
+}
@@ -0,0 +1,339 @@
+package routes
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/vovanwin/api-my-site/ent"
+	"github.com/vovanwin/api-my-site/ent/category"
+	"github.com/vovanwin/api-my-site/ent/page"
+	"github.com/vovanwin/api-my-site/ent/post"
+	"github.com/vovanwin/api-my-site/ent/predicate"
+	"github.com/vovanwin/api-my-site/ent/project"
+	"github.com/vovanwin/api-my-site/ent/tag"
+	"github.com/vovanwin/api-my-site/pkg/controller"
+	"github.com/vovanwin/api-my-site/pkg/feed"
+	"github.com/vovanwin/api-my-site/pkg/services"
+)
+
+type (
+	feeds struct {
+		controller.Controller
+	}
+
+	// feedFormat renders a feed in a given format
+	feedFormat struct {
+		contentType string
+		render      func(feed.Feed) ([]byte, error)
+	}
+)
+
+var (
+	feedRSS  = feedFormat{contentType: feed.ContentTypeRSS, render: feed.Feed.RSS}
+	feedAtom = feedFormat{contentType: feed.ContentTypeAtom, render: feed.Feed.Atom}
+	feedJSON = feedFormat{contentType: feed.ContentTypeJSON, render: feed.Feed.JSON}
+)
+
+// RSS отдает ленту RSS 2.0
+func (c *feeds) RSS(ctx echo.Context) error {
+	return c.render(ctx, feedRSS)
+}
+
+// Atom отдает ленту Atom
+func (c *feeds) Atom(ctx echo.Context) error {
+	return c.render(ctx, feedAtom)
+}
+
+// JSON отдает ленту JSON Feed
+func (c *feeds) JSON(ctx echo.Context) error {
+	return c.render(ctx, feedJSON)
+}
+
+// Sitemap отдает карту сайта, либо индекс карт сайта, если адресов больше, чем помещается в одну карту
+func (c *feeds) Sitemap(ctx echo.Context) error {
+	urls, err := c.sitemapURLs(ctx)
+	if err != nil {
+		return c.Fail(err, "не удается построить карту сайта")
+	}
+
+	next, err := c.nextPublication(ctx)
+	if err != nil {
+		return c.Fail(err, "не удается загрузить запланированные записи")
+	}
+
+	pages := feed.SitemapPages(len(urls))
+	if pages == 1 {
+		return c.renderSitemap(ctx, urls, next)
+	}
+
+	sitemaps := make([]feed.SitemapURL, 0, pages)
+	for i := 1; i <= pages; i++ {
+		sitemaps = append(sitemaps, feed.SitemapURL{
+			Loc: c.absoluteURL(ctx.Echo().Reverse("sitemap.page", fmt.Sprintf("%d.xml", i))),
+		})
+	}
+
+	body, err := feed.SitemapIndex(sitemaps)
+	if err != nil {
+		return c.Fail(err, "не удается построить индекс карт сайта")
+	}
+
+	return c.RenderCachedUntil(ctx, feed.ContentTypeSitemap, body, time.Time{}, next, services.CacheTagContent)
+}
+
+// SitemapPage отдает одну из карт сайта, перечисленных в индексе
+func (c *feeds) SitemapPage(ctx echo.Context) error {
+	page, err := strconv.Atoi(strings.TrimSuffix(ctx.Param("page"), ".xml"))
+	if err != nil || page < 1 {
+		return echo.NewHTTPError(http.StatusNotFound)
+	}
+
+	urls, err := c.sitemapURLs(ctx)
+	if err != nil {
+		return c.Fail(err, "не удается построить карту сайта")
+	}
+
+	if page > feed.SitemapPages(len(urls)) {
+		return echo.NewHTTPError(http.StatusNotFound)
+	}
+
+	next, err := c.nextPublication(ctx)
+	if err != nil {
+		return c.Fail(err, "не удается загрузить запланированные записи")
+	}
+
+	start := (page - 1) * feed.SitemapMaxURLs
+	end := start + feed.SitemapMaxURLs
+	if end > len(urls) {
+		end = len(urls)
+	}
+
+	return c.renderSitemap(ctx, urls[start:end], next)
+}
+
+// render строит ленту опубликованных записей, ограниченную тегом или категорией из пути запроса, если они указаны
+func (c *feeds) render(ctx echo.Context, format feedFormat) error {
+	cfg := c.Container.Config
+	f := feed.Feed{
+		Title:       cfg.App.Name,
+		Description: cfg.Feed.Description,
+		Language:    cfg.Feed.Language,
+		Link:        c.absoluteURL("/"),
+		FeedURL:     c.absoluteURL(ctx.Request().URL.Path),
+	}
+
+	var where []predicate.Post
+
+	switch {
+	case ctx.Param("tag") != "":
+		t, err := c.Container.ORM.Tag.
+			Query().
+			Where(tag.Slug(ctx.Param("tag"))).
+			Only(ctx.Request().Context())
+
+		switch err.(type) {
+		case nil:
+		case *ent.NotFoundError:
+			return echo.NewHTTPError(http.StatusNotFound)
+		default:
+			return c.Fail(err, "не удается загрузить тег")
+		}
+
+		f.Title = fmt.Sprintf("%s: %s", f.Title, t.Name)
+		f.Link = c.absoluteURL("/tags/" + t.Slug)
+		where = append(where, post.HasTagsWith(tag.ID(t.ID)))
+
+	case ctx.Param("category") != "":
+		cat, err := c.Container.ORM.Category.
+			Query().
+			Where(category.Slug(ctx.Param("category"))).
+			Only(ctx.Request().Context())
+
+		switch err.(type) {
+		case nil:
+		case *ent.NotFoundError:
+			return echo.NewHTTPError(http.StatusNotFound)
+		default:
+			return c.Fail(err, "не удается загрузить категорию")
+		}
+
+		f.Title = fmt.Sprintf("%s: %s", f.Title, cat.Name)
+		f.Link = c.absoluteURL("/categories/" + cat.Slug)
+		where = append(where, post.HasCategoryWith(category.ID(cat.ID)))
+	}
+
+	// The feed is cached until the next scheduled post of the feed is published
+	next, err := c.nextPublication(ctx, where...)
+	if err != nil {
+		return c.Fail(err, "не удается загрузить запланированные записи")
+	}
+
+	posts, err := c.Container.ORM.Post.
+		Query().
+		Where(append(where, post.PublishedAtNotNil(), post.PublishedAtLTE(time.Now()))...).
+		WithAuthor().
+		WithCategory().
+		WithTags().
+		Order(ent.Desc(post.FieldPublishedAt)).
+		Limit(cfg.Feed.Limit).
+		All(ctx.Request().Context())
+
+	if err != nil {
+		return c.Fail(err, "не удается загрузить записи для ленты")
+	}
+
+	for _, p := range posts {
+		link := c.absoluteURL("/posts/" + p.Slug)
+		item := feed.Item{
+			ID:        link,
+			Title:     p.Title,
+			Link:      link,
+			Summary:   p.Summary,
+			Content:   p.Body,
+			Published: *p.PublishedAt,
+			Updated:   p.UpdatedAt,
+		}
+		if p.Edges.Author != nil {
+			item.Author = p.Edges.Author.Name
+		}
+		if p.Edges.Category != nil {
+			item.Tags = append(item.Tags, p.Edges.Category.Name)
+		}
+		for _, t := range p.Edges.Tags {
+			item.Tags = append(item.Tags, t.Name)
+		}
+		if item.Updated.After(f.Updated) {
+			f.Updated = item.Updated
+		}
+		f.Items = append(f.Items, item)
+	}
+
+	body, err := format.render(f)
+	if err != nil {
+		return c.Fail(err, "не удается построить ленту")
+	}
+
+	return c.RenderCachedUntil(ctx, format.contentType, body, f.Updated, next, services.CacheTagContent)
+}
+
+// renderSitemap отдает карту сайта с заданными адресами, кэшируя ее не дольше заданного времени
+func (c *feeds) renderSitemap(ctx echo.Context, urls []feed.SitemapURL, until time.Time) error {
+	body, err := feed.Sitemap(urls)
+	if err != nil {
+		return c.Fail(err, "не удается построить карту сайта")
+	}
+
+	var lastModified time.Time
+	for _, u := range urls {
+		if u.LastMod.After(lastModified) {
+			lastModified = u.LastMod
+		}
+	}
+
+	return c.RenderCachedUntil(ctx, feed.ContentTypeSitemap, body, lastModified, until, services.CacheTagContent)
+}
+
+// nextPublication возвращает время публикации ближайшей запланированной записи, подходящей под условия,
+// либо нулевое время, если таких записей нет
+func (c *feeds) nextPublication(ctx echo.Context, where ...predicate.Post) (time.Time, error) {
+	p, err := c.Container.ORM.Post.
+		Query().
+		Where(append(where, post.PublishedAtGT(time.Now()))...).
+		Order(ent.Asc(post.FieldPublishedAt)).
+		Select(post.FieldPublishedAt).
+		First(ctx.Request().Context())
+
+	switch err.(type) {
+	case nil:
+		return *p.PublishedAt, nil
+	case *ent.NotFoundError:
+		return time.Time{}, nil
+	default:
+		return time.Time{}, err
+	}
+}
+
+// sitemapURLs возвращает все публичные адреса сайта
+func (c *feeds) sitemapURLs(ctx echo.Context) ([]feed.SitemapURL, error) {
+	urls := []feed.SitemapURL{{Loc: c.absoluteURL("/")}}
+	now := time.Now()
+
+	posts, err := c.Container.ORM.Post.
+		Query().
+		Where(post.PublishedAtNotNil(), post.PublishedAtLTE(now)).
+		Order(ent.Desc(post.FieldPublishedAt)).
+		Select(post.FieldSlug, post.FieldUpdatedAt).
+		All(ctx.Request().Context())
+
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range posts {
+		urls = append(urls, feed.SitemapURL{Loc: c.absoluteURL("/posts/" + p.Slug), LastMod: p.UpdatedAt})
+	}
+
+	pages, err := c.Container.ORM.Page.
+		Query().
+		Where(page.Published(true)).
+		Order(ent.Asc(page.FieldMenuOrder), ent.Asc(page.FieldID)).
+		Select(page.FieldSlug, page.FieldUpdatedAt).
+		All(ctx.Request().Context())
+
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range pages {
+		urls = append(urls, feed.SitemapURL{Loc: c.absoluteURL("/" + p.Slug), LastMod: p.UpdatedAt})
+	}
+
+	projects, err := c.Container.ORM.Project.
+		Query().
+		Order(ent.Asc(project.FieldPosition), ent.Desc(project.FieldCreatedAt)).
+		Select(project.FieldSlug, project.FieldUpdatedAt).
+		All(ctx.Request().Context())
+
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range projects {
+		urls = append(urls, feed.SitemapURL{Loc: c.absoluteURL("/projects/" + p.Slug), LastMod: p.UpdatedAt})
+	}
+
+	tags, err := c.Container.ORM.Tag.
+		Query().
+		Where(tag.HasPostsWith(post.PublishedAtLTE(now))).
+		Select(tag.FieldSlug).
+		Strings(ctx.Request().Context())
+
+	if err != nil {
+		return nil, err
+	}
+	for _, slug := range tags {
+		urls = append(urls, feed.SitemapURL{Loc: c.absoluteURL("/tags/" + slug)})
+	}
+
+	categories, err := c.Container.ORM.Category.
+		Query().
+		Where(category.HasPostsWith(post.PublishedAtLTE(now))).
+		Select(category.FieldSlug).
+		Strings(ctx.Request().Context())
+
+	if err != nil {
+		return nil, err
+	}
+	for _, slug := range categories {
+		urls = append(urls, feed.SitemapURL{Loc: c.absoluteURL("/categories/" + slug)})
+	}
+
+	return urls, nil
+}
+
+// absoluteURL возвращает абсолютный адрес заданного пути на сайте
+func (c *feeds) absoluteURL(path string) string {
+	return strings.TrimSuffix(c.Container.Config.App.URL, "/") + path
+}
@@ -0,0 +1,44 @@
+package routes
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vovanwin/api-my-site/pkg/factory"
+)
+
+func TestFeeds_Sitemap(t *testing.T) {
+	ctx := context.Background()
+	f := factory.New(c.ORM, time.Now().UnixNano())
+
+	published, err := f.Tag().Create(ctx)
+	require.NoError(t, err)
+	scheduled, err := f.Tag().Create(ctx)
+	require.NoError(t, err)
+	draft, err := f.Category().Create(ctx)
+	require.NoError(t, err)
+
+	_, err = f.Post().Tags(published).Published().Create(ctx)
+	require.NoError(t, err)
+	_, err = f.Post().Tags(scheduled).Category(draft).PublishedAt(time.Now().Add(time.Hour)).Create(ctx)
+	require.NoError(t, err)
+
+	resp := request(t).
+		setRoute("sitemap").
+		get().
+		assertStatusCode(http.StatusOK)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	// Listings without published posts would be empty
+	assert.Contains(t, string(body), "/tags/"+published.Slug+"<")
+	assert.NotContains(t, string(body), "/tags/"+scheduled.Slug+"<")
+	assert.NotContains(t, string(body), "/categories/"+draft.Slug+"<")
+}
@@ -0,0 +1,179 @@
+package routes
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/vovanwin/api-my-site/pkg/context"
+	"github.com/vovanwin/api-my-site/pkg/controller"
+	"github.com/vovanwin/api-my-site/pkg/i18n"
+	"github.com/vovanwin/api-my-site/pkg/logging"
+	"github.com/vovanwin/api-my-site/pkg/problem"
+	"github.com/vovanwin/api-my-site/pkg/services"
+)
+
+type (
+	flags struct {
+		controller.Controller
+	}
+
+	flagForm struct {
+		Key         string                    `form:"key" json:"key" normalize:"lower" validate:"required,slug" label:"field.key"`
+		Description string                    `form:"description" json:"description" label:"field.description"`
+		Enabled     bool                      `form:"enabled" json:"enabled"`
+		Rollout     int                       `form:"rollout" json:"rollout" validate:"gte=0,lte=100" label:"field.rollout"`
+		UserIDs     []int                     `form:"user_ids" json:"user_ids" label:"field.user_ids"`
+		Roles       []string                  `form:"roles" json:"roles" validate:"dive,oneof=user admin" label:"field.roles"`
+		Submission  controller.FormSubmission `form:"-" json:"-"`
+	}
+
+	flagResponse struct {
+		Key         string    `json:"key"`
+		Description string    `json:"description"`
+		Enabled     bool      `json:"enabled"`
+		Rollout     int       `json:"rollout"`
+		UserIDs     []int     `json:"user_ids"`
+		Roles       []string  `json:"roles"`
+		CreatedAt   time.Time `json:"created_at"`
+		UpdatedAt   time.Time `json:"updated_at"`
+	}
+)
+
+// Index возвращает все флаги функциональности
+func (c *flags) Index(ctx echo.Context) error {
+	fs, err := c.Container.FeatureFlags.List(ctx.Request().Context())
+	if err != nil {
+		return c.Fail(err, "не удается загрузить флаги функциональности")
+	}
+
+	res := make([]flagResponse, 0, len(fs))
+	for _, f := range fs {
+		res = append(res, newFlagResponse(f))
+	}
+
+	return ctx.JSON(http.StatusOK, res)
+}
+
+// Show возвращает флаг функциональности
+func (c *flags) Show(ctx echo.Context) error {
+	f := ctx.Get(context.FeatureFlagKey).(services.FeatureFlag)
+	return ctx.JSON(http.StatusOK, newFlagResponse(f))
+}
+
+// Create создает флаг функциональности
+// Если доля пользователей не указана, включенный флаг действует для всех
+func (c *flags) Create(ctx echo.Context) error {
+	form := flagForm{Rollout: 100}
+	if err := c.bind(ctx, &form); err != nil {
+		return err
+	}
+
+	if form.Submission.HasErrors() {
+		return problem.Validation(form.Submission.GetAllFieldErrors())
+	}
+
+	f, err := c.Container.FeatureFlags.Create(ctx.Request().Context(), form.flag())
+	switch {
+	case errors.Is(err, services.ErrFeatureFlagExists):
+		return problem.New(http.StatusConflict, problem.CodeConflict, i18n.Ctx(ctx, "flag.key_taken"))
+	case err != nil:
+		return c.Fail(err, "не удается сохранить флаг функциональности")
+	}
+
+	logging.Infof(ctx.Request().Context(), "создан флаг функциональности: %s", f.Key)
+
+	return ctx.JSON(http.StatusCreated, newFlagResponse(f))
+}
+
+// Update изменяет флаг функциональности
+// Ключ флага изменить нельзя, так как по нему флаг проверяется в коде
+func (c *flags) Update(ctx echo.Context) error {
+	form := flagForm{Rollout: 100}
+	if err := c.bind(ctx, &form); err != nil {
+		return err
+	}
+
+	if form.Submission.HasErrors() {
+		return problem.Validation(form.Submission.GetAllFieldErrors())
+	}
+
+	f, err := c.Container.FeatureFlags.Update(ctx.Request().Context(), form.flag())
+	switch {
+	case errors.Is(err, services.ErrFeatureFlagNotFound):
+		return echo.NewHTTPError(http.StatusNotFound)
+	case err != nil:
+		return c.Fail(err, "не удается сохранить флаг функциональности")
+	}
+
+	return ctx.JSON(http.StatusOK, newFlagResponse(f))
+}
+
+// Delete удаляет флаг функциональности
+// Проверки удаленного флага в коде считают его выключенным
+func (c *flags) Delete(ctx echo.Context) error {
+	f := ctx.Get(context.FeatureFlagKey).(services.FeatureFlag)
+
+	err := c.Container.FeatureFlags.Delete(ctx.Request().Context(), f.Key)
+	switch {
+	case errors.Is(err, services.ErrFeatureFlagNotFound):
+		return echo.NewHTTPError(http.StatusNotFound)
+	case err != nil:
+		return c.Fail(err, "не удается удалить флаг функциональности")
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// bind разбирает и проверяет форму флага функциональности
+// Ключ изменяемого флага берется из адреса, поэтому ключ из формы заменяется до проверки
+func (c *flags) bind(ctx echo.Context, form *flagForm) error {
+	if err := ctx.Bind(form); err != nil {
+		return err
+	}
+
+	if f, ok := ctx.Get(context.FeatureFlagKey).(services.FeatureFlag); ok {
+		form.Key = f.Key
+	}
+
+	if err := form.Submission.Process(ctx, form); err != nil {
+		return c.Fail(err, "не удается обработать отправку формы")
+	}
+
+	return nil
+}
+
+// flag переносит значения формы во флаг функциональности
+func (f *flagForm) flag() services.FeatureFlag {
+	return services.FeatureFlag{
+		Key:         f.Key,
+		Description: f.Description,
+		Enabled:     f.Enabled,
+		Rollout:     f.Rollout,
+		UserIDs:     f.UserIDs,
+		Roles:       f.Roles,
+	}
+}
+
+// newFlagResponse формирует ответ с описанием флага функциональности
+func newFlagResponse(f services.FeatureFlag) flagResponse {
+	res := flagResponse{
+		Key:         f.Key,
+		Description: f.Description,
+		Enabled:     f.Enabled,
+		Rollout:     f.Rollout,
+		UserIDs:     f.UserIDs,
+		Roles:       f.Roles,
+		CreatedAt:   f.CreatedAt,
+		UpdatedAt:   f.UpdatedAt,
+	}
+	if res.UserIDs == nil {
+		res.UserIDs = []int{}
+	}
+	if res.Roles == nil {
+		res.Roles = []string{}
+	}
+	return res
+}
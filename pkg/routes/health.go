@@ -0,0 +1,34 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/vovanwin/api-my-site/pkg/controller"
+	"github.com/vovanwin/api-my-site/pkg/services"
+)
+
+type health struct {
+	controller.Controller
+}
+
+// Live сообщает, что процесс запущен и обрабатывает запросы
+// Зависимости не проверяются, чтобы их недоступность не приводила к перезапуску процесса
+func (c *health) Live(ctx echo.Context) error {
+	return ctx.JSON(http.StatusOK, services.HealthReport{Status: services.HealthStatusOK})
+}
+
+// Ready сообщает, готово ли приложение принимать трафик, с результатом и временем каждой проверки
+// Во время остановки сервера проверки не выполняются и сразу возвращается ошибка
+func (c *health) Ready(ctx echo.Context) error {
+	report := c.Container.Health.Check(ctx.Request().Context())
+
+	status := http.StatusOK
+	if report.Status != services.HealthStatusOK {
+		status = http.StatusServiceUnavailable
+	}
+
+	ctx.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+	return ctx.JSON(status, report)
+}
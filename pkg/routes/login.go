@@ -1,12 +1,16 @@
 package routes
 
 import (
-	"github.com/sirupsen/logrus"
 	"net/http"
 	"strings"
 
+	"github.com/vovanwin/api-my-site/ent"
 	"github.com/vovanwin/api-my-site/ent/user"
 	"github.com/vovanwin/api-my-site/pkg/controller"
+	"github.com/vovanwin/api-my-site/pkg/i18n"
+	"github.com/vovanwin/api-my-site/pkg/logging"
+	"github.com/vovanwin/api-my-site/pkg/problem"
+	"github.com/vovanwin/api-my-site/pkg/services"
 
 	"github.com/labstack/echo/v4"
 )
@@ -17,42 +21,49 @@ type (
 	}
 
 	loginForm struct {
-		Email      string `form:"email" validate:"required,email"`
-		Password   string `form:"password" validate:"required"`
-		Submission controller.FormSubmission
+		Email      string                    `form:"email" json:"email" normalize:"lower" validate:"required,email" label:"field.email"`
+		Password   string                    `form:"password" json:"password" validate:"required" label:"field.password" trim:"-"`
+		Submission controller.FormSubmission `form:"-" json:"-"`
+	}
+
+	// tokenResponse содержит выданный токен
+	tokenResponse struct {
+		Token string `json:"token"`
 	}
 )
 
 func (c *login) Post(ctx echo.Context) error {
 	var form loginForm
 
-	authFailed := func() error {
-		form.Submission.SetFieldError("Email", "")
-		form.Submission.SetFieldError("Password", "")
-		return ctx.JSON(http.StatusBadRequest, map[string]interface{}{
-			"message": "Неверные учетные данные. Пожалуйста, попробуйте снова",
-		})
+	authFailed := func(u *ent.User) error {
+		entry := services.AuditEntry{
+			Action: "auth.login_failed",
+			After:  map[string]interface{}{"email": strings.ToLower(form.Email)},
+		}
+		if u != nil {
+			entry.TargetType = "user"
+			entry.TargetID = &u.ID
+		}
+		c.Container.Audit.Record(ctx.Request().Context(), entry)
+
+		return problem.New(
+			http.StatusUnauthorized,
+			problem.CodeInvalidCredentials,
+			i18n.Ctx(ctx, "auth.invalid_credentials"),
+		)
 	}
 
 	// Parse the form values
 	if err := ctx.Bind(&form); err != nil {
-		logrus.Infof("не удается разобрать форму входа в систему: %s", err)
-		return ctx.JSON(http.StatusBadRequest, map[string]interface{}{
-			"message": "Неверные учетные данные. Пожалуйста, попробуйте снова",
-		})
+		return err
 	}
 
-	if err := form.Submission.Process(ctx, form); err != nil {
-		logrus.Infof("\"не удается обработать отправку формы\": %s", err)
-		return ctx.JSON(http.StatusBadRequest, map[string]interface{}{
-			"message": "Неверные учетные данные. Пожалуйста, попробуйте снова",
-		})
+	if err := form.Submission.Process(ctx, &form); err != nil {
+		return c.Fail(err, "не удается обработать отправку формы")
 	}
 
 	if form.Submission.HasErrors() {
-		return ctx.JSON(http.StatusBadRequest, map[string]interface{}{
-			"message": "Неверные учетные данные. Пожалуйста, попробуйте снова",
-		})
+		return problem.Validation(form.Submission.GetAllFieldErrors())
 	}
 
 	// Попытка загрузить пользователя
@@ -63,29 +74,42 @@ func (c *login) Post(ctx echo.Context) error {
 
 	switch err.(type) {
 	case *ent.NotFoundError:
-		return authFailed()
+		return authFailed(nil)
 	case nil:
 	default:
-		return ctx.JSON(http.StatusBadRequest, map[string]interface{}{
-			"message": "ошибка при запросе пользователя во время входа в систему",
-		})
+		return c.Fail(err, "ошибка при запросе пользователя во время входа в систему")
 	}
 
 	// Проверьте правильность пароля
 	err = c.Container.Auth.CheckPassword(form.Password, u.Password)
 	if err != nil {
-		return authFailed()
+		return authFailed(u)
+	}
+
+	if u.BlockedAt != nil {
+		return problem.New(http.StatusForbidden, problem.CodeAccountBlocked, "")
+	}
+
+	// Вход в систему в течение льготного периода отменяет удаление учетной записи
+	if u.DeleteAt != nil {
+		if u, err = u.Update().ClearDeleteAt().Save(ctx.Request().Context()); err != nil {
+			return c.Fail(err, "не удается отменить удаление учетной записи")
+		}
+		logging.Infof(ctx.Request().Context(), "отменено удаление пользователя: %d", u.ID)
 	}
 
 	// Войдите в систему пользователя
-	token, err := c.Container.Auth.Login(ctx, u.ID)
+	token, err := c.Container.Auth.Login(ctx, u)
 	if err != nil {
-		return ctx.JSON(http.StatusBadRequest, map[string]interface{}{
-			"message": "Неверные учетные данные. Пожалуйста, попробуйте снова",
-		})
+		return c.Fail(err, "не удается войти в систему")
 	}
 
-	return ctx.JSON(http.StatusOK, map[string]interface{}{
-		"token": token,
+	c.Container.Audit.Record(ctx.Request().Context(), services.AuditEntry{
+		Action:     "auth.login",
+		ActorID:    &u.ID,
+		TargetType: "user",
+		TargetID:   &u.ID,
 	})
+
+	return ctx.JSON(http.StatusOK, tokenResponse{Token: token})
 }
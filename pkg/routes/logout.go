@@ -0,0 +1,34 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/vovanwin/api-my-site/ent"
+	"github.com/vovanwin/api-my-site/pkg/context"
+	"github.com/vovanwin/api-my-site/pkg/controller"
+	"github.com/vovanwin/api-my-site/pkg/services"
+)
+
+type logout struct {
+	controller.Controller
+}
+
+// Post завершает сеанс пользователя
+// Токены не хранятся на сервере, поэтому отзываются все выданные пользователю токены
+func (c *logout) Post(ctx echo.Context) error {
+	u := ctx.Get(context.AuthenticatedUserKey).(*ent.User)
+
+	if err := c.Container.Auth.RevokeTokens(ctx, u.ID); err != nil {
+		return c.Fail(err, "не удается выйти из системы")
+	}
+
+	c.Container.Audit.Record(ctx.Request().Context(), services.AuditEntry{
+		Action:     "auth.logout",
+		TargetType: "user",
+		TargetID:   &u.ID,
+	})
+
+	return ctx.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,397 @@
+package routes
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/vovanwin/api-my-site/ent"
+	"github.com/vovanwin/api-my-site/ent/user"
+	"github.com/vovanwin/api-my-site/pkg/context"
+	"github.com/vovanwin/api-my-site/pkg/controller"
+	"github.com/vovanwin/api-my-site/pkg/i18n"
+	"github.com/vovanwin/api-my-site/pkg/logging"
+	"github.com/vovanwin/api-my-site/pkg/problem"
+	"github.com/vovanwin/api-my-site/pkg/services"
+	"github.com/vovanwin/api-my-site/pkg/tasks"
+)
+
+type (
+	me struct {
+		controller.Controller
+	}
+
+	// meForm содержит изменяемые поля профиля, поля без значения не изменяются
+	meForm struct {
+		Name       *string                   `form:"name" json:"name" validate:"omitempty,min=1,max=100" label:"field.name"`
+		AvatarID   *int                      `form:"avatar_id" json:"avatar_id" validate:"omitempty,min=0" label:"field.avatar"`
+		Bio        *string                   `form:"bio" json:"bio" validate:"omitempty,max=1000" label:"field.bio"`
+		Locale     *string                   `form:"locale" json:"locale" validate:"omitempty,locale" label:"field.locale"`
+		Timezone   *string                   `form:"timezone" json:"timezone" validate:"omitempty,timezone" label:"field.timezone"`
+		Submission controller.FormSubmission `form:"-" json:"-"`
+	}
+
+	meEmailForm struct {
+		Email      string                    `form:"email" json:"email" normalize:"lower" validate:"required,email,unique_email" label:"field.email"`
+		Password   string                    `form:"password" json:"password" validate:"required" label:"field.password" trim:"-"`
+		Submission controller.FormSubmission `form:"-" json:"-"`
+	}
+
+	mePasswordForm struct {
+		CurrentPassword string                    `form:"current_password" json:"current_password" validate:"required" label:"field.current_password" trim:"-"`
+		Password        string                    `form:"password" json:"password" validate:"required,password" label:"field.password" trim:"-"`
+		ConfirmPassword string                    `form:"password-confirm" json:"password-confirm" validate:"required,eqfield=Password" label:"field.password_confirm" trim:"-"`
+		Submission      controller.FormSubmission `form:"-" json:"-"`
+	}
+
+	meDeleteForm struct {
+		Password   string                    `form:"password" json:"password" validate:"required" label:"field.password" trim:"-"`
+		Submission controller.FormSubmission `form:"-" json:"-"`
+	}
+
+	emailConfirmForm struct {
+		Token      string                    `form:"token" json:"token" validate:"required" label:"field.token"`
+		Submission controller.FormSubmission `form:"-" json:"-"`
+	}
+
+	meResponse struct {
+		ID        int            `json:"id"`
+		Name      string         `json:"name"`
+		Email     string         `json:"email"`
+		Verified  bool           `json:"verified"`
+		Role      string         `json:"role"`
+		Bio       string         `json:"bio"`
+		Locale    string         `json:"locale"`
+		Timezone  string         `json:"timezone"`
+		Avatar    *mediaResponse `json:"avatar"`
+		DeleteAt  *time.Time     `json:"delete_at"`
+		CreatedAt time.Time      `json:"created_at"`
+	}
+)
+
+// Get возвращает профиль аутентифицированного пользователя
+func (c *me) Get(ctx echo.Context) error {
+	return c.render(ctx, http.StatusOK, c.user(ctx))
+}
+
+// Update изменяет переданные поля профиля аутентифицированного пользователя
+// Пустая биография очищает ее, а нулевой идентификатор аватара удаляет аватар
+func (c *me) Update(ctx echo.Context) error {
+	u := c.user(ctx)
+
+	var form meForm
+	if err := ctx.Bind(&form); err != nil {
+		return err
+	}
+
+	if err := form.Submission.Process(ctx, &form); err != nil {
+		return c.Fail(err, "не удается обработать отправку формы")
+	}
+
+	// Аватаром может быть только собственное изображение пользователя
+	if form.AvatarID != nil && *form.AvatarID != 0 && !form.Submission.FieldHasErrors("avatar_id") {
+		m, err := c.Container.ORM.Media.Get(ctx.Request().Context(), *form.AvatarID)
+		if err == nil {
+			var owned bool
+			if owned, err = m.QueryOwner().Where(user.ID(u.ID)).Exist(ctx.Request().Context()); err == nil && !owned {
+				err = &ent.NotFoundError{}
+			}
+		}
+		switch err.(type) {
+		case nil:
+			if !strings.HasPrefix(m.MimeType, "image/") {
+				form.Submission.SetFieldError("avatar_id", i18n.Ctx(ctx, "me.avatar_not_image"))
+			}
+		case *ent.NotFoundError:
+			form.Submission.SetFieldError("avatar_id", i18n.Ctx(ctx, "me.avatar_not_found"))
+		default:
+			return c.Fail(err, "не удается загрузить аватар")
+		}
+	}
+
+	if form.Submission.HasErrors() {
+		return problem.Validation(form.Submission.GetAllFieldErrors())
+	}
+
+	update := u.Update()
+	if form.Name != nil {
+		update.SetName(*form.Name)
+	}
+	if form.AvatarID != nil {
+		if *form.AvatarID == 0 {
+			update.ClearAvatar()
+		} else {
+			update.SetAvatarID(*form.AvatarID)
+		}
+	}
+	if form.Bio != nil {
+		update.SetBio(*form.Bio)
+	}
+	if form.Locale != nil {
+		update.SetLocale(*form.Locale)
+	}
+	if form.Timezone != nil {
+		update.SetTimezone(*form.Timezone)
+	}
+
+	u, err := update.Save(ctx.Request().Context())
+	if err != nil {
+		return c.Fail(err, "не удается сохранить профиль")
+	}
+
+	return c.render(ctx, http.StatusOK, u)
+}
+
+// Email отправляет на новый адрес электронной почты ссылку для подтверждения его смены
+// Адрес изменяется только после перехода по ссылке, после чего он считается подтвержденным
+func (c *me) Email(ctx echo.Context) error {
+	u := c.user(ctx)
+
+	var form meEmailForm
+	if err := ctx.Bind(&form); err != nil {
+		return err
+	}
+
+	if err := form.Submission.Process(ctx, &form); err != nil {
+		return c.Fail(err, "не удается обработать отправку формы")
+	}
+
+	if form.Submission.HasErrors() {
+		return problem.Validation(form.Submission.GetAllFieldErrors())
+	}
+
+	if err := c.checkPassword(ctx, u, form.Password); err != nil {
+		return err
+	}
+
+	token, err := c.Container.Auth.GenerateEmailChangeToken(u, form.Email)
+	if err != nil {
+		return c.Fail(err, "не удается сгенерировать токен смены адреса электронной почты")
+	}
+
+	link := fmt.Sprintf("%s/email/confirm?token=%s", strings.TrimSuffix(c.Container.Config.App.URL, "/"), url.QueryEscape(token))
+	err = c.Container.Mail.
+		Compose().
+		To(form.Email).
+		Subject(i18n.Ctx(ctx, "me.email_change_subject")).
+		Body(i18n.Ctx(ctx, "me.email_change_body", i18n.Params{"url": link})).
+		Send(ctx.Request().Context())
+	if err != nil {
+		return c.Fail(err, "не удается отправить ссылку для смены адреса электронной почты")
+	}
+
+	return ctx.JSON(http.StatusAccepted, messageResponse{Message: i18n.Ctx(ctx, "me.email_change_sent")})
+}
+
+// ConfirmEmail изменяет адрес электронной почты пользователя по токену из ссылки подтверждения
+func (c *me) ConfirmEmail(ctx echo.Context) error {
+	var form emailConfirmForm
+	if err := ctx.Bind(&form); err != nil {
+		return err
+	}
+
+	if err := form.Submission.Process(ctx, &form); err != nil {
+		return c.Fail(err, "не удается обработать отправку формы")
+	}
+
+	if form.Submission.HasErrors() {
+		return problem.Validation(form.Submission.GetAllFieldErrors())
+	}
+
+	invalidToken := func() error {
+		return problem.New(http.StatusBadRequest, problem.CodeBadRequest, i18n.Ctx(ctx, "me.email_change_invalid"))
+	}
+
+	change, err := c.Container.Auth.ValidateEmailChangeToken(form.Token)
+	if err != nil {
+		return invalidToken()
+	}
+
+	u, err := c.Container.ORM.User.Get(ctx.Request().Context(), change.UserID)
+	switch err.(type) {
+	case nil:
+	case *ent.NotFoundError:
+		return invalidToken()
+	default:
+		return c.Fail(err, "не удается загрузить пользователя")
+	}
+
+	// Токены, выданные до смены пароля, недействительны, как и токены, выданные до смены адреса,
+	// поэтому каждый токен можно использовать только один раз
+	if u.TokenVersion != change.TokenVersion || !strings.EqualFold(u.Email, change.From) {
+		return invalidToken()
+	}
+
+	previous := u.Email
+	u, err = u.Update().
+		SetEmail(change.Email).
+		SetVerified(true).
+		Save(ctx.Request().Context())
+
+	switch err.(type) {
+	case nil:
+		logging.Infof(ctx.Request().Context(), "изменен адрес электронной почты пользователя: %d", u.ID)
+	case *ent.ConstraintError:
+		return problem.New(http.StatusConflict, problem.CodeConflict, i18n.Ctx(ctx, "auth.user_exists"))
+	default:
+		return c.Fail(err, "не удается изменить адрес электронной почты")
+	}
+
+	// Прежний адрес уведомляется о смене, чтобы владелец заметил захват учетной записи
+	err = c.Container.Mail.
+		Compose().
+		To(previous).
+		Subject(i18n.Ctx(ctx, "me.email_changed_subject")).
+		Body(i18n.Ctx(ctx, "me.email_changed_body", i18n.Params{"email": u.Email})).
+		Send(ctx.Request().Context())
+	if err != nil {
+		logging.Errorf(ctx.Request().Context(), "не удается уведомить прежний адрес о смене адреса электронной почты: %v", err)
+	}
+
+	return c.render(ctx, http.StatusOK, u)
+}
+
+// Password изменяет пароль аутентифицированного пользователя
+// Все выданные токены отзываются, а в ответе возвращается новый токен
+func (c *me) Password(ctx echo.Context) error {
+	u := c.user(ctx)
+
+	var form mePasswordForm
+	if err := ctx.Bind(&form); err != nil {
+		return err
+	}
+
+	if err := form.Submission.Process(ctx, &form); err != nil {
+		return c.Fail(err, "не удается обработать отправку формы")
+	}
+
+	if form.Submission.HasErrors() {
+		return problem.Validation(form.Submission.GetAllFieldErrors())
+	}
+
+	if err := c.checkPassword(ctx, u, form.CurrentPassword); err != nil {
+		return err
+	}
+
+	hash, err := c.Container.Auth.HashPassword(form.Password)
+	if err != nil {
+		return c.Fail(err, "не удается хешировать пароль")
+	}
+
+	u, err = u.Update().
+		SetPassword(hash).
+		AddTokenVersion(1).
+		Save(ctx.Request().Context())
+	if err != nil {
+		return c.Fail(err, "не удается изменить пароль")
+	}
+
+	c.Container.Audit.Record(ctx.Request().Context(), services.AuditEntry{
+		Action:     "auth.password_change",
+		TargetType: "user",
+		TargetID:   &u.ID,
+	})
+
+	if err := c.Container.Auth.DeletePasswordTokens(ctx, u.ID); err != nil {
+		logging.Errorf(ctx.Request().Context(), "не удается удалить токены сброса пароля пользователя %d: %v", u.ID, err)
+	}
+
+	token, err := c.Container.Auth.Login(ctx, u)
+	if err != nil {
+		return c.Fail(err, "не удается войти в систему")
+	}
+
+	return ctx.JSON(http.StatusOK, tokenResponse{Token: token})
+}
+
+// Delete удаляет учетную запись аутентифицированного пользователя по истечении льготного периода
+// Все выданные токены отзываются, а вход в систему до истечения периода отменяет удаление
+func (c *me) Delete(ctx echo.Context) error {
+	u := c.user(ctx)
+
+	var form meDeleteForm
+	if err := ctx.Bind(&form); err != nil {
+		return err
+	}
+
+	if err := form.Submission.Process(ctx, &form); err != nil {
+		return c.Fail(err, "не удается обработать отправку формы")
+	}
+
+	if form.Submission.HasErrors() {
+		return problem.Validation(form.Submission.GetAllFieldErrors())
+	}
+
+	if err := c.checkPassword(ctx, u, form.Password); err != nil {
+		return err
+	}
+
+	deleteAt := time.Now().Add(c.Container.Config.App.AccountDeletionGracePeriod).Truncate(time.Second)
+	u, err := u.Update().
+		SetDeleteAt(deleteAt).
+		AddTokenVersion(1).
+		Save(ctx.Request().Context())
+	if err != nil {
+		return c.Fail(err, "не удается запланировать удаление учетной записи")
+	}
+
+	err = c.Container.Tasks.
+		New(tasks.TypeUserDelete).
+		Context(ctx.Request().Context()).
+		Payload(tasks.UserDeletePayload{UserID: u.ID}).
+		At(deleteAt).
+		MaxRetries(3).
+		Save()
+	if err != nil {
+		return c.Fail(err, "не удается поставить в очередь удаление учетной записи")
+	}
+
+	logging.Infof(ctx.Request().Context(), "запланировано удаление пользователя %d: %s", u.ID, deleteAt)
+
+	return c.render(ctx, http.StatusAccepted, u)
+}
+
+// user возвращает аутентифицированного пользователя
+func (c *me) user(ctx echo.Context) *ent.User {
+	return ctx.Get(context.AuthenticatedUserKey).(*ent.User)
+}
+
+// checkPassword проверяет текущий пароль пользователя перед изменением учетной записи
+func (c *me) checkPassword(ctx echo.Context, u *ent.User, password string) error {
+	if err := c.Container.Auth.CheckPassword(password, u.Password); err != nil {
+		return problem.New(http.StatusForbidden, problem.CodeInvalidCredentials, i18n.Ctx(ctx, "me.invalid_password"))
+	}
+	return nil
+}
+
+// render отправляет профиль пользователя вместе с аватаром
+func (c *me) render(ctx echo.Context, status int, u *ent.User) error {
+	res := meResponse{
+		ID:        u.ID,
+		Name:      u.Name,
+		Email:     u.Email,
+		Verified:  u.Verified,
+		Role:      u.Role.String(),
+		Bio:       u.Bio,
+		Locale:    u.Locale,
+		Timezone:  u.Timezone,
+		DeleteAt:  u.DeleteAt,
+		CreatedAt: u.CreatedAt,
+	}
+
+	avatar, err := u.QueryAvatar().WithVariants().Only(ctx.Request().Context())
+	switch err.(type) {
+	case nil:
+		m := newMediaResponse(ctx, c.Container.Media, avatar)
+		res.Avatar = &m
+	case *ent.NotFoundError:
+	default:
+		return c.Fail(err, "не удается загрузить аватар")
+	}
+
+	return ctx.JSON(status, res)
+}
@@ -0,0 +1,183 @@
+package routes
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vovanwin/api-my-site/ent"
+	"github.com/vovanwin/api-my-site/pkg/factory"
+	"github.com/vovanwin/api-my-site/pkg/tasks"
+)
+
+// createUser creates a verified user with the default factory password
+func createUser(t *testing.T) *ent.User {
+	u, err := factory.New(c.ORM, time.Now().UnixNano()).User().Verified().Create(context.Background())
+	require.NoError(t, err)
+	return u
+}
+
+// logIn logs a user in and returns the issued token
+func logIn(t *testing.T, email, password string) string {
+	var res tokenResponse
+	request(t).
+		setRoute("login.post").
+		send(http.MethodPost, map[string]string{"email": email, "password": password}).
+		assertStatusCode(http.StatusOK).
+		toJSON(&res)
+	require.NotEmpty(t, res.Token)
+	return res.Token
+}
+
+func TestMe_WrongPassword(t *testing.T) {
+	u := createUser(t)
+	token := logIn(t, u.Email, factory.DefaultPassword)
+
+	cases := []struct {
+		route  string
+		method string
+		body   map[string]string
+	}{
+		{"me.email", http.MethodPost, map[string]string{"email": "wrong.password@localhost.localhost", "password": "wrong"}},
+		{"me.password", http.MethodPost, map[string]string{
+			"current_password": "wrong",
+			"password":         "New-password-123",
+			"password-confirm": "New-password-123",
+		}},
+		{"me.delete", http.MethodDelete, map[string]string{"password": "wrong"}},
+	}
+
+	for _, test := range cases {
+		request(t).
+			setRoute(test.route).
+			setToken(token).
+			send(test.method, test.body).
+			assertStatusCode(http.StatusForbidden)
+	}
+
+	// Nothing should have changed
+	u, err := c.ORM.User.Get(context.Background(), u.ID)
+	require.NoError(t, err)
+	assert.Nil(t, u.DeleteAt)
+	assert.Zero(t, u.TokenVersion)
+	assert.NoError(t, c.Auth.CheckPassword(factory.DefaultPassword, u.Password))
+}
+
+func TestMe_Password(t *testing.T) {
+	u := createUser(t)
+	old := logIn(t, u.Email, factory.DefaultPassword)
+
+	var res tokenResponse
+	request(t).
+		setRoute("me.password").
+		setToken(old).
+		send(http.MethodPost, map[string]string{
+			"current_password": factory.DefaultPassword,
+			"password":         "New-password-123",
+			"password-confirm": "New-password-123",
+		}).
+		assertStatusCode(http.StatusOK).
+		toJSON(&res)
+
+	u, err := c.ORM.User.Get(context.Background(), u.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, u.TokenVersion)
+
+	// Tokens issued before the change are revoked, while the returned one is valid
+	request(t).
+		setRoute("me.get").
+		setToken(old).
+		send(http.MethodGet, nil).
+		assertStatusCode(http.StatusUnauthorized)
+	request(t).
+		setRoute("me.get").
+		setToken(res.Token).
+		send(http.MethodGet, nil).
+		assertStatusCode(http.StatusOK)
+
+	logIn(t, u.Email, "New-password-123")
+}
+
+func TestMe_ConfirmEmail(t *testing.T) {
+	u := createUser(t)
+	token, err := c.Auth.GenerateEmailChangeToken(u, "confirmed."+u.Email)
+	require.NoError(t, err)
+
+	var res meResponse
+	request(t).
+		setRoute("auth.email.confirm").
+		send(http.MethodPost, map[string]string{"token": token}).
+		assertStatusCode(http.StatusOK).
+		toJSON(&res)
+	assert.Equal(t, "confirmed."+u.Email, res.Email)
+	assert.True(t, res.Verified)
+
+	// The token can only be used once
+	request(t).
+		setRoute("auth.email.confirm").
+		send(http.MethodPost, map[string]string{"token": token}).
+		assertStatusCode(http.StatusBadRequest)
+
+	// Expired tokens are rejected
+	expiration := c.Config.App.EmailVerificationTokenExpiration
+	c.Config.App.EmailVerificationTokenExpiration = -time.Minute
+	u, err = c.ORM.User.Get(context.Background(), u.ID)
+	require.NoError(t, err)
+	token, err = c.Auth.GenerateEmailChangeToken(u, "expired."+u.Email)
+	c.Config.App.EmailVerificationTokenExpiration = expiration
+	require.NoError(t, err)
+
+	request(t).
+		setRoute("auth.email.confirm").
+		send(http.MethodPost, map[string]string{"token": token}).
+		assertStatusCode(http.StatusBadRequest)
+
+	u, err = c.ORM.User.Get(context.Background(), u.ID)
+	require.NoError(t, err)
+	assert.Equal(t, res.Email, u.Email)
+}
+
+func TestMe_Delete(t *testing.T) {
+	u := createUser(t)
+	token := logIn(t, u.Email, factory.DefaultPassword)
+
+	var res meResponse
+	request(t).
+		setRoute("me.delete").
+		setToken(token).
+		send(http.MethodDelete, map[string]string{"password": factory.DefaultPassword}).
+		assertStatusCode(http.StatusAccepted).
+		toJSON(&res)
+	require.NotNil(t, res.DeleteAt)
+
+	// The deletion is scheduled for the end of the grace period
+	scheduled, err := c.Tasks.List("default", "scheduled", 1000)
+	require.NoError(t, err)
+	var found bool
+	for _, task := range scheduled {
+		var payload tasks.UserDeletePayload
+		if task.Type == tasks.TypeUserDelete && json.Unmarshal(task.Payload, &payload) == nil && payload.UserID == u.ID {
+			found = true
+			assert.WithinDuration(t, *res.DeleteAt, task.NextProcessAt, time.Second)
+		}
+	}
+	assert.True(t, found)
+
+	// The session is revoked
+	request(t).
+		setRoute("me.get").
+		setToken(token).
+		send(http.MethodGet, nil).
+		assertStatusCode(http.StatusUnauthorized)
+
+	// Logging in again cancels the deletion
+	logIn(t, u.Email, factory.DefaultPassword)
+	u, err = c.ORM.User.Get(context.Background(), u.ID)
+	require.NoError(t, err)
+	assert.Nil(t, u.DeleteAt)
+}
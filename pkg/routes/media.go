@@ -0,0 +1,284 @@
+package routes
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/vovanwin/api-my-site/ent"
+	"github.com/vovanwin/api-my-site/pkg/context"
+	"github.com/vovanwin/api-my-site/pkg/controller"
+	"github.com/vovanwin/api-my-site/pkg/i18n"
+	"github.com/vovanwin/api-my-site/pkg/logging"
+	"github.com/vovanwin/api-my-site/pkg/problem"
+	"github.com/vovanwin/api-my-site/pkg/services"
+	"github.com/vovanwin/api-my-site/pkg/tasks"
+)
+
+// mediaMultipartOverhead stores the extra body size allowed on top of the file for the multipart encoding
+const mediaMultipartOverhead = 1 << 20
+
+type (
+	media struct {
+		controller.Controller
+	}
+
+	mediaResponse struct {
+		ID        int                    `json:"id"`
+		Filename  string                 `json:"filename"`
+		MimeType  string                 `json:"mime_type"`
+		Size      int64                  `json:"size"`
+		Checksum  string                 `json:"checksum"`
+		Width     int                    `json:"width"`
+		Height    int                    `json:"height"`
+		Private   bool                   `json:"private"`
+		Processed bool                   `json:"processed"`
+		URL       string                 `json:"url"`
+		Srcset    string                 `json:"srcset"`
+		Variants  []mediaVariantResponse `json:"variants"`
+		CreatedAt time.Time              `json:"created_at"`
+	}
+
+	mediaVariantResponse struct {
+		Name     string `json:"name"`
+		MimeType string `json:"mime_type"`
+		Size     int64  `json:"size"`
+		Width    int    `json:"width"`
+		Height   int    `json:"height"`
+		URL      string `json:"url"`
+	}
+)
+
+// Post загружает новый медиафайл
+func (c *media) Post(ctx echo.Context) error {
+	u := ctx.Get(context.AuthenticatedUserKey).(*ent.User)
+
+	// Limit the body size before the multipart form is parsed
+	ctx.Request().Body = http.MaxBytesReader(
+		ctx.Response(),
+		ctx.Request().Body,
+		c.Container.Config.Storage.MaxUploadSize+mediaMultipartOverhead,
+	)
+
+	fh, err := ctx.FormFile("file")
+	if err != nil {
+		return problem.New(http.StatusBadRequest, problem.CodeBadRequest, i18n.Ctx(ctx, "media.file_missing")).
+			WithInternal(err)
+	}
+
+	f, err := fh.Open()
+	if err != nil {
+		return c.Fail(err, "не удается открыть загруженный файл")
+	}
+	defer f.Close()
+
+	m, err := c.Container.Media.Upload(ctx.Request().Context(), services.MediaUpload{
+		OwnerID:  u.ID,
+		Filename: fh.Filename,
+		Private:  ctx.FormValue("private") == "true",
+		Content:  f,
+	})
+
+	switch err.(type) {
+	case nil:
+	case services.MediaTooLargeError:
+		return problem.New(http.StatusRequestEntityTooLarge, problem.CodePayloadTooLarge, i18n.Ctx(ctx, "media.too_large"))
+	case services.MediaTooManyPixelsError:
+		return problem.New(http.StatusRequestEntityTooLarge, problem.CodePayloadTooLarge, i18n.Ctx(ctx, "media.too_many_pixels"))
+	case services.UnsupportedMediaTypeError:
+		return problem.New(http.StatusUnsupportedMediaType, problem.CodeUnsupportedMediaType, i18n.Ctx(ctx, "media.unsupported_type"))
+	case services.MediaQuotaExceededError:
+		return problem.New(http.StatusForbidden, problem.CodeQuotaExceeded, i18n.Ctx(ctx, "media.quota_exceeded"))
+	default:
+		return c.Fail(err, "не удается сохранить загруженный файл")
+	}
+
+	logging.Infof(ctx.Request().Context(), "загружен медиафайл: %d", m.ID)
+
+	// Queue generation of the variants
+	err = c.Container.Tasks.
+		New(tasks.TypeMediaVariants).
+		Context(ctx.Request().Context()).
+		Payload(tasks.MediaVariantsPayload{MediaID: m.ID}).
+		MaxRetries(3).
+		Save()
+	if err != nil {
+		logging.Errorf(ctx.Request().Context(), "не удается поставить в очередь создание вариантов медиафайла %d: %v", m.ID, err)
+	}
+
+	return ctx.JSON(http.StatusCreated, newMediaResponse(ctx, c.Container.Media, m))
+}
+
+// Get возвращает описание медиафайла
+func (c *media) Get(ctx echo.Context) error {
+	m := ctx.Get(context.MediaKey).(*ent.Media)
+
+	if m.Private && !c.isOwner(ctx, m) {
+		return echo.NewHTTPError(http.StatusNotFound)
+	}
+
+	return ctx.JSON(http.StatusOK, newMediaResponse(ctx, c.Container.Media, m))
+}
+
+// File отдает содержимое медиафайла
+// Закрытые файлы доступны только владельцу или по подписанной ссылке.
+// Пока из файла не удалены метаданные, он доступен только владельцу
+func (c *media) File(ctx echo.Context) error {
+	m := ctx.Get(context.MediaKey).(*ent.Media)
+	owner := c.isOwner(ctx, m)
+
+	if m.Private && !owner {
+		if err := c.Container.Media.VerifySignedQuery(m.ID, ctx.QueryParams()); err != nil {
+			return echo.NewHTTPError(http.StatusForbidden)
+		}
+	}
+
+	// The original may still carry the location and camera details
+	if m.ProcessedAt == nil && !owner {
+		return echo.NewHTTPError(http.StatusNotFound)
+	}
+
+	etag := `"` + m.Checksum + `"`
+	if ctx.Request().Header.Get("If-None-Match") == etag {
+		return ctx.NoContent(http.StatusNotModified)
+	}
+
+	f, err := c.Container.Media.Open(ctx.Request().Context(), m)
+	switch err.(type) {
+	case nil:
+	case services.StorageObjectNotFoundError:
+		return echo.NewHTTPError(http.StatusNotFound)
+	default:
+		return c.Fail(err, "не удается открыть медиафайл")
+	}
+	defer f.Close()
+
+	c.setFileHeaders(ctx, m, false)
+	ctx.Response().Header().Set("ETag", etag)
+
+	return ctx.Stream(http.StatusOK, m.MimeType, f)
+}
+
+// Variant отдает содержимое варианта медиафайла
+// Доступ к вариантам закрытых файлов проверяется так же, как и к самому файлу
+func (c *media) Variant(ctx echo.Context) error {
+	m := ctx.Get(context.MediaKey).(*ent.Media)
+
+	if m.Private && !c.isOwner(ctx, m) {
+		if err := c.Container.Media.VerifySignedQuery(m.ID, ctx.QueryParams()); err != nil {
+			return echo.NewHTTPError(http.StatusForbidden)
+		}
+	}
+
+	var variant *ent.MediaVariant
+	for _, v := range m.Edges.Variants {
+		if v.Name == ctx.Param("variant") {
+			variant = v
+		}
+	}
+	if variant == nil {
+		return echo.NewHTTPError(http.StatusNotFound)
+	}
+
+	f, err := c.Container.Media.OpenVariant(ctx.Request().Context(), variant)
+	switch err.(type) {
+	case nil:
+	case services.StorageObjectNotFoundError:
+		return echo.NewHTTPError(http.StatusNotFound)
+	default:
+		return c.Fail(err, "не удается открыть вариант медиафайла")
+	}
+	defer f.Close()
+
+	c.setFileHeaders(ctx, m, true)
+
+	return ctx.Stream(http.StatusOK, variant.MimeType, f)
+}
+
+// Delete удаляет медиафайл
+func (c *media) Delete(ctx echo.Context) error {
+	m := ctx.Get(context.MediaKey).(*ent.Media)
+
+	if !c.isOwner(ctx, m) {
+		return echo.NewHTTPError(http.StatusNotFound)
+	}
+
+	if err := c.Container.Media.Delete(ctx.Request().Context(), m); err != nil {
+		return c.Fail(err, "не удается удалить медиафайл")
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// isOwner указывает, является ли аутентифицированный пользователь владельцем медиафайла
+func (c *media) isOwner(ctx echo.Context, m *ent.Media) bool {
+	u, ok := ctx.Get(context.AuthenticatedUserKey).(*ent.User)
+	if !ok {
+		return false
+	}
+	owner, err := m.Edges.OwnerOrErr()
+	return err == nil && owner.ID == u.ID
+}
+
+// setFileHeaders устанавливает заголовки ответа с содержимым медиафайла
+// Оригинал перезаписывается после очистки метаданных, поэтому неизменяемыми считаются только варианты
+func (c *media) setFileHeaders(ctx echo.Context, m *ent.Media, immutable bool) {
+	switch {
+	case m.Private:
+		ctx.Response().Header().Set("Cache-Control", "private, no-store")
+	case immutable:
+		ctx.Response().Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	default:
+		ctx.Response().Header().Set("Cache-Control", "public, no-cache")
+	}
+	ctx.Response().Header().Set("X-Content-Type-Options", "nosniff")
+}
+
+// newMediaResponse формирует ответ с описанием медиафайла
+// Варианты, отличающиеся только шириной, собираются в значение для атрибута srcset
+func newMediaResponse(ctx echo.Context, mc *services.MediaClient, m *ent.Media) mediaResponse {
+	var query string
+	if m.Private {
+		query = "?" + mc.SignedQuery(m.ID).Encode()
+	}
+	url := ctx.Echo().Reverse("media.file", m.ID) + query
+
+	variants := make([]mediaVariantResponse, 0, len(m.Edges.Variants))
+	srcset := make([]string, 0, len(m.Edges.Variants)+1)
+	for _, v := range m.Edges.Variants {
+		vURL := ctx.Echo().Reverse("media.variant", m.ID, v.Name) + query
+		variants = append(variants, mediaVariantResponse{
+			Name:     v.Name,
+			MimeType: v.MimeType,
+			Size:     v.Size,
+			Width:    v.Width,
+			Height:   v.Height,
+			URL:      vURL,
+		})
+		if strings.HasPrefix(v.Name, "w") {
+			srcset = append(srcset, fmt.Sprintf("%s %dw", vURL, v.Width))
+		}
+	}
+	if m.Width > 0 {
+		srcset = append(srcset, fmt.Sprintf("%s %dw", url, m.Width))
+	}
+
+	return mediaResponse{
+		ID:        m.ID,
+		Filename:  m.Filename,
+		MimeType:  m.MimeType,
+		Size:      m.Size,
+		Checksum:  m.Checksum,
+		Width:     m.Width,
+		Height:    m.Height,
+		Private:   m.Private,
+		Processed: m.ProcessedAt != nil,
+		URL:       url,
+		Srcset:    strings.Join(srcset, ", "),
+		Variants:  variants,
+		CreatedAt: m.CreatedAt,
+	}
+}
@@ -0,0 +1,560 @@
+package routes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"mime"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/vovanwin/api-my-site/pkg/controller"
+	"github.com/vovanwin/api-my-site/pkg/i18n"
+	"github.com/vovanwin/api-my-site/pkg/openapi"
+	"github.com/vovanwin/api-my-site/pkg/paging"
+	"github.com/vovanwin/api-my-site/pkg/services"
+)
+
+const (
+	// apiPrefix содержит префикс маршрутов, описанных в спецификации
+	apiPrefix = "/api/"
+
+	// apiVersion содержит версию API, указанную в спецификации
+	apiVersion = "1.0"
+)
+
+type openAPI struct {
+	controller.Controller
+
+	// once строит спецификацию при первом запросе, когда все маршруты уже зарегистрированы
+	once sync.Once
+	spec []byte
+	err  error
+}
+
+// Spec возвращает спецификацию OpenAPI
+func (c *openAPI) Spec(ctx echo.Context) error {
+	c.once.Do(func() {
+		var doc *openapi.Document
+		if doc, c.err = apiSpec(c.Container).Build(ctx.Echo().Routes(), apiPrefix); c.err == nil {
+			c.spec, c.err = json.Marshal(doc)
+		}
+	})
+
+	if c.err != nil {
+		return c.Fail(c.err, "не удается построить спецификацию OpenAPI")
+	}
+
+	return ctx.Blob(http.StatusOK, echo.MIMEApplicationJSONCharsetUTF8, c.spec)
+}
+
+// UI возвращает страницу Swagger UI со спецификацией OpenAPI
+func (c *openAPI) UI(ctx echo.Context) error {
+	page, err := openapi.SwaggerUI(
+		c.Container.Config.App.Name,
+		ctx.Echo().Reverse("openapi.spec"),
+		ctx.Echo().Reverse("openapi.ui"),
+	)
+	if err != nil {
+		return c.Fail(err, "не удается сформировать страницу Swagger UI")
+	}
+
+	return ctx.HTMLBlob(http.StatusOK, page)
+}
+
+// Asset возвращает встроенный в приложение файл Swagger UI, чтобы страница не загружала скрипты с CDN
+func (c *openAPI) Asset(ctx echo.Context) error {
+	name := ctx.Param("file")
+	b, err := openapi.SwaggerAsset(name)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return echo.NewHTTPError(http.StatusNotFound)
+	case err != nil:
+		return c.Fail(err, "не удается прочитать файл Swagger UI")
+	}
+
+	contentType := mime.TypeByExtension(path.Ext(name))
+	if contentType == "" {
+		contentType = echo.MIMEOctetStream
+	}
+	return ctx.Blob(http.StatusOK, contentType, b)
+}
+
+// apiSpec описывает маршруты API для спецификации OpenAPI
+// Каждый маршрут, зарегистрированный с префиксом /api/, должен быть описан здесь
+func apiSpec(c *services.Container) *openapi.Builder {
+	id := func(name, description string) openapi.Parameter {
+		return openapi.PathParam(name, description, 0)
+	}
+
+	b := openapi.NewBuilder(openapi.Info{
+		Title:   c.Config.App.Name,
+		Version: apiVersion,
+		Description: fmt.Sprintf(
+			"Версия API указывается префиксом пути (/api/%[1]s/...) или заголовком "+
+				"Accept: application/%[2]s.%[1]s+json. Без указания версии используется %[1]s. "+
+				"Устаревшие маршруты отвечают с заголовками Deprecation и Sunset.",
+			apiVersions[0], apiVendor,
+		),
+	})
+
+	// Правила проверки, зарегистрированные в services.Validator
+	b.Rule("slug", func(s *openapi.Schema, _ string) {
+		s.Pattern = services.SlugPattern.String()
+	})
+	b.Rule("phone", func(s *openapi.Schema, _ string) {
+		s.Pattern = services.PhonePattern.String()
+	})
+	b.Rule("locale", func(s *openapi.Schema, _ string) {
+		s.Enum = i18n.Locales()
+	})
+	b.Rule("password", func(s *openapi.Schema, _ string) {
+		min, max := services.PasswordMinLength, services.PasswordMaxLength
+		s.Format = "password"
+		s.MinLength, s.MaxLength = &min, &max
+	})
+
+	// Документация
+	b.Operation("openapi.spec", openapi.Operation{
+		Summary:   "Спецификация OpenAPI",
+		Tags:      []string{"docs"},
+		Responses: map[int]interface{}{http.StatusOK: map[string]interface{}{}},
+	})
+	b.Operation("openapi.ui", openapi.Operation{
+		Summary:   "Swagger UI",
+		Tags:      []string{"docs"},
+		Produces:  echo.MIMETextHTMLCharsetUTF8,
+		Responses: map[int]interface{}{http.StatusOK: ""},
+	})
+	b.Operation("openapi.ui.asset", openapi.Operation{
+		Summary:   "Файл Swagger UI, встроенный в приложение",
+		Tags:      []string{"docs"},
+		Params:    []openapi.Parameter{openapi.PathParam("file", "Имя файла", "")},
+		Produces:  "*/*",
+		Responses: map[int]interface{}{http.StatusOK: ""},
+		Errors:    []int{http.StatusNotFound},
+	})
+
+	// Навигация
+	b.Operation("nav", openapi.Operation{
+		Summary:   "Дерево меню навигации",
+		Tags:      []string{"pages"},
+		Responses: map[int]interface{}{http.StatusOK: []*navItem{}},
+	})
+
+	// Аутентификация
+	b.Operation("login.post", openapi.Operation{
+		Summary:   "Вход в систему",
+		Tags:      []string{"auth"},
+		Request:   loginForm{},
+		Responses: map[int]interface{}{http.StatusOK: tokenResponse{}},
+		Errors:    []int{http.StatusUnauthorized, http.StatusForbidden},
+	})
+	b.Operation("register.post", openapi.Operation{
+		Summary:   "Регистрация пользователя",
+		Tags:      []string{"auth"},
+		Request:   registerForm{},
+		Responses: map[int]interface{}{http.StatusOK: registerResponse{}},
+		Errors:    []int{http.StatusForbidden, http.StatusConflict},
+	})
+	b.Operation("reset_password.post", openapi.Operation{
+		Summary:   "Установка нового пароля по ссылке для сброса пароля",
+		Tags:      []string{"auth"},
+		Request:   resetPasswordForm{},
+		Responses: map[int]interface{}{http.StatusOK: tokenResponse{}},
+		Errors:    []int{http.StatusForbidden},
+	})
+	b.Operation("logout.post", openapi.Operation{
+		Summary:   "Выход из системы, отзывающий все выданные токены",
+		Tags:      []string{"auth"},
+		Auth:      true,
+		Responses: map[int]interface{}{http.StatusNoContent: nil},
+	})
+	b.Operation("auth.email.confirm", openapi.Operation{
+		Summary:   "Подтверждение смены адреса электронной почты по токену из ссылки",
+		Tags:      []string{"auth"},
+		Request:   emailConfirmForm{},
+		Responses: map[int]interface{}{http.StatusOK: meResponse{}},
+		Errors:    []int{http.StatusConflict},
+	})
+
+	// Профиль
+	b.Operation("me.get", openapi.Operation{
+		Summary:   "Профиль текущего пользователя",
+		Tags:      []string{"me"},
+		Auth:      true,
+		Responses: map[int]interface{}{http.StatusOK: meResponse{}},
+	})
+	b.Operation("me.update", openapi.Operation{
+		Summary:   "Изменение профиля, поля без значения не изменяются, avatar_id 0 удаляет аватар",
+		Tags:      []string{"me"},
+		Auth:      true,
+		Request:   meForm{},
+		Responses: map[int]interface{}{http.StatusOK: meResponse{}},
+	})
+	b.Operation("me.email", openapi.Operation{
+		Summary:   "Смена адреса электронной почты с подтверждением по ссылке, отправленной на новый адрес",
+		Tags:      []string{"me"},
+		Auth:      true,
+		Request:   meEmailForm{},
+		Responses: map[int]interface{}{http.StatusAccepted: messageResponse{}},
+		Errors:    []int{http.StatusForbidden},
+	})
+	b.Operation("me.password", openapi.Operation{
+		Summary:   "Смена пароля, отзывающая все выданные токены",
+		Tags:      []string{"me"},
+		Auth:      true,
+		Request:   mePasswordForm{},
+		Responses: map[int]interface{}{http.StatusOK: tokenResponse{}},
+		Errors:    []int{http.StatusForbidden},
+	})
+	b.Operation("me.delete", openapi.Operation{
+		Summary:   "Удаление учетной записи по истечении льготного периода, вход в систему до его истечения отменяет удаление",
+		Tags:      []string{"me"},
+		Auth:      true,
+		Request:   meDeleteForm{},
+		Responses: map[int]interface{}{http.StatusAccepted: meResponse{}},
+		Errors:    []int{http.StatusForbidden},
+	})
+
+	// Медиафайлы
+	mediaID := id("media", "Идентификатор медиафайла")
+	signature := []openapi.Parameter{
+		openapi.QueryParam("expires", "Время истечения подписи закрытого файла в секундах Unix", 0),
+		openapi.QueryParam("signature", "Подпись ссылки на закрытый файл", ""),
+	}
+	b.Operation("media.post", openapi.Operation{
+		Summary: "Загрузка медиафайла",
+		Tags:    []string{"media"},
+		Auth:    true,
+		Request: struct {
+			File    openapi.File `json:"file" validate:"required"`
+			Private bool         `json:"private"`
+		}{},
+		Consumes:  []string{echo.MIMEMultipartForm},
+		Responses: map[int]interface{}{http.StatusCreated: mediaResponse{}},
+		Errors: []int{
+			http.StatusForbidden,
+			http.StatusRequestEntityTooLarge,
+			http.StatusUnsupportedMediaType,
+		},
+	})
+	b.Operation("media.get", openapi.Operation{
+		Summary:   "Описание медиафайла",
+		Tags:      []string{"media"},
+		Params:    []openapi.Parameter{mediaID},
+		Responses: map[int]interface{}{http.StatusOK: mediaResponse{}},
+		Errors:    []int{http.StatusNotFound},
+	})
+	b.Operation("media.file", openapi.Operation{
+		Summary:  "Содержимое медиафайла",
+		Tags:     []string{"media"},
+		Params:   append([]openapi.Parameter{mediaID}, signature...),
+		Produces: echo.MIMEOctetStream,
+		Responses: map[int]interface{}{
+			http.StatusOK:          openapi.File{},
+			http.StatusNotModified: nil,
+		},
+		Errors: []int{http.StatusForbidden, http.StatusNotFound},
+	})
+	b.Operation("media.variant", openapi.Operation{
+		Summary: "Содержимое варианта изображения",
+		Tags:    []string{"media"},
+		Params: append([]openapi.Parameter{
+			mediaID,
+			openapi.PathParam("variant", "Название варианта", ""),
+		}, signature...),
+		Produces: echo.MIMEOctetStream,
+		Responses: map[int]interface{}{
+			http.StatusOK:          openapi.File{},
+			http.StatusNotModified: nil,
+		},
+		Errors: []int{http.StatusForbidden, http.StatusNotFound},
+	})
+	b.Operation("media.delete", openapi.Operation{
+		Summary:   "Удаление медиафайла",
+		Tags:      []string{"media"},
+		Auth:      true,
+		Params:    []openapi.Parameter{mediaID},
+		Responses: map[int]interface{}{http.StatusNoContent: nil},
+		Errors:    []int{http.StatusNotFound},
+	})
+
+	// Страницы и проекты
+	slug := openapi.PathParam("slug", "Адрес", "")
+	b.Operation("pages.list", openapi.Operation{
+		Summary:   "Опубликованные страницы",
+		Tags:      []string{"pages"},
+		Responses: map[int]interface{}{http.StatusOK: []pageResponse{}},
+	})
+	b.Operation("pages.get", openapi.Operation{
+		Summary:   "Опубликованная страница",
+		Tags:      []string{"pages"},
+		Params:    []openapi.Parameter{slug},
+		Responses: map[int]interface{}{http.StatusOK: pageResponse{}},
+		Errors:    []int{http.StatusNotFound},
+	})
+	b.Operation("projects.list", openapi.Operation{
+		Summary: "Проекты портфолио",
+		Tags:    []string{"projects"},
+		Params: []openapi.Parameter{
+			openapi.QueryParam("featured", "Только избранные проекты", false),
+		},
+		Responses: map[int]interface{}{http.StatusOK: []projectResponse{}},
+	})
+	b.Operation("projects.get", openapi.Operation{
+		Summary:   "Проект портфолио",
+		Tags:      []string{"projects"},
+		Params:    []openapi.Parameter{slug},
+		Responses: map[int]interface{}{http.StatusOK: projectResponse{}},
+		Errors:    []int{http.StatusNotFound},
+	})
+
+	// Администрирование
+	admin := func(op openapi.Operation) openapi.Operation {
+		op.Auth = true
+		op.Tags = append(op.Tags, "admin")
+		op.Errors = append(op.Errors, http.StatusForbidden)
+		return op
+	}
+	pageID := id("page", "Идентификатор страницы")
+	projectID := id("project", "Идентификатор проекта")
+	messageID := id("message", "Идентификатор сообщения")
+	flagKey := openapi.PathParam("flag", "Ключ флага функциональности", "")
+
+	b.Operation("admin.pages.index", admin(openapi.Operation{
+		Summary:   "Все страницы, включая черновики",
+		Responses: map[int]interface{}{http.StatusOK: []pageResponse{}},
+	}))
+	b.Operation("admin.pages.create", admin(openapi.Operation{
+		Summary:   "Создание страницы",
+		Request:   pageForm{},
+		Responses: map[int]interface{}{http.StatusCreated: pageResponse{}},
+		Errors:    []int{http.StatusConflict},
+	}))
+	b.Operation("admin.pages.show", admin(openapi.Operation{
+		Summary:   "Страница",
+		Params:    []openapi.Parameter{pageID},
+		Responses: map[int]interface{}{http.StatusOK: pageResponse{}},
+		Errors:    []int{http.StatusNotFound},
+	}))
+	b.Operation("admin.pages.update", admin(openapi.Operation{
+		Summary:   "Изменение страницы",
+		Params:    []openapi.Parameter{pageID},
+		Request:   pageForm{},
+		Responses: map[int]interface{}{http.StatusOK: pageResponse{}},
+		Errors:    []int{http.StatusNotFound, http.StatusConflict},
+	}))
+	b.Operation("admin.pages.delete", admin(openapi.Operation{
+		Summary:   "Удаление страницы",
+		Params:    []openapi.Parameter{pageID},
+		Responses: map[int]interface{}{http.StatusNoContent: nil},
+		Errors:    []int{http.StatusNotFound},
+	}))
+	b.Operation("admin.projects.index", admin(openapi.Operation{
+		Summary:   "Все проекты в обход кеша",
+		Responses: map[int]interface{}{http.StatusOK: []projectResponse{}},
+	}))
+	b.Operation("admin.projects.create", admin(openapi.Operation{
+		Summary:   "Создание проекта",
+		Request:   projectForm{},
+		Responses: map[int]interface{}{http.StatusCreated: projectResponse{}},
+		Errors:    []int{http.StatusConflict},
+	}))
+	b.Operation("admin.projects.show", admin(openapi.Operation{
+		Summary:   "Проект",
+		Params:    []openapi.Parameter{projectID},
+		Responses: map[int]interface{}{http.StatusOK: projectResponse{}},
+		Errors:    []int{http.StatusNotFound},
+	}))
+	b.Operation("admin.projects.update", admin(openapi.Operation{
+		Summary:   "Изменение проекта",
+		Params:    []openapi.Parameter{projectID},
+		Request:   projectForm{},
+		Responses: map[int]interface{}{http.StatusOK: projectResponse{}},
+		Errors:    []int{http.StatusNotFound, http.StatusConflict},
+	}))
+	b.Operation("admin.projects.delete", admin(openapi.Operation{
+		Summary:   "Удаление проекта",
+		Params:    []openapi.Parameter{projectID},
+		Responses: map[int]interface{}{http.StatusNoContent: nil},
+		Errors:    []int{http.StatusNotFound},
+	}))
+
+	// Обратная связь
+	b.Operation("contact.get", openapi.Operation{
+		Summary:   "Токен формы обратной связи",
+		Tags:      []string{"contact"},
+		Responses: map[int]interface{}{http.StatusOK: tokenResponse{}},
+	})
+	b.Operation("contact.post", openapi.Operation{
+		Summary:   "Отправка сообщения с формы обратной связи",
+		Tags:      []string{"contact"},
+		Request:   contactForm{},
+		Responses: map[int]interface{}{http.StatusAccepted: messageResponse{}},
+		Errors:    []int{http.StatusTooManyRequests},
+	})
+	b.Operation("admin.messages.index", admin(openapi.Operation{
+		Summary: "Сообщения с формы обратной связи",
+		Tags:    []string{"contact"},
+		Params: append(listParams(contactMessageList),
+			openapi.QueryParam("handled", "Только обработанные или необработанные сообщения", false),
+		),
+		Responses: map[int]interface{}{http.StatusOK: contactMessagePage{}},
+	}))
+	b.Operation("admin.messages.handle", admin(openapi.Operation{
+		Summary:   "Отметка сообщения как обработанного",
+		Tags:      []string{"contact"},
+		Params:    []openapi.Parameter{messageID},
+		Responses: map[int]interface{}{http.StatusOK: contactMessageResponse{}},
+		Errors:    []int{http.StatusNotFound},
+	}))
+	b.Operation("admin.messages.unhandle", admin(openapi.Operation{
+		Summary:   "Снятие отметки об обработке сообщения",
+		Tags:      []string{"contact"},
+		Params:    []openapi.Parameter{messageID},
+		Responses: map[int]interface{}{http.StatusOK: contactMessageResponse{}},
+		Errors:    []int{http.StatusNotFound},
+	}))
+
+	// Пользователи
+	userID := id("user", "Идентификатор пользователя")
+	userAction := func(summary string, status int, response interface{}, errors ...int) openapi.Operation {
+		return admin(openapi.Operation{
+			Summary:   summary,
+			Tags:      []string{"users"},
+			Params:    []openapi.Parameter{userID},
+			Responses: map[int]interface{}{status: response},
+			Errors:    append([]int{http.StatusNotFound}, errors...),
+		})
+	}
+	b.Operation("admin.users.index", admin(openapi.Operation{
+		Summary: "Пользователи",
+		Tags:    []string{"users"},
+		Params: append(listParams(userList),
+			openapi.QueryParam("q", "Поиск по имени и адресу электронной почты", ""),
+		),
+		Responses: map[int]interface{}{http.StatusOK: userPage{}},
+	}))
+	b.Operation("admin.users.show", userAction(
+		"Пользователь вместе с токенами сброса пароля", http.StatusOK, userDetailResponse{},
+	))
+	b.Operation("admin.users.delete", userAction(
+		"Удаление пользователя вместе с его медиафайлами и записями", http.StatusAccepted, userResponse{},
+		http.StatusConflict,
+	))
+	b.Operation("admin.users.block", userAction(
+		"Блокировка пользователя", http.StatusOK, userResponse{}, http.StatusConflict,
+	))
+	b.Operation("admin.users.unblock", userAction(
+		"Снятие блокировки пользователя, не ожидающего удаления", http.StatusOK, userResponse{},
+		http.StatusConflict,
+	))
+	b.Operation("admin.users.verify", userAction(
+		"Подтверждение адреса электронной почты пользователя", http.StatusOK, userResponse{},
+	))
+	b.Operation("admin.users.password_reset", userAction(
+		"Принудительный сброс пароля с отправкой пользователю ссылки для установки нового", http.StatusAccepted, messageResponse{},
+	))
+	b.Operation("admin.users.impersonate", userAction(
+		"Токен для действий от имени пользователя, каждый запрос с которым записывается в журнал",
+		http.StatusOK, impersonationResponse{}, http.StatusConflict,
+	))
+	b.Operation("admin.users.sessions.revoke", userAction(
+		"Отзыв всех выданных пользователю токенов", http.StatusNoContent, nil,
+	))
+	b.Operation("admin.audit.index", admin(openapi.Operation{
+		Summary:   "Журнал аудита действий, связанных с безопасностью и администрированием",
+		Tags:      []string{"audit"},
+		Params:    listParams(auditEventList),
+		Responses: map[int]interface{}{http.StatusOK: auditEventPage{}},
+	}))
+	b.Operation("admin.flags.index", admin(openapi.Operation{
+		Summary:   "Все флаги функциональности",
+		Tags:      []string{"flags"},
+		Responses: map[int]interface{}{http.StatusOK: []flagResponse{}},
+	}))
+	b.Operation("admin.flags.create", admin(openapi.Operation{
+		Summary:   "Создание флага функциональности",
+		Tags:      []string{"flags"},
+		Request:   flagForm{},
+		Responses: map[int]interface{}{http.StatusCreated: flagResponse{}},
+		Errors:    []int{http.StatusConflict},
+	}))
+	b.Operation("admin.flags.show", admin(openapi.Operation{
+		Summary:   "Флаг функциональности",
+		Tags:      []string{"flags"},
+		Params:    []openapi.Parameter{flagKey},
+		Responses: map[int]interface{}{http.StatusOK: flagResponse{}},
+		Errors:    []int{http.StatusNotFound},
+	}))
+	b.Operation("admin.flags.update", admin(openapi.Operation{
+		Summary:   "Изменение флага функциональности",
+		Tags:      []string{"flags"},
+		Params:    []openapi.Parameter{flagKey},
+		Request:   flagForm{},
+		Responses: map[int]interface{}{http.StatusOK: flagResponse{}},
+		Errors:    []int{http.StatusNotFound},
+	}))
+	b.Operation("admin.flags.delete", admin(openapi.Operation{
+		Summary:   "Удаление флага функциональности",
+		Tags:      []string{"flags"},
+		Params:    []openapi.Parameter{flagKey},
+		Responses: map[int]interface{}{http.StatusNoContent: nil},
+		Errors:    []int{http.StatusNotFound},
+	}))
+	b.Operation("admin.settings.get", admin(openapi.Operation{
+		Summary:   "Текущие значения настроек, изменяемых без перезапуска, и результат их последней перезагрузки",
+		Tags:      []string{"settings"},
+		Responses: map[int]interface{}{http.StatusOK: settingsResponse{}},
+	}))
+
+	return b
+}
+
+// listParams описывает параметры постраничного вывода, сортировки и фильтрации списка ресурса
+func listParams(r paging.Resource) []openapi.Parameter {
+	params := []openapi.Parameter{
+		openapi.QueryParam("limit", fmt.Sprintf("Количество элементов страницы, не более %d", paging.MaxLimit), 0),
+		openapi.QueryParam("cursor", "Курсор следующей страницы из поля next_cursor предыдущей страницы", ""),
+		openapi.QueryParam("total", "Подсчитать общее количество элементов", false),
+	}
+
+	names := make([]string, 0, len(r.Fields))
+	for name := range r.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var sortable []string
+	for _, name := range names {
+		f := r.Fields[name]
+		if f.Sort {
+			sortable = append(sortable, name)
+		}
+		if len(f.Filter) > 0 {
+			ops := make([]string, 0, len(f.Filter))
+			for _, op := range f.Filter {
+				ops = append(ops, string(op))
+			}
+			params = append(params, openapi.QueryParam(
+				"filter["+name+"]",
+				fmt.Sprintf("Фильтр в формате оператор:значение, операторы: %s", strings.Join(ops, ", ")),
+				"",
+			))
+		}
+	}
+
+	return append(params, openapi.QueryParam(
+		"sort",
+		fmt.Sprintf("Поля сортировки через запятую, с минусом для убывания: %s. По умолчанию %s",
+			strings.Join(sortable, ", "), r.Sort),
+		"",
+	))
+}
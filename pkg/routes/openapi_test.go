@@ -0,0 +1,66 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vovanwin/api-my-site/pkg/openapi"
+)
+
+func TestOpenAPI_Spec(t *testing.T) {
+	resp := request(t).
+		setRoute("openapi.spec").
+		get().
+		assertStatusCode(http.StatusOK)
+	defer resp.Body.Close()
+
+	var doc struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&doc))
+
+	// Every API route must be in the specification
+	param := regexp.MustCompile(`:(\w+)`)
+	for _, r := range c.Web.Routes() {
+		if !openapi.Documented(r, apiPrefix) {
+			continue
+		}
+		path := param.ReplaceAllString(r.Path, "{$1}")
+		assert.Contains(t, doc.Paths[path], strings.ToLower(r.Method), "%s %s (%s)", r.Method, r.Path, r.Name)
+	}
+
+	// Admins can read pages and projects bypassing the cache of the public routes
+	for _, path := range []string{"/api/admin/pages", "/api/admin/pages/{page}", "/api/admin/projects", "/api/admin/projects/{project}"} {
+		assert.Contains(t, doc.Paths[path], "get", path)
+	}
+}
+
+func TestOpenAPI_Documented(t *testing.T) {
+	_, err := apiSpec(c).Build(c.Web.Routes(), apiPrefix)
+	assert.NoError(t, err)
+}
+
+func TestOpenAPI_UI(t *testing.T) {
+	doc := request(t).
+		setRoute("openapi.ui").
+		get().
+		assertStatusCode(http.StatusOK).
+		toDoc()
+
+	// The page loads the assets embedded in the application
+	css, _ := doc.Find(`link[rel="stylesheet"]`).Attr("href")
+	assert.Equal(t, "/api/docs/swagger-ui.css", css)
+	js, _ := doc.Find("script[src]").Attr("src")
+	assert.Equal(t, "/api/docs/swagger-ui-bundle.js", js)
+
+	request(t).
+		setRoute("openapi.ui.asset", "missing.js").
+		get().
+		assertStatusCode(http.StatusNotFound)
+}
@@ -0,0 +1,346 @@
+package routes
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/vovanwin/api-my-site/ent"
+	"github.com/vovanwin/api-my-site/ent/page"
+	"github.com/vovanwin/api-my-site/pkg/context"
+	"github.com/vovanwin/api-my-site/pkg/controller"
+	"github.com/vovanwin/api-my-site/pkg/i18n"
+	"github.com/vovanwin/api-my-site/pkg/logging"
+	"github.com/vovanwin/api-my-site/pkg/problem"
+	"github.com/vovanwin/api-my-site/pkg/services"
+)
+
+// pageMaxDepth stores the maximum nesting of pages walked when checking the parent of a page for cycles
+const pageMaxDepth = 32
+
+type (
+	pages struct {
+		controller.Controller
+	}
+
+	pageForm struct {
+		Title           string                    `form:"title" json:"title" validate:"required" label:"field.title"`
+		Slug            string                    `form:"slug" json:"slug" normalize:"lower" validate:"required,slug" label:"field.slug"`
+		Body            string                    `form:"body" json:"body" label:"field.body"`
+		ParentID        int                       `form:"parent_id" json:"parent_id" label:"field.parent"`
+		MenuOrder       int                       `form:"menu_order" json:"menu_order" label:"field.menu_order"`
+		ShowInMenu      bool                      `form:"show_in_menu" json:"show_in_menu"`
+		MetaTitle       string                    `form:"meta_title" json:"meta_title" label:"field.meta_title"`
+		MetaDescription string                    `form:"meta_description" json:"meta_description" label:"field.meta_description"`
+		Published       bool                      `form:"published" json:"published"`
+		Submission      controller.FormSubmission `form:"-" json:"-"`
+	}
+
+	pageResponse struct {
+		ID              int       `json:"id"`
+		Title           string    `json:"title"`
+		Slug            string    `json:"slug"`
+		Body            string    `json:"body"`
+		ParentID        *int      `json:"parent_id"`
+		MenuOrder       int       `json:"menu_order"`
+		ShowInMenu      bool      `json:"show_in_menu"`
+		MetaTitle       string    `json:"meta_title"`
+		MetaDescription string    `json:"meta_description"`
+		Published       bool      `json:"published"`
+		CreatedAt       time.Time `json:"created_at"`
+		UpdatedAt       time.Time `json:"updated_at"`
+	}
+
+	navItem struct {
+		Title    string     `json:"title"`
+		Slug     string     `json:"slug"`
+		URL      string     `json:"url"`
+		Children []*navItem `json:"children,omitempty"`
+	}
+)
+
+// Nav возвращает дерево меню навигации из опубликованных страниц, отмеченных для показа в меню
+// Страницы, родитель которых скрыт из меню, не показываются вместе с родителем
+func (c *pages) Nav(ctx echo.Context) error {
+	ps, err := c.Container.ORM.Page.
+		Query().
+		Where(page.Published(true), page.ShowInMenu(true)).
+		WithParent().
+		Order(ent.Asc(page.FieldMenuOrder), ent.Asc(page.FieldID)).
+		All(ctx.Request().Context())
+
+	if err != nil {
+		return c.Fail(err, "не удается загрузить меню")
+	}
+
+	var lastModified time.Time
+	items := make(map[int]*navItem, len(ps))
+	for _, p := range ps {
+		items[p.ID] = &navItem{
+			Title: p.Title,
+			Slug:  p.Slug,
+			URL:   "/" + p.Slug,
+		}
+		if p.UpdatedAt.After(lastModified) {
+			lastModified = p.UpdatedAt
+		}
+	}
+
+	tree := make([]*navItem, 0, len(ps))
+	for _, p := range ps {
+		if p.Edges.Parent == nil {
+			tree = append(tree, items[p.ID])
+			continue
+		}
+		if parent, ok := items[p.Edges.Parent.ID]; ok {
+			parent.Children = append(parent.Children, items[p.ID])
+		}
+	}
+
+	return c.RenderCachedJSON(ctx, tree, lastModified, services.CacheTagContent)
+}
+
+// List возвращает опубликованные страницы
+func (c *pages) List(ctx echo.Context) error {
+	ps, err := c.Container.ORM.Page.
+		Query().
+		Where(page.Published(true)).
+		WithParent().
+		Order(ent.Asc(page.FieldMenuOrder), ent.Asc(page.FieldID)).
+		All(ctx.Request().Context())
+
+	if err != nil {
+		return c.Fail(err, "не удается загрузить страницы")
+	}
+
+	var lastModified time.Time
+	res := make([]pageResponse, 0, len(ps))
+	for _, p := range ps {
+		res = append(res, newPageResponse(p))
+		if p.UpdatedAt.After(lastModified) {
+			lastModified = p.UpdatedAt
+		}
+	}
+
+	return c.RenderCachedJSON(ctx, res, lastModified, services.CacheTagContent)
+}
+
+// Get возвращает опубликованную страницу по ее адресу
+func (c *pages) Get(ctx echo.Context) error {
+	p, err := c.Container.ORM.Page.
+		Query().
+		Where(page.Slug(ctx.Param("slug")), page.Published(true)).
+		WithParent().
+		Only(ctx.Request().Context())
+
+	switch err.(type) {
+	case nil:
+	case *ent.NotFoundError:
+		return echo.NewHTTPError(http.StatusNotFound)
+	default:
+		return c.Fail(err, "не удается загрузить страницу")
+	}
+
+	return c.RenderCachedJSON(ctx, newPageResponse(p), p.UpdatedAt, services.CacheTagContent)
+}
+
+// Index возвращает все страницы, включая неопубликованные
+func (c *pages) Index(ctx echo.Context) error {
+	ps, err := c.Container.ORM.Page.
+		Query().
+		WithParent().
+		Order(ent.Asc(page.FieldMenuOrder), ent.Asc(page.FieldID)).
+		All(ctx.Request().Context())
+
+	if err != nil {
+		return c.Fail(err, "не удается загрузить страницы")
+	}
+
+	res := make([]pageResponse, 0, len(ps))
+	for _, p := range ps {
+		res = append(res, newPageResponse(p))
+	}
+
+	return ctx.JSON(http.StatusOK, res)
+}
+
+// Show возвращает страницу, включая неопубликованную
+func (c *pages) Show(ctx echo.Context) error {
+	p := ctx.Get(context.PageKey).(*ent.Page)
+	return ctx.JSON(http.StatusOK, newPageResponse(p))
+}
+
+// Create создает страницу
+func (c *pages) Create(ctx echo.Context) error {
+	var form pageForm
+	if err := c.bind(ctx, &form, 0); err != nil {
+		return err
+	}
+
+	if form.Submission.HasErrors() {
+		return problem.Validation(form.Submission.GetAllFieldErrors())
+	}
+
+	create := c.Container.ORM.Page.Create()
+	form.apply(create.Mutation())
+	p, err := create.Save(ctx.Request().Context())
+	if err != nil {
+		return c.saveError(ctx, err)
+	}
+
+	logging.Infof(ctx.Request().Context(), "создана страница: %d", p.ID)
+
+	return c.respond(ctx, http.StatusCreated, p.ID)
+}
+
+// Update изменяет страницу
+func (c *pages) Update(ctx echo.Context) error {
+	p := ctx.Get(context.PageKey).(*ent.Page)
+
+	var form pageForm
+	if err := c.bind(ctx, &form, p.ID); err != nil {
+		return err
+	}
+
+	if form.Submission.HasErrors() {
+		return problem.Validation(form.Submission.GetAllFieldErrors())
+	}
+
+	update := p.Update()
+	form.apply(update.Mutation())
+	if _, err := update.Save(ctx.Request().Context()); err != nil {
+		return c.saveError(ctx, err)
+	}
+
+	return c.respond(ctx, http.StatusOK, p.ID)
+}
+
+// Delete удаляет страницу
+// Вложенные страницы при этом становятся страницами верхнего уровня
+func (c *pages) Delete(ctx echo.Context) error {
+	p := ctx.Get(context.PageKey).(*ent.Page)
+
+	if err := c.Container.ORM.Page.DeleteOne(p).Exec(ctx.Request().Context()); err != nil {
+		return c.Fail(err, "не удается удалить страницу")
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// bind разбирает и проверяет форму страницы
+// pageID содержит идентификатор изменяемой страницы, либо 0 при создании новой
+func (c *pages) bind(ctx echo.Context, form *pageForm, pageID int) error {
+	if err := ctx.Bind(form); err != nil {
+		return err
+	}
+
+	if err := form.Submission.Process(ctx, form); err != nil {
+		return c.Fail(err, "не удается обработать отправку формы")
+	}
+
+	if form.ParentID != 0 {
+		ok, err := c.isValidParent(ctx, form.ParentID, pageID)
+		if err != nil {
+			return c.Fail(err, "не удается проверить родительскую страницу")
+		}
+		if !ok {
+			form.Submission.SetFieldError("parent_id", i18n.Ctx(ctx, "page.parent_invalid"))
+		}
+	}
+
+	return nil
+}
+
+// isValidParent указывает, может ли страница parentID быть родителем страницы pageID,
+// то есть существует и не является самой страницей или одной из вложенных в нее страниц
+func (c *pages) isValidParent(ctx echo.Context, parentID, pageID int) (bool, error) {
+	id := parentID
+	for i := 0; i < pageMaxDepth; i++ {
+		if id == pageID {
+			return false, nil
+		}
+
+		p, err := c.Container.ORM.Page.
+			Query().
+			Where(page.ID(id)).
+			WithParent().
+			Only(ctx.Request().Context())
+
+		switch err.(type) {
+		case nil:
+		case *ent.NotFoundError:
+			return false, nil
+		default:
+			return false, err
+		}
+
+		if p.Edges.Parent == nil {
+			return true, nil
+		}
+		id = p.Edges.Parent.ID
+	}
+
+	return false, nil
+}
+
+// saveError формирует ответ на ошибку сохранения страницы
+func (c *pages) saveError(ctx echo.Context, err error) error {
+	if ent.IsConstraintError(err) {
+		return problem.New(http.StatusConflict, problem.CodeConflict, i18n.Ctx(ctx, "page.slug_taken"))
+	}
+	return c.Fail(err, "не удается сохранить страницу")
+}
+
+// respond загружает сохраненную страницу и отправляет ее в ответе
+func (c *pages) respond(ctx echo.Context, status, pageID int) error {
+	p, err := c.Container.ORM.Page.
+		Query().
+		Where(page.ID(pageID)).
+		WithParent().
+		Only(ctx.Request().Context())
+
+	if err != nil {
+		return c.Fail(err, "не удается загрузить страницу")
+	}
+
+	return ctx.JSON(status, newPageResponse(p))
+}
+
+// apply переносит значения формы в мутацию страницы
+func (f *pageForm) apply(m *ent.PageMutation) {
+	m.SetTitle(f.Title)
+	m.SetSlug(f.Slug)
+	m.SetBody(f.Body)
+	m.SetMenuOrder(f.MenuOrder)
+	m.SetShowInMenu(f.ShowInMenu)
+	m.SetMetaTitle(f.MetaTitle)
+	m.SetMetaDescription(f.MetaDescription)
+	m.SetPublished(f.Published)
+	if f.ParentID != 0 {
+		m.SetParentID(f.ParentID)
+	} else {
+		m.ClearParent()
+	}
+}
+
+// newPageResponse формирует ответ с описанием страницы
+func newPageResponse(p *ent.Page) pageResponse {
+	res := pageResponse{
+		ID:              p.ID,
+		Title:           p.Title,
+		Slug:            p.Slug,
+		Body:            p.Body,
+		MenuOrder:       p.MenuOrder,
+		ShowInMenu:      p.ShowInMenu,
+		MetaTitle:       p.MetaTitle,
+		MetaDescription: p.MetaDescription,
+		Published:       p.Published,
+		CreatedAt:       p.CreatedAt,
+		UpdatedAt:       p.UpdatedAt,
+	}
+	if p.Edges.Parent != nil {
+		res.ParentID = &p.Edges.Parent.ID
+	}
+	return res
+}
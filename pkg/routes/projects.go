@@ -0,0 +1,294 @@
+package routes
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/vovanwin/api-my-site/ent"
+	"github.com/vovanwin/api-my-site/ent/predicate"
+	"github.com/vovanwin/api-my-site/ent/project"
+	"github.com/vovanwin/api-my-site/pkg/context"
+	"github.com/vovanwin/api-my-site/pkg/controller"
+	"github.com/vovanwin/api-my-site/pkg/i18n"
+	"github.com/vovanwin/api-my-site/pkg/logging"
+	"github.com/vovanwin/api-my-site/pkg/problem"
+	"github.com/vovanwin/api-my-site/pkg/services"
+)
+
+type (
+	projects struct {
+		controller.Controller
+	}
+
+	projectForm struct {
+		Title         string                    `form:"title" json:"title" validate:"required" label:"field.title"`
+		Slug          string                    `form:"slug" json:"slug" normalize:"lower" validate:"required,slug" label:"field.slug"`
+		Description   string                    `form:"description" json:"description" label:"field.description"`
+		Stack         []string                  `form:"stack" json:"stack" label:"field.stack"`
+		RepositoryURL string                    `form:"repository_url" json:"repository_url" normalize:"url" validate:"omitempty,url" label:"field.repository_url"`
+		DemoURL       string                    `form:"demo_url" json:"demo_url" normalize:"url" validate:"omitempty,url" label:"field.demo_url"`
+		CoverID       int                       `form:"cover_id" json:"cover_id" label:"field.cover"`
+		Position      int                       `form:"position" json:"position" label:"field.position"`
+		Featured      bool                      `form:"featured" json:"featured"`
+		Submission    controller.FormSubmission `form:"-" json:"-"`
+	}
+
+	projectResponse struct {
+		ID            int            `json:"id"`
+		Title         string         `json:"title"`
+		Slug          string         `json:"slug"`
+		Description   string         `json:"description"`
+		Stack         []string       `json:"stack"`
+		RepositoryURL string         `json:"repository_url"`
+		DemoURL       string         `json:"demo_url"`
+		Position      int            `json:"position"`
+		Featured      bool           `json:"featured"`
+		Cover         *mediaResponse `json:"cover"`
+		CreatedAt     time.Time      `json:"created_at"`
+		UpdatedAt     time.Time      `json:"updated_at"`
+	}
+)
+
+// List возвращает проекты портфолио, либо только избранные, если передан параметр featured=true
+func (c *projects) List(ctx echo.Context) error {
+	var where []predicate.Project
+	if ctx.QueryParam("featured") == "true" {
+		where = append(where, project.Featured(true))
+	}
+
+	ps, err := c.Container.ORM.Project.
+		Query().
+		Where(where...).
+		WithCover(func(q *ent.MediaQuery) {
+			q.WithVariants()
+		}).
+		Order(ent.Asc(project.FieldPosition), ent.Desc(project.FieldCreatedAt)).
+		All(ctx.Request().Context())
+
+	if err != nil {
+		return c.Fail(err, "не удается загрузить проекты")
+	}
+
+	var lastModified time.Time
+	res := make([]projectResponse, 0, len(ps))
+	for _, p := range ps {
+		res = append(res, c.response(ctx, p))
+		if p.UpdatedAt.After(lastModified) {
+			lastModified = p.UpdatedAt
+		}
+	}
+
+	return c.RenderCachedJSON(ctx, res, lastModified, services.CacheTagContent)
+}
+
+// Get возвращает проект по его адресу
+func (c *projects) Get(ctx echo.Context) error {
+	p, err := c.Container.ORM.Project.
+		Query().
+		Where(project.Slug(ctx.Param("slug"))).
+		WithCover(func(q *ent.MediaQuery) {
+			q.WithVariants()
+		}).
+		Only(ctx.Request().Context())
+
+	switch err.(type) {
+	case nil:
+	case *ent.NotFoundError:
+		return echo.NewHTTPError(http.StatusNotFound)
+	default:
+		return c.Fail(err, "не удается загрузить проект")
+	}
+
+	return c.RenderCachedJSON(ctx, c.response(ctx, p), p.UpdatedAt, services.CacheTagContent)
+}
+
+// Index возвращает все проекты в обход кеша, в котором публичный список может устареть
+func (c *projects) Index(ctx echo.Context) error {
+	ps, err := c.Container.ORM.Project.
+		Query().
+		WithCover(func(q *ent.MediaQuery) {
+			q.WithVariants()
+		}).
+		Order(ent.Asc(project.FieldPosition), ent.Desc(project.FieldCreatedAt)).
+		All(ctx.Request().Context())
+
+	if err != nil {
+		return c.Fail(err, "не удается загрузить проекты")
+	}
+
+	res := make([]projectResponse, 0, len(ps))
+	for _, p := range ps {
+		res = append(res, c.response(ctx, p))
+	}
+
+	return ctx.JSON(http.StatusOK, res)
+}
+
+// Show возвращает проект в обход кеша
+func (c *projects) Show(ctx echo.Context) error {
+	p := ctx.Get(context.ProjectKey).(*ent.Project)
+	return c.respond(ctx, http.StatusOK, p.ID)
+}
+
+// Create создает проект
+func (c *projects) Create(ctx echo.Context) error {
+	var form projectForm
+	if err := c.bind(ctx, &form); err != nil {
+		return err
+	}
+
+	if form.Submission.HasErrors() {
+		return problem.Validation(form.Submission.GetAllFieldErrors())
+	}
+
+	create := c.Container.ORM.Project.Create()
+	form.apply(create.Mutation())
+	p, err := create.Save(ctx.Request().Context())
+	if err != nil {
+		return c.saveError(ctx, err)
+	}
+
+	logging.Infof(ctx.Request().Context(), "создан проект: %d", p.ID)
+
+	return c.respond(ctx, http.StatusCreated, p.ID)
+}
+
+// Update изменяет проект
+func (c *projects) Update(ctx echo.Context) error {
+	p := ctx.Get(context.ProjectKey).(*ent.Project)
+
+	var form projectForm
+	if err := c.bind(ctx, &form); err != nil {
+		return err
+	}
+
+	if form.Submission.HasErrors() {
+		return problem.Validation(form.Submission.GetAllFieldErrors())
+	}
+
+	update := p.Update()
+	form.apply(update.Mutation())
+	if _, err := update.Save(ctx.Request().Context()); err != nil {
+		return c.saveError(ctx, err)
+	}
+
+	return c.respond(ctx, http.StatusOK, p.ID)
+}
+
+// Delete удаляет проект
+// Медиафайл обложки при этом не удаляется
+func (c *projects) Delete(ctx echo.Context) error {
+	p := ctx.Get(context.ProjectKey).(*ent.Project)
+
+	if err := c.Container.ORM.Project.DeleteOne(p).Exec(ctx.Request().Context()); err != nil {
+		return c.Fail(err, "не удается удалить проект")
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// bind разбирает и проверяет форму проекта
+func (c *projects) bind(ctx echo.Context, form *projectForm) error {
+	if err := ctx.Bind(form); err != nil {
+		return err
+	}
+
+	if err := form.Submission.Process(ctx, form); err != nil {
+		return c.Fail(err, "не удается обработать отправку формы")
+	}
+
+	// The cover is shown publicly, so it must not be a private media
+	if form.CoverID != 0 {
+		m, err := c.Container.ORM.Media.Get(ctx.Request().Context(), form.CoverID)
+		switch err.(type) {
+		case nil:
+			if m.Private {
+				form.Submission.SetFieldError("cover_id", i18n.Ctx(ctx, "project.cover_private"))
+			}
+		case *ent.NotFoundError:
+			form.Submission.SetFieldError("cover_id", i18n.Ctx(ctx, "project.cover_not_found"))
+		default:
+			return c.Fail(err, "не удается загрузить обложку")
+		}
+	}
+
+	return nil
+}
+
+// saveError формирует ответ на ошибку сохранения проекта
+func (c *projects) saveError(ctx echo.Context, err error) error {
+	if ent.IsConstraintError(err) {
+		return problem.New(http.StatusConflict, problem.CodeConflict, i18n.Ctx(ctx, "project.slug_taken"))
+	}
+	return c.Fail(err, "не удается сохранить проект")
+}
+
+// respond загружает сохраненный проект и отправляет его в ответе
+func (c *projects) respond(ctx echo.Context, status, projectID int) error {
+	p, err := c.Container.ORM.Project.
+		Query().
+		Where(project.ID(projectID)).
+		WithCover(func(q *ent.MediaQuery) {
+			q.WithVariants()
+		}).
+		Only(ctx.Request().Context())
+
+	if err != nil {
+		return c.Fail(err, "не удается загрузить проект")
+	}
+
+	return ctx.JSON(status, c.response(ctx, p))
+}
+
+// response формирует ответ с описанием проекта
+func (c *projects) response(ctx echo.Context, p *ent.Project) projectResponse {
+	res := projectResponse{
+		ID:            p.ID,
+		Title:         p.Title,
+		Slug:          p.Slug,
+		Description:   p.Description,
+		Stack:         p.Stack,
+		RepositoryURL: p.RepositoryURL,
+		DemoURL:       p.DemoURL,
+		Position:      p.Position,
+		Featured:      p.Featured,
+		CreatedAt:     p.CreatedAt,
+		UpdatedAt:     p.UpdatedAt,
+	}
+	if res.Stack == nil {
+		res.Stack = []string{}
+	}
+	if p.Edges.Cover != nil {
+		cover := newMediaResponse(ctx, c.Container.Media, p.Edges.Cover)
+		res.Cover = &cover
+	}
+	return res
+}
+
+// apply переносит значения формы в мутацию проекта
+// Пустые элементы стека технологий отбрасываются
+func (f *projectForm) apply(m *ent.ProjectMutation) {
+	stack := make([]string, 0, len(f.Stack))
+	for _, s := range f.Stack {
+		if s = strings.TrimSpace(s); s != "" {
+			stack = append(stack, s)
+		}
+	}
+
+	m.SetTitle(f.Title)
+	m.SetSlug(f.Slug)
+	m.SetDescription(f.Description)
+	m.SetStack(stack)
+	m.SetRepositoryURL(f.RepositoryURL)
+	m.SetDemoURL(f.DemoURL)
+	m.SetPosition(f.Position)
+	m.SetFeatured(f.Featured)
+	if f.CoverID != 0 {
+		m.SetCoverID(f.CoverID)
+	} else {
+		m.ClearCover()
+	}
+}
@@ -0,0 +1,49 @@
+package routes
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vovanwin/api-my-site/pkg/factory"
+)
+
+func TestProjects_Admin(t *testing.T) {
+	_, token := createAdmin(t)
+	p, err := factory.New(c.ORM, time.Now().UnixNano()).Project().Create(context.Background())
+	require.NoError(t, err)
+
+	var list []projectResponse
+	request(t).
+		setRoute("admin.projects.index").
+		setToken(token).
+		send(http.MethodGet, nil).
+		assertStatusCode(http.StatusOK).
+		toJSON(&list)
+	var found bool
+	for _, item := range list {
+		found = found || item.ID == p.ID
+	}
+	assert.True(t, found)
+
+	var res projectResponse
+	request(t).
+		setRoute("admin.projects.show", p.ID).
+		setToken(token).
+		send(http.MethodGet, nil).
+		assertStatusCode(http.StatusOK).
+		toJSON(&res)
+	assert.Equal(t, p.Slug, res.Slug)
+
+	// Other users can't use the admin routes
+	u := createUser(t)
+	request(t).
+		setRoute("admin.projects.show", p.ID).
+		setToken(logIn(t, u.Email, factory.DefaultPassword)).
+		send(http.MethodGet, nil).
+		assertStatusCode(http.StatusForbidden)
+}
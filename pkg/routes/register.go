@@ -1,10 +1,13 @@
 package routes
 
 import (
-	"github.com/sirupsen/logrus"
 	"net/http"
 
+	"github.com/vovanwin/api-my-site/ent"
 	"github.com/vovanwin/api-my-site/pkg/controller"
+	"github.com/vovanwin/api-my-site/pkg/i18n"
+	"github.com/vovanwin/api-my-site/pkg/logging"
+	"github.com/vovanwin/api-my-site/pkg/problem"
 
 	"github.com/labstack/echo/v4"
 )
@@ -15,11 +18,18 @@ type (
 	}
 
 	registerForm struct {
-		Name            string `form:"name" validate:"required"`
-		Email           string `form:"email" validate:"required,email"`
-		Password        string `form:"password" validate:"required"`
-		ConfirmPassword string `form:"password-confirm" validate:"required,eqfield=Password"`
-		Submission      controller.FormSubmission
+		Name            string                    `form:"name" json:"name" validate:"required" label:"field.name"`
+		Email           string                    `form:"email" json:"email" normalize:"lower" validate:"required,email,unique_email" label:"field.email"`
+		Password        string                    `form:"password" json:"password" validate:"required,password" label:"field.password" trim:"-"`
+		ConfirmPassword string                    `form:"password-confirm" json:"password-confirm" validate:"required,eqfield=Password" label:"field.password_confirm" trim:"-"`
+		Submission      controller.FormSubmission `form:"-" json:"-"`
+	}
+
+	// registerResponse содержит токен созданного пользователя, либо сообщение о создании учетной записи,
+	// если войти в систему не удалось
+	registerResponse struct {
+		Token   string `json:"token,omitempty"`
+		Message string `json:"message,omitempty"`
 	}
 )
 
@@ -28,29 +38,23 @@ func (c *register) Post(ctx echo.Context) error {
 
 	// Parse the form values
 	if err := ctx.Bind(&form); err != nil {
-		return ctx.JSON(http.StatusBadRequest, map[string]interface{}{
-			"message": "не удается разобрать регистрационную форму",
-		})
+		return err
 	}
 
-	if err := form.Submission.Process(ctx, form); err != nil {
-		return ctx.JSON(http.StatusBadRequest, map[string]interface{}{
-			"message": "не удается обработать отправку формы",
-		})
+	if err := form.Submission.Process(ctx, &form); err != nil {
+		return c.Fail(err, "не удается обработать отправку формы")
 	}
-	logrus.Infof("ДО: %s", "Ошибки")
+	logging.Infof(ctx.Request().Context(), "ДО: %s", "Ошибки")
 
 	if form.Submission.HasErrors() {
-		return ctx.JSON(http.StatusUnprocessableEntity, form.Submission.GetAllFieldErrors())
+		return problem.Validation(form.Submission.GetAllFieldErrors())
 	}
-	logrus.Infof("После: %s", "Ошибки")
+	logging.Infof(ctx.Request().Context(), "После: %s", "Ошибки")
 
 	// Hash the password
 	pwHash, err := c.Container.Auth.HashPassword(form.Password)
 	if err != nil {
-		return ctx.JSON(http.StatusUnprocessableEntity, map[string]interface{}{
-			"message": "не удается хешировать пароль",
-		})
+		return c.Fail(err, "не удается хешировать пароль")
 	}
 
 	// Attempt creating the user
@@ -63,39 +67,34 @@ func (c *register) Post(ctx echo.Context) error {
 
 	switch err.(type) {
 	case nil:
-		logrus.Infof("создан пользователь: %s", u.Name)
+		logging.Infof(ctx.Request().Context(), "создан пользователь: %s", u.Name)
 	case *ent.ConstraintError:
-		return ctx.JSON(http.StatusBadRequest, map[string]interface{}{
-			"message": "Пользователь с этим адресом электронной почты уже существует. Пожалуйста, войдите в систему.",
-		})
+		return problem.New(
+			http.StatusConflict,
+			problem.CodeConflict,
+			i18n.Ctx(ctx, "auth.user_exists"),
+		)
 	default:
-		logrus.Errorf("Ошибка создания пользователя: %s", err)
-		return ctx.JSON(http.StatusBadRequest, map[string]interface{}{
-			"message": "не удалось создать пользователя.",
-		})
+		return c.Fail(err, "не удалось создать пользователя")
 	}
 	// Log the user in
-	token, err := c.Container.Auth.Login(ctx, u.ID)
+	token, err := c.Container.Auth.Login(ctx, u)
 	if err != nil {
-		logrus.Errorf("не удается войти в систему: %v", err)
-		return ctx.JSON(http.StatusOK, map[string]interface{}{
-			"message": "Ваша учетная запись была создана.",
-		})
+		logging.Errorf(ctx.Request().Context(), "не удается войти в систему: %v", err)
+		return ctx.JSON(http.StatusOK, registerResponse{Message: i18n.Ctx(ctx, "auth.account_created")})
 	}
 
 	// Send the verification email
 	//c.sendVerificationEmail(ctx, u)
 
-	return ctx.JSON(http.StatusOK, map[string]interface{}{
-		"token": token,
-	})
+	return ctx.JSON(http.StatusOK, registerResponse{Token: token})
 }
 
 func (c *register) sendVerificationEmail(ctx echo.Context, usr *ent.User) {
 	// Generate a token
 	//token, err := c.Container.Auth.GenerateEmailVerificationToken(usr.Email)
 	//if err != nil {
-	//	logrus.Errorf("не удается сгенерировать токен подтверждения электронной почты: %v", err)
+	//	logging.Errorf(ctx.Request().Context(), "не удается сгенерировать токен подтверждения электронной почты: %v", err)
 	//	return
 	//}
 	//
@@ -109,7 +108,7 @@ func (c *register) sendVerificationEmail(ctx echo.Context, usr *ent.User) {
 	////	Send(ctx)
 	//
 	//if err != nil {
-	//	logrus.Errorf("не удается отправить ссылку для подтверждения по электронной почте: %v", err)
+	//	logging.Errorf(ctx.Request().Context(), "не удается отправить ссылку для подтверждения по электронной почте: %v", err)
 	//	return
 	//}
 }
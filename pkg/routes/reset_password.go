@@ -0,0 +1,97 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/vovanwin/api-my-site/ent"
+	"github.com/vovanwin/api-my-site/pkg/controller"
+	"github.com/vovanwin/api-my-site/pkg/i18n"
+	"github.com/vovanwin/api-my-site/pkg/logging"
+	"github.com/vovanwin/api-my-site/pkg/problem"
+	"github.com/vovanwin/api-my-site/pkg/services"
+)
+
+type (
+	resetPassword struct {
+		controller.Controller
+	}
+
+	resetPasswordForm struct {
+		UserID          int                       `form:"user_id" json:"user_id" validate:"required" label:"field.user"`
+		TokenID         int                       `form:"token_id" json:"token_id" validate:"required" label:"field.token"`
+		Token           string                    `form:"token" json:"token" validate:"required" label:"field.token"`
+		Password        string                    `form:"password" json:"password" validate:"required,password" label:"field.password" trim:"-"`
+		ConfirmPassword string                    `form:"password-confirm" json:"password-confirm" validate:"required,eqfield=Password" label:"field.password_confirm" trim:"-"`
+		Submission      controller.FormSubmission `form:"-" json:"-"`
+	}
+)
+
+// Post устанавливает новый пароль по токену из ссылки для сброса пароля
+// Все выданные пользователю токены и ссылки для сброса пароля отзываются, а в ответе возвращается новый токен
+func (c *resetPassword) Post(ctx echo.Context) error {
+	var form resetPasswordForm
+
+	if err := ctx.Bind(&form); err != nil {
+		return err
+	}
+
+	if err := form.Submission.Process(ctx, &form); err != nil {
+		return c.Fail(err, "не удается обработать отправку формы")
+	}
+
+	if form.Submission.HasErrors() {
+		return problem.Validation(form.Submission.GetAllFieldErrors())
+	}
+
+	_, err := c.Container.Auth.GetValidPasswordToken(ctx, form.UserID, form.TokenID, form.Token)
+	switch err.(type) {
+	case nil:
+	case services.InvalidPasswordTokenError:
+		return problem.New(http.StatusBadRequest, problem.CodeInvalidToken, i18n.Ctx(ctx, "auth.reset_token_invalid"))
+	default:
+		return c.Fail(err, "ошибка при проверке токена сброса пароля")
+	}
+
+	hash, err := c.Container.Auth.HashPassword(form.Password)
+	if err != nil {
+		return c.Fail(err, "не удается хешировать пароль")
+	}
+
+	u, err := c.Container.ORM.User.
+		UpdateOneID(form.UserID).
+		SetPassword(hash).
+		AddTokenVersion(1).
+		Save(ctx.Request().Context())
+
+	switch err.(type) {
+	case nil:
+		logging.Infof(ctx.Request().Context(), "сброшен пароль пользователя: %d", u.ID)
+		c.Container.Audit.Record(ctx.Request().Context(), services.AuditEntry{
+			Action:     "auth.password_reset",
+			ActorID:    &u.ID,
+			TargetType: "user",
+			TargetID:   &u.ID,
+		})
+	case *ent.NotFoundError:
+		return problem.New(http.StatusBadRequest, problem.CodeInvalidToken, i18n.Ctx(ctx, "auth.reset_token_invalid"))
+	default:
+		return c.Fail(err, "не удается изменить пароль")
+	}
+
+	if err := c.Container.Auth.DeletePasswordTokens(ctx, u.ID); err != nil {
+		logging.Errorf(ctx.Request().Context(), "не удается удалить токены сброса пароля пользователя %d: %v", u.ID, err)
+	}
+
+	if u.BlockedAt != nil {
+		return problem.New(http.StatusForbidden, problem.CodeAccountBlocked, "")
+	}
+
+	token, err := c.Container.Auth.Login(ctx, u)
+	if err != nil {
+		return c.Fail(err, "не удается войти в систему")
+	}
+
+	return ctx.JSON(http.StatusOK, tokenResponse{Token: token})
+}
@@ -1,32 +1,80 @@
 package routes
 
 import (
+	"crypto/subtle"
+	"errors"
+
 	"github.com/golang-jwt/jwt/v4"
 	echojwt "github.com/labstack/echo-jwt/v4"
 	"github.com/sirupsen/logrus"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/vovanwin/api-my-site/config"
+	"github.com/vovanwin/api-my-site/pkg/context"
 	"github.com/vovanwin/api-my-site/pkg/controller"
+	"github.com/vovanwin/api-my-site/pkg/logging"
 	"github.com/vovanwin/api-my-site/pkg/middleware"
+	"github.com/vovanwin/api-my-site/pkg/problem"
 	"github.com/vovanwin/api-my-site/pkg/services"
 
 	"github.com/labstack/echo/v4"
 	echomw "github.com/labstack/echo/v4/middleware"
 )
 
+const (
+	// apiVendor содержит производителя в типе содержимого, которым клиенты выбирают версию API,
+	// например application/vnd.api-my-site.v1+json
+	apiVendor = "vnd.api-my-site"
+)
+
+// apiVersions содержит поддерживаемые версии API, первая из которых используется, если версия не указана.
+// Все версии обслуживаются общими маршрутами: отличия новой версии описываются адаптерами
+// middleware.Versioned, а вывод старой версии из эксплуатации - middleware.Deprecated на маршрутах
+var apiVersions = []string{"v1"}
+
 // BuildRouter builds the router
 func BuildRouter(c *services.Container) {
-	log := logrus.New()
-
 	// Статические файлы с надлежащим управлением кэшем
 	// функциональная карта.File() следует использовать в шаблонах для добавления ключа кэша к URL-адресу, чтобы разбить кэш
 	// после каждого перезапуска сервера
-	c.Web.Group("", middleware.CacheControl(c.Config.Cache.Expiration.StaticFile)).
+	staticMaxAge := func() time.Duration {
+		return c.Settings.Current().Cache.Expiration.StaticFile
+	}
+	c.Web.Group("", middleware.CacheControl(staticMaxAge)).
 		Static(config.StaticPrefix, config.StaticDir)
 
+	// Base controller
+	ctr := controller.NewController(c)
+
+	// Error handler
+	errHandler := errorHandler{Controller: ctr}
+	c.Web.HTTPErrorHandler = errHandler.Get
+
+	// Ленты и карта сайта
+	feedRoutes(c, ctr)
+
+	// Метрики Prometheus
+	metricsRoutes(c)
+
+	// Проверки работоспособности и готовности
+	healthRoutes(c, ctr)
+
+	// Версия API определяется до выбора маршрута, так как сегмент пути с версией удаляется.
+	// Завершающий слеш удаляется раньше, чтобы перенаправление сохраняло версию в пути
+	c.Web.Pre(
+		echomw.RemoveTrailingSlashWithConfig(echomw.TrailingSlashConfig{
+			Skipper: func(ctx echo.Context) bool {
+				return !strings.HasPrefix(ctx.Request().URL.Path, "/api/")
+			},
+			RedirectCode: http.StatusMovedPermanently,
+		}),
+		middleware.APIVersion("/api", apiVendor, apiVersions...),
+	)
+
 	// Нестатическая группа маршрутов к файлам
-	g := c.Web.Group("api")
+	g := c.Web.Group("/api", middleware.Metrics(c.Metrics), middleware.Tracing(c.Tracing))
 
 	// Force HTTPS, if enabled
 	if c.Config.HTTP.TLS.Enabled {
@@ -34,60 +82,86 @@ func BuildRouter(c *services.Container) {
 	}
 
 	g.Use(
-		echomw.RemoveTrailingSlashWithConfig(echomw.TrailingSlashConfig{
-			RedirectCode: http.StatusMovedPermanently,
-		}),
 		echomw.Recover(),
 		echomw.Secure(),
-		echomw.RequestID(),
+		// Идентификатор запроса сохраняется и в контексте, так как после echomw.Timeout заголовки ответа не видны
+		echomw.RequestIDWithConfig(echomw.RequestIDConfig{
+			RequestIDHandler: func(ctx echo.Context, id string) {
+				ctx.Set(context.RequestIDKey, id)
+			},
+		}),
+		middleware.Locale(),
 		echomw.Gzip(),
+		// Запрос записывается в журнал, сохраненный в контексте запроса middleware.Logger ниже по цепочке,
+		// так как к моменту записи запрос уже заменен вложенными middleware
 		echomw.RequestLoggerWithConfig(echomw.RequestLoggerConfig{
-			LogURI:    true,
-			LogStatus: true,
+			LogURI:       true,
+			LogStatus:    true,
+			LogMethod:    true,
+			LogLatency:   true,
+			LogError:     true,
+			LogRequestID: true,
 			LogValuesFunc: func(c echo.Context, values echomw.RequestLoggerValues) error {
-				log.WithFields(logrus.Fields{
-					"URI":       values.URI,
-					"status":    values.Status,
-					"Method":    values.Method,
-					"Error":     values.Error,
-					"latency":   values.Latency,
-					"RequestID": values.RequestID,
-				}).Info("request")
+				// Ответ на ошибку отправляется позже обработчиком ошибок, поэтому статус определяется по ошибке
+				status := values.Status
+				if values.Error != nil {
+					status = problem.FromError(values.Error).Status
+				}
+
+				entry := logging.WithFields(c.Request().Context(), logrus.Fields{
+					"uri":        values.URI,
+					"status":     status,
+					"method":     values.Method,
+					"latency":    values.Latency,
+					"request_id": values.RequestID,
+				})
+				if values.Error != nil {
+					entry = entry.WithError(values.Error)
+				}
+				entry.Info("запрос обработан")
 
 				return nil
 			},
 		}),
-		middleware.LogRequestID(),
 		// Configure middleware with the custom claims type
+		// Запросы без токена пропускаются, чтобы публичные маршруты оставались доступными
 		echojwt.WithConfig(echojwt.Config{
 			NewClaimsFunc: func(ctx echo.Context) jwt.Claims {
 				return new(services.JwtCustomClaims)
 			},
 			SigningKey: []byte(c.Config.App.EncryptionKey),
+			ErrorHandler: func(ctx echo.Context, err error) error {
+				if errors.Is(err, echojwt.ErrJWTMissing) {
+					return nil
+				}
+				return echo.ErrUnauthorized.WithInternal(err)
+			},
+			ContinueOnIgnoredError: true,
 		}),
 		echomw.TimeoutWithConfig(echomw.TimeoutConfig{
 			Timeout: c.Config.App.Timeout,
 		}),
 		middleware.LoadAuthenticatedUser(c.Auth),
-		middleware.ServeCachedPage(c.Cache),
+		middleware.Logger(),
+		middleware.AuditActor(),
 	)
 
-	// Base controller
-	ctr := controller.NewController(c)
-
 	// Example routes
 	navRoutes(c, g, ctr)
 	userRoutes(c, g, ctr)
+	mediaRoutes(c, g, ctr)
+	contentRoutes(c, g, ctr)
+	contactRoutes(c, g, ctr)
+	adminUserRoutes(c, g, ctr)
+	auditRoutes(c, g, ctr)
+	settingsRoutes(c, g, ctr)
+	flagRoutes(c, g, ctr)
+	docsRoutes(c, g, ctr)
 }
 
 func navRoutes(c *services.Container, g *echo.Group, ctr controller.Controller) {
-
-	groupNav := g.Group("/user", ctr.AuthMiddleware())
-
-	groupNav.GET("/test", func(c echo.Context) error {
-
-		return c.JSON(http.StatusOK, "claims")
-	}, middleware.RequireAuthentication())
+	pages := pages{Controller: ctr}
+	g.GET("/nav", pages.Nav, middleware.ServeCachedPage(c.Cache)).Name = "nav"
 }
 
 func userRoutes(c *services.Container, g *echo.Group, ctr controller.Controller) {
@@ -98,4 +172,178 @@ func userRoutes(c *services.Container, g *echo.Group, ctr controller.Controller)
 
 	register := register{Controller: ctr}
 	noAuth.POST("/register", register.Post).Name = "register.post"
+
+	resetPassword := resetPassword{Controller: ctr}
+	noAuth.POST("/password/reset", resetPassword.Post).Name = "reset_password.post"
+
+	me := me{Controller: ctr}
+	g.POST("/auth/email/confirm", me.ConfirmEmail).Name = "auth.email.confirm"
+
+	logout := logout{Controller: ctr}
+	g.POST("/auth/logout", logout.Post, middleware.RequireAuthentication()).Name = "logout.post"
+
+	profile := g.Group("/me", middleware.RequireAuthentication())
+	profile.GET("", me.Get).Name = "me.get"
+	profile.PATCH("", me.Update).Name = "me.update"
+	profile.DELETE("", me.Delete).Name = "me.delete"
+	profile.POST("/email", me.Email).Name = "me.email"
+	profile.POST("/password", me.Password).Name = "me.password"
+}
+
+func mediaRoutes(c *services.Container, g *echo.Group, ctr controller.Controller) {
+	media := media{Controller: ctr}
+
+	groupMedia := g.Group("/media")
+	groupMedia.POST("", media.Post, middleware.RequireAuthentication()).Name = "media.post"
+
+	item := groupMedia.Group("/:media", middleware.LoadMedia(c.ORM))
+	item.GET("", media.Get).Name = "media.get"
+	item.GET("/file", media.File).Name = "media.file"
+	item.GET("/variants/:variant", media.Variant).Name = "media.variant"
+	item.DELETE("", media.Delete, middleware.RequireAuthentication()).Name = "media.delete"
+}
+
+func contentRoutes(c *services.Container, g *echo.Group, ctr controller.Controller) {
+	pages := pages{Controller: ctr}
+	g.GET("/pages", pages.List, middleware.ServeCachedPage(c.Cache)).Name = "pages.list"
+	g.GET("/pages/:slug", pages.Get, middleware.ServeCachedPage(c.Cache)).Name = "pages.get"
+
+	projects := projects{Controller: ctr}
+	g.GET("/projects", projects.List, middleware.ServeCachedPage(c.Cache, "featured")).Name = "projects.list"
+	g.GET("/projects/:slug", projects.Get, middleware.ServeCachedPage(c.Cache)).Name = "projects.get"
+
+	admin := g.Group("/admin", middleware.RequireAdmin())
+
+	adminPages := admin.Group("/pages")
+	adminPages.GET("", pages.Index).Name = "admin.pages.index"
+	adminPages.POST("", pages.Create).Name = "admin.pages.create"
+
+	page := adminPages.Group("/:page", middleware.LoadPage(c.ORM))
+	page.GET("", pages.Show).Name = "admin.pages.show"
+	page.PUT("", pages.Update).Name = "admin.pages.update"
+	page.DELETE("", pages.Delete).Name = "admin.pages.delete"
+
+	adminProjects := admin.Group("/projects")
+	adminProjects.GET("", projects.Index).Name = "admin.projects.index"
+	adminProjects.POST("", projects.Create).Name = "admin.projects.create"
+
+	project := adminProjects.Group("/:project", middleware.LoadProject(c.ORM))
+	project.GET("", projects.Show).Name = "admin.projects.show"
+	project.PUT("", projects.Update).Name = "admin.projects.update"
+	project.DELETE("", projects.Delete).Name = "admin.projects.delete"
+}
+
+func contactRoutes(c *services.Container, g *echo.Group, ctr controller.Controller) {
+	contact := contact{Controller: ctr}
+	g.GET("/contact", contact.Get).Name = "contact.get"
+	g.POST("/contact", contact.Post, middleware.RateLimit(func() middleware.RateLimitPolicy {
+		return middleware.RateLimitPolicy(c.Settings.Current().Contact.RateLimit)
+	})).Name = "contact.post"
+
+	messages := g.Group("/admin/messages", middleware.RequireAdmin())
+	messages.GET("", contact.Index).Name = "admin.messages.index"
+
+	message := messages.Group("/:message", middleware.LoadContactMessage(c.ORM))
+	message.POST("/handled", contact.Handle).Name = "admin.messages.handle"
+	message.DELETE("/handled", contact.Unhandle).Name = "admin.messages.unhandle"
+}
+
+func adminUserRoutes(c *services.Container, g *echo.Group, ctr controller.Controller) {
+	users := users{Controller: ctr}
+
+	adminUsers := g.Group("/admin/users", middleware.RequireAdmin())
+	adminUsers.GET("", users.Index).Name = "admin.users.index"
+
+	item := adminUsers.Group("/:user", middleware.LoadUser(c.ORM))
+	item.GET("", users.Show).Name = "admin.users.show"
+	item.DELETE("", users.Delete).Name = "admin.users.delete"
+	item.POST("/block", users.Block).Name = "admin.users.block"
+	item.DELETE("/block", users.Unblock).Name = "admin.users.unblock"
+	item.POST("/verify", users.Verify).Name = "admin.users.verify"
+	item.POST("/password-reset", users.PasswordReset).Name = "admin.users.password_reset"
+	item.POST("/impersonate", users.Impersonate).Name = "admin.users.impersonate"
+	item.DELETE("/sessions", users.RevokeSessions).Name = "admin.users.sessions.revoke"
+}
+
+func auditRoutes(c *services.Container, g *echo.Group, ctr controller.Controller) {
+	audit := audit{Controller: ctr}
+	g.GET("/admin/audit", audit.Index, middleware.RequireAdmin()).Name = "admin.audit.index"
+}
+
+// docsRoutes регистрирует спецификацию OpenAPI, а вне production окружения и Swagger UI
+func docsRoutes(c *services.Container, g *echo.Group, ctr controller.Controller) {
+	docs := &openAPI{Controller: ctr}
+	g.GET("/openapi.json", docs.Spec).Name = "openapi.spec"
+
+	if c.Config.App.Environment != config.EnvProduction {
+		g.GET("/docs", docs.UI).Name = "openapi.ui"
+		g.GET("/docs/:file", docs.Asset).Name = "openapi.ui.asset"
+	}
+}
+
+// metricsRoutes регистрирует метрики Prometheus, если они не обслуживаются на отдельном порту
+// Если задан токен, для получения метрик требуется передать его в заголовке Authorization
+func metricsRoutes(c *services.Container) {
+	if !c.Config.Metrics.Enabled || c.Config.Metrics.Port != 0 {
+		return
+	}
+
+	var mw []echo.MiddlewareFunc
+	if token := c.Config.Metrics.Token; token != "" {
+		mw = append(mw, echomw.KeyAuth(func(key string, ctx echo.Context) (bool, error) {
+			return subtle.ConstantTimeCompare([]byte(key), []byte(token)) == 1, nil
+		}))
+	}
+
+	c.Web.GET("/metrics", echo.WrapHandler(c.Metrics.Handler()), mw...).Name = "metrics"
+}
+
+func settingsRoutes(c *services.Container, g *echo.Group, ctr controller.Controller) {
+	settings := settings{Controller: ctr}
+	g.GET("/admin/settings", settings.Get, middleware.RequireAdmin()).Name = "admin.settings.get"
+}
+
+func flagRoutes(c *services.Container, g *echo.Group, ctr controller.Controller) {
+	flags := flags{Controller: ctr}
+
+	adminFlags := g.Group("/admin/flags", middleware.RequireAdmin())
+	adminFlags.GET("", flags.Index).Name = "admin.flags.index"
+	adminFlags.POST("", flags.Create).Name = "admin.flags.create"
+
+	flag := adminFlags.Group("/:flag", middleware.LoadFeatureFlag(c.FeatureFlags))
+	flag.GET("", flags.Show).Name = "admin.flags.show"
+	flag.PUT("", flags.Update).Name = "admin.flags.update"
+	flag.DELETE("", flags.Delete).Name = "admin.flags.delete"
+}
+
+// healthRoutes регистрирует проверки работоспособности и готовности для оркестратора
+// Они обслуживаются вне группы /api, чтобы не зависеть от версии API, ограничения времени и журналирования запросов
+func healthRoutes(c *services.Container, ctr controller.Controller) {
+	health := health{Controller: ctr}
+	c.Web.GET("/healthz", health.Live).Name = "health.live"
+	c.Web.GET("/readyz", health.Ready).Name = "health.ready"
+}
+
+func feedRoutes(c *services.Container, ctr controller.Controller) {
+	g := c.Web.Group("",
+		echomw.Recover(),
+		echomw.RequestID(),
+		middleware.Locale(),
+		echomw.Gzip(),
+		// Ленты и карты сайта не читают параметры запроса
+		middleware.ServeCachedPage(c.Cache),
+	)
+
+	feeds := feeds{Controller: ctr}
+	g.GET("/feed.xml", feeds.RSS).Name = "feed.rss"
+	g.GET("/atom.xml", feeds.Atom).Name = "feed.atom"
+	g.GET("/feed.json", feeds.JSON).Name = "feed.json"
+	g.GET("/tags/:tag/feed.xml", feeds.RSS).Name = "feed.tag.rss"
+	g.GET("/tags/:tag/atom.xml", feeds.Atom).Name = "feed.tag.atom"
+	g.GET("/tags/:tag/feed.json", feeds.JSON).Name = "feed.tag.json"
+	g.GET("/categories/:category/feed.xml", feeds.RSS).Name = "feed.category.rss"
+	g.GET("/categories/:category/atom.xml", feeds.Atom).Name = "feed.category.atom"
+	g.GET("/categories/:category/feed.json", feeds.JSON).Name = "feed.category.json"
+	g.GET("/sitemap.xml", feeds.Sitemap).Name = "sitemap"
+	g.GET("/sitemap/:page", feeds.SitemapPage).Name = "sitemap.page"
 }
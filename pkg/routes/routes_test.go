@@ -1,6 +1,9 @@
 package routes
 
 import (
+	"bytes"
+	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/cookiejar"
 	"net/http/httptest"
@@ -12,6 +15,7 @@ import (
 	"github.com/vovanwin/api-my-site/pkg/services"
 
 	"github.com/PuerkitoBio/goquery"
+	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/stretchr/testify/require"
@@ -49,6 +53,7 @@ type httpRequest struct {
 	route  string
 	client http.Client
 	body   url.Values
+	token  string
 	t      *testing.T
 }
 
@@ -71,7 +76,12 @@ func (h *httpRequest) setClient(client http.Client) *httpRequest {
 }
 
 func (h *httpRequest) setRoute(route string, params ...interface{}) *httpRequest {
-	h.route = srv.URL + c.Web.Reverse(route, params)
+	h.route = srv.URL + c.Web.Reverse(route, params...)
+	return h
+}
+
+func (h *httpRequest) setToken(token string) *httpRequest {
+	h.token = token
 	return h
 }
 
@@ -112,6 +122,30 @@ func (h *httpRequest) post() *httpResponse {
 	return &r
 }
 
+// send makes a request with the JSON encoded body, authenticated with the token if there is one
+func (h *httpRequest) send(method string, body interface{}) *httpResponse {
+	var r io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		require.NoError(h.t, err)
+		r = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequest(method, h.route, r)
+	require.NoError(h.t, err)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	if h.token != "" {
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+h.token)
+	}
+
+	resp, err := h.client.Do(req)
+	require.NoError(h.t, err)
+	return &httpResponse{
+		t:        h.t,
+		Response: resp,
+	}
+}
+
 type httpResponse struct {
 	*http.Response
 	t *testing.T
@@ -127,6 +161,14 @@ func (h *httpResponse) assertRedirect(t *testing.T, route string, params ...inte
 	return h
 }
 
+func (h *httpResponse) toJSON(v interface{}) *httpResponse {
+	err := json.NewDecoder(h.Body).Decode(v)
+	require.NoError(h.t, err)
+	err = h.Body.Close()
+	assert.NoError(h.t, err)
+	return h
+}
+
 func (h *httpResponse) toDoc() *goquery.Document {
 	doc, err := goquery.NewDocumentFromReader(h.Body)
 	require.NoError(h.t, err)
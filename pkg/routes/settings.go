@@ -0,0 +1,33 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/vovanwin/api-my-site/config"
+	"github.com/vovanwin/api-my-site/pkg/controller"
+	"github.com/vovanwin/api-my-site/pkg/services"
+)
+
+type (
+	settings struct {
+		controller.Controller
+	}
+
+	// settingsResponse содержит текущие значения изменяемых без перезапуска настроек и результат последней
+	// перезагрузки, если она выполнялась
+	settingsResponse struct {
+		Settings   map[string]interface{}   `json:"settings"`
+		LastReload *services.SettingsReload `json:"last_reload"`
+	}
+)
+
+// Get возвращает текущие значения изменяемых без перезапуска настроек и результат последней перезагрузки
+func (c *settings) Get(ctx echo.Context) error {
+	ctx.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+	return ctx.JSON(http.StatusOK, settingsResponse{
+		Settings:   config.Reloadable(*c.Container.Settings.Current()),
+		LastReload: c.Container.Settings.LastReload(),
+	})
+}
@@ -0,0 +1,436 @@
+package routes
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+
+	"github.com/vovanwin/api-my-site/ent"
+	"github.com/vovanwin/api-my-site/ent/predicate"
+	"github.com/vovanwin/api-my-site/ent/user"
+	"github.com/vovanwin/api-my-site/pkg/context"
+	"github.com/vovanwin/api-my-site/pkg/controller"
+	"github.com/vovanwin/api-my-site/pkg/i18n"
+	"github.com/vovanwin/api-my-site/pkg/logging"
+	"github.com/vovanwin/api-my-site/pkg/paging"
+	"github.com/vovanwin/api-my-site/pkg/problem"
+	"github.com/vovanwin/api-my-site/pkg/services"
+	"github.com/vovanwin/api-my-site/pkg/tasks"
+)
+
+type (
+	users struct {
+		controller.Controller
+	}
+
+	userResponse struct {
+		ID       int    `json:"id"`
+		Name     string `json:"name"`
+		Email    string `json:"email"`
+		Verified bool   `json:"verified"`
+		Role     string `json:"role"`
+		Bio      string `json:"bio"`
+		Locale   string `json:"locale"`
+		Timezone string `json:"timezone"`
+		// TokenVersion содержит версию токенов пользователя, токены предыдущих версий отозваны
+		TokenVersion int        `json:"token_version"`
+		BlockedAt    *time.Time `json:"blocked_at"`
+		DeleteAt     *time.Time `json:"delete_at"`
+		CreatedAt    time.Time  `json:"created_at"`
+	}
+
+	// userDetailResponse содержит пользователя вместе с его токенами сброса пароля
+	userDetailResponse struct {
+		userResponse
+		PasswordTokens []passwordTokenResponse `json:"password_tokens"`
+	}
+
+	passwordTokenResponse struct {
+		ID        int       `json:"id"`
+		CreatedAt time.Time `json:"created_at"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+
+	// impersonationResponse содержит токен для действий от имени пользователя
+	impersonationResponse struct {
+		Token          string    `json:"token"`
+		ImpersonatorID int       `json:"impersonator_id"`
+		ExpiresAt      time.Time `json:"expires_at"`
+	}
+
+	// userPage содержит страницу пользователей
+	userPage = paging.Page[userResponse]
+)
+
+// userList описывает поля, по которым можно сортировать и фильтровать пользователей
+var userList = paging.Resource{
+	Sort: "-created_at",
+	Fields: map[string]paging.Field{
+		"created_at": {
+			Column: user.FieldCreatedAt,
+			Kind:   paging.Time,
+			Sort:   true,
+			Filter: paging.Comparable,
+		},
+		"name": {
+			Column: user.FieldName,
+			Kind:   paging.String,
+			Sort:   true,
+			Filter: []paging.Op{paging.Eq, paging.Contains},
+		},
+		"email": {
+			Column: user.FieldEmail,
+			Kind:   paging.String,
+			Sort:   true,
+			Filter: []paging.Op{paging.Eq, paging.In, paging.Contains},
+		},
+		"role": {
+			Column: user.FieldRole,
+			Kind:   paging.String,
+			Filter: []paging.Op{paging.Eq, paging.In},
+		},
+		"verified": {
+			Column: user.FieldVerified,
+			Kind:   paging.Bool,
+			Filter: []paging.Op{paging.Eq},
+		},
+		"blocked_at": {
+			Column:   user.FieldBlockedAt,
+			Kind:     paging.Time,
+			Filter:   paging.Comparable,
+			Nullable: true,
+		},
+		"delete_at": {
+			Column:   user.FieldDeleteAt,
+			Kind:     paging.Time,
+			Filter:   paging.Comparable,
+			Nullable: true,
+		},
+	},
+}
+
+// Index возвращает страницу пользователей, по умолчанию новые первыми
+// Параметр q ограничивает список пользователями, имя или адрес электронной почты которых содержат его
+func (c *users) Index(ctx echo.Context) error {
+	p, err := c.Paginate(ctx, userList)
+	if err != nil {
+		return err
+	}
+
+	where := []predicate.User{predicate.User(p.Where())}
+	if q := strings.TrimSpace(ctx.QueryParam("q")); q != "" {
+		where = append(where, user.Or(user.NameContainsFold(q), user.EmailContainsFold(q)))
+	}
+
+	query := c.Container.ORM.User.
+		Query().
+		Where(where...)
+
+	var total int
+	if p.Total {
+		if total, err = query.Clone().Count(ctx.Request().Context()); err != nil {
+			return c.Fail(err, "не удается подсчитать пользователей")
+		}
+	}
+
+	list, err := query.
+		Where(predicate.User(p.Seek())).
+		Order(user.OrderOption(p.Order())).
+		Limit(p.Fetch()).
+		All(ctx.Request().Context())
+
+	if err != nil {
+		return c.Fail(err, "не удается загрузить пользователей")
+	}
+
+	page, err := paging.NewPage(p, list, newUserResponse)
+	if err != nil {
+		return c.Fail(err, "не удается сформировать страницу пользователей")
+	}
+	if p.Total {
+		page.Total = &total
+	}
+
+	return c.RenderPage(ctx, page)
+}
+
+// Show возвращает пользователя вместе с его токенами сброса пароля
+func (c *users) Show(ctx echo.Context) error {
+	u := ctx.Get(context.UserKey).(*ent.User)
+
+	tokens, err := u.QueryOwner().All(ctx.Request().Context())
+	if err != nil {
+		return c.Fail(err, "не удается загрузить токены сброса пароля")
+	}
+
+	res := userDetailResponse{
+		userResponse:   newUserResponse(u),
+		PasswordTokens: make([]passwordTokenResponse, 0, len(tokens)),
+	}
+	for _, t := range tokens {
+		res.PasswordTokens = append(res.PasswordTokens, passwordTokenResponse{
+			ID:        t.ID,
+			CreatedAt: t.CreatedAt,
+			ExpiresAt: t.CreatedAt.Add(c.Container.Config.App.PasswordToken.Expiration),
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, res)
+}
+
+// Block блокирует пользователя, после чего его токены не принимаются, а вход в систему запрещен
+func (c *users) Block(ctx echo.Context) error {
+	u := ctx.Get(context.UserKey).(*ent.User)
+
+	if err := c.notSelf(ctx, u); err != nil {
+		return err
+	}
+
+	if u.BlockedAt == nil {
+		var err error
+		u, err = u.Update().
+			SetBlockedAt(time.Now()).
+			Save(ctx.Request().Context())
+		if err != nil {
+			return c.Fail(err, "не удается заблокировать пользователя")
+		}
+		logging.Infof(ctx.Request().Context(), "заблокирован пользователь: %d", u.ID)
+		c.Container.Audit.Record(ctx.Request().Context(), services.AuditEntry{
+			Action:     "user.block",
+			TargetType: "user",
+			TargetID:   &u.ID,
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, newUserResponse(u))
+}
+
+// Unblock снимает блокировку пользователя
+// Пользователя, ожидающего удаления, разблокировать нельзя, иначе вход в систему отменил бы удаление
+func (c *users) Unblock(ctx echo.Context) error {
+	u := ctx.Get(context.UserKey).(*ent.User)
+
+	if u.DeleteAt != nil {
+		return problem.New(http.StatusConflict, problem.CodeConflict, i18n.Ctx(ctx, "users.unblock_deleting"))
+	}
+
+	u, err := u.Update().
+		ClearBlockedAt().
+		Save(ctx.Request().Context())
+	if err != nil {
+		return c.Fail(err, "не удается разблокировать пользователя")
+	}
+
+	c.Container.Audit.Record(ctx.Request().Context(), services.AuditEntry{
+		Action:     "user.unblock",
+		TargetType: "user",
+		TargetID:   &u.ID,
+	})
+
+	return ctx.JSON(http.StatusOK, newUserResponse(u))
+}
+
+// Verify отмечает адрес электронной почты пользователя как подтвержденный
+func (c *users) Verify(ctx echo.Context) error {
+	u := ctx.Get(context.UserKey).(*ent.User)
+
+	u, err := u.Update().
+		SetVerified(true).
+		Save(ctx.Request().Context())
+	if err != nil {
+		return c.Fail(err, "не удается подтвердить адрес электронной почты")
+	}
+
+	c.Container.Audit.Record(ctx.Request().Context(), services.AuditEntry{
+		Action:     "user.verify",
+		TargetType: "user",
+		TargetID:   &u.ID,
+	})
+
+	return ctx.JSON(http.StatusOK, newUserResponse(u))
+}
+
+// PasswordReset принудительно сбрасывает пароль пользователя
+// Прежний пароль и все выданные токены перестают действовать, а пользователю отправляется ссылка
+// для установки нового пароля
+func (c *users) PasswordReset(ctx echo.Context) error {
+	u := ctx.Get(context.UserKey).(*ent.User)
+
+	// Пароль заменяется случайным, который никому не известен
+	random, err := c.Container.Auth.RandomToken(64)
+	if err != nil {
+		return c.Fail(err, "не удается сгенерировать пароль")
+	}
+	hash, err := c.Container.Auth.HashPassword(random)
+	if err != nil {
+		return c.Fail(err, "не удается хешировать пароль")
+	}
+
+	u, err = u.Update().
+		SetPassword(hash).
+		AddTokenVersion(1).
+		Save(ctx.Request().Context())
+	if err != nil {
+		return c.Fail(err, "не удается сбросить пароль")
+	}
+
+	if err := c.Container.Auth.DeletePasswordTokens(ctx, u.ID); err != nil {
+		return c.Fail(err, "не удается удалить токены сброса пароля")
+	}
+
+	token, pt, err := c.Container.Auth.GeneratePasswordResetToken(ctx, u.ID)
+	if err != nil {
+		return c.Fail(err, "не удается сгенерировать токен сброса пароля")
+	}
+
+	link := fmt.Sprintf("%s/password/reset?%s", strings.TrimSuffix(c.Container.Config.App.URL, "/"), url.Values{
+		"user_id":  []string{fmt.Sprint(u.ID)},
+		"token_id": []string{fmt.Sprint(pt.ID)},
+		"token":    []string{token},
+	}.Encode())
+	err = c.Container.Mail.
+		Compose().
+		To(u.Email).
+		Subject(i18n.Ctx(ctx, "users.password_reset_subject")).
+		Body(i18n.Ctx(ctx, "users.password_reset_body", i18n.Params{"url": link})).
+		Send(ctx.Request().Context())
+	if err != nil {
+		return c.Fail(err, "не удается отправить ссылку для сброса пароля")
+	}
+
+	logging.Infof(ctx.Request().Context(), "принудительно сброшен пароль пользователя: %d", u.ID)
+	c.Container.Audit.Record(ctx.Request().Context(), services.AuditEntry{
+		Action:     "user.password_reset_forced",
+		TargetType: "user",
+		TargetID:   &u.ID,
+	})
+
+	return ctx.JSON(http.StatusAccepted, messageResponse{Message: i18n.Ctx(ctx, "users.password_reset_sent")})
+}
+
+// Impersonate выдает администратору токен для действий от имени пользователя
+// Действовать от имени других администраторов нельзя, а каждый запрос с таким токеном записывается в журнал
+func (c *users) Impersonate(ctx echo.Context) error {
+	u := ctx.Get(context.UserKey).(*ent.User)
+	admin := ctx.Get(context.AuthenticatedUserKey).(*ent.User)
+
+	if err := c.notSelf(ctx, u); err != nil {
+		return err
+	}
+	if u.Role == user.RoleAdmin {
+		return problem.New(http.StatusForbidden, problem.CodeForbidden, i18n.Ctx(ctx, "users.impersonate_admin"))
+	}
+	if u.BlockedAt != nil {
+		return problem.New(http.StatusConflict, problem.CodeConflict, i18n.Ctx(ctx, "users.impersonate_blocked"))
+	}
+
+	token, err := c.Container.Auth.Impersonate(ctx, admin, u)
+	if err != nil {
+		return c.Fail(err, "не удается выдать токен для действий от имени пользователя")
+	}
+
+	logging.WithFields(ctx.Request().Context(), logrus.Fields{
+		"impersonator_id": admin.ID,
+		"user_id":         u.ID,
+		"ip":              ctx.RealIP(),
+	}).Warn("администратор начал действовать от имени пользователя")
+	c.Container.Audit.Record(ctx.Request().Context(), services.AuditEntry{
+		Action:     "user.impersonate",
+		TargetType: "user",
+		TargetID:   &u.ID,
+	})
+
+	return ctx.JSON(http.StatusOK, impersonationResponse{
+		Token:          token,
+		ImpersonatorID: admin.ID,
+		ExpiresAt:      time.Now().Add(c.Container.Config.App.ImpersonationTokenExpiration).Truncate(time.Second),
+	})
+}
+
+// RevokeSessions отзывает все выданные пользователю токены
+func (c *users) RevokeSessions(ctx echo.Context) error {
+	u := ctx.Get(context.UserKey).(*ent.User)
+
+	if err := c.Container.Auth.RevokeTokens(ctx, u.ID); err != nil {
+		return c.Fail(err, "не удается отозвать токены пользователя")
+	}
+
+	c.Container.Audit.Record(ctx.Request().Context(), services.AuditEntry{
+		Action:     "user.sessions_revoke",
+		TargetType: "user",
+		TargetID:   &u.ID,
+	})
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// Delete удаляет пользователя вместе с его медиафайлами и записями без льготного периода
+func (c *users) Delete(ctx echo.Context) error {
+	u := ctx.Get(context.UserKey).(*ent.User)
+
+	if err := c.notSelf(ctx, u); err != nil {
+		return err
+	}
+
+	// Пользователь блокируется, чтобы вход в систему до удаления не отменил его
+	update := u.Update().
+		SetDeleteAt(time.Now()).
+		AddTokenVersion(1)
+	if u.BlockedAt == nil {
+		update.SetBlockedAt(time.Now())
+	}
+	u, err := update.Save(ctx.Request().Context())
+	if err != nil {
+		return c.Fail(err, "не удается удалить пользователя")
+	}
+
+	err = c.Container.Tasks.
+		New(tasks.TypeUserDelete).
+		Context(ctx.Request().Context()).
+		Payload(tasks.UserDeletePayload{UserID: u.ID}).
+		MaxRetries(3).
+		Save()
+	if err != nil {
+		return c.Fail(err, "не удается поставить в очередь удаление пользователя")
+	}
+
+	logging.Infof(ctx.Request().Context(), "поставлено в очередь удаление пользователя: %d", u.ID)
+	c.Container.Audit.Record(ctx.Request().Context(), services.AuditEntry{
+		Action:     "user.delete",
+		TargetType: "user",
+		TargetID:   &u.ID,
+	})
+
+	return ctx.JSON(http.StatusAccepted, newUserResponse(u))
+}
+
+// notSelf запрещает администратору применять действие к собственной учетной записи
+func (c *users) notSelf(ctx echo.Context, u *ent.User) error {
+	if admin := ctx.Get(context.AuthenticatedUserKey).(*ent.User); admin.ID == u.ID {
+		return problem.New(http.StatusConflict, problem.CodeConflict, i18n.Ctx(ctx, "users.self"))
+	}
+	return nil
+}
+
+// newUserResponse формирует ответ с описанием пользователя
+func newUserResponse(u *ent.User) userResponse {
+	return userResponse{
+		ID:           u.ID,
+		Name:         u.Name,
+		Email:        u.Email,
+		Verified:     u.Verified,
+		Role:         u.Role.String(),
+		Bio:          u.Bio,
+		Locale:       u.Locale,
+		Timezone:     u.Timezone,
+		TokenVersion: u.TokenVersion,
+		BlockedAt:    u.BlockedAt,
+		DeleteAt:     u.DeleteAt,
+		CreatedAt:    u.CreatedAt,
+	}
+}
@@ -0,0 +1,236 @@
+package routes
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vovanwin/api-my-site/ent"
+	"github.com/vovanwin/api-my-site/ent/auditevent"
+	"github.com/vovanwin/api-my-site/pkg/factory"
+)
+
+// createAdmin creates an administrator and returns it along with its token
+func createAdmin(t *testing.T) (*ent.User, string) {
+	u, err := factory.New(c.ORM, time.Now().UnixNano()).User().Verified().Admin().Create(context.Background())
+	require.NoError(t, err)
+	return u, logIn(t, u.Email, factory.DefaultPassword)
+}
+
+// assertAudited asserts that the admin performed the action on the user
+func assertAudited(t *testing.T, action string, admin, u *ent.User) {
+	exists, err := c.ORM.AuditEvent.
+		Query().
+		Where(
+			auditevent.Action(action),
+			auditevent.ActorID(admin.ID),
+			auditevent.TargetType("user"),
+			auditevent.TargetID(u.ID),
+		).
+		Exist(context.Background())
+	require.NoError(t, err)
+	assert.True(t, exists, action)
+}
+
+func TestUsers_NotSelf(t *testing.T) {
+	admin, token := createAdmin(t)
+
+	cases := []struct {
+		route  string
+		method string
+	}{
+		{"admin.users.block", http.MethodPost},
+		{"admin.users.impersonate", http.MethodPost},
+		{"admin.users.delete", http.MethodDelete},
+	}
+
+	for _, test := range cases {
+		request(t).
+			setRoute(test.route, admin.ID).
+			setToken(token).
+			send(test.method, nil).
+			assertStatusCode(http.StatusConflict)
+	}
+
+	// Nothing should have changed
+	admin, err := c.ORM.User.Get(context.Background(), admin.ID)
+	require.NoError(t, err)
+	assert.Nil(t, admin.BlockedAt)
+	assert.Nil(t, admin.DeleteAt)
+	assert.Zero(t, admin.TokenVersion)
+}
+
+func TestUsers_Impersonate(t *testing.T) {
+	_, token := createAdmin(t)
+
+	// Other administrators can't be impersonated
+	other, _ := createAdmin(t)
+	request(t).
+		setRoute("admin.users.impersonate", other.ID).
+		setToken(token).
+		send(http.MethodPost, nil).
+		assertStatusCode(http.StatusForbidden)
+
+	// Neither can blocked users
+	blocked, err := factory.New(c.ORM, time.Now().UnixNano()).User().Verified().Blocked().Create(context.Background())
+	require.NoError(t, err)
+	request(t).
+		setRoute("admin.users.impersonate", blocked.ID).
+		setToken(token).
+		send(http.MethodPost, nil).
+		assertStatusCode(http.StatusConflict)
+
+	// The issued token acts as the user
+	u := createUser(t)
+	var res impersonationResponse
+	request(t).
+		setRoute("admin.users.impersonate", u.ID).
+		setToken(token).
+		send(http.MethodPost, nil).
+		assertStatusCode(http.StatusOK).
+		toJSON(&res)
+	require.NotEmpty(t, res.Token)
+
+	var me meResponse
+	request(t).
+		setRoute("me.get").
+		setToken(res.Token).
+		send(http.MethodGet, nil).
+		assertStatusCode(http.StatusOK).
+		toJSON(&me)
+	assert.Equal(t, u.ID, me.ID)
+}
+
+func TestUsers_Block(t *testing.T) {
+	admin, token := createAdmin(t)
+	u := createUser(t)
+	session := logIn(t, u.Email, factory.DefaultPassword)
+
+	var res userResponse
+	request(t).
+		setRoute("admin.users.block", u.ID).
+		setToken(token).
+		send(http.MethodPost, nil).
+		assertStatusCode(http.StatusOK).
+		toJSON(&res)
+	require.NotNil(t, res.BlockedAt)
+	assertAudited(t, "user.block", admin, u)
+
+	// The token issued before the block is no longer accepted
+	request(t).
+		setRoute("me.get").
+		setToken(session).
+		send(http.MethodGet, nil).
+		assertStatusCode(http.StatusForbidden)
+
+	// And the user can't log in again
+	request(t).
+		setRoute("login.post").
+		send(http.MethodPost, map[string]string{"email": u.Email, "password": factory.DefaultPassword}).
+		assertStatusCode(http.StatusForbidden)
+
+	// Unblocking restores access
+	request(t).
+		setRoute("admin.users.unblock", u.ID).
+		setToken(token).
+		send(http.MethodDelete, nil).
+		assertStatusCode(http.StatusOK)
+	assertAudited(t, "user.unblock", admin, u)
+	request(t).
+		setRoute("me.get").
+		setToken(session).
+		send(http.MethodGet, nil).
+		assertStatusCode(http.StatusOK)
+}
+
+func TestUsers_Verify(t *testing.T) {
+	admin, token := createAdmin(t)
+	u, err := factory.New(c.ORM, time.Now().UnixNano()).User().Create(context.Background())
+	require.NoError(t, err)
+
+	var res userResponse
+	request(t).
+		setRoute("admin.users.verify", u.ID).
+		setToken(token).
+		send(http.MethodPost, nil).
+		assertStatusCode(http.StatusOK).
+		toJSON(&res)
+	assert.True(t, res.Verified)
+	assertAudited(t, "user.verify", admin, u)
+}
+
+func TestUsers_PasswordReset(t *testing.T) {
+	_, token := createAdmin(t)
+	u := createUser(t)
+	session := logIn(t, u.Email, factory.DefaultPassword)
+	old, _, err := factory.New(c.ORM, time.Now().UnixNano()).PasswordToken().User(u).Create(context.Background())
+	require.NoError(t, err)
+
+	request(t).
+		setRoute("admin.users.password_reset", u.ID).
+		setToken(token).
+		send(http.MethodPost, nil).
+		assertStatusCode(http.StatusAccepted)
+
+	// Tokens issued before the reset are revoked
+	request(t).
+		setRoute("me.get").
+		setToken(session).
+		send(http.MethodGet, nil).
+		assertStatusCode(http.StatusUnauthorized)
+
+	// The previous password and reset tokens no longer work, while a new reset token was issued
+	u, err = c.ORM.User.Get(context.Background(), u.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, u.TokenVersion)
+	assert.Error(t, c.Auth.CheckPassword(factory.DefaultPassword, u.Password))
+
+	tokens, err := u.QueryOwner().All(context.Background())
+	require.NoError(t, err)
+	require.Len(t, tokens, 1)
+	assert.NotEqual(t, old.ID, tokens[0].ID)
+}
+
+func TestUsers_Delete(t *testing.T) {
+	admin, token := createAdmin(t)
+	u := createUser(t)
+	session := logIn(t, u.Email, factory.DefaultPassword)
+
+	var res userResponse
+	request(t).
+		setRoute("admin.users.delete", u.ID).
+		setToken(token).
+		send(http.MethodDelete, nil).
+		assertStatusCode(http.StatusAccepted).
+		toJSON(&res)
+	assert.NotNil(t, res.DeleteAt)
+	assert.NotNil(t, res.BlockedAt)
+	assertAudited(t, "user.delete", admin, u)
+
+	// The session is revoked and logging in can't cancel the deletion
+	request(t).
+		setRoute("me.get").
+		setToken(session).
+		send(http.MethodGet, nil).
+		assertStatusCode(http.StatusUnauthorized)
+	request(t).
+		setRoute("login.post").
+		send(http.MethodPost, map[string]string{"email": u.Email, "password": factory.DefaultPassword}).
+		assertStatusCode(http.StatusForbidden)
+
+	// Unblocking would let a login cancel the deletion
+	request(t).
+		setRoute("admin.users.unblock", u.ID).
+		setToken(token).
+		send(http.MethodDelete, nil).
+		assertStatusCode(http.StatusConflict)
+
+	u, err := c.ORM.User.Get(context.Background(), u.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, u.BlockedAt)
+	assert.NotNil(t, u.DeleteAt)
+}
@@ -0,0 +1,383 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/vovanwin/api-my-site/ent"
+	"github.com/vovanwin/api-my-site/ent/auditevent"
+	"github.com/vovanwin/api-my-site/ent/hook"
+	"github.com/vovanwin/api-my-site/ent/user"
+	"github.com/vovanwin/api-my-site/pkg/logging"
+)
+
+const (
+	// auditRedacted replaces the values of sensitive fields in audit events
+	auditRedacted = "[redacted]"
+)
+
+// auditSensitiveFields contains the fields whose values are never written to audit events
+var auditSensitiveFields = map[string]bool{
+	"password": true,
+	"hash":     true,
+}
+
+type auditActorKey struct{}
+
+// AuditActor describes who performed an audited action and where the request came from
+type AuditActor struct {
+	// UserID stores the ID of the authenticated user, if any
+	UserID *int
+
+	// ImpersonatorID stores the ID of the admin acting on behalf of the user, if any
+	ImpersonatorID *int
+
+	// IP stores the client IP address
+	IP string
+
+	// UserAgent stores the client user agent
+	UserAgent string
+
+	// RequestID stores the ID of the request
+	RequestID string
+}
+
+// WithAuditActor returns a copy of the context carrying the audit actor
+// Events recorded with the returned context, including those recorded by ORM hooks, are attributed to the actor
+func WithAuditActor(ctx context.Context, actor AuditActor) context.Context {
+	return context.WithValue(ctx, auditActorKey{}, actor)
+}
+
+// GetAuditActor returns the audit actor carried by the context
+func GetAuditActor(ctx context.Context) (AuditActor, bool) {
+	actor, ok := ctx.Value(auditActorKey{}).(AuditActor)
+	return actor, ok
+}
+
+// AuditEntry describes an audit event to be recorded
+type AuditEntry struct {
+	// Action stores the name of the action, such as auth.login or user.update
+	Action string
+
+	// ActorID overrides the user ID of the actor carried by the context
+	ActorID *int
+
+	// TargetType stores the type of the entity the action was performed on
+	TargetType string
+
+	// TargetID stores the ID of the entity the action was performed on
+	TargetID *int
+
+	// Before stores the changed values prior to the action
+	Before map[string]interface{}
+
+	// After stores the changed values following the action
+	After map[string]interface{}
+}
+
+// AuditClient records security-relevant and admin actions
+type AuditClient struct {
+	// orm stores the ORM client
+	orm *ent.Client
+}
+
+// NewAuditClient creates a new AuditClient
+func NewAuditClient(orm *ent.Client) *AuditClient {
+	return &AuditClient{
+		orm: orm,
+	}
+}
+
+// Record records an audit event, attributing it to the actor carried by the context
+// Failing to record an event never fails the audited action, so errors are only logged
+func (c *AuditClient) Record(ctx context.Context, e AuditEntry) {
+	c.record(ctx, c.orm, e)
+}
+
+// record records an audit event with the given ORM client
+func (c *AuditClient) record(ctx context.Context, orm *ent.Client, e AuditEntry) {
+	if err := c.insert(ctx, orm, e); err != nil {
+		logging.WithError(ctx, err).WithField("action", e.Action).Error("failed to record audit event")
+	}
+}
+
+// insert inserts an audit event with the given ORM client
+func (c *AuditClient) insert(ctx context.Context, orm *ent.Client, e AuditEntry) error {
+	actor, _ := GetAuditActor(ctx)
+	if e.ActorID != nil {
+		actor.UserID = e.ActorID
+	}
+
+	return orm.AuditEvent.
+		Create().
+		SetAction(e.Action).
+		SetNillableActorID(actor.UserID).
+		SetNillableImpersonatorID(actor.ImpersonatorID).
+		SetTargetType(e.TargetType).
+		SetNillableTargetID(e.TargetID).
+		SetIP(actor.IP).
+		SetUserAgent(actor.UserAgent).
+		SetRequestID(actor.RequestID).
+		SetBefore(redactAuditValues(e.Before)).
+		SetAfter(redactAuditValues(e.After)).
+		Exec(ctx)
+}
+
+// Prune deletes the audit events created before the given time and returns how many were deleted
+func (c *AuditClient) Prune(ctx context.Context, before time.Time) (int, error) {
+	return c.orm.AuditEvent.
+		Delete().
+		Where(auditevent.CreatedAtLT(before)).
+		Exec(ctx)
+}
+
+// auditMutation is implemented by the generated mutations of entities with integer IDs
+type auditMutation interface {
+	ent.Mutation
+	ID() (int, bool)
+	IDs(ctx context.Context) ([]int, error)
+}
+
+// mutationClient is implemented by the generated mutations
+type mutationClient interface {
+	Client() *ent.Client
+}
+
+// mutationTx is implemented by the generated mutations
+type mutationTx interface {
+	Tx() (*ent.Tx, error)
+}
+
+// recordMutation records an audit event with the ORM client the mutation runs with, which is bound to its
+// transaction, if any, so that the event is committed or rolled back along with the audited change
+// In a transaction the event is inserted under a savepoint, since a failed insert would otherwise abort the
+// transaction and fail the audited change with it
+func (c *AuditClient) recordMutation(ctx context.Context, m ent.Mutation, e AuditEntry) {
+	mc, ok := m.(mutationClient)
+	if !ok {
+		c.record(ctx, c.orm, e)
+		return
+	}
+
+	mt, ok := m.(mutationTx)
+	if !ok {
+		c.record(ctx, mc.Client(), e)
+		return
+	}
+	tx, err := mt.Tx()
+	if err != nil {
+		// The mutation doesn't run in a transaction
+		c.record(ctx, mc.Client(), e)
+		return
+	}
+
+	if err := c.insertSavepoint(ctx, tx, e); err != nil {
+		logging.WithError(ctx, err).WithField("action", e.Action).Error("failed to record audit event")
+	}
+}
+
+// insertSavepoint inserts an audit event in a transaction under a savepoint, which is rolled back on failure
+// so that the transaction can go on
+func (c *AuditClient) insertSavepoint(ctx context.Context, tx *ent.Tx, e AuditEntry) error {
+	if _, err := tx.ExecContext(ctx, "SAVEPOINT audit_event"); err != nil {
+		return fmt.Errorf("creating savepoint: %w", err)
+	}
+
+	if err := c.insert(ctx, tx.Client(), e); err != nil {
+		if _, rerr := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT audit_event"); rerr != nil {
+			return fmt.Errorf("%w: rolling back to savepoint: %v", err, rerr)
+		}
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT audit_event"); err != nil {
+		return fmt.Errorf("releasing savepoint: %w", err)
+	}
+	return nil
+}
+
+// Hook returns an ORM hook which records an audit event for every entity created, updated or deleted
+// by a mutation. Events are named after the entity type and the operation, such as user.update, and
+// hold the values of the changed fields before and after an update. Events are written in the transaction
+// of the mutation, if any, and failing to write them never fails the mutation
+func (c *AuditClient) Hook() ent.Hook {
+	return func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			am, ok := m.(auditMutation)
+			if !ok {
+				return next.Mutate(ctx, m)
+			}
+
+			fields := auditChangedFields(m)
+			var ids []int
+			var before map[string]interface{}
+
+			// Capture the state which is gone once the mutation is applied
+			switch {
+			case m.Op().Is(ent.OpUpdateOne):
+				before = make(map[string]interface{}, len(fields))
+				for _, name := range fields {
+					if v, err := m.OldField(ctx, name); err == nil {
+						before[name] = v
+					}
+				}
+			case m.Op().Is(ent.OpUpdate), m.Op().Is(ent.OpDelete), m.Op().Is(ent.OpDeleteOne):
+				var err error
+				if ids, err = am.IDs(ctx); err != nil {
+					return nil, err
+				}
+			}
+
+			v, err := next.Mutate(ctx, m)
+			if err != nil {
+				return v, err
+			}
+
+			if id, ok := am.ID(); ok && !m.Op().Is(ent.OpUpdate) {
+				ids = []int{id}
+			}
+
+			var after map[string]interface{}
+			if !m.Op().Is(ent.OpDelete) && !m.Op().Is(ent.OpDeleteOne) {
+				if len(fields) == 0 {
+					return v, nil
+				}
+				after = auditAfterValues(m, v, fields)
+			}
+
+			targetType := auditTargetType(m.Type())
+			action := targetType + "." + auditOperation(m.Op())
+			for i := range ids {
+				c.recordMutation(ctx, m, AuditEntry{
+					Action:     action,
+					TargetType: targetType,
+					TargetID:   &ids[i],
+					Before:     before,
+					After:      after,
+				})
+			}
+
+			return v, nil
+		})
+	}
+}
+
+// RoleChangeHook returns an ORM hook which records a user.role_change event whenever the role of a user is changed
+func (c *AuditClient) RoleChangeHook() ent.Hook {
+	return hook.If(func(next ent.Mutator) ent.Mutator {
+		return hook.UserFunc(func(ctx context.Context, m *ent.UserMutation) (ent.Value, error) {
+			old, err := m.OldRole(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			v, err := next.Mutate(ctx, m)
+			if err != nil {
+				return v, err
+			}
+
+			if role, _ := m.Role(); role != old {
+				id, _ := m.ID()
+				c.recordMutation(ctx, m, AuditEntry{
+					Action:     "user.role_change",
+					TargetType: "user",
+					TargetID:   &id,
+					Before:     map[string]interface{}{"role": old},
+					After:      map[string]interface{}{"role": role},
+				})
+			}
+
+			return v, nil
+		})
+	}, hook.And(hook.HasOp(ent.OpUpdateOne), hook.HasFields(user.FieldRole)))
+}
+
+// auditChangedFields returns the sorted names of the fields changed by a mutation
+func auditChangedFields(m ent.Mutation) []string {
+	seen := make(map[string]bool)
+	for _, list := range [][]string{m.Fields(), m.AddedFields(), m.ClearedFields()} {
+		for _, name := range list {
+			seen[name] = true
+		}
+	}
+
+	fields := make([]string, 0, len(seen))
+	for name := range seen {
+		fields = append(fields, name)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// auditAfterValues returns the values of the changed fields following a mutation
+// Values are taken from the saved entity where possible, so that fields changed by adding to them hold
+// their resulting value; otherwise from the mutation itself
+func auditAfterValues(m ent.Mutation, v ent.Value, fields []string) map[string]interface{} {
+	var saved map[string]interface{}
+	if b, err := json.Marshal(v); err == nil {
+		_ = json.Unmarshal(b, &saved)
+	}
+
+	after := make(map[string]interface{}, len(fields))
+	for _, name := range fields {
+		if value, ok := saved[name]; ok {
+			after[name] = value
+		} else if value, ok := m.Field(name); ok {
+			after[name] = value
+		} else if value, ok := m.AddedField(name); ok {
+			after[name] = map[string]interface{}{"add": value}
+		} else {
+			after[name] = nil
+		}
+	}
+	return after
+}
+
+// redactAuditValues replaces the values of sensitive fields
+func redactAuditValues(values map[string]interface{}) map[string]interface{} {
+	if values == nil {
+		return nil
+	}
+
+	redacted := make(map[string]interface{}, len(values))
+	for name, value := range values {
+		if auditSensitiveFields[name] {
+			value = auditRedacted
+		}
+		redacted[name] = value
+	}
+	return redacted
+}
+
+// auditTargetType converts an entity type name, such as PasswordToken, into an audit target type,
+// such as password_token
+func auditTargetType(typ string) string {
+	var b strings.Builder
+	for i, r := range typ {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// auditOperation returns the name of the operation of a mutation
+func auditOperation(op ent.Op) string {
+	switch {
+	case op.Is(ent.OpCreate):
+		return "create"
+	case op.Is(ent.OpDelete), op.Is(ent.OpDeleteOne):
+		return "delete"
+	default:
+		return "update"
+	}
+}
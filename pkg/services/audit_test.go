@@ -0,0 +1,200 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vovanwin/api-my-site/ent"
+	"github.com/vovanwin/api-my-site/ent/auditevent"
+	"github.com/vovanwin/api-my-site/ent/user"
+	"github.com/vovanwin/api-my-site/pkg/factory"
+	"github.com/vovanwin/api-my-site/pkg/tests"
+)
+
+func TestAuditTargetType(t *testing.T) {
+	assert.Equal(t, "user", auditTargetType("User"))
+	assert.Equal(t, "password_token", auditTargetType("PasswordToken"))
+}
+
+func TestRedactAuditValues(t *testing.T) {
+	assert.Nil(t, redactAuditValues(nil))
+	assert.Equal(t,
+		map[string]interface{}{"name": "a", "password": auditRedacted, "hash": auditRedacted},
+		redactAuditValues(map[string]interface{}{"name": "a", "password": "secret", "hash": "secret"}),
+	)
+}
+
+func TestAuditClient_Hook(t *testing.T) {
+	u, err := tests.CreateUser(c.ORM)
+	require.NoError(t, err)
+
+	actorID := usr.ID
+	reqCtx := WithAuditActor(context.Background(), AuditActor{
+		UserID:    &actorID,
+		IP:        "127.0.0.1",
+		UserAgent: "test",
+		RequestID: "abc",
+	})
+
+	events := func(action string) []*ent.AuditEvent {
+		list, err := c.ORM.AuditEvent.
+			Query().
+			Where(
+				auditevent.Action(action),
+				auditevent.TargetType("user"),
+				auditevent.TargetID(u.ID),
+			).
+			All(context.Background())
+		require.NoError(t, err)
+		return list
+	}
+
+	require.Len(t, events("user.create"), 1)
+
+	_, err = u.Update().
+		SetName("Renamed").
+		SetPassword("new").
+		AddTokenVersion(1).
+		SetRole(user.RoleAdmin).
+		Save(reqCtx)
+	require.NoError(t, err)
+
+	updates := events("user.update")
+	require.Len(t, updates, 1)
+	e := updates[0]
+	assert.Equal(t, &actorID, e.ActorID)
+	assert.Equal(t, "127.0.0.1", e.IP)
+	assert.Equal(t, "test", e.UserAgent)
+	assert.Equal(t, "abc", e.RequestID)
+	assert.Equal(t, u.Name, e.Before["name"])
+	assert.Equal(t, "Renamed", e.After["name"])
+	assert.Equal(t, auditRedacted, e.Before["password"])
+	assert.Equal(t, auditRedacted, e.After["password"])
+	assert.EqualValues(t, 0, e.Before["token_version"])
+	assert.EqualValues(t, 1, e.After["token_version"])
+
+	roles := events("user.role_change")
+	require.Len(t, roles, 1)
+	assert.Equal(t, "user", roles[0].Before["role"])
+	assert.Equal(t, "admin", roles[0].After["role"])
+
+	// Updates which change nothing aren't recorded
+	require.NoError(t, c.ORM.User.UpdateOneID(u.ID).Exec(reqCtx))
+	assert.Len(t, events("user.update"), 1)
+
+	require.NoError(t, c.ORM.User.DeleteOneID(u.ID).Exec(reqCtx))
+	assert.Len(t, events("user.delete"), 1)
+}
+
+func TestAuditClient_HookAdministered(t *testing.T) {
+	ctx := context.Background()
+	f := factory.New(c.ORM, time.Now().UnixNano())
+
+	exists := func(targetType string, id int) bool {
+		ok, err := c.ORM.AuditEvent.
+			Query().
+			Where(
+				auditevent.Action(targetType+".create"),
+				auditevent.TargetType(targetType),
+				auditevent.TargetID(id),
+			).
+			Exist(ctx)
+		require.NoError(t, err)
+		return ok
+	}
+
+	flag, err := f.FeatureFlag().Create(ctx)
+	require.NoError(t, err)
+	assert.True(t, exists("feature_flag", flag.ID))
+
+	page, err := f.Page().Create(ctx)
+	require.NoError(t, err)
+	assert.True(t, exists("page", page.ID))
+
+	project, err := f.Project().Create(ctx)
+	require.NoError(t, err)
+	assert.True(t, exists("project", project.ID))
+
+	media, err := f.Media().Create(ctx)
+	require.NoError(t, err)
+	assert.True(t, exists("media", media.ID))
+}
+
+func TestAuditClient_Prune(t *testing.T) {
+	c.Audit.Record(context.Background(), AuditEntry{Action: "test.prune"})
+
+	count, err := c.Audit.Prune(context.Background(), time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	count, err = c.Audit.Prune(context.Background(), time.Now().Add(time.Second))
+	require.NoError(t, err)
+	assert.Positive(t, count)
+
+	exists, err := c.ORM.AuditEvent.Query().Where(auditevent.Action("test.prune")).Exist(context.Background())
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestAuditClient_HookTransaction(t *testing.T) {
+	bg := context.Background()
+	count := func(email string) int {
+		events, err := c.ORM.AuditEvent.
+			Query().
+			Where(auditevent.Action("user.create")).
+			All(bg)
+		require.NoError(t, err)
+		matched := 0
+		for _, e := range events {
+			if e.After["email"] == email {
+				matched++
+			}
+		}
+		return matched
+	}
+
+	// Events of rolled back changes are rolled back as well
+	tx, err := c.ORM.Tx(bg)
+	require.NoError(t, err)
+	_, err = tx.User.Create().SetName("Rolled back").SetEmail("rolledback@localhost").SetPassword("x").Save(bg)
+	require.NoError(t, err)
+	require.NoError(t, tx.Rollback())
+	assert.Equal(t, 0, count("rolledback@localhost"))
+
+	tx, err = c.ORM.Tx(bg)
+	require.NoError(t, err)
+	_, err = tx.User.Create().SetName("Committed").SetEmail("committed@localhost").SetPassword("x").Save(bg)
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit())
+	assert.Equal(t, 1, count("committed@localhost"))
+}
+
+func TestAuditClient_HookTransactionFailure(t *testing.T) {
+	// Postgres rejects NUL bytes in text, so the audit event can't be inserted
+	ctx := WithAuditActor(context.Background(), AuditActor{UserAgent: "broken\x00agent"})
+
+	tx, err := c.ORM.Tx(ctx)
+	require.NoError(t, err)
+	u, err := tx.User.Create().SetName("Unaudited").SetEmail("unaudited@localhost").SetPassword("x").Save(ctx)
+	require.NoError(t, err)
+
+	// The transaction goes on and the change is committed without the event
+	_, err = tx.User.UpdateOne(u).SetName("Still unaudited").Save(ctx)
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit())
+
+	u, err = c.ORM.User.Get(context.Background(), u.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Still unaudited", u.Name)
+
+	exists, err := c.ORM.AuditEvent.
+		Query().
+		Where(auditevent.TargetType("user"), auditevent.TargetID(u.ID)).
+		Exist(context.Background())
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
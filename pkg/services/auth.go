@@ -59,10 +59,11 @@ func NewAuthClient(cfg *config.Config, orm *ent.Client) *AuthClient {
 	}
 }
 
-// Login logs in a user of a given ID
-func (c *AuthClient) Login(ctx echo.Context, userID int) (string, error) {
+// Login logs in a given user, issuing a token bound to the current token version of the user
+func (c *AuthClient) Login(ctx echo.Context, u *ent.User) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"user_id": userID,
+		"user_id": u.ID,
+		"ver":     u.TokenVersion,
 		"exp":     time.Now().Add(c.config.App.EmailVerificationTokenExpiration).Unix(),
 	})
 
@@ -75,31 +76,86 @@ func (c *AuthClient) Logout(ctx echo.Context) error {
 	return nil
 }
 
+// RevokeTokens отзывает все выданные пользователю токены, увеличивая версию его токенов
+func (c *AuthClient) RevokeTokens(ctx echo.Context, userID int) error {
+	return c.orm.User.
+		UpdateOneID(userID).
+		AddTokenVersion(1).
+		Exec(ctx.Request().Context())
+}
+
+// Impersonate выдает администратору токен, позволяющий действовать от имени пользователя.
+// Токен действует недолго, отзывается вместе с токенами пользователя и содержит идентификатор администратора
+func (c *AuthClient) Impersonate(ctx echo.Context, admin, u *ent.User) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id":         u.ID,
+		"ver":             u.TokenVersion,
+		"impersonator_id": admin.ID,
+		"exp":             time.Now().Add(c.config.App.ImpersonationTokenExpiration).Unix(),
+	})
+
+	return token.SignedString([]byte(c.config.App.EncryptionKey))
+}
+
 type JwtCustomClaims struct {
-	UserId int `json:"user_id"`
+	UserId       int `json:"user_id"`
+	TokenVersion int `json:"ver"`
+	// ImpersonatorID содержит идентификатор администратора, действующего от имени пользователя
+	ImpersonatorID int `json:"impersonator_id,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// claims возвращает утверждения токена запроса, если он есть
+func (c *AuthClient) claims(ctx echo.Context) (*JwtCustomClaims, error) {
+	userToken, ok := ctx.Get("user").(*jwt.Token)
+	if !ok {
+		return nil, NotAuthenticatedError{}
+	}
+	claims, ok := userToken.Claims.(*JwtCustomClaims)
+	if !ok || claims.UserId == 0 {
+		return nil, NotAuthenticatedError{}
+	}
+	return claims, nil
+}
+
 // GetAuthenticatedUserID возвращает идентификатор аутентифицированного пользователя, если пользователь вошел в систему
 func (c *AuthClient) GetAuthenticatedUserID(ctx echo.Context) (int, error) {
-	if ctx.Get("user") == nil {
-		return 0, NotAuthenticatedError{}
+	claims, err := c.claims(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return claims.UserId, nil
+}
+
+// GetImpersonatorID возвращает идентификатор администратора, действующего от имени аутентифицированного
+// пользователя, если запрос выполнен с токеном, выданным Impersonate
+func (c *AuthClient) GetImpersonatorID(ctx echo.Context) (int, bool) {
+	claims, err := c.claims(ctx)
+	if err != nil || claims.ImpersonatorID == 0 {
+		return 0, false
 	}
-	userToken := ctx.Get("user").(*jwt.Token)
-	claims := userToken.Claims.(*JwtCustomClaims)
-	userId := claims.UserId
-	return userId, nil
+	return claims.ImpersonatorID, true
 }
 
-// GetAuthenticatedUser возвращает аутентифицированного пользователя, если пользователь вошел в систему
+// GetAuthenticatedUser возвращает аутентифицированного пользователя, если пользователь вошел в систему.
+// Токены, выданные до отзыва токенов пользователя, не аутентифицируют его
 func (c *AuthClient) GetAuthenticatedUser(ctx echo.Context) (*ent.User, error) {
-	if userID, err := c.GetAuthenticatedUserID(ctx); err == nil {
-		return c.orm.User.Query().
-			Where(user.ID(userID)).
-			Only(ctx.Request().Context())
+	claims, err := c.claims(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil, NotAuthenticatedError{}
+	u, err := c.orm.User.Query().
+		Where(user.ID(claims.UserId)).
+		Only(ctx.Request().Context())
+	if err != nil {
+		return nil, err
+	}
+
+	if u.TokenVersion != claims.TokenVersion {
+		return nil, NotAuthenticatedError{}
+	}
+	return u, nil
 }
 
 // HashPassword возвращает хэш заданного пароля
@@ -228,3 +284,66 @@ func (c *AuthClient) ValidateEmailVerificationToken(token string) (string, error
 
 	return "", errors.New("недействительный или просроченный токен")
 }
+
+// EmailChange содержит запрошенную смену адреса электронной почты пользователя
+type EmailChange struct {
+	UserID       int
+	From         string
+	Email        string
+	TokenVersion int
+}
+
+// GenerateEmailChangeToken генерирует токен подтверждения смены адреса электронной почты пользователя на новый.
+// Токен подписывается отдельным ключом, чтобы его нельзя было использовать для входа в систему, и
+// перестает действовать после отзыва токенов пользователя. Токен содержит текущий адрес, чтобы после смены
+// адреса его нельзя было использовать повторно
+func (c *AuthClient) GenerateEmailChangeToken(u *ent.User, email string) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id": u.ID,
+		"from":    u.Email,
+		"email":   email,
+		"ver":     u.TokenVersion,
+		"exp":     time.Now().Add(c.config.App.EmailVerificationTokenExpiration).Unix(),
+	})
+
+	return token.SignedString(c.emailChangeKey())
+}
+
+// ValidateEmailChangeToken проверяет токен подтверждения смены адреса электронной почты и возвращает
+// запрошенную смену, если токен действителен и срок его действия не истек
+func (c *AuthClient) ValidateEmailChangeToken(token string) (*EmailChange, error) {
+	var claims struct {
+		UserID       int    `json:"user_id"`
+		From         string `json:"from"`
+		Email        string `json:"email"`
+		TokenVersion int    `json:"ver"`
+		jwt.RegisteredClaims
+	}
+
+	t, err := jwt.ParseWithClaims(token, &claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("неожиданный способ подписи: %v", t.Header["alg"])
+		}
+
+		return c.emailChangeKey(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !t.Valid || claims.UserID == 0 || claims.From == "" || claims.Email == "" {
+		return nil, errors.New("недействительный или просроченный токен")
+	}
+
+	return &EmailChange{
+		UserID:       claims.UserID,
+		From:         claims.From,
+		Email:        claims.Email,
+		TokenVersion: claims.TokenVersion,
+	}, nil
+}
+
+// emailChangeKey возвращает ключ подписи токенов смены адреса электронной почты
+func (c *AuthClient) emailChangeKey() []byte {
+	return []byte(c.config.App.EncryptionKey + ":email-change")
+}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vovanwin/api-my-site/config"
+	"github.com/vovanwin/api-my-site/ent"
+	"github.com/vovanwin/api-my-site/pkg/factory"
+	"github.com/vovanwin/api-my-site/pkg/tests"
+)
+
+func TestAuthClient_EmailChangeToken(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.App.EncryptionKey = "key"
+	cfg.App.EmailVerificationTokenExpiration = time.Hour
+	client := NewAuthClient(cfg, nil)
+
+	u := &ent.User{ID: 1, Email: "old@localhost.localhost", TokenVersion: 2}
+	token, err := client.GenerateEmailChangeToken(u, "new@localhost.localhost")
+	require.NoError(t, err)
+
+	change, err := client.ValidateEmailChangeToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, EmailChange{
+		UserID:       1,
+		From:         "old@localhost.localhost",
+		Email:        "new@localhost.localhost",
+		TokenVersion: 2,
+	}, *change)
+
+	// Email change tokens can't be used to log in
+	_, err = jwt.Parse(token, func(*jwt.Token) (interface{}, error) {
+		return []byte(cfg.App.EncryptionKey), nil
+	})
+	assert.Error(t, err)
+
+	login, err := client.Login(ctx, u)
+	require.NoError(t, err)
+	_, err = client.ValidateEmailChangeToken(login)
+	assert.Error(t, err)
+}
+
+func TestAuthClient_RevokeTokens(t *testing.T) {
+	u, err := tests.CreateUser(c.ORM)
+	require.NoError(t, err)
+
+	authenticate := func(token string) (*ent.User, error) {
+		claims := new(JwtCustomClaims)
+		parsed, err := jwt.ParseWithClaims(token, claims, func(*jwt.Token) (interface{}, error) {
+			return []byte(c.Config.App.EncryptionKey), nil
+		})
+		require.NoError(t, err)
+		ctx.Set("user", parsed)
+		defer ctx.Set("user", nil)
+		return c.Auth.GetAuthenticatedUser(ctx)
+	}
+
+	token, err := c.Auth.Login(ctx, u)
+	require.NoError(t, err)
+	got, err := authenticate(token)
+	require.NoError(t, err)
+	assert.Equal(t, u.ID, got.ID)
+
+	require.NoError(t, c.Auth.RevokeTokens(ctx, u.ID))
+	_, err = authenticate(token)
+	assert.IsType(t, NotAuthenticatedError{}, err)
+
+	u, err = c.ORM.User.Get(ctx.Request().Context(), u.ID)
+	require.NoError(t, err)
+	token, err = c.Auth.Login(ctx, u)
+	require.NoError(t, err)
+	_, err = authenticate(token)
+	assert.NoError(t, err)
+}
+
+func TestAuthClient_CheckPassword(t *testing.T) {
+	// Test users are created with a hashed password
+	assert.NoError(t, c.Auth.CheckPassword(factory.DefaultPassword, usr.Password))
+	assert.Error(t, c.Auth.CheckPassword("wrong", usr.Password))
+}
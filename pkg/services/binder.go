@@ -0,0 +1,175 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/vovanwin/api-my-site/pkg/i18n"
+	"github.com/vovanwin/api-my-site/pkg/problem"
+)
+
+// Binder binds request bodies to forms, accepting JSON, URL encoded and multipart bodies alike
+//
+// Unlike the default binder of echo, it is strict: unknown fields, bodies exceeding the size limit and
+// unsupported content types are rejected with a problem error. Fields tagged with `json:"-"` or
+// `form:"-"` are never bound, which keeps non-input fields such as the form submission out of reach.
+//
+// After binding, string fields are trimmed unless tagged with `trim:"-"` and the normalizers listed in
+// the normalize tag are applied, see Normalize.
+type Binder struct {
+	// limit stores the maximum size of a request body in bytes
+	limit int64
+
+	// params binds the path and query parameters
+	params echo.DefaultBinder
+}
+
+// NewBinder creates a new Binder
+func NewBinder(limit int64) *Binder {
+	return &Binder{
+		limit: limit,
+	}
+}
+
+// Bind binds the path parameters, the query parameters of requests without a body and the request body
+func (b *Binder) Bind(i interface{}, ctx echo.Context) error {
+	if err := b.params.BindPathParams(ctx, i); err != nil {
+		return err
+	}
+
+	switch ctx.Request().Method {
+	case http.MethodGet, http.MethodDelete, http.MethodHead:
+		if err := b.params.BindQueryParams(ctx, i); err != nil {
+			return err
+		}
+	}
+
+	if err := b.bindBody(ctx, i); err != nil {
+		return err
+	}
+
+	Normalize(i)
+	return nil
+}
+
+// bindBody binds the request body according to its content type
+func (b *Binder) bindBody(ctx echo.Context, i interface{}) error {
+	req := ctx.Request()
+	if req.ContentLength == 0 {
+		return nil
+	}
+	if req.ContentLength > b.limit {
+		return problem.New(http.StatusRequestEntityTooLarge, problem.CodePayloadTooLarge, "")
+	}
+	req.Body = http.MaxBytesReader(ctx.Response(), req.Body, b.limit)
+
+	ctype, _, _ := mime.ParseMediaType(req.Header.Get(echo.HeaderContentType))
+	switch ctype {
+	case echo.MIMEApplicationJSON:
+		return b.bindJSON(ctx, i)
+	case echo.MIMEApplicationForm, echo.MIMEMultipartForm:
+		return b.bindForm(ctx, i)
+	default:
+		return problem.New(http.StatusUnsupportedMediaType, problem.CodeUnsupportedMediaType, "")
+	}
+}
+
+// bindJSON decodes a JSON body, rejecting unknown fields and trailing data
+func (b *Binder) bindJSON(ctx echo.Context, i interface{}) error {
+	dec := json.NewDecoder(ctx.Request().Body)
+	dec.DisallowUnknownFields()
+
+	err := dec.Decode(i)
+	if err == nil && dec.More() {
+		err = errors.New("unexpected data after the JSON value")
+	}
+	if err == nil {
+		return nil
+	}
+
+	var (
+		tooLarge  *http.MaxBytesError
+		typeError *json.UnmarshalTypeError
+	)
+	switch {
+	case errors.As(err, &tooLarge):
+		return problem.New(http.StatusRequestEntityTooLarge, problem.CodePayloadTooLarge, "").
+			WithInternal(err)
+	case errors.As(err, &typeError):
+		return malformed(i18n.Ctx(ctx, "request.invalid_field", i18n.Params{"field": typeError.Field}), err)
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		field, _ := strconv.Unquote(strings.TrimPrefix(err.Error(), "json: unknown field "))
+		return malformed(i18n.Ctx(ctx, "request.unknown_field", i18n.Params{"field": field}), err)
+	default:
+		return malformed(i18n.Ctx(ctx, "request.malformed"), err)
+	}
+}
+
+// bindForm binds a URL encoded or multipart body, rejecting unknown fields
+func (b *Binder) bindForm(ctx echo.Context, i interface{}) error {
+	params, err := ctx.FormParams()
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return problem.New(http.StatusRequestEntityTooLarge, problem.CodePayloadTooLarge, "").
+				WithInternal(err)
+		}
+		return malformed(i18n.Ctx(ctx, "request.malformed"), err)
+	}
+
+	known := formFields(reflect.TypeOf(i))
+	for name := range params {
+		if _, ok := known[strings.ToLower(name)]; !ok {
+			return malformed(
+				i18n.Ctx(ctx, "request.unknown_field", i18n.Params{"field": name}),
+				fmt.Errorf("unknown form field %q", name),
+			)
+		}
+	}
+
+	if err := b.params.BindBody(ctx, i); err != nil {
+		return malformed(i18n.Ctx(ctx, "request.malformed"), err)
+	}
+	return nil
+}
+
+// malformed creates an error for a request body which can't be bound
+func malformed(detail string, err error) error {
+	return problem.New(http.StatusBadRequest, problem.CodeBadRequest, detail).WithInternal(err)
+}
+
+// formFields returns the lowercased names of the form fields of a struct, including the fields of
+// untagged nested structs, the same way echo binds them
+func formFields(t reflect.Type) map[string]struct{} {
+	fields := make(map[string]struct{})
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return fields
+	}
+
+	for n := 0; n < t.NumField(); n++ {
+		f := t.Field(n)
+		switch name := f.Tag.Get("form"); name {
+		case "-":
+		case "":
+			if f.Type.Kind() == reflect.Struct {
+				for k := range formFields(f.Type) {
+					fields[k] = struct{}{}
+				}
+			}
+		default:
+			fields[strings.ToLower(name)] = struct{}{}
+		}
+	}
+	return fields
+}
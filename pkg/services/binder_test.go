@@ -0,0 +1,129 @@
+package services
+
+import (
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vovanwin/api-my-site/pkg/problem"
+)
+
+type binderForm struct {
+	Email    string   `form:"email" json:"email" normalize:"lower"`
+	Name     string   `form:"name" json:"name"`
+	Password string   `form:"password" json:"password" trim:"-"`
+	Age      int      `form:"age" json:"age"`
+	Tags     []string `form:"tags" json:"tags"`
+	Internal struct {
+		Set bool
+	} `form:"-" json:"-"`
+}
+
+func bindRequest(t *testing.T, req *http.Request, limit int64) (binderForm, error) {
+	t.Helper()
+	ctx := echo.New().NewContext(req, httptest.NewRecorder())
+	var form binderForm
+	err := NewBinder(limit).Bind(&form, ctx)
+	return form, err
+}
+
+func assertProblem(t *testing.T, err error, status int) {
+	t.Helper()
+	require.Error(t, err)
+	pe, ok := err.(*problem.Error)
+	require.True(t, ok, "%T is not a problem", err)
+	assert.Equal(t, status, pe.Status)
+}
+
+func TestBinder_JSON(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(
+		`{"email":" John@Example.com ","name":" John ","password":" secret ","age":30,"tags":["a"]}`,
+	))
+	req.Header.Set(echo.HeaderContentType, "application/json; charset=utf-8")
+
+	form, err := bindRequest(t, req, 1024)
+	require.NoError(t, err)
+	assert.Equal(t, "john@example.com", form.Email)
+	assert.Equal(t, "John", form.Name)
+	assert.Equal(t, " secret ", form.Password)
+	assert.Equal(t, 30, form.Age)
+	assert.Equal(t, []string{"a"}, form.Tags)
+}
+
+func TestBinder_Form(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("email=A@B.C&age=5&tags=a&tags=b"))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+
+	form, err := bindRequest(t, req, 1024)
+	require.NoError(t, err)
+	assert.Equal(t, "a@b.c", form.Email)
+	assert.Equal(t, 5, form.Age)
+	assert.Equal(t, []string{"a", "b"}, form.Tags)
+}
+
+func TestBinder_Multipart(t *testing.T) {
+	body := new(strings.Builder)
+	w := multipart.NewWriter(body)
+	require.NoError(t, w.WriteField("name", " Multi "))
+	require.NoError(t, w.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body.String()))
+	req.Header.Set(echo.HeaderContentType, w.FormDataContentType())
+
+	form, err := bindRequest(t, req, 1024)
+	require.NoError(t, err)
+	assert.Equal(t, "Multi", form.Name)
+}
+
+func TestBinder_Rejects(t *testing.T) {
+	cases := []struct {
+		name   string
+		ctype  string
+		body   string
+		limit  int64
+		status int
+	}{
+		{"unknown json field", echo.MIMEApplicationJSON, `{"email":"a@b.c","role":"admin"}`, 1024, http.StatusBadRequest},
+		{"excluded json field", echo.MIMEApplicationJSON, `{"Internal":{"Set":true}}`, 1024, http.StatusBadRequest},
+		{"invalid json type", echo.MIMEApplicationJSON, `{"age":"old"}`, 1024, http.StatusBadRequest},
+		{"malformed json", echo.MIMEApplicationJSON, `{"email":`, 1024, http.StatusBadRequest},
+		{"trailing json", echo.MIMEApplicationJSON, `{"email":"a@b.c"}{}`, 1024, http.StatusBadRequest},
+		{"unknown form field", echo.MIMEApplicationForm, "email=a@b.c&role=admin", 1024, http.StatusBadRequest},
+		{"excluded form field", echo.MIMEApplicationForm, "-=1", 1024, http.StatusBadRequest},
+		{"too large", echo.MIMEApplicationJSON, `{"name":"` + strings.Repeat("a", 100) + `"}`, 32, http.StatusRequestEntityTooLarge},
+		{"unsupported type", echo.MIMETextPlain, "email=a@b.c", 1024, http.StatusUnsupportedMediaType},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, tc.ctype)
+			_, err := bindRequest(t, req, tc.limit)
+			assertProblem(t, err, tc.status)
+		})
+	}
+}
+
+func TestBinder_TooLargeChunked(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name":"`+strings.Repeat("a", 100)+`"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.ContentLength = -1
+
+	_, err := bindRequest(t, req, 32)
+	assertProblem(t, err, http.StatusRequestEntityTooLarge)
+}
+
+func TestBinder_Empty(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.Header.Set(echo.HeaderContentType, echo.MIMETextPlain)
+
+	form, err := bindRequest(t, req, 1024)
+	require.NoError(t, err)
+	assert.Equal(t, binderForm{}, form)
+}
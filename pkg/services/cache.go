@@ -13,6 +13,10 @@ import (
 	"github.com/vovanwin/api-my-site/config"
 )
 
+// CacheTagContent stores the cache tag of data built from the site content, such as feeds and sitemaps,
+// which is flushed whenever the content changes
+const CacheTagContent = "content"
+
 type (
 	// CacheClient is the client that allows you to interact with the cache
 	CacheClient struct {
@@ -211,6 +215,7 @@ func (c *cacheFlush) Tags(tags ...string) *cacheFlush {
 }
 
 // Execute flushes the data from the cache
+// If a group is set without a key, all the data in the group is flushed
 func (c *cacheFlush) Execute(ctx context.Context) error {
 	if len(c.tags) > 0 {
 		if err := c.client.cache.Invalidate(ctx, store.InvalidateOptions{
@@ -220,9 +225,23 @@ func (c *cacheFlush) Execute(ctx context.Context) error {
 		}
 	}
 
-	if c.key != "" {
+	switch {
+	case c.key != "":
 		return c.client.cache.Delete(ctx, c.client.cacheKey(c.group, c.key))
+	case c.group != "":
+		return c.flushGroup(ctx)
 	}
 
 	return nil
 }
+
+// flushGroup deletes all the keys of the group
+func (c *cacheFlush) flushGroup(ctx context.Context) error {
+	iter := c.client.Client.Scan(ctx, 0, c.client.cacheKey(c.group, "*"), 100).Iterator()
+	for iter.Next(ctx) {
+		if err := c.client.Client.Del(ctx, iter.Val()).Err(); err != nil {
+			return err
+		}
+	}
+	return iter.Err()
+}
@@ -103,3 +103,21 @@ func TestCacheClient(t *testing.T) {
 	// The data should be gone
 	assertFlushed()
 }
+
+func TestCacheClient_FlushGroup(t *testing.T) {
+	ctx := context.Background()
+	for _, key := range []string{"a", "b"} {
+		err := c.Cache.Set().Group("flushgroup").Key(key).Data(key).Save(ctx)
+		require.NoError(t, err)
+	}
+	require.NoError(t, c.Cache.Set().Group("othergroup").Key("a").Data("a").Save(ctx))
+
+	require.NoError(t, c.Cache.Flush().Group("flushgroup").Execute(ctx))
+
+	for _, key := range []string{"a", "b"} {
+		_, err := c.Cache.Get().Group("flushgroup").Key(key).Type(new(string)).Fetch(ctx)
+		assert.Equal(t, redis.Nil, err)
+	}
+	_, err := c.Cache.Get().Group("othergroup").Key("a").Type(new(string)).Fetch(ctx)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,172 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vovanwin/api-my-site/config"
+	"github.com/vovanwin/api-my-site/ent"
+)
+
+// contactTokenCacheGroup is the cache group the nonces of the used contact form tokens are stored in
+const contactTokenCacheGroup = "contact_token"
+
+// InvalidContactTokenError is an error returned when a contact form token is malformed, forged, expired or
+// used already
+type InvalidContactTokenError struct{}
+
+// Error implements the error interface.
+func (e InvalidContactTokenError) Error() string {
+	return "invalid or expired contact form token"
+}
+
+// ContactSubmittedTooFastError is an error returned when a contact form is submitted faster than a human could
+// fill it in, which indicates a bot
+type ContactSubmittedTooFastError struct{}
+
+// Error implements the error interface.
+func (e ContactSubmittedTooFastError) Error() string {
+	return "contact form submitted too fast"
+}
+
+// ContactClient handles messages sent through the contact form
+type ContactClient struct {
+	// config stores application configuration
+	config *config.Config
+
+	// orm stores the ORM client
+	orm *ent.Client
+
+	// cache stores the cache client used to record the tokens which were used
+	cache *CacheClient
+
+	// mail stores the mail client used to forward messages
+	mail *MailClient
+}
+
+// NewContactClient creates a new ContactClient
+func NewContactClient(cfg *config.Config, orm *ent.Client, cache *CacheClient, mail *MailClient) *ContactClient {
+	return &ContactClient{
+		config: cfg,
+		orm:    orm,
+		cache:  cache,
+		mail:   mail,
+	}
+}
+
+// GenerateToken generates a single-use token recording when the contact form was rendered
+func (c *ContactClient) GenerateToken() (string, error) {
+	nonce, err := randomHex(16)
+	if err != nil {
+		return "", err
+	}
+
+	renderedAt := time.Now().UnixMilli()
+	return fmt.Sprintf("%d.%s.%s", renderedAt, nonce, c.signature(renderedAt, nonce)), nil
+}
+
+// ValidateToken validates a token generated by GenerateToken
+// The form must be submitted no sooner than the minimum submit time and before the token expires.
+// Whether the token was used already is checked by UseToken
+func (c *ContactClient) ValidateToken(token string) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[1] == "" {
+		return InvalidContactTokenError{}
+	}
+
+	renderedAt, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return InvalidContactTokenError{}
+	}
+
+	if !hmac.Equal([]byte(c.signature(renderedAt, parts[1])), []byte(parts[2])) {
+		return InvalidContactTokenError{}
+	}
+
+	elapsed := time.Since(time.UnixMilli(renderedAt))
+	switch {
+	case elapsed > c.config.Contact.TokenExpiration:
+		return InvalidContactTokenError{}
+	case elapsed < c.config.Contact.MinSubmitTime:
+		return ContactSubmittedTooFastError{}
+	}
+
+	return nil
+}
+
+// UseToken marks a valid token as used, failing if it was used already, so a token can't be replayed
+// The token is remembered for as long as it's valid
+func (c *ContactClient) UseToken(ctx context.Context, token string) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return InvalidContactTokenError{}
+	}
+
+	fresh, err := c.cache.Client.
+		SetNX(ctx, c.cache.cacheKey(contactTokenCacheGroup, parts[1]), 1, c.config.Contact.TokenExpiration).
+		Result()
+	if err != nil {
+		return err
+	}
+	if !fresh {
+		return InvalidContactTokenError{}
+	}
+
+	return nil
+}
+
+// Forward emails a contact message to the configured recipient and marks it as forwarded
+// Messages that were already forwarded are skipped, so retried tasks don't send duplicates
+func (c *ContactClient) Forward(ctx context.Context, messageID int) error {
+	msg, err := c.orm.ContactMessage.Get(ctx, messageID)
+	if err != nil {
+		return err
+	}
+
+	if msg.ForwardedAt != nil {
+		return nil
+	}
+
+	subject := fmt.Sprintf("[%s] Сообщение от %s", c.config.App.Name, msg.Name)
+	if msg.Subject != "" {
+		subject = fmt.Sprintf("[%s] %s", c.config.App.Name, msg.Subject)
+	}
+
+	body := fmt.Sprintf(
+		"Имя: %s\nEmail: %s\nIP: %s\nДата: %s\n\n%s\n",
+		msg.Name,
+		msg.Email,
+		msg.IP,
+		msg.CreatedAt.Format(time.RFC1123Z),
+		msg.Message,
+	)
+
+	err = c.mail.
+		Compose().
+		To(c.config.Contact.Recipient).
+		ReplyTo(msg.Email).
+		Subject(subject).
+		Body(body).
+		Send(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	return msg.Update().
+		SetForwardedAt(time.Now()).
+		Exec(ctx)
+}
+
+// signature generates the signature of the time a contact form was rendered at and the nonce of the token
+func (c *ContactClient) signature(renderedAt int64, nonce string) string {
+	return hex.EncodeToString(hmacSHA256(
+		[]byte(c.config.App.EncryptionKey),
+		fmt.Sprintf("contact:%d:%s", renderedAt, nonce),
+	))
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vovanwin/api-my-site/config"
+)
+
+func TestContactClient_ValidateToken(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.App.EncryptionKey = "key"
+	cfg.Contact.MinSubmitTime = time.Second
+	cfg.Contact.TokenExpiration = time.Hour
+	client := NewContactClient(cfg, nil, nil, nil)
+
+	token, err := client.GenerateToken()
+	require.NoError(t, err)
+	assert.IsType(t, ContactSubmittedTooFastError{}, client.ValidateToken(token))
+
+	renderedAt := time.Now().Add(-time.Minute).UnixMilli()
+	token = fmt.Sprintf("%d.nonce.%s", renderedAt, client.signature(renderedAt, "nonce"))
+	assert.NoError(t, client.ValidateToken(token))
+
+	forged := fmt.Sprintf("%d.nonce.%s", renderedAt-1, client.signature(renderedAt, "nonce"))
+	assert.IsType(t, InvalidContactTokenError{}, client.ValidateToken(forged))
+
+	forged = fmt.Sprintf("%d.other.%s", renderedAt, client.signature(renderedAt, "nonce"))
+	assert.IsType(t, InvalidContactTokenError{}, client.ValidateToken(forged))
+
+	renderedAt = time.Now().Add(-2 * time.Hour).UnixMilli()
+	token = fmt.Sprintf("%d.nonce.%s", renderedAt, client.signature(renderedAt, "nonce"))
+	assert.IsType(t, InvalidContactTokenError{}, client.ValidateToken(token))
+
+	for _, token := range []string{"", "abc", "1.2", "1..3", "1.2.3"} {
+		assert.IsType(t, InvalidContactTokenError{}, client.ValidateToken(token))
+	}
+}
+
+func TestContactClient_UseToken(t *testing.T) {
+	token, err := c.Contact.GenerateToken()
+	require.NoError(t, err)
+
+	assert.NoError(t, c.Contact.UseToken(context.Background(), token))
+	assert.IsType(t, InvalidContactTokenError{}, c.Contact.UseToken(context.Background(), token))
+
+	other, err := c.Contact.GenerateToken()
+	require.NoError(t, err)
+	assert.NoError(t, c.Contact.UseToken(context.Background(), other))
+
+	assert.IsType(t, InvalidContactTokenError{}, c.Contact.UseToken(context.Background(), "abc"))
+}
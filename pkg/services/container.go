@@ -4,11 +4,10 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"github.com/vovanwin/api-my-site/ent"
+	"net"
 
 	"entgo.io/ent/dialect"
 	entsql "entgo.io/ent/dialect/sql"
-	"entgo.io/ent/dialect/sql/schema"
 
 	// Required by ent
 	_ "github.com/jackc/pgx/v4/stdlib"
@@ -16,6 +15,9 @@ import (
 	"github.com/labstack/gommon/log"
 
 	"github.com/vovanwin/api-my-site/config"
+	"github.com/vovanwin/api-my-site/ent"
+	"github.com/vovanwin/api-my-site/ent/hook"
+	"github.com/vovanwin/api-my-site/pkg/logging"
 	// Require by ent
 	_ "github.com/vovanwin/api-my-site/ent/runtime"
 )
@@ -32,6 +34,12 @@ type Container struct {
 	// Config stores the application configuration
 	Config *config.Config
 
+	// Settings stores the snapshot of the configuration whose reloadable values are updated at runtime
+	Settings *SettingsClient
+
+	// Tracing stores the tracing client
+	Tracing *TracingClient
+
 	// Cache contains the cache client
 	Cache *CacheClient
 
@@ -41,24 +49,60 @@ type Container struct {
 	// ORM stores a client to the ORM
 	ORM *ent.Client
 
+	// Audit stores the audit log client
+	Audit *AuditClient
+
 	// Auth stores an authentication client
 	Auth *AuthClient
 
+	// FeatureFlags stores the feature flag client
+	FeatureFlags *FeatureFlagClient
+
 	// Tasks stores the task client
 	Tasks *TaskClient
+
+	// Metrics stores the metrics client
+	Metrics *MetricsClient
+
+	// Health stores the health check client
+	Health *HealthClient
+
+	// Storage stores the media storage backend
+	Storage Storage
+
+	// Media stores the media client
+	Media *MediaClient
+
+	// Mail stores the mail client
+	Mail *MailClient
+
+	// Contact stores the contact form client
+	Contact *ContactClient
 }
 
 // NewContainer creates and initializes a new Container
 func NewContainer() *Container {
 	c := new(Container)
 	c.initConfig()
-	c.initValidator()
+	c.initSettings()
+	c.initLogging()
+	c.initTracing()
 	c.initWeb()
 	c.initCache()
 	c.initDatabase()
 	c.initORM()
+	c.initValidator()
+	c.initAudit()
+	c.initHooks()
 	c.initAuth()
+	c.initFeatureFlags()
 	c.initTasks()
+	c.initMetrics()
+	c.initHealth()
+	c.initStorage()
+	c.initMedia()
+	c.initMail()
+	c.initContact()
 	return c
 }
 
@@ -76,6 +120,9 @@ func (c *Container) Shutdown() error {
 	if err := c.Database.Close(); err != nil {
 		return err
 	}
+	if err := c.Tracing.Shutdown(context.Background()); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -89,9 +136,48 @@ func (c *Container) initConfig() {
 	c.Config = &cfg
 }
 
-// initValidator initializes the validator
+// initSettings initializes the settings client
+func (c *Container) initSettings() {
+	c.Settings = NewSettingsClient(c.Config)
+}
+
+// initLogging configures the application logger
+// Sensitive data is removed from all entries, and outside of the local environment entries are written
+// as JSON, so log collectors can parse them. The level and the redaction rules follow the settings as they're
+// reloaded
+func (c *Container) initLogging() {
+	apply := func(ctx context.Context, cfg *config.Config) {
+		if err := logging.SetLevel(cfg.Logging.Level); err != nil {
+			logging.Errorf(ctx, "failed to set log level: %v", err)
+		}
+
+		logging.SetRedactor(logging.NewRedactor(
+			cfg.Logging.Redaction.Keys,
+			cfg.Logging.Redaction.PartialKeys,
+		))
+	}
+	apply(context.Background(), c.Settings.Current())
+	c.Settings.Subscribe(apply)
+
+	if c.Config.App.Environment != config.EnvLocal {
+		logging.SetJSONFormat()
+	}
+}
+
+// initTracing initializes tracing
+// This must happen before the other services are initialized, since their calls are traced
+func (c *Container) initTracing() {
+	var err error
+	if c.Tracing, err = NewTracingClient(c.Config); err != nil {
+		panic(fmt.Sprintf("failed to initialize tracing: %v", err))
+	}
+}
+
+// initValidator initializes the validator and sets it as the validator of the web framework
+// This must happen after the ORM is initialized, since some of the validation rules query the database
 func (c *Container) initValidator() {
-	c.Validator = NewValidator()
+	c.Validator = NewValidator(c.ORM)
+	c.Web.Validator = c.Validator
 }
 
 // initWeb initializes the web framework
@@ -106,7 +192,37 @@ func (c *Container) initWeb() {
 		c.Web.Logger.SetLevel(log.DEBUG)
 	}
 
-	c.Web.Validator = c.Validator
+	c.Web.Binder = NewBinder(c.Config.HTTP.BodyLimit)
+
+	// Without it, Echo takes the client IP from headers any client can set
+	var err error
+	if c.Web.IPExtractor, err = newIPExtractor(c.Config.HTTP.TrustedProxies); err != nil {
+		panic(err)
+	}
+}
+
+// newIPExtractor returns the extractor of the client IP
+// The IP is taken from the connection unless trusted proxies are set, in which case X-Forwarded-For is read
+// up to the first address outside of them
+func newIPExtractor(proxies []string) (echo.IPExtractor, error) {
+	if len(proxies) == 0 {
+		return echo.ExtractIPDirect(), nil
+	}
+
+	opts := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+	for _, proxy := range proxies {
+		_, ipNet, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", proxy, err)
+		}
+		opts = append(opts, echo.TrustIPRange(ipNet))
+	}
+
+	return echo.ExtractIPFromXFFHeader(opts...), nil
 }
 
 // initCache initializes the cache
@@ -115,6 +231,7 @@ func (c *Container) initCache() {
 	if c.Cache, err = NewCacheClient(c.Config); err != nil {
 		panic(err)
 	}
+	c.Cache.Client.AddHook(c.Tracing.RedisHook())
 }
 
 // initDatabase initializes the database
@@ -158,21 +275,101 @@ func (c *Container) initDatabase() {
 	}
 }
 
-// initORM initializes the ORM
+// initORM initializes the ORM and migrates the schema, unless migrating on startup is disabled
 func (c *Container) initORM() {
 	drv := entsql.OpenDB(dialect.Postgres, c.Database)
-	c.ORM = ent.NewClient(ent.Driver(drv))
-	if err := c.ORM.Schema.Create(context.Background(), schema.WithAtlas(true)); err != nil {
+	c.ORM = ent.NewClient(ent.Driver(c.Tracing.Driver(drv)))
+	if !c.Config.Database.Migrate {
+		return
+	}
+	if err := Migrate(context.Background(), c.ORM, false); err != nil {
 		panic(fmt.Sprintf("не удалось создать базу данных schema: %v", err))
 	}
 }
 
+// initHooks registers the ORM hooks that depend on other services
+func (c *Container) initHooks() {
+	// Flush cached content, such as feeds and listings, whenever the content changes
+	flushContent := func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			v, err := next.Mutate(ctx, m)
+			if err == nil {
+				if err := c.Cache.Flush().Tags(CacheTagContent).Execute(ctx); err != nil {
+					logging.Errorf(ctx, "failed to flush cached content: %v", err)
+				}
+			}
+			return v, err
+		})
+	}
+	c.ORM.Post.Use(flushContent)
+	c.ORM.Tag.Use(flushContent)
+	c.ORM.Category.Use(flushContent)
+	c.ORM.Page.Use(flushContent)
+	c.ORM.Project.Use(flushContent)
+	c.ORM.Media.Use(flushContent)
+
+	// Record changes to security-relevant and administered entities in the audit log
+	audit := hook.On(c.Audit.Hook(), ent.OpCreate|ent.OpUpdate|ent.OpUpdateOne|ent.OpDelete|ent.OpDeleteOne)
+	c.ORM.User.Use(audit, c.Audit.RoleChangeHook())
+	c.ORM.PasswordToken.Use(audit)
+	c.ORM.FeatureFlag.Use(audit)
+	c.ORM.Page.Use(audit)
+	c.ORM.Project.Use(audit)
+	c.ORM.Media.Use(audit)
+}
+
+// initAudit initializes the audit log client
+func (c *Container) initAudit() {
+	c.Audit = NewAuditClient(c.ORM)
+}
+
 // initAuth initializes the authentication client
 func (c *Container) initAuth() {
 	c.Auth = NewAuthClient(c.Config, c.ORM)
 }
 
+// initFeatureFlags initializes the feature flag client
+func (c *Container) initFeatureFlags() {
+	c.FeatureFlags = NewFeatureFlagClient(NewORMFeatureFlagStore(c.ORM, c.Cache), c.Settings)
+}
+
 // initTasks initializes the task client
 func (c *Container) initTasks() {
 	c.Tasks = NewTaskClient(c.Config)
 }
+
+// initMetrics initializes the metrics client and registers the metrics of the database, cache and task queues
+func (c *Container) initMetrics() {
+	c.Metrics = NewMetricsClient()
+	c.Metrics.RegisterDatabase(c.Database)
+	c.Metrics.RegisterCache(c.Cache)
+	c.Metrics.RegisterTasks(c.Tasks)
+}
+
+// initHealth initializes the health check client
+func (c *Container) initHealth() {
+	c.Health = NewHealthClient(c.Config, c.Database, c.ORM, c.Cache)
+}
+
+// initStorage initializes the media storage backend
+func (c *Container) initStorage() {
+	var err error
+	if c.Storage, err = NewStorage(c.Config); err != nil {
+		panic(fmt.Sprintf("failed to initialize storage: %v", err))
+	}
+}
+
+// initMedia initializes the media client
+func (c *Container) initMedia() {
+	c.Media = NewMediaClient(c.Config, c.ORM, c.Storage)
+}
+
+// initMail initializes the mail client
+func (c *Container) initMail() {
+	c.Mail = NewMailClient(c.Config)
+}
+
+// initContact initializes the contact form client
+func (c *Container) initContact() {
+	c.Contact = NewContactClient(c.Config, c.ORM, c.Cache, c.Mail)
+}
@@ -1,18 +1,58 @@
 package services
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewContainer(t *testing.T) {
 	assert.NotNil(t, c.Web)
 	assert.NotNil(t, c.Config)
+	assert.NotNil(t, c.Settings)
+	assert.NotNil(t, c.Tracing)
 	assert.NotNil(t, c.Validator)
 	assert.NotNil(t, c.Cache)
 	assert.NotNil(t, c.Database)
 	assert.NotNil(t, c.ORM)
+	assert.NotNil(t, c.Audit)
 	assert.NotNil(t, c.Auth)
+	assert.NotNil(t, c.FeatureFlags)
 	assert.NotNil(t, c.Tasks)
+	assert.NotNil(t, c.Metrics)
+	assert.NotNil(t, c.Health)
+	assert.NotNil(t, c.Storage)
+	assert.NotNil(t, c.Media)
+	assert.NotNil(t, c.Mail)
+	assert.NotNil(t, c.Contact)
+}
+
+func TestNewIPExtractor(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "10.0.0.2:1234"
+	req.Header.Set("X-Forwarded-For", "203.0.113.7, 198.51.100.1")
+	req.Header.Set("X-Real-IP", "203.0.113.8")
+
+	// By default the headers are ignored, as any client can set them
+	extract, err := newIPExtractor(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.2", extract(req))
+
+	// The container uses the same extractor
+	assert.Equal(t, "10.0.0.2", c.Web.IPExtractor(req))
+
+	// A trusted proxy forwards the address it got the request from
+	extract, err = newIPExtractor([]string{"10.0.0.0/8"})
+	require.NoError(t, err)
+	assert.Equal(t, "198.51.100.1", extract(req))
+
+	// The header is ignored when the request didn't come through a trusted proxy
+	req.RemoteAddr = "192.168.0.2:1234"
+	assert.Equal(t, "192.168.0.2", extract(req))
+
+	_, err = newIPExtractor([]string{"10.0.0.1"})
+	assert.Error(t, err)
 }
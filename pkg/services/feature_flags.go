@@ -0,0 +1,180 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"strconv"
+	"time"
+
+	"github.com/vovanwin/api-my-site/ent"
+)
+
+var (
+	// ErrFeatureFlagNotFound is returned when a feature flag does not exist
+	ErrFeatureFlagNotFound = errors.New("feature flag not found")
+
+	// ErrFeatureFlagExists is returned when creating a feature flag whose key is taken
+	ErrFeatureFlagExists = errors.New("feature flag already exists")
+)
+
+// FeatureFlag describes a feature flag and who it's enabled for
+type FeatureFlag struct {
+	// Key stores the unique key the flag is checked by
+	Key string
+
+	// Description stores what the flag is for
+	Description string
+
+	// Enabled turns the flag on; a disabled flag is off for everyone
+	Enabled bool
+
+	// Rollout stores the percentage of users an enabled flag is on for, from 0 to 100
+	Rollout int
+
+	// UserIDs contains the IDs of the users an enabled flag is always on for
+	UserIDs []int
+
+	// Roles contains the roles of the users an enabled flag is always on for
+	Roles []string
+
+	// CreatedAt stores when the flag was created
+	CreatedAt time.Time
+
+	// UpdatedAt stores when the flag was last updated
+	UpdatedAt time.Time
+}
+
+// EnabledFor returns whether the flag is on for the user, who is nil if anonymous
+// Users in the allowlists always get an enabled flag, and the others get it if they fall within the rollout.
+// Each user falls into the same bucket of a flag every time, so raising the rollout only adds users, while
+// the buckets of different flags are independent. Anonymous users only get flags rolled out to everyone
+func (f FeatureFlag) EnabledFor(u *ent.User) bool {
+	if !f.Enabled {
+		return false
+	}
+
+	if u != nil {
+		for _, id := range f.UserIDs {
+			if id == u.ID {
+				return true
+			}
+		}
+		for _, role := range f.Roles {
+			if role == u.Role.String() {
+				return true
+			}
+		}
+	}
+
+	switch {
+	case f.Rollout >= 100:
+		return true
+	case f.Rollout <= 0, u == nil:
+		return false
+	}
+
+	return featureFlagBucket(f.Key, u.ID) < f.Rollout
+}
+
+// featureFlagBucket returns the bucket of the user for the flag, from 0 to 99
+func featureFlagBucket(key string, userID int) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key + ":" + strconv.Itoa(userID)))
+	return int(h.Sum32() % 100)
+}
+
+// FeatureFlagStore is the interface that feature flag backends implement
+type FeatureFlagStore interface {
+	// List returns all the flags ordered by key
+	List(ctx context.Context) ([]FeatureFlag, error)
+
+	// Create stores a new flag
+	// ErrFeatureFlagExists is returned if the key is taken
+	Create(ctx context.Context, flag FeatureFlag) (FeatureFlag, error)
+
+	// Update replaces the settings of the flag with the same key
+	// ErrFeatureFlagNotFound is returned if the flag does not exist
+	Update(ctx context.Context, flag FeatureFlag) (FeatureFlag, error)
+
+	// Delete deletes the flag with the given key
+	// ErrFeatureFlagNotFound is returned if the flag does not exist
+	Delete(ctx context.Context, key string) error
+}
+
+// FeatureFlagClient checks whether features are enabled, so that unfinished features can be shipped turned off
+// The flags are stored in the backend, and can be overridden for an environment in the features section of the
+// configuration, which takes precedence and is reloadable
+type FeatureFlagClient struct {
+	// store stores the feature flag backend
+	store FeatureFlagStore
+
+	// settings stores the settings client providing the overrides
+	settings *SettingsClient
+}
+
+// NewFeatureFlagClient creates a new FeatureFlagClient
+func NewFeatureFlagClient(store FeatureFlagStore, settings *SettingsClient) *FeatureFlagClient {
+	return &FeatureFlagClient{
+		store:    store,
+		settings: settings,
+	}
+}
+
+// Enabled returns whether the flag with the given key is on for the user, who is nil if anonymous
+// Flags that don't exist are off
+func (c *FeatureFlagClient) Enabled(ctx context.Context, key string, u *ent.User) (bool, error) {
+	if on, ok := c.settings.Current().Features[key]; ok {
+		return on, nil
+	}
+
+	flag, err := c.Get(ctx, key)
+	switch {
+	case errors.Is(err, ErrFeatureFlagNotFound):
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+
+	return flag.EnabledFor(u), nil
+}
+
+// List returns all the flags ordered by key
+func (c *FeatureFlagClient) List(ctx context.Context) ([]FeatureFlag, error) {
+	return c.store.List(ctx)
+}
+
+// Get returns the flag with the given key
+// ErrFeatureFlagNotFound is returned if the flag does not exist
+func (c *FeatureFlagClient) Get(ctx context.Context, key string) (FeatureFlag, error) {
+	flags, err := c.store.List(ctx)
+	if err != nil {
+		return FeatureFlag{}, err
+	}
+
+	for _, flag := range flags {
+		if flag.Key == key {
+			return flag, nil
+		}
+	}
+
+	return FeatureFlag{}, ErrFeatureFlagNotFound
+}
+
+// Create stores a new flag
+// ErrFeatureFlagExists is returned if the key is taken
+func (c *FeatureFlagClient) Create(ctx context.Context, flag FeatureFlag) (FeatureFlag, error) {
+	return c.store.Create(ctx, flag)
+}
+
+// Update replaces the settings of the flag with the same key
+// ErrFeatureFlagNotFound is returned if the flag does not exist
+func (c *FeatureFlagClient) Update(ctx context.Context, flag FeatureFlag) (FeatureFlag, error) {
+	return c.store.Update(ctx, flag)
+}
+
+// Delete deletes the flag with the given key
+// ErrFeatureFlagNotFound is returned if the flag does not exist
+func (c *FeatureFlagClient) Delete(ctx context.Context, key string) error {
+	return c.store.Delete(ctx, key)
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryFeatureFlagStore stores feature flags in memory
+// It's meant for tests and for running without a database; the flags are lost when the process exits
+type MemoryFeatureFlagStore struct {
+	mu    sync.RWMutex
+	flags map[string]FeatureFlag
+}
+
+// NewMemoryFeatureFlagStore creates a new MemoryFeatureFlagStore holding the given flags
+func NewMemoryFeatureFlagStore(flags ...FeatureFlag) *MemoryFeatureFlagStore {
+	s := &MemoryFeatureFlagStore{
+		flags: make(map[string]FeatureFlag, len(flags)),
+	}
+	now := time.Now()
+	for _, flag := range flags {
+		if flag.CreatedAt.IsZero() {
+			flag.CreatedAt, flag.UpdatedAt = now, now
+		}
+		s.flags[flag.Key] = copyFeatureFlag(flag)
+	}
+	return s
+}
+
+// List returns all the flags ordered by key
+func (s *MemoryFeatureFlagStore) List(ctx context.Context) ([]FeatureFlag, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	flags := make([]FeatureFlag, 0, len(s.flags))
+	for _, flag := range s.flags {
+		flags = append(flags, copyFeatureFlag(flag))
+	}
+	sort.Slice(flags, func(i, j int) bool {
+		return flags[i].Key < flags[j].Key
+	})
+
+	return flags, nil
+}
+
+// Create stores a new flag
+func (s *MemoryFeatureFlagStore) Create(ctx context.Context, flag FeatureFlag) (FeatureFlag, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.flags[flag.Key]; ok {
+		return FeatureFlag{}, ErrFeatureFlagExists
+	}
+
+	flag.CreatedAt = time.Now()
+	flag.UpdatedAt = flag.CreatedAt
+	s.flags[flag.Key] = copyFeatureFlag(flag)
+
+	return flag, nil
+}
+
+// Update replaces the settings of the flag with the same key
+func (s *MemoryFeatureFlagStore) Update(ctx context.Context, flag FeatureFlag) (FeatureFlag, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.flags[flag.Key]
+	if !ok {
+		return FeatureFlag{}, ErrFeatureFlagNotFound
+	}
+
+	flag.CreatedAt = existing.CreatedAt
+	flag.UpdatedAt = time.Now()
+	s.flags[flag.Key] = copyFeatureFlag(flag)
+
+	return flag, nil
+}
+
+// Delete deletes the flag with the given key
+func (s *MemoryFeatureFlagStore) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.flags[key]; !ok {
+		return ErrFeatureFlagNotFound
+	}
+	delete(s.flags, key)
+
+	return nil
+}
+
+// copyFeatureFlag returns a copy of the flag which shares no slices with it
+func copyFeatureFlag(flag FeatureFlag) FeatureFlag {
+	flag.UserIDs = append([]int(nil), flag.UserIDs...)
+	flag.Roles = append([]string(nil), flag.Roles...)
+	return flag
+}
@@ -0,0 +1,192 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/vovanwin/api-my-site/ent"
+	"github.com/vovanwin/api-my-site/ent/featureflag"
+	"github.com/vovanwin/api-my-site/pkg/logging"
+)
+
+const (
+	// featureFlagCacheGroup stores the cache group the feature flags are cached under
+	featureFlagCacheGroup = "feature_flags"
+
+	// featureFlagCacheKey stores the cache key the feature flags are cached under
+	featureFlagCacheKey = "all"
+
+	// featureFlagCacheExpiration stores how long the feature flags are cached
+	// The cache is flushed whenever the flags are changed through the store, so this only limits how long
+	// changes made to the database directly take to be picked up
+	featureFlagCacheExpiration = 5 * time.Minute
+)
+
+// cachedFeatureFlags holds the feature flags in the cache
+type cachedFeatureFlags struct {
+	Flags []FeatureFlag
+}
+
+// ORMFeatureFlagStore stores feature flags in the database and caches them, since they're checked on
+// every request that depends on them
+type ORMFeatureFlagStore struct {
+	// orm stores the ORM client
+	orm *ent.Client
+
+	// cache stores the cache client
+	cache *CacheClient
+}
+
+// NewORMFeatureFlagStore creates a new ORMFeatureFlagStore
+func NewORMFeatureFlagStore(orm *ent.Client, cache *CacheClient) *ORMFeatureFlagStore {
+	return &ORMFeatureFlagStore{
+		orm:   orm,
+		cache: cache,
+	}
+}
+
+// List returns all the flags ordered by key
+// The flags are loaded from the database if they're not cached, or if the cache is unavailable
+func (s *ORMFeatureFlagStore) List(ctx context.Context) ([]FeatureFlag, error) {
+	cached, err := s.cache.
+		Get().
+		Group(featureFlagCacheGroup).
+		Key(featureFlagCacheKey).
+		Type(new(cachedFeatureFlags)).
+		Fetch(ctx)
+
+	switch {
+	case err == nil:
+		return cached.(*cachedFeatureFlags).Flags, nil
+	case err != redis.Nil:
+		logging.Errorf(ctx, "failed getting cached feature flags: %v", err)
+	}
+
+	entities, err := s.orm.FeatureFlag.
+		Query().
+		Order(ent.Asc(featureflag.FieldKey)).
+		All(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	flags := make([]FeatureFlag, len(entities))
+	for i, f := range entities {
+		flags[i] = newFeatureFlag(f)
+	}
+
+	err = s.cache.
+		Set().
+		Group(featureFlagCacheGroup).
+		Key(featureFlagCacheKey).
+		Data(cachedFeatureFlags{Flags: flags}).
+		Expiration(featureFlagCacheExpiration).
+		Save(ctx)
+
+	if err != nil {
+		logging.Errorf(ctx, "failed to cache feature flags: %v", err)
+	}
+
+	return flags, nil
+}
+
+// Create stores a new flag
+func (s *ORMFeatureFlagStore) Create(ctx context.Context, flag FeatureFlag) (FeatureFlag, error) {
+	f, err := s.orm.FeatureFlag.
+		Create().
+		SetKey(flag.Key).
+		SetDescription(flag.Description).
+		SetEnabled(flag.Enabled).
+		SetRollout(flag.Rollout).
+		SetUserIds(flag.UserIDs).
+		SetRoles(flag.Roles).
+		Save(ctx)
+
+	switch {
+	case ent.IsConstraintError(err):
+		return FeatureFlag{}, ErrFeatureFlagExists
+	case err != nil:
+		return FeatureFlag{}, err
+	}
+
+	s.flush(ctx)
+	return newFeatureFlag(f), nil
+}
+
+// Update replaces the settings of the flag with the same key
+func (s *ORMFeatureFlagStore) Update(ctx context.Context, flag FeatureFlag) (FeatureFlag, error) {
+	f, err := s.orm.FeatureFlag.
+		Query().
+		Where(featureflag.Key(flag.Key)).
+		Only(ctx)
+
+	switch {
+	case ent.IsNotFound(err):
+		return FeatureFlag{}, ErrFeatureFlagNotFound
+	case err != nil:
+		return FeatureFlag{}, err
+	}
+
+	f, err = f.Update().
+		SetDescription(flag.Description).
+		SetEnabled(flag.Enabled).
+		SetRollout(flag.Rollout).
+		SetUserIds(flag.UserIDs).
+		SetRoles(flag.Roles).
+		Save(ctx)
+
+	if err != nil {
+		return FeatureFlag{}, err
+	}
+
+	s.flush(ctx)
+	return newFeatureFlag(f), nil
+}
+
+// Delete deletes the flag with the given key
+func (s *ORMFeatureFlagStore) Delete(ctx context.Context, key string) error {
+	count, err := s.orm.FeatureFlag.
+		Delete().
+		Where(featureflag.Key(key)).
+		Exec(ctx)
+
+	switch {
+	case err != nil:
+		return err
+	case count == 0:
+		return ErrFeatureFlagNotFound
+	}
+
+	s.flush(ctx)
+	return nil
+}
+
+// flush removes the flags from the cache, so that changes take effect straight away
+func (s *ORMFeatureFlagStore) flush(ctx context.Context) {
+	err := s.cache.
+		Flush().
+		Group(featureFlagCacheGroup).
+		Key(featureFlagCacheKey).
+		Execute(ctx)
+
+	if err != nil {
+		logging.Errorf(ctx, "failed to flush cached feature flags: %v", err)
+	}
+}
+
+// newFeatureFlag converts a feature flag entity
+func newFeatureFlag(f *ent.FeatureFlag) FeatureFlag {
+	return FeatureFlag{
+		Key:         f.Key,
+		Description: f.Description,
+		Enabled:     f.Enabled,
+		Rollout:     f.Rollout,
+		UserIDs:     f.UserIds,
+		Roles:       f.Roles,
+		CreatedAt:   f.CreatedAt,
+		UpdatedAt:   f.UpdatedAt,
+	}
+}
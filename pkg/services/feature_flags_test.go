@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vovanwin/api-my-site/config"
+	"github.com/vovanwin/api-my-site/ent"
+	"github.com/vovanwin/api-my-site/ent/user"
+)
+
+func TestFeatureFlag_EnabledFor(t *testing.T) {
+	alice := &ent.User{ID: 1, Role: user.RoleUser}
+	admin := &ent.User{ID: 2, Role: user.RoleAdmin}
+
+	flag := FeatureFlag{Key: "editor", Enabled: true, Rollout: 0, UserIDs: []int{1}, Roles: []string{"admin"}}
+	assert.True(t, flag.EnabledFor(alice))
+	assert.True(t, flag.EnabledFor(admin))
+	assert.False(t, flag.EnabledFor(&ent.User{ID: 3, Role: user.RoleUser}))
+	assert.False(t, flag.EnabledFor(nil))
+
+	flag.Enabled = false
+	assert.False(t, flag.EnabledFor(alice))
+
+	flag = FeatureFlag{Key: "editor", Enabled: true, Rollout: 100}
+	assert.True(t, flag.EnabledFor(nil))
+
+	// Roughly the rollout percentage of users get the flag, and raising it only adds users
+	flag.Rollout = 30
+	wider := flag
+	wider.Rollout = 60
+	count := 0
+	for id := 1; id <= 1000; id++ {
+		u := &ent.User{ID: id, Role: user.RoleUser}
+		on := flag.EnabledFor(u)
+		assert.Equal(t, on, flag.EnabledFor(u))
+		if on {
+			count++
+			assert.True(t, wider.EnabledFor(u))
+		}
+	}
+	assert.InDelta(t, 300, count, 60)
+	assert.False(t, flag.EnabledFor(nil))
+}
+
+func TestFeatureFlagClient(t *testing.T) {
+	cfg, err := config.GetConfig()
+	require.NoError(t, err)
+	cfg.Features = map[string]bool{"forced": true, "killed": false}
+
+	ctx := context.Background()
+	flags := NewFeatureFlagClient(NewMemoryFeatureFlagStore(
+		FeatureFlag{Key: "killed", Enabled: true, Rollout: 100},
+		FeatureFlag{Key: "stored", Enabled: true, Rollout: 100},
+	), NewSettingsClient(&cfg))
+
+	enabled := func(key string) bool {
+		on, err := flags.Enabled(ctx, key, nil)
+		require.NoError(t, err)
+		return on
+	}
+
+	// Overrides take precedence over the stored flags
+	assert.True(t, enabled("forced"))
+	assert.False(t, enabled("killed"))
+	assert.True(t, enabled("stored"))
+	assert.False(t, enabled("missing"))
+
+	_, err = flags.Create(ctx, FeatureFlag{Key: "stored"})
+	assert.ErrorIs(t, err, ErrFeatureFlagExists)
+	_, err = flags.Update(ctx, FeatureFlag{Key: "missing"})
+	assert.ErrorIs(t, err, ErrFeatureFlagNotFound)
+
+	updated, err := flags.Update(ctx, FeatureFlag{Key: "stored", Enabled: false})
+	require.NoError(t, err)
+	assert.False(t, updated.CreatedAt.IsZero())
+	assert.False(t, enabled("stored"))
+
+	require.NoError(t, flags.Delete(ctx, "stored"))
+	assert.ErrorIs(t, flags.Delete(ctx, "stored"), ErrFeatureFlagNotFound)
+	_, err = flags.Get(ctx, "stored")
+	assert.ErrorIs(t, err, ErrFeatureFlagNotFound)
+}
+
+func TestORMFeatureFlagStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewORMFeatureFlagStore(c.ORM, c.Cache)
+
+	created, err := store.Create(ctx, FeatureFlag{Key: "orm-flag", Enabled: true, Rollout: 50, Roles: []string{"admin"}})
+	require.NoError(t, err)
+	assert.Equal(t, 50, created.Rollout)
+
+	_, err = store.Create(ctx, FeatureFlag{Key: "orm-flag"})
+	assert.ErrorIs(t, err, ErrFeatureFlagExists)
+
+	// The flags are cached, and the cache is flushed when they change
+	flags, err := store.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, flags, 1)
+	assert.Equal(t, []string{"admin"}, flags[0].Roles)
+
+	_, err = store.Update(ctx, FeatureFlag{Key: "orm-flag", Rollout: 10})
+	require.NoError(t, err)
+	flags, err = store.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 10, flags[0].Rollout)
+	assert.False(t, flags[0].Enabled)
+
+	require.NoError(t, store.Delete(ctx, "orm-flag"))
+	assert.ErrorIs(t, store.Delete(ctx, "orm-flag"), ErrFeatureFlagNotFound)
+	flags, err = store.List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, flags)
+}
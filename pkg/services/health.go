@@ -0,0 +1,207 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/vovanwin/api-my-site/config"
+	"github.com/vovanwin/api-my-site/ent"
+)
+
+const (
+	// HealthStatusOK indicates that the application or a dependency is healthy
+	HealthStatusOK = "ok"
+
+	// HealthStatusFail indicates that the application or a dependency is unhealthy
+	HealthStatusFail = "fail"
+
+	// HealthStatusDraining indicates that the application is shutting down and no longer accepts traffic
+	HealthStatusDraining = "draining"
+
+	// healthHeartbeatKey stores the cache key the worker heartbeat is recorded under
+	healthHeartbeatKey = "health:heartbeat:worker"
+)
+
+// HealthCheck describes the result of checking a single dependency
+type HealthCheck struct {
+	Status    string  `json:"status"`
+	LatencyMS float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// HealthReport describes the readiness of the application and the results of the checks it's based on
+type HealthReport struct {
+	Status string                 `json:"status"`
+	Checks map[string]HealthCheck `json:"checks,omitempty"`
+}
+
+// HealthClient checks whether the application and the services it depends on are able to serve requests
+type HealthClient struct {
+	// config stores the application configuration
+	config *config.Config
+
+	// db stores the database connection
+	db *sql.DB
+
+	// orm stores the ORM client
+	orm *ent.Client
+
+	// cache stores the cache client
+	cache *CacheClient
+
+	// draining is set once the application starts shutting down
+	draining int32
+
+	// migrated is set once the database schema has been found up to date
+	migrated int32
+
+	// checks maps the check names to the functions performing them
+	checks map[string]func(ctx context.Context) error
+}
+
+// NewHealthClient creates a new HealthClient
+func NewHealthClient(cfg *config.Config, db *sql.DB, orm *ent.Client, cache *CacheClient) *HealthClient {
+	h := &HealthClient{
+		config: cfg,
+		db:     db,
+		orm:    orm,
+		cache:  cache,
+	}
+
+	h.checks = map[string]func(ctx context.Context) error{
+		"database":   h.checkDatabase,
+		"cache":      h.checkCache,
+		"migrations": h.checkMigrations,
+		"worker":     h.checkHeartbeat,
+	}
+
+	return h
+}
+
+// Drain marks the application as shutting down, so that it's reported as not ready from then on
+func (h *HealthClient) Drain() {
+	atomic.StoreInt32(&h.draining, 1)
+}
+
+// Draining returns whether the application is shutting down
+func (h *HealthClient) Draining() bool {
+	return atomic.LoadInt32(&h.draining) == 1
+}
+
+// Check runs all the checks concurrently, each within the configured timeout, and reports whether the
+// application is ready to serve requests. The checks are skipped if the application is shutting down
+func (h *HealthClient) Check(ctx context.Context) HealthReport {
+	if h.Draining() {
+		return HealthReport{Status: HealthStatusDraining}
+	}
+
+	report := HealthReport{
+		Status: HealthStatusOK,
+		Checks: make(map[string]HealthCheck, len(h.checks)),
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, check := range h.checks {
+		wg.Add(1)
+		go func(name string, check func(ctx context.Context) error) {
+			defer wg.Done()
+
+			result := h.run(ctx, check)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[name] = result
+			if result.Status != HealthStatusOK {
+				report.Status = HealthStatusFail
+			}
+		}(name, check)
+	}
+	wg.Wait()
+
+	return report
+}
+
+// Beat records the heartbeat of the worker, which Check requires to be recent
+func (h *HealthClient) Beat(ctx context.Context) error {
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	return h.cache.Client.Set(ctx, healthHeartbeatKey, now, 0).Err()
+}
+
+// run performs a check within the configured timeout and measures how long it took
+func (h *HealthClient) run(ctx context.Context, check func(ctx context.Context) error) HealthCheck {
+	if h.config.Health.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, h.config.Health.Timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	err := check(ctx)
+	result := HealthCheck{
+		Status:    HealthStatusOK,
+		LatencyMS: float64(time.Since(start).Microseconds()) / 1000,
+	}
+
+	if err != nil {
+		result.Status = HealthStatusFail
+		result.Error = err.Error()
+	}
+
+	return result
+}
+
+// checkDatabase checks that the database accepts connections
+func (h *HealthClient) checkDatabase(ctx context.Context) error {
+	return h.db.PingContext(ctx)
+}
+
+// checkCache checks that the cache accepts connections
+func (h *HealthClient) checkCache(ctx context.Context) error {
+	return h.cache.Client.Ping(ctx).Err()
+}
+
+// checkMigrations checks that the database schema matches the ORM schema
+// Once it does, it's not checked again, since the schema is only ever migrated forward
+func (h *HealthClient) checkMigrations(ctx context.Context) error {
+	if atomic.LoadInt32(&h.migrated) == 1 {
+		return nil
+	}
+
+	pending, err := MigrationPlan(ctx, h.orm, false)
+	switch {
+	case err != nil:
+		return err
+	case len(pending) > 0:
+		return fmt.Errorf("%d pending schema changes", len(pending))
+	}
+
+	atomic.StoreInt32(&h.migrated, 1)
+	return nil
+}
+
+// checkHeartbeat checks that the worker has recorded its heartbeat recently
+func (h *HealthClient) checkHeartbeat(ctx context.Context) error {
+	value, err := h.cache.Client.Get(ctx, healthHeartbeatKey).Int64()
+	switch {
+	case errors.Is(err, redis.Nil):
+		return errors.New("no heartbeat recorded")
+	case err != nil:
+		return err
+	}
+
+	age := time.Since(time.Unix(value, 0))
+	if maxAge := h.config.Health.HeartbeatMaxAge; maxAge > 0 && age > maxAge {
+		return fmt.Errorf("last heartbeat %s ago", age.Truncate(time.Second))
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthClient_Check(t *testing.T) {
+	h := NewHealthClient(c.Config, c.Database, c.ORM, c.Cache)
+	ctx := context.Background()
+	require.NoError(t, c.Cache.Client.Del(ctx, healthHeartbeatKey).Err())
+
+	report := h.Check(ctx)
+	assert.Equal(t, HealthStatusFail, report.Status)
+	assert.Equal(t, HealthStatusOK, report.Checks["database"].Status)
+	assert.Equal(t, HealthStatusOK, report.Checks["cache"].Status)
+	assert.Equal(t, HealthStatusOK, report.Checks["migrations"].Status)
+	assert.Equal(t, HealthStatusFail, report.Checks["worker"].Status)
+	assert.NotEmpty(t, report.Checks["worker"].Error)
+
+	require.NoError(t, h.Beat(ctx))
+	report = h.Check(ctx)
+	assert.Equal(t, HealthStatusOK, report.Status)
+	assert.Len(t, report.Checks, 4)
+
+	h.Drain()
+	assert.True(t, h.Draining())
+	report = h.Check(ctx)
+	assert.Equal(t, HealthStatusDraining, report.Status)
+	assert.Empty(t, report.Checks)
+}
@@ -0,0 +1,172 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	netmail "net/mail"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/vovanwin/api-my-site/config"
+)
+
+type (
+	// MailClient provides a client for sending email
+	MailClient struct {
+		// config stores application configuration
+		config *config.Config
+
+		// send stores the function that delivers a composed message, which is replaceable in tests
+		send func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+	}
+
+	// mail represents an email to be sent
+	mail struct {
+		client  *MailClient
+		from    string
+		to      string
+		replyTo string
+		subject string
+		body    string
+	}
+)
+
+// NewMailClient creates a new MailClient
+func NewMailClient(cfg *config.Config) *MailClient {
+	return &MailClient{
+		config: cfg,
+		send:   smtp.SendMail,
+	}
+}
+
+// Compose creates a new email
+func (c *MailClient) Compose() *mail {
+	return &mail{
+		client: c,
+		from:   c.config.Mail.FromAddress,
+	}
+}
+
+// skipSend determines if mail sending should be skipped
+func (c *MailClient) skipSend() bool {
+	return c.config.App.Environment == config.EnvTest
+}
+
+// From sets the email from address
+func (m *mail) From(from string) *mail {
+	m.from = from
+	return m
+}
+
+// To sets the email address this email will be sent to
+func (m *mail) To(to string) *mail {
+	m.to = to
+	return m
+}
+
+// ReplyTo sets the email address replies to this email will be sent to
+func (m *mail) ReplyTo(replyTo string) *mail {
+	m.replyTo = replyTo
+	return m
+}
+
+// Subject sets the subject line of the email
+func (m *mail) Subject(subject string) *mail {
+	m.subject = subject
+	return m
+}
+
+// Body sets the plain text body of the email
+func (m *mail) Body(body string) *mail {
+	m.body = body
+	return m
+}
+
+// Send attempts to send the email
+func (m *mail) Send(ctx context.Context) error {
+	if m.to == "" {
+		return errors.New("email cannot be sent without a to address")
+	}
+
+	if m.from == "" {
+		return errors.New("email cannot be sent without a from address")
+	}
+
+	if m.client.skipSend() {
+		return nil
+	}
+
+	msg, err := m.message()
+	if err != nil {
+		return err
+	}
+
+	cfg := m.client.config.Mail
+	var auth smtp.Auth
+	if cfg.User != "" {
+		auth = smtp.PlainAuth("", cfg.User, cfg.Password, cfg.Hostname)
+	}
+
+	from, _ := netmail.ParseAddress(m.from)
+	to, _ := netmail.ParseAddress(m.to)
+
+	// smtp.SendMail doesn't support contexts, so the context is only checked before sending
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return m.client.send(
+		fmt.Sprintf("%s:%d", cfg.Hostname, cfg.Port),
+		auth,
+		from.Address,
+		[]string{to.Address},
+		msg,
+	)
+}
+
+// message builds the RFC 5322 message of the email
+// Addresses are parsed and re-encoded so that user provided values cannot inject headers
+func (m *mail) message() ([]byte, error) {
+	headers := []struct {
+		name  string
+		value string
+	}{
+		{name: "From", value: m.from},
+		{name: "To", value: m.to},
+		{name: "Reply-To", value: m.replyTo},
+	}
+
+	var buf bytes.Buffer
+	for _, h := range headers {
+		if h.value == "" {
+			continue
+		}
+		addr, err := netmail.ParseAddress(h.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s address: %w", strings.ToLower(h.name), err)
+		}
+		fmt.Fprintf(&buf, "%s: %s\r\n", h.name, addr.String())
+	}
+
+	subject := strings.NewReplacer("\r", " ", "\n", " ").Replace(m.subject)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+
+	w := quotedprintable.NewWriter(&buf)
+	if _, err := w.Write([]byte(m.body)); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
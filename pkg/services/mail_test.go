@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	"net/smtp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vovanwin/api-my-site/config"
+)
+
+func TestMailClient_Send(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.App.Environment = config.EnvLocal
+	cfg.Mail.Hostname = "localhost"
+	cfg.Mail.Port = 25
+	cfg.Mail.FromAddress = "site@localhost"
+	client := NewMailClient(cfg)
+
+	var (
+		addr string
+		to   []string
+		msg  string
+	)
+	client.send = func(a string, _ smtp.Auth, _ string, t []string, m []byte) error {
+		addr, to, msg = a, t, string(m)
+		return nil
+	}
+
+	err := client.Compose().Subject("Test").Send(context.Background())
+	assert.Error(t, err)
+
+	err = client.
+		Compose().
+		To("admin@localhost").
+		ReplyTo("Visitor <visitor@localhost>").
+		Subject("Привет\r\nBcc: x@localhost").
+		Body("Сообщение").
+		Send(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, "localhost:25", addr)
+	assert.Equal(t, []string{"admin@localhost"}, to)
+	assert.Contains(t, msg, "From: <site@localhost>\r\n")
+	assert.Contains(t, msg, "Reply-To: \"Visitor\" <visitor@localhost>\r\n")
+	assert.NotContains(t, msg, "\r\nBcc:")
+	assert.True(t, strings.Contains(msg, "Subject: =?utf-8?q?"))
+
+	err = client.Compose().To("admin@localhost").ReplyTo("not an address").Send(context.Background())
+	assert.Error(t, err)
+}
@@ -0,0 +1,332 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	// Register the decoders used to read image dimensions
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+
+	_ "golang.org/x/image/webp"
+
+	"github.com/vovanwin/api-my-site/config"
+	"github.com/vovanwin/api-my-site/ent"
+	"github.com/vovanwin/api-my-site/ent/media"
+	"github.com/vovanwin/api-my-site/ent/user"
+)
+
+// mediaTypes stores the content types that can be uploaded mapped to the file extension used in storage keys
+var mediaTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// UnsupportedMediaTypeError is an error returned when the uploaded content is not an allowed type
+type UnsupportedMediaTypeError struct {
+	MimeType string
+}
+
+// Error implements the error interface.
+func (e UnsupportedMediaTypeError) Error() string {
+	return fmt.Sprintf("unsupported media type: %s", e.MimeType)
+}
+
+// MediaTooLargeError is an error returned when the uploaded content exceeds the maximum upload size
+type MediaTooLargeError struct {
+	Limit int64
+}
+
+// Error implements the error interface.
+func (e MediaTooLargeError) Error() string {
+	return fmt.Sprintf("media exceeds the maximum size of %d bytes", e.Limit)
+}
+
+// MediaTooManyPixelsError is an error returned when the uploaded image exceeds the maximum pixel count
+type MediaTooManyPixelsError struct {
+	Limit int64
+}
+
+// Error implements the error interface.
+func (e MediaTooManyPixelsError) Error() string {
+	return fmt.Sprintf("media exceeds the maximum of %d pixels", e.Limit)
+}
+
+// MediaQuotaExceededError is an error returned when an upload would exceed the user's storage quota
+type MediaQuotaExceededError struct {
+	Quota int64
+}
+
+// Error implements the error interface.
+func (e MediaQuotaExceededError) Error() string {
+	return fmt.Sprintf("media quota of %d bytes exceeded", e.Quota)
+}
+
+// InvalidMediaSignatureError is an error returned when a signed media URL is invalid or expired
+type InvalidMediaSignatureError struct{}
+
+// Error implements the error interface.
+func (e InvalidMediaSignatureError) Error() string {
+	return "invalid or expired media signature"
+}
+
+// MediaUpload describes a file being uploaded
+type MediaUpload struct {
+	// OwnerID stores the ID of the user uploading the file
+	OwnerID int
+
+	// Filename stores the original name of the file
+	Filename string
+
+	// Private indicates if the file can only be accessed by the owner or with a signed URL
+	Private bool
+
+	// Content stores the file content
+	Content io.Reader
+}
+
+// MediaClient is the client that handles storing uploaded media
+type MediaClient struct {
+	config  *config.Config
+	orm     *ent.Client
+	storage Storage
+}
+
+// NewMediaClient creates a new media client
+func NewMediaClient(cfg *config.Config, orm *ent.Client, storage Storage) *MediaClient {
+	return &MediaClient{
+		config:  cfg,
+		orm:     orm,
+		storage: storage,
+	}
+}
+
+// Upload validates and stores an uploaded file and creates the media entity describing it.
+// The content type is sniffed from the content itself rather than trusted from the client, and the
+// upload is rejected if it exceeds the maximum upload size, the maximum pixel count or the owner's quota.
+func (c *MediaClient) Upload(ctx context.Context, upload MediaUpload) (*ent.Media, error) {
+	// Buffer the upload to a temporary file so it can be inspected before it is stored
+	tmp, err := os.CreateTemp("", "media-*")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+	}()
+
+	hash := sha256.New()
+	limit := c.config.Storage.MaxUploadSize
+	size, err := io.Copy(io.MultiWriter(tmp, hash), io.LimitReader(upload.Content, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if size > limit {
+		return nil, MediaTooLargeError{Limit: limit}
+	}
+
+	// Sniff the content type
+	head := make([]byte, 512)
+	n, err := tmp.ReadAt(head, 0)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	mimeType := http.DetectContentType(head[:n])
+	ext, ok := mediaTypes[mimeType]
+	if !ok {
+		return nil, UnsupportedMediaTypeError{MimeType: mimeType}
+	}
+
+	// Read the dimensions, which also ensures the image is not corrupt
+	if _, err = tmp.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	dims, _, err := image.DecodeConfig(tmp)
+	if err != nil {
+		return nil, UnsupportedMediaTypeError{MimeType: mimeType}
+	}
+
+	// A small file can declare huge dimensions, which would exhaust the memory of the worker decoding it
+	if err = c.checkPixels(dims); err != nil {
+		return nil, err
+	}
+
+	// Store the file before the quota is checked, so the owner is only locked for the insert
+	name, err := randomHex(16)
+	if err != nil {
+		return nil, err
+	}
+	key := fmt.Sprintf("%d/%s%s", upload.OwnerID, name, ext)
+
+	if _, err = tmp.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	if err = c.storage.Put(ctx, key, tmp, size, mimeType); err != nil {
+		return nil, err
+	}
+
+	m, err := c.insert(ctx, upload.OwnerID, size, func(create *ent.MediaCreate) {
+		create.
+			SetKey(key).
+			SetFilename(upload.Filename).
+			SetMimeType(mimeType).
+			SetSize(size).
+			SetChecksum(hex.EncodeToString(hash.Sum(nil))).
+			SetWidth(dims.Width).
+			SetHeight(dims.Height).
+			SetPrivate(upload.Private).
+			SetOwnerID(upload.OwnerID)
+	})
+
+	if err != nil {
+		// Do not leave orphaned files behind
+		_ = c.storage.Delete(ctx, key)
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// insert creates a media entity of a given size unless it would exceed the owner's quota
+// The owner's row is locked until the entity is created, so concurrent uploads of the same owner can't
+// both pass the check
+func (c *MediaClient) insert(ctx context.Context, ownerID int, size int64, fn func(*ent.MediaCreate)) (*ent.Media, error) {
+	tx, err := c.orm.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := c.insertTx(ctx, tx.Client(), ownerID, size, fn)
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return nil, fmt.Errorf("%w: rolling back: %v", err, rbErr)
+		}
+		return nil, err
+	}
+
+	return m, tx.Commit()
+}
+
+// insertTx checks the quota and creates the media entity using the transactional client
+func (c *MediaClient) insertTx(ctx context.Context, orm *ent.Client, ownerID int, size int64, fn func(*ent.MediaCreate)) (*ent.Media, error) {
+	if _, err := orm.User.Query().Where(user.ID(ownerID)).ForUpdate().Only(ctx); err != nil {
+		return nil, err
+	}
+
+	used, err := usedQuota(ctx, orm, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if quota := c.config.Storage.UserQuota; quota > 0 && used+size > quota {
+		return nil, MediaQuotaExceededError{Quota: quota}
+	}
+
+	create := orm.Media.Create()
+	fn(create)
+	return create.Save(ctx)
+}
+
+// checkPixels returns MediaTooManyPixelsError if an image exceeds the maximum pixel count
+func (c *MediaClient) checkPixels(dims image.Config) error {
+	limit := c.config.Storage.MaxImagePixels
+	if int64(dims.Width)*int64(dims.Height) > limit {
+		return MediaTooManyPixelsError{Limit: limit}
+	}
+	return nil
+}
+
+// UsedQuota returns the amount of bytes stored by a given user
+func (c *MediaClient) UsedQuota(ctx context.Context, userID int) (int64, error) {
+	return usedQuota(ctx, c.orm, userID)
+}
+
+// usedQuota returns the amount of bytes stored by a given user using a given client
+func usedQuota(ctx context.Context, orm *ent.Client, userID int) (int64, error) {
+	sizes, err := orm.Media.
+		Query().
+		Where(media.HasOwnerWith(user.ID(userID))).
+		Select(media.FieldSize).
+		Ints(ctx)
+
+	if err != nil {
+		return 0, err
+	}
+
+	var total int64
+	for _, s := range sizes {
+		total += int64(s)
+	}
+	return total, nil
+}
+
+// Open opens the stored file of a given media entity
+func (c *MediaClient) Open(ctx context.Context, m *ent.Media) (io.ReadCloser, error) {
+	return c.storage.Get(ctx, m.Key)
+}
+
+// Delete removes a media entity along with its stored file and variants
+func (c *MediaClient) Delete(ctx context.Context, m *ent.Media) error {
+	if err := c.deleteVariants(ctx, m); err != nil {
+		return err
+	}
+	if err := c.orm.Media.DeleteOne(m).Exec(ctx); err != nil {
+		return err
+	}
+	return c.storage.Delete(ctx, m.Key)
+}
+
+// SignedQuery returns the query parameters that grant temporary access to a given media entity.
+// The signature expires after the duration stored in the configuration.
+func (c *MediaClient) SignedQuery(mediaID int) url.Values {
+	expires := time.Now().Add(c.config.Storage.SignedURLExpiration).Unix()
+	return url.Values{
+		"expires":   []string{strconv.FormatInt(expires, 10)},
+		"signature": []string{c.signature(mediaID, expires)},
+	}
+}
+
+// VerifySignedQuery verifies query parameters generated by SignedQuery for a given media entity
+func (c *MediaClient) VerifySignedQuery(mediaID int, query url.Values) error {
+	expires, err := strconv.ParseInt(query.Get("expires"), 10, 64)
+	if err != nil || time.Now().Unix() > expires {
+		return InvalidMediaSignatureError{}
+	}
+
+	expected := c.signature(mediaID, expires)
+	if !hmac.Equal([]byte(expected), []byte(query.Get("signature"))) {
+		return InvalidMediaSignatureError{}
+	}
+
+	return nil
+}
+
+// signature generates the signature of a media entity ID and expiration timestamp
+func (c *MediaClient) signature(mediaID int, expires int64) string {
+	return hex.EncodeToString(hmacSHA256(
+		[]byte(c.config.App.EncryptionKey),
+		fmt.Sprintf("media:%d:%d", mediaID, expires),
+	))
+}
+
+// randomHex generates a random hex string from a given amount of bytes
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,266 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"image"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vovanwin/api-my-site/pkg/tests"
+)
+
+func testPNG(t *testing.T, width, height int) []byte {
+	var buf bytes.Buffer
+	err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height)))
+	require.NoError(t, err)
+	return buf.Bytes()
+}
+
+func TestMediaClient_Upload(t *testing.T) {
+	ctx := context.Background()
+	content := testPNG(t, 20, 10)
+
+	m, err := c.Media.Upload(ctx, MediaUpload{
+		OwnerID:  usr.ID,
+		Filename: "test.png",
+		Content:  bytes.NewReader(content),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", m.MimeType)
+	assert.Equal(t, int64(len(content)), m.Size)
+	assert.Equal(t, 20, m.Width)
+	assert.Equal(t, 10, m.Height)
+	assert.Len(t, m.Checksum, 64)
+
+	// The file should be stored
+	r, err := c.Media.Open(ctx, m)
+	require.NoError(t, err)
+	stored, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	assert.Equal(t, content, stored)
+
+	used, err := c.Media.UsedQuota(ctx, usr.ID)
+	require.NoError(t, err)
+	assert.Equal(t, m.Size, used)
+
+	// Content that is not an image should be rejected regardless of the filename
+	_, err = c.Media.Upload(ctx, MediaUpload{
+		OwnerID:  usr.ID,
+		Filename: "test.png",
+		Content:  strings.NewReader("<html></html>"),
+	})
+	assert.IsType(t, UnsupportedMediaTypeError{}, err)
+
+	// Content exceeding the maximum size should be rejected
+	_, err = c.Media.Upload(ctx, MediaUpload{
+		OwnerID:  usr.ID,
+		Filename: "test.png",
+		Content:  bytes.NewReader(make([]byte, c.Config.Storage.MaxUploadSize+1)),
+	})
+	assert.IsType(t, MediaTooLargeError{}, err)
+
+	// Images exceeding the maximum pixel count should be rejected before they're decoded
+	pixels := c.Config.Storage.MaxImagePixels
+	c.Config.Storage.MaxImagePixels = 199
+	_, err = c.Media.Upload(ctx, MediaUpload{
+		OwnerID:  usr.ID,
+		Filename: "test.png",
+		Content:  bytes.NewReader(content),
+	})
+	c.Config.Storage.MaxImagePixels = pixels
+	assert.IsType(t, MediaTooManyPixelsError{}, err)
+
+	// Content exceeding the quota should be rejected
+	quota := c.Config.Storage.UserQuota
+	c.Config.Storage.UserQuota = used + 1
+	_, err = c.Media.Upload(ctx, MediaUpload{
+		OwnerID:  usr.ID,
+		Filename: "test.png",
+		Content:  bytes.NewReader(content),
+	})
+	c.Config.Storage.UserQuota = quota
+	assert.IsType(t, MediaQuotaExceededError{}, err)
+
+	// Deleting removes the file
+	require.NoError(t, c.Media.Delete(ctx, m))
+	_, err = c.Media.Open(ctx, m)
+	assert.IsType(t, StorageObjectNotFoundError{}, err)
+}
+
+func TestMediaClient_Upload_ConcurrentQuota(t *testing.T) {
+	ctx := context.Background()
+	content := testPNG(t, 20, 10)
+
+	owner, err := tests.CreateUser(c.ORM)
+	require.NoError(t, err)
+
+	// The quota leaves room for a single upload, so only one of the concurrent uploads may succeed
+	quota := c.Config.Storage.UserQuota
+	c.Config.Storage.UserQuota = int64(len(content))
+	defer func() {
+		c.Config.Storage.UserQuota = quota
+	}()
+
+	const uploads = 5
+	errs := make(chan error, uploads)
+	for i := 0; i < uploads; i++ {
+		go func() {
+			_, err := c.Media.Upload(ctx, MediaUpload{
+				OwnerID:  owner.ID,
+				Filename: "test.png",
+				Content:  bytes.NewReader(content),
+			})
+			errs <- err
+		}()
+	}
+
+	var stored int
+	for i := 0; i < uploads; i++ {
+		switch err := <-errs; err.(type) {
+		case nil:
+			stored++
+		case MediaQuotaExceededError:
+		default:
+			t.Fatal(err)
+		}
+	}
+	assert.Equal(t, 1, stored)
+
+	used, err := c.Media.UsedQuota(ctx, owner.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(content)), used)
+}
+
+func TestMediaClient_SignedQuery(t *testing.T) {
+	q := c.Media.SignedQuery(1)
+	assert.NoError(t, c.Media.VerifySignedQuery(1, q))
+	assert.Error(t, c.Media.VerifySignedQuery(2, q))
+
+	q.Set("expires", "1")
+	assert.Error(t, c.Media.VerifySignedQuery(1, q))
+}
+
+func TestMediaClient_GenerateVariants(t *testing.T) {
+	ctx := context.Background()
+
+	m, err := c.Media.Upload(ctx, MediaUpload{
+		OwnerID:  usr.ID,
+		Filename: "test.png",
+		Content:  bytes.NewReader(testPNG(t, 1000, 500)),
+	})
+	require.NoError(t, err)
+
+	// Generating twice should replace the variants rather than duplicate them
+	require.NoError(t, c.Media.GenerateVariants(ctx, m.ID))
+	require.NoError(t, c.Media.GenerateVariants(ctx, m.ID))
+
+	m, err = c.ORM.Media.Get(ctx, m.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, m.ProcessedAt)
+
+	variants, err := m.QueryVariants().All(ctx)
+	require.NoError(t, err)
+	dims := make(map[string][2]int)
+	for _, v := range variants {
+		dims[v.Name] = [2]int{v.Width, v.Height}
+	}
+	assert.Equal(t, map[string][2]int{
+		"thumb": {320, 320},
+		"w640":  {640, 320},
+	}, dims)
+
+	// Deleting the media removes the variants
+	require.NoError(t, c.Media.Delete(ctx, m))
+	_, err = c.Media.OpenVariant(ctx, variants[0])
+	assert.IsType(t, StorageObjectNotFoundError{}, err)
+}
+
+func TestMediaClient_GenerateVariants_TooManyPixels(t *testing.T) {
+	ctx := context.Background()
+
+	m, err := c.Media.Upload(ctx, MediaUpload{
+		OwnerID:  usr.ID,
+		Filename: "test.png",
+		Content:  bytes.NewReader(testPNG(t, 20, 10)),
+	})
+	require.NoError(t, err)
+
+	// The limit is checked again in case it was lowered after the upload
+	pixels := c.Config.Storage.MaxImagePixels
+	c.Config.Storage.MaxImagePixels = 199
+	err = c.Media.GenerateVariants(ctx, m.ID)
+	c.Config.Storage.MaxImagePixels = pixels
+	assert.IsType(t, MediaTooManyPixelsError{}, err)
+
+	m, err = c.ORM.Media.Get(ctx, m.ID)
+	require.NoError(t, err)
+	assert.Nil(t, m.ProcessedAt)
+	require.NoError(t, c.Media.Delete(ctx, m))
+}
+
+func TestStripWebPMetadata(t *testing.T) {
+	chunk := func(fourCC string, payload ...byte) []byte {
+		b := append([]byte(fourCC), 0, 0, 0, 0)
+		binary.LittleEndian.PutUint32(b[4:], uint32(len(payload)))
+		b = append(b, payload...)
+		if len(payload)%2 == 1 {
+			b = append(b, 0)
+		}
+		return b
+	}
+	webp := func(chunks ...[]byte) []byte {
+		b := []byte("RIFF\x00\x00\x00\x00WEBP")
+		for _, c := range chunks {
+			b = append(b, c...)
+		}
+		binary.LittleEndian.PutUint32(b[4:], uint32(len(b)-8))
+		return b
+	}
+
+	in := webp(
+		chunk("VP8X", webpFlagEXIF|webpFlagXMP|0x10, 0, 0, 0, 1, 0, 0, 1, 0, 0),
+		chunk("VP8L", 1, 2, 3),
+		chunk("EXIF", []byte("GPS")...),
+		chunk("XMP ", []byte("<x/>")...),
+	)
+	out, err := stripWebPMetadata(in)
+	require.NoError(t, err)
+	assert.Equal(t, webp(
+		chunk("VP8X", 0x10, 0, 0, 0, 1, 0, 0, 1, 0, 0),
+		chunk("VP8L", 1, 2, 3),
+	), out)
+
+	// The input is left untouched
+	assert.Equal(t, byte(webpFlagEXIF|webpFlagXMP|0x10), in[20])
+
+	for _, data := range [][]byte{
+		[]byte("GIF89a"),
+		webp(chunk("VP8L", 1, 2, 3))[:22],
+		webp([]byte("VP8L\xff\x00\x00\x00")),
+	} {
+		_, err = stripWebPMetadata(data)
+		assert.Error(t, err)
+	}
+}
+
+func TestEncodeVariant(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+	enc, err := encodeVariant(img)
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", enc.mimeType)
+
+	for i := range img.Pix {
+		img.Pix[i] = 0xff
+	}
+	enc, err = encodeVariant(img)
+	require.NoError(t, err)
+	assert.Equal(t, "image/jpeg", enc.mimeType)
+}
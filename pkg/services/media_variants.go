@@ -0,0 +1,293 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/disintegration/imaging"
+
+	"github.com/vovanwin/api-my-site/ent"
+	"github.com/vovanwin/api-my-site/ent/media"
+	"github.com/vovanwin/api-my-site/ent/mediavariant"
+)
+
+// mediaVariantJPEGQuality stores the quality used when encoding JPEG variants
+const mediaVariantJPEGQuality = 85
+
+const (
+	// webpFlagEXIF is the flag of the WebP extended header indicating the file has EXIF metadata
+	webpFlagEXIF = 0x08
+
+	// webpFlagXMP is the flag of the WebP extended header indicating the file has XMP metadata
+	webpFlagXMP = 0x04
+)
+
+// MediaVariantSpec describes a variant generated for uploaded images
+type MediaVariantSpec struct {
+	// Name stores the name of the variant
+	Name string
+
+	// Width stores the width of the variant
+	Width int
+
+	// Height stores the height of the variant, which is only used when cropping
+	Height int
+
+	// Crop indicates if the image should be cropped to fill the exact dimensions
+	Crop bool
+}
+
+// MediaVariantSpecs stores the variants generated for every uploaded image
+// Variants wider than the original image are skipped, except for the thumbnail
+var MediaVariantSpecs = []MediaVariantSpec{
+	{Name: "thumb", Width: 320, Height: 320, Crop: true},
+	{Name: "w640", Width: 640},
+	{Name: "w1280", Width: 1280},
+}
+
+// encodedImage stores an encoded image ready to be stored
+type encodedImage struct {
+	data     []byte
+	mimeType string
+	ext      string
+}
+
+// GenerateVariants generates the resized variants of a given media entity and records them against it.
+// EXIF orientation is applied and, since every variant is re-encoded, no metadata is carried over.
+// The original is stripped of metadata once as well, so location and camera details are not served to visitors.
+// This is safe to call more than once, any existing variants are replaced.
+func (c *MediaClient) GenerateVariants(ctx context.Context, mediaID int) error {
+	m, err := c.orm.Media.Get(ctx, mediaID)
+	if err != nil {
+		return err
+	}
+
+	r, err := c.storage.Get(ctx, m.Key)
+	if err != nil {
+		return err
+	}
+	data, err := io.ReadAll(r)
+	_ = r.Close()
+	if err != nil {
+		return err
+	}
+
+	// Check the pixel count again before decoding, since the limit may have been lowered since the upload
+	dims, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	if err = c.checkPixels(dims); err != nil {
+		return err
+	}
+
+	img, err := imaging.Decode(bytes.NewReader(data), imaging.AutoOrientation(true))
+	if err != nil {
+		return err
+	}
+
+	// Replace the original with a copy stripped of metadata, unless that was done by a previous run
+	if m.ProcessedAt == nil {
+		if m, err = c.sanitizeOriginal(ctx, m, data, img); err != nil {
+			return err
+		}
+	}
+
+	// Remove variants left by a previous run
+	if err = c.deleteVariants(ctx, m); err != nil {
+		return err
+	}
+
+	bounds := img.Bounds()
+	base := strings.TrimSuffix(m.Key, path.Ext(m.Key))
+	creates := make([]*ent.MediaVariantCreate, 0, len(MediaVariantSpecs))
+
+	for _, spec := range MediaVariantSpecs {
+		var resized *image.NRGBA
+		switch {
+		case spec.Crop:
+			resized = imaging.Fill(img, spec.Width, spec.Height, imaging.Center, imaging.Lanczos)
+		case bounds.Dx() > spec.Width:
+			resized = imaging.Resize(img, spec.Width, 0, imaging.Lanczos)
+		default:
+			continue
+		}
+
+		enc, err := encodeVariant(resized)
+		if err != nil {
+			return err
+		}
+
+		key := base + "_" + spec.Name + enc.ext
+		if err = c.storage.Put(ctx, key, bytes.NewReader(enc.data), int64(len(enc.data)), enc.mimeType); err != nil {
+			return err
+		}
+
+		creates = append(creates, c.orm.MediaVariant.
+			Create().
+			SetName(spec.Name).
+			SetKey(key).
+			SetMimeType(enc.mimeType).
+			SetSize(int64(len(enc.data))).
+			SetWidth(resized.Bounds().Dx()).
+			SetHeight(resized.Bounds().Dy()).
+			SetMedia(m))
+	}
+
+	if err = c.orm.MediaVariant.CreateBulk(creates...).Exec(ctx); err != nil {
+		return err
+	}
+
+	return m.Update().
+		SetProcessedAt(time.Now()).
+		Exec(ctx)
+}
+
+// OpenVariant opens the stored file of a given media variant
+func (c *MediaClient) OpenVariant(ctx context.Context, v *ent.MediaVariant) (io.ReadCloser, error) {
+	return c.storage.Get(ctx, v.Key)
+}
+
+// sanitizeOriginal replaces the original file of a media entity with a copy without metadata
+// JPEG and PNG images are re-encoded from the decoded image, which has the EXIF orientation applied.
+// GIFs are re-encoded frame by frame to keep the animation, and WebP images, which can't be encoded,
+// have their metadata chunks removed instead
+func (c *MediaClient) sanitizeOriginal(ctx context.Context, m *ent.Media, data []byte, img image.Image) (*ent.Media, error) {
+	var buf bytes.Buffer
+	width, height := img.Bounds().Dx(), img.Bounds().Dy()
+
+	switch m.MimeType {
+	case "image/png":
+		if err := png.Encode(&buf, img); err != nil {
+			return nil, err
+		}
+	case "image/jpeg":
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 95}); err != nil {
+			return nil, err
+		}
+	case "image/gif":
+		g, err := gif.DecodeAll(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		if err = gif.EncodeAll(&buf, g); err != nil {
+			return nil, err
+		}
+		width, height = g.Config.Width, g.Config.Height
+	case "image/webp":
+		stripped, err := stripWebPMetadata(data)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(stripped)
+		width, height = m.Width, m.Height
+	default:
+		return m, nil
+	}
+
+	if err := c.storage.Put(ctx, m.Key, bytes.NewReader(buf.Bytes()), int64(buf.Len()), m.MimeType); err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(buf.Bytes())
+	return m.Update().
+		SetSize(int64(buf.Len())).
+		SetChecksum(hex.EncodeToString(sum[:])).
+		SetWidth(width).
+		SetHeight(height).
+		Save(ctx)
+}
+
+// stripWebPMetadata removes the EXIF and XMP chunks from a WebP file and clears their flags in the
+// extended header, leaving the image data untouched
+func stripWebPMetadata(data []byte) ([]byte, error) {
+	if len(data) < 12 || string(data[:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return nil, errors.New("not a WebP file")
+	}
+
+	out := make([]byte, 12, len(data))
+	copy(out, data[:12])
+
+	for rest := data[12:]; len(rest) > 0; {
+		if len(rest) < 8 {
+			return nil, errors.New("truncated WebP chunk header")
+		}
+		size := uint64(binary.LittleEndian.Uint32(rest[4:8]))
+		if 8+size > uint64(len(rest)) {
+			return nil, errors.New("truncated WebP chunk")
+		}
+
+		// Chunks are padded to an even size, though the padding of the last one is sometimes missing
+		end := int(8 + size + size&1)
+		if end > len(rest) {
+			end = len(rest)
+		}
+		chunk := rest[:end]
+		rest = rest[end:]
+
+		switch string(chunk[:4]) {
+		case "EXIF", "XMP ":
+			continue
+		case "VP8X":
+			if size > 0 {
+				chunk = append([]byte(nil), chunk...)
+				chunk[8] &^= webpFlagEXIF | webpFlagXMP
+			}
+		}
+		out = append(out, chunk...)
+	}
+
+	binary.LittleEndian.PutUint32(out[4:8], uint32(len(out)-8))
+	return out, nil
+}
+
+// deleteVariants removes all variants of a given media entity along with their stored files
+func (c *MediaClient) deleteVariants(ctx context.Context, m *ent.Media) error {
+	variants, err := m.QueryVariants().All(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range variants {
+		if err = c.storage.Delete(ctx, v.Key); err != nil {
+			return err
+		}
+	}
+
+	_, err = c.orm.MediaVariant.
+		Delete().
+		Where(mediavariant.HasMediaWith(media.ID(m.ID))).
+		Exec(ctx)
+
+	return err
+}
+
+// encodeVariant encodes a variant as a JPEG, or as a PNG when it has transparency
+// WebP variants are not produced since there is no pure Go WebP encoder, only a decoder
+func encodeVariant(img *image.NRGBA) (encodedImage, error) {
+	var buf bytes.Buffer
+
+	if !img.Opaque() {
+		if err := png.Encode(&buf, img); err != nil {
+			return encodedImage{}, err
+		}
+		return encodedImage{data: buf.Bytes(), mimeType: "image/png", ext: ".png"}, nil
+	}
+
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: mediaVariantJPEGQuality}); err != nil {
+		return encodedImage{}, err
+	}
+	return encodedImage{data: buf.Bytes(), mimeType: "image/jpeg", ext: ".jpg"}, nil
+}
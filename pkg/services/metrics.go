@@ -0,0 +1,251 @@
+package services
+
+import (
+	"crypto/subtle"
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/hibiken/asynq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// metricsNamespace prefixes the names of all the application metrics
+const metricsNamespace = "app"
+
+// MetricsClient collects the application metrics and exposes them to Prometheus
+type MetricsClient struct {
+	// Registry stores the registry all the application metrics are registered with
+	Registry *prometheus.Registry
+
+	// requests counts the handled HTTP requests
+	requests *prometheus.CounterVec
+
+	// requestDuration observes how long it takes to handle HTTP requests
+	requestDuration *prometheus.HistogramVec
+
+	// tasks counts the processed tasks
+	tasks *prometheus.CounterVec
+
+	// taskDuration observes how long it takes to process tasks
+	taskDuration *prometheus.HistogramVec
+}
+
+// NewMetricsClient creates a new MetricsClient with the Go runtime and process metrics registered
+func NewMetricsClient() *MetricsClient {
+	m := &MetricsClient{
+		Registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "The number of handled HTTP requests by route name, method and status.",
+		}, []string{"route", "method", "status"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "How long it took to handle HTTP requests by route name, method and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"route", "method", "status"}),
+		tasks: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "tasks",
+			Name:      "processed_total",
+			Help:      "The number of tasks processed by this process by task type and result.",
+		}, []string{"type", "result"}),
+		taskDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "tasks",
+			Name:      "duration_seconds",
+			Help:      "How long it took this process to process tasks by task type.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"type"}),
+	}
+
+	m.Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.requests,
+		m.requestDuration,
+		m.tasks,
+		m.taskDuration,
+	)
+
+	return m
+}
+
+// RegisterDatabase registers the connection pool metrics of the database
+func (m *MetricsClient) RegisterDatabase(db *sql.DB) {
+	m.Registry.MustRegister(collectors.NewDBStatsCollector(db, metricsNamespace))
+}
+
+// RegisterCache registers the connection pool metrics of the cache
+func (m *MetricsClient) RegisterCache(cache *CacheClient) {
+	m.Registry.MustRegister(newRedisPoolCollector(cache.Client))
+}
+
+// RegisterTasks registers the metrics of the task queues, which are shared by all the processes using them
+func (m *MetricsClient) RegisterTasks(tasks *TaskClient) {
+	m.Registry.MustRegister(newTaskQueueCollector(tasks.inspector))
+}
+
+// ObserveRequest records a handled HTTP request
+func (m *MetricsClient) ObserveRequest(route, method string, status int, duration time.Duration) {
+	code := strconv.Itoa(status)
+	m.requests.WithLabelValues(route, method, code).Inc()
+	m.requestDuration.WithLabelValues(route, method, code).Observe(duration.Seconds())
+}
+
+// ObserveTask records a processed task
+func (m *MetricsClient) ObserveTask(typ string, err error, duration time.Duration) {
+	result := "succeeded"
+	if err != nil {
+		result = "failed"
+	}
+	m.tasks.WithLabelValues(typ, result).Inc()
+	m.taskDuration.WithLabelValues(typ).Observe(duration.Seconds())
+}
+
+// Handler returns the HTTP handler exposing the metrics to Prometheus
+// Metrics which fail to be collected are left out rather than failing the whole scrape
+func (m *MetricsClient) Handler() http.Handler {
+	return promhttp.HandlerFor(m.Registry, promhttp.HandlerOpts{
+		ErrorHandling: promhttp.ContinueOnError,
+	})
+}
+
+// ProtectedHandler returns the metrics handler requiring the bearer token in the Authorization header
+// It's used by the metrics servers listening on their own ports; an empty token leaves the metrics public
+func (m *MetricsClient) ProtectedHandler(token string) http.Handler {
+	handler := m.Handler()
+	if token == "" {
+		return handler
+	}
+
+	expected := []byte("Bearer " + token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), expected) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// redisPoolCollector collects the connection pool metrics of a Redis client
+type redisPoolCollector struct {
+	client   *redis.Client
+	hits     *prometheus.Desc
+	misses   *prometheus.Desc
+	timeouts *prometheus.Desc
+	stale    *prometheus.Desc
+	conns    *prometheus.Desc
+}
+
+func newRedisPoolCollector(client *redis.Client) *redisPoolCollector {
+	desc := func(name, help string, labels ...string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(metricsNamespace, "cache_pool", name), help, labels, nil)
+	}
+
+	return &redisPoolCollector{
+		client:   client,
+		hits:     desc("hits_total", "The number of times a free connection was found in the pool."),
+		misses:   desc("misses_total", "The number of times a free connection was not found in the pool."),
+		timeouts: desc("timeouts_total", "The number of times a wait for a connection timed out."),
+		stale:    desc("stale_connections_total", "The number of stale connections removed from the pool."),
+		conns:    desc("connections", "The number of connections in the pool by state.", "state"),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *redisPoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.hits
+	ch <- c.misses
+	ch <- c.timeouts
+	ch <- c.stale
+	ch <- c.conns
+}
+
+// Collect implements prometheus.Collector
+func (c *redisPoolCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.client.PoolStats()
+	ch <- prometheus.MustNewConstMetric(c.hits, prometheus.CounterValue, float64(stats.Hits))
+	ch <- prometheus.MustNewConstMetric(c.misses, prometheus.CounterValue, float64(stats.Misses))
+	ch <- prometheus.MustNewConstMetric(c.timeouts, prometheus.CounterValue, float64(stats.Timeouts))
+	ch <- prometheus.MustNewConstMetric(c.stale, prometheus.CounterValue, float64(stats.StaleConns))
+	ch <- prometheus.MustNewConstMetric(c.conns, prometheus.GaugeValue, float64(stats.TotalConns), "total")
+	ch <- prometheus.MustNewConstMetric(c.conns, prometheus.GaugeValue, float64(stats.IdleConns), "idle")
+}
+
+// taskQueueCollector collects the metrics of the task queues
+type taskQueueCollector struct {
+	inspector *asynq.Inspector
+	size      *prometheus.Desc
+	tasks     *prometheus.Desc
+	latency   *prometheus.Desc
+	processed *prometheus.Desc
+	failed    *prometheus.Desc
+}
+
+func newTaskQueueCollector(inspector *asynq.Inspector) *taskQueueCollector {
+	desc := func(name, help string, labels ...string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(metricsNamespace, "tasks_queue", name), help, labels, nil)
+	}
+
+	return &taskQueueCollector{
+		inspector: inspector,
+		size:      desc("size", "The number of tasks in the queue.", "queue"),
+		tasks:     desc("tasks", "The number of tasks in the queue by state.", "queue", "state"),
+		latency:   desc("latency_seconds", "How long the oldest pending task has been waiting in the queue.", "queue"),
+		processed: desc("processed_total", "The number of tasks processed from the queue by all the workers.", "queue"),
+		failed:    desc("failed_total", "The number of tasks from the queue which failed to be processed.", "queue"),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *taskQueueCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.size
+	ch <- c.tasks
+	ch <- c.latency
+	ch <- c.processed
+	ch <- c.failed
+}
+
+// Collect implements prometheus.Collector
+func (c *taskQueueCollector) Collect(ch chan<- prometheus.Metric) {
+	queues, err := c.inspector.Queues()
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(c.size, err)
+		return
+	}
+
+	for _, queue := range queues {
+		info, err := c.inspector.GetQueueInfo(queue)
+		if err != nil {
+			ch <- prometheus.NewInvalidMetric(c.size, err)
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(c.size, prometheus.GaugeValue, float64(info.Size), queue)
+		ch <- prometheus.MustNewConstMetric(c.latency, prometheus.GaugeValue, info.Latency.Seconds(), queue)
+		ch <- prometheus.MustNewConstMetric(c.processed, prometheus.CounterValue, float64(info.ProcessedTotal), queue)
+		ch <- prometheus.MustNewConstMetric(c.failed, prometheus.CounterValue, float64(info.FailedTotal), queue)
+
+		for state, count := range map[string]int{
+			"pending":   info.Pending,
+			"active":    info.Active,
+			"scheduled": info.Scheduled,
+			"retry":     info.Retry,
+			"archived":  info.Archived,
+			"completed": info.Completed,
+		} {
+			ch <- prometheus.MustNewConstMetric(c.tasks, prometheus.GaugeValue, float64(count), queue, state)
+		}
+	}
+}
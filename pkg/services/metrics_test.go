@@ -0,0 +1,62 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsClient_Observe(t *testing.T) {
+	m := NewMetricsClient()
+	m.ObserveRequest("pages.get", http.MethodGet, http.StatusOK, time.Millisecond)
+	m.ObserveRequest("pages.get", http.MethodGet, http.StatusOK, time.Millisecond)
+	m.ObserveRequest("pages.get", http.MethodGet, http.StatusNotFound, time.Millisecond)
+	m.ObserveTask("example", nil, time.Millisecond)
+	m.ObserveTask("example", errors.New("failed"), time.Millisecond)
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(m.requests.WithLabelValues("pages.get", http.MethodGet, "200")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.requests.WithLabelValues("pages.get", http.MethodGet, "404")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.tasks.WithLabelValues("example", "succeeded")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.tasks.WithLabelValues("example", "failed")))
+}
+
+func TestMetricsClient_Handler(t *testing.T) {
+	require.NoError(t, c.Tasks.New("metrics_test").Save())
+
+	rec := httptest.NewRecorder()
+	c.Metrics.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	body := rec.Body.String()
+	assert.Contains(t, body, `go_sql_open_connections{db_name="app"}`)
+	assert.Contains(t, body, `app_cache_pool_connections{state="total"}`)
+	assert.Contains(t, body, `app_tasks_queue_size{queue="default"}`)
+}
+
+func TestMetricsClient_ProtectedHandler(t *testing.T) {
+	m := NewMetricsClient()
+
+	scrape := func(handler http.Handler, auth string) int {
+		req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	handler := m.ProtectedHandler("secret")
+	assert.Equal(t, http.StatusUnauthorized, scrape(handler, ""))
+	assert.Equal(t, http.StatusUnauthorized, scrape(handler, "Bearer wrong"))
+	assert.Equal(t, http.StatusOK, scrape(handler, "Bearer secret"))
+
+	// Without a token the metrics are public
+	assert.Equal(t, http.StatusOK, scrape(m.ProtectedHandler(""), ""))
+}
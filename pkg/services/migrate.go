@@ -0,0 +1,47 @@
+package services
+
+import (
+	"context"
+
+	"ariga.io/atlas/sql/migrate"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql/schema"
+
+	"github.com/vovanwin/api-my-site/ent"
+)
+
+// MigrationPlan returns the SQL statements which migrating the database to the ORM schema would run, without
+// running them. If drop is set, columns and indexes which aren't in the ORM schema are dropped as well
+func MigrationPlan(ctx context.Context, orm *ent.Client, drop bool) ([]string, error) {
+	var statements []string
+	opts := append(migrationOptions(drop), schema.WithApplyHook(func(next schema.Applier) schema.Applier {
+		return schema.ApplyFunc(func(ctx context.Context, conn dialect.ExecQuerier, plan *migrate.Plan) error {
+			for _, change := range plan.Changes {
+				statements = append(statements, change.Cmd)
+			}
+			return nil
+		})
+	}))
+
+	if err := orm.Schema.Create(ctx, opts...); err != nil {
+		return nil, err
+	}
+
+	return statements, nil
+}
+
+// Migrate migrates the database to the ORM schema
+// Tables and columns are only ever added, unless drop is set, in which case columns and indexes which aren't
+// in the ORM schema of the running build are dropped along with their data
+func Migrate(ctx context.Context, orm *ent.Client, drop bool) error {
+	return orm.Schema.Create(ctx, migrationOptions(drop)...)
+}
+
+// migrationOptions returns the options the schema is migrated with
+func migrationOptions(drop bool) []schema.MigrateOption {
+	return []schema.MigrateOption{
+		schema.WithAtlas(true),
+		schema.WithDropColumn(drop),
+		schema.WithDropIndex(drop),
+	}
+}
@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+
+	"github.com/vovanwin/api-my-site/config"
+	"github.com/vovanwin/api-my-site/pkg/logging"
+)
+
+const (
+	// SettingsReloadOK indicates that the settings were reloaded
+	SettingsReloadOK = "ok"
+
+	// SettingsReloadFail indicates that the settings could not be reloaded and the previous ones are kept
+	SettingsReloadFail = "fail"
+
+	// settingsReloadDelay stores how long the config files must be left unchanged before they're reloaded,
+	// since editors often write a file in several steps
+	settingsReloadDelay = 100 * time.Millisecond
+)
+
+// SettingsReload describes the result of reloading the settings
+type SettingsReload struct {
+	Time            time.Time `json:"time"`
+	Status          string    `json:"status"`
+	Error           string    `json:"error,omitempty"`
+	Changed         []string  `json:"changed,omitempty"`
+	RestartRequired []string  `json:"restart_required,omitempty"`
+}
+
+// SettingsClient holds a snapshot of the configuration whose reloadable values are updated while the application
+// is running. Code that depends on reloadable values should read them from Current on every use, or subscribe to
+// changes, rather than from the configuration the container was created with
+type SettingsClient struct {
+	// current stores the *config.Config snapshot, which is replaced but never modified
+	current atomic.Value
+
+	// mu serializes reloads and guards the fields below
+	mu sync.Mutex
+
+	// subscribers contains the functions called with the new snapshot whenever the settings change
+	subscribers []func(ctx context.Context, cfg *config.Config)
+
+	// last stores the result of the last reload, if there was one
+	last *SettingsReload
+
+	// timer delays reloads until the config files are no longer changing
+	timer *time.Timer
+}
+
+// NewSettingsClient creates a new SettingsClient
+func NewSettingsClient(cfg *config.Config) *SettingsClient {
+	s := new(SettingsClient)
+	snapshot := *cfg
+	s.current.Store(&snapshot)
+	return s
+}
+
+// Current returns the current snapshot of the configuration, which must not be modified
+func (s *SettingsClient) Current() *config.Config {
+	return s.current.Load().(*config.Config)
+}
+
+// Subscribe registers a function which is called with the new snapshot whenever a reload changes the settings
+func (s *SettingsClient) Subscribe(fn func(ctx context.Context, cfg *config.Config)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.subscribers = append(s.subscribers, fn)
+}
+
+// LastReload returns the result of the last reload, or nil if the settings haven't been reloaded
+func (s *SettingsClient) LastReload() *SettingsReload {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.last == nil {
+		return nil
+	}
+	last := *s.last
+	return &last
+}
+
+// Reload loads the configuration again and, if it's valid, replaces the snapshot and notifies the subscribers
+// of any changes to the reloadable values. Changes to the other values are logged, since they require a restart
+func (s *SettingsClient) Reload(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	next, changed, restart, err := config.Reload(*s.Current())
+	s.last = &SettingsReload{
+		Time:            time.Now(),
+		Status:          SettingsReloadOK,
+		Changed:         changed,
+		RestartRequired: restart,
+	}
+
+	if err != nil {
+		s.last.Status = SettingsReloadFail
+		s.last.Error = err.Error()
+		logging.Errorf(ctx, "failed to reload settings, keeping the current ones: %v", err)
+		return err
+	}
+
+	if len(restart) > 0 {
+		logging.Warningf(ctx, "settings changed which require a restart: %v", restart)
+	}
+
+	if len(changed) == 0 {
+		return nil
+	}
+
+	s.current.Store(&next)
+	for _, fn := range s.subscribers {
+		fn(ctx, &next)
+	}
+	logging.Infof(ctx, "settings reloaded: %v", changed)
+
+	return nil
+}
+
+// Watch reloads the settings whenever one of the config files changes or the process receives SIGHUP
+func (s *SettingsClient) Watch() error {
+	files, err := config.Files()
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		v := viper.New()
+		v.SetConfigFile(file)
+		v.OnConfigChange(func(fsnotify.Event) {
+			s.scheduleReload()
+		})
+		v.WatchConfig()
+	}
+
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		for range hup {
+			_ = s.Reload(context.Background())
+		}
+	}()
+
+	return nil
+}
+
+// scheduleReload reloads the settings once the config files have been left unchanged for a moment
+func (s *SettingsClient) scheduleReload() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.timer != nil {
+		s.timer.Stop()
+	}
+	s.timer = time.AfterFunc(settingsReloadDelay, func() {
+		_ = s.Reload(context.Background())
+	})
+}
@@ -0,0 +1,45 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vovanwin/api-my-site/config"
+)
+
+func TestSettingsClient_Reload(t *testing.T) {
+	cfg, err := config.GetConfig()
+	require.NoError(t, err)
+
+	s := NewSettingsClient(&cfg)
+	assert.Nil(t, s.LastReload())
+
+	var notified *config.Config
+	s.Subscribe(func(ctx context.Context, cfg *config.Config) {
+		notified = cfg
+	})
+
+	// Nothing changed
+	require.NoError(t, s.Reload(context.Background()))
+	assert.Nil(t, notified)
+	assert.Equal(t, SettingsReloadOK, s.LastReload().Status)
+
+	// A reloadable value changed
+	t.Setenv("PAGODA_LOGGING_LEVEL", "warn")
+	require.NoError(t, s.Reload(context.Background()))
+	require.NotNil(t, notified)
+	assert.Equal(t, "warn", notified.Logging.Level)
+	assert.Equal(t, notified, s.Current())
+	assert.Equal(t, []string{"logging.level"}, s.LastReload().Changed)
+	assert.Equal(t, "info", cfg.Logging.Level)
+
+	// An invalid value keeps the current settings
+	t.Setenv("PAGODA_LOGGING_LEVEL", "loud")
+	require.Error(t, s.Reload(context.Background()))
+	assert.Equal(t, SettingsReloadFail, s.LastReload().Status)
+	assert.NotEmpty(t, s.LastReload().Error)
+	assert.Equal(t, "warn", s.Current().Logging.Level)
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/vovanwin/api-my-site/config"
+)
+
+const (
+	// StorageDriverLocal stores the name of the local filesystem storage driver
+	StorageDriverLocal = "local"
+
+	// StorageDriverS3 stores the name of the S3-compatible storage driver
+	StorageDriverS3 = "s3"
+)
+
+// StorageObjectNotFoundError is an error returned when an object does not exist in the storage
+type StorageObjectNotFoundError struct {
+	Key string
+}
+
+// Error implements the error interface.
+func (e StorageObjectNotFoundError) Error() string {
+	return fmt.Sprintf("storage object not found: %s", e.Key)
+}
+
+// Storage is the interface that media storage backends implement
+type Storage interface {
+	// Put stores the contents of the reader under the given key, replacing any existing object
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+
+	// Get opens the object stored under the given key
+	// A StorageObjectNotFoundError is returned if the object does not exist
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Delete removes the object stored under the given key
+	// Deleting an object that does not exist is not an error
+	Delete(ctx context.Context, key string) error
+}
+
+// NewStorage creates the storage backend selected in the configuration
+func NewStorage(cfg *config.Config) (Storage, error) {
+	switch cfg.Storage.Driver {
+	case StorageDriverLocal, "":
+		return NewLocalStorage(cfg.Storage.Local.Directory)
+	case StorageDriverS3:
+		return NewS3Storage(S3StorageConfig{
+			Endpoint:  cfg.Storage.S3.Endpoint,
+			Region:    cfg.Storage.S3.Region,
+			Bucket:    cfg.Storage.S3.Bucket,
+			AccessKey: cfg.Storage.S3.AccessKey,
+			SecretKey: cfg.Storage.S3.SecretKey,
+			UseSSL:    cfg.Storage.S3.UseSSL,
+		})
+	default:
+		return nil, fmt.Errorf("unknown storage driver: %s", cfg.Storage.Driver)
+	}
+}
+
+// cleanStorageKey normalizes a storage key and rejects keys that would escape the storage root
+func cleanStorageKey(key string) (string, error) {
+	k := strings.TrimPrefix(path.Clean("/"+key), "/")
+	if k == "" || k != strings.TrimPrefix(key, "/") {
+		return "", fmt.Errorf("invalid storage key: %q", key)
+	}
+	return k, nil
+}
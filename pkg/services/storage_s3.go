@@ -0,0 +1,232 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// s3UnsignedPayload is used as the payload hash so uploads can be streamed without buffering
+	s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+
+	// s3EmptyPayload is the SHA-256 hash of an empty request body
+	s3EmptyPayload = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+// S3StorageConfig stores the connection settings of an S3-compatible storage
+type S3StorageConfig struct {
+	// Endpoint stores the host (and optional port) of the service, ie "localhost:9000"
+	Endpoint string
+
+	// Region stores the region used for request signing
+	Region string
+
+	// Bucket stores the name of the bucket media is stored in
+	Bucket string
+
+	// AccessKey stores the access key ID
+	AccessKey string
+
+	// SecretKey stores the secret access key
+	SecretKey string
+
+	// UseSSL indicates if HTTPS should be used
+	UseSSL bool
+}
+
+// S3Storage stores media in an S3-compatible object storage such as AWS S3 or MinIO
+// Requests are signed with AWS Signature Version 4 and use path-style addressing, which every
+// S3-compatible service supports
+type S3Storage struct {
+	config S3StorageConfig
+
+	// Client stores the HTTP client used to talk to the service
+	Client *http.Client
+
+	// now returns the current time and is overridable for tests
+	now func() time.Time
+}
+
+// NewS3Storage creates a new S3-compatible storage
+func NewS3Storage(cfg S3StorageConfig) (*S3Storage, error) {
+	if cfg.Endpoint == "" || cfg.Bucket == "" {
+		return nil, errors.New("s3 storage requires an endpoint and a bucket")
+	}
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+
+	return &S3Storage{
+		config: cfg,
+		Client: http.DefaultClient,
+		now:    time.Now,
+	}, nil
+}
+
+// Put uploads the contents of the reader under the given key
+func (s *S3Storage) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	req, err := s.newRequest(ctx, http.MethodPut, key, r)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := s.do(req, s3UnsignedPayload)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// Get downloads the object stored under the given key
+func (s *S3Storage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := s.newRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.do(req, s3EmptyPayload)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// Delete removes the object stored under the given key
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	req, err := s.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.do(req, s3EmptyPayload)
+	switch err.(type) {
+	case nil:
+		return resp.Body.Close()
+	case StorageObjectNotFoundError:
+		return nil
+	default:
+		return err
+	}
+}
+
+// newRequest builds a request for the object stored under the given key
+func (s *S3Storage) newRequest(ctx context.Context, method, key string, body io.Reader) (*http.Request, error) {
+	k, err := cleanStorageKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	scheme := "http"
+	if s.config.UseSSL {
+		scheme = "https"
+	}
+
+	u := url.URL{
+		Scheme: scheme,
+		Host:   s.config.Endpoint,
+		Path:   fmt.Sprintf("/%s/%s", s.config.Bucket, k),
+	}
+	return http.NewRequestWithContext(ctx, method, u.String(), body)
+}
+
+// do signs and executes a request, converting error responses to errors
+func (s *S3Storage) do(req *http.Request, payloadHash string) (*http.Response, error) {
+	s.sign(req, payloadHash)
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		_ = resp.Body.Close()
+		return nil, StorageObjectNotFoundError{Key: strings.TrimPrefix(req.URL.Path, "/"+s.config.Bucket+"/")}
+	case resp.StatusCode >= http.StatusMultipleChoices:
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("s3 request failed with status %d: %s", resp.StatusCode, msg)
+	}
+
+	return resp, nil
+}
+
+// sign adds an AWS Signature Version 4 authorization header to the request
+func (s *S3Storage) sign(req *http.Request, payloadHash string) {
+	now := s.now().UTC()
+	date := now.Format("20060102")
+	timestamp := now.Format("20060102T150405Z")
+
+	req.Header.Set("Host", req.URL.Host)
+	req.Header.Set("X-Amz-Date", timestamp)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	// Build the canonical headers, which must be sorted and lowercase
+	names := make([]string, 0, len(req.Header))
+	for name := range req.Header {
+		names = append(names, strings.ToLower(name))
+	}
+	sort.Strings(names)
+
+	var headers strings.Builder
+	for _, name := range names {
+		headers.WriteString(name)
+		headers.WriteString(":")
+		headers.WriteString(strings.TrimSpace(req.Header.Get(name)))
+		headers.WriteString("\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.Query().Encode(),
+		headers.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := fmt.Sprintf("%s/%s/s3/aws4_request", date, s.config.Region)
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		timestamp,
+		scope,
+		hex.EncodeToString(requestHash[:]),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.config.SecretKey), date)
+	key = hmacSHA256(key, s.config.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.config.AccessKey,
+		scope,
+		signedHeaders,
+		signature,
+	))
+}
+
+// hmacSHA256 computes an HMAC-SHA256 of the data with the given key
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
@@ -1,14 +1,21 @@
 package services
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"time"
 
 	"github.com/hibiken/asynq"
 	"github.com/vovanwin/api-my-site/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// TaskStates contains the states tasks can be listed in
+var TaskStates = []string{"pending", "active", "scheduled", "retry", "archived", "completed"}
+
 type (
 	// TaskClient is that client that allows you to queue or schedule task execution
 	TaskClient struct {
@@ -17,11 +24,15 @@ type (
 
 		// scheduler stores the asynq scheduler
 		scheduler *asynq.Scheduler
+
+		// inspector stores the asynq inspector used to inspect the queues and collect their metrics
+		inspector *asynq.Inspector
 	}
 
 	// task handles task creation operations
 	task struct {
 		client     *TaskClient
+		ctx        context.Context
 		typ        string
 		payload    interface{}
 		periodic   *string
@@ -52,11 +63,15 @@ func NewTaskClient(cfg *config.Config) *TaskClient {
 	return &TaskClient{
 		client:    asynq.NewClient(conn),
 		scheduler: asynq.NewScheduler(conn, nil),
+		inspector: asynq.NewInspector(conn),
 	}
 }
 
 // Close closes the connection to the task service
 func (t *TaskClient) Close() error {
+	if err := t.inspector.Close(); err != nil {
+		return err
+	}
 	return t.client.Close()
 }
 
@@ -66,6 +81,43 @@ func (t *TaskClient) StartScheduler() error {
 	return t.scheduler.Run()
 }
 
+// Queues returns the names of the task queues
+func (t *TaskClient) Queues() ([]string, error) {
+	return t.inspector.Queues()
+}
+
+// List returns up to limit tasks of the queue in the given state, which is one of TaskStates
+func (t *TaskClient) List(queue, state string, limit int) ([]*asynq.TaskInfo, error) {
+	opts := []asynq.ListOption{asynq.PageSize(limit)}
+
+	switch state {
+	case "pending":
+		return t.inspector.ListPendingTasks(queue, opts...)
+	case "active":
+		return t.inspector.ListActiveTasks(queue, opts...)
+	case "scheduled":
+		return t.inspector.ListScheduledTasks(queue, opts...)
+	case "retry":
+		return t.inspector.ListRetryTasks(queue, opts...)
+	case "archived":
+		return t.inspector.ListArchivedTasks(queue, opts...)
+	case "completed":
+		return t.inspector.ListCompletedTasks(queue, opts...)
+	default:
+		return nil, fmt.Errorf("unknown task state: %s", state)
+	}
+}
+
+// Retry runs a scheduled, retrying or archived task of the queue right away
+func (t *TaskClient) Retry(queue, id string) error {
+	return t.inspector.RunTask(queue, id)
+}
+
+// RetryArchived runs all the archived tasks of the queue right away and returns how many there were
+func (t *TaskClient) RetryArchived(queue string) (int, error) {
+	return t.inspector.RunAllArchivedTasks(queue)
+}
+
 // New starts a task creation operation
 func (t *TaskClient) New(typ string) *task {
 	return &task{
@@ -80,6 +132,12 @@ func (t *task) Payload(payload interface{}) *task {
 	return t
 }
 
+// Context sets the context the task is created in, so the trace of the context is continued when the task is executed
+func (t *task) Context(ctx context.Context) *task {
+	t.ctx = ctx
+	return t
+}
+
 // Periodic sets the task to execute periodically according to a given interval
 // The interval can be either in cron form ("*/5 * * * *") or "@every 30s"
 func (t *task) Periodic(interval string) *task {
@@ -142,6 +200,18 @@ func (t *task) Save() error {
 		}
 	}
 
+	// Carry the trace over to the worker
+	if t.ctx != nil {
+		ctx, span := otel.Tracer(tracerName).Start(t.ctx, "enqueue "+t.typ,
+			trace.WithSpanKind(trace.SpanKindProducer),
+			trace.WithAttributes(attribute.String("asynq.task.type", t.typ)),
+		)
+		defer func() {
+			endSpan(span, err)
+		}()
+		payload = InjectTaskTrace(ctx, payload)
+	}
+
 	// Build the task options
 	opts := make([]asynq.Option, 0)
 	if t.queue != nil {
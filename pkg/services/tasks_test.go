@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestTaskClient_New(t *testing.T) {
@@ -33,3 +34,25 @@ func TestTaskClient_New(t *testing.T) {
 	assert.Equal(t, 7*time.Second, *tk.retain)
 	assert.NoError(t, tk.Save())
 }
+
+func TestTaskClient_List(t *testing.T) {
+	require.NoError(t, c.Tasks.New("task_list").Queue("list").Wait(time.Hour).Save())
+
+	tasks, err := c.Tasks.List("list", "scheduled", 10)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "task_list", tasks[0].Type)
+
+	queues, err := c.Tasks.Queues()
+	require.NoError(t, err)
+	assert.Contains(t, queues, "list")
+
+	// Running it moves it to the pending tasks
+	require.NoError(t, c.Tasks.Retry("list", tasks[0].ID))
+	tasks, err = c.Tasks.List("list", "pending", 10)
+	require.NoError(t, err)
+	assert.Len(t, tasks, 1)
+
+	_, err = c.Tasks.List("list", "unknown", 10)
+	assert.Error(t, err)
+}
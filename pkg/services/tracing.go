@@ -0,0 +1,363 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"entgo.io/ent/dialect"
+	"github.com/go-redis/redis/v8"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/vovanwin/api-my-site/config"
+)
+
+const (
+	// tracerName stores the name of the tracer the application spans are created with
+	tracerName = "github.com/vovanwin/api-my-site"
+
+	// TaskTraceKey stores the key of the trace context injected into task payloads
+	TaskTraceKey = "_trace"
+
+	// TracingExporterOTLP exports spans to an OTLP collector over HTTP
+	TracingExporterOTLP = "otlp"
+
+	// TracingExporterStdout writes spans to the standard output
+	TracingExporterStdout = "stdout"
+
+	// TracingExporterMemory keeps spans in memory, which is used by the tests
+	TracingExporterMemory = "memory"
+)
+
+// TracingClient traces requests, database queries, cache calls and tasks with OpenTelemetry
+// The trace context is propagated in the W3C Trace Context format
+type TracingClient struct {
+	// Recorded stores the exported spans when they are kept in memory, such as in tests
+	Recorded *tracetest.InMemoryExporter
+
+	// provider stores the tracer provider, which is nil if tracing is disabled
+	provider *sdktrace.TracerProvider
+
+	// tracer stores the tracer the application spans are created with
+	tracer trace.Tracer
+}
+
+// NewTracingClient creates a new TracingClient and installs it as the global tracer provider and propagator
+// Spans are kept in memory in the test environment, and nothing is traced if tracing is disabled otherwise
+func NewTracingClient(cfg *config.Config) (*TracingClient, error) {
+	t := &TracingClient{}
+
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	exporter := cfg.Tracing.Exporter
+	if cfg.App.Environment == config.EnvTest {
+		exporter = TracingExporterMemory
+	} else if !cfg.Tracing.Enabled {
+		t.tracer = trace.NewNoopTracerProvider().Tracer(tracerName)
+		return t, nil
+	}
+
+	var spanExporter sdktrace.SpanExporter
+	var err error
+	switch exporter {
+	case TracingExporterOTLP:
+		opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.Tracing.Endpoint)}
+		if cfg.Tracing.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		spanExporter, err = otlptracehttp.New(context.Background(), opts...)
+	case TracingExporterStdout:
+		spanExporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case TracingExporterMemory:
+		t.Recorded = tracetest.NewInMemoryExporter()
+		spanExporter = t.Recorded
+	default:
+		err = fmt.Errorf("unknown tracing exporter: %s", exporter)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		semconv.ServiceName(cfg.App.Name),
+		attribute.String("deployment.environment", string(cfg.App.Environment)),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.Tracing.SampleRatio))),
+	}
+	if t.Recorded != nil {
+		// Export the spans as soon as they end, so tests can inspect them straight away
+		opts = append(opts, sdktrace.WithSyncer(spanExporter), sdktrace.WithSampler(sdktrace.AlwaysSample()))
+	} else {
+		opts = append(opts, sdktrace.WithBatcher(spanExporter))
+	}
+
+	t.provider = sdktrace.NewTracerProvider(opts...)
+	t.tracer = t.provider.Tracer(tracerName)
+	otel.SetTracerProvider(t.provider)
+
+	return t, nil
+}
+
+// Tracer returns the tracer the application spans are created with
+func (t *TracingClient) Tracer() trace.Tracer {
+	return t.tracer
+}
+
+// Shutdown exports the remaining spans and stops tracing
+func (t *TracingClient) Shutdown(ctx context.Context) error {
+	if t.provider == nil {
+		return nil
+	}
+	return t.provider.Shutdown(ctx)
+}
+
+// Driver wraps an ORM driver so that every database query is traced as a child of the span in its context
+func (t *TracingClient) Driver(drv dialect.Driver) dialect.Driver {
+	return &tracedDriver{Driver: drv, tracer: t.tracer}
+}
+
+// RedisHook returns a Redis client hook which traces every command as a child of the span in its context
+func (t *TracingClient) RedisHook() redis.Hook {
+	return &redisTracingHook{tracer: t.tracer}
+}
+
+// InjectTaskTrace adds the trace context of the span in the context to a JSON object task payload,
+// so the worker can continue the trace when it processes the task
+// Payloads which aren't JSON objects are returned as is
+func InjectTaskTrace(ctx context.Context, payload []byte) []byte {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return payload
+	}
+
+	fields := make(map[string]json.RawMessage)
+	if len(payload) > 0 {
+		if err := json.Unmarshal(payload, &fields); err != nil {
+			return payload
+		}
+	}
+
+	tc, err := json.Marshal(carrier)
+	if err != nil {
+		return payload
+	}
+	fields[TaskTraceKey] = tc
+
+	traced, err := json.Marshal(fields)
+	if err != nil {
+		return payload
+	}
+	return traced
+}
+
+// ExtractTaskTrace returns a copy of the context carrying the trace context injected into a task payload
+// by InjectTaskTrace
+func ExtractTaskTrace(ctx context.Context, payload []byte) context.Context {
+	var fields struct {
+		Trace propagation.MapCarrier `json:"_trace"`
+	}
+	if err := json.Unmarshal(payload, &fields); err != nil || fields.Trace == nil {
+		return ctx
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, fields.Trace)
+}
+
+// dbSystem returns the database system attribute of an ORM dialect
+func dbSystem(name string) attribute.KeyValue {
+	switch name {
+	case dialect.Postgres:
+		return semconv.DBSystemPostgreSQL
+	default:
+		return semconv.DBSystemKey.String(name)
+	}
+}
+
+// endSpan records the error, if any, and ends the span
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// tracedDriver traces the queries executed by an ORM driver
+type tracedDriver struct {
+	dialect.Driver
+	tracer trace.Tracer
+}
+
+// Exec implements dialect.Driver
+func (d *tracedDriver) Exec(ctx context.Context, query string, args, v any) error {
+	ctx, span := d.start(ctx, query)
+	err := d.Driver.Exec(ctx, query, args, v)
+	endSpan(span, err)
+	return err
+}
+
+// Query implements dialect.Driver
+func (d *tracedDriver) Query(ctx context.Context, query string, args, v any) error {
+	ctx, span := d.start(ctx, query)
+	err := d.Driver.Query(ctx, query, args, v)
+	endSpan(span, err)
+	return err
+}
+
+// Tx implements dialect.Driver
+func (d *tracedDriver) Tx(ctx context.Context) (dialect.Tx, error) {
+	tx, err := d.Driver.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &tracedTx{Tx: tx, driver: d}, nil
+}
+
+// BeginTx starts a transaction with options, if the wrapped driver supports them
+func (d *tracedDriver) BeginTx(ctx context.Context, opts *sql.TxOptions) (dialect.Tx, error) {
+	drv, ok := d.Driver.(interface {
+		BeginTx(context.Context, *sql.TxOptions) (dialect.Tx, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("driver.BeginTx is not supported")
+	}
+
+	tx, err := drv.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &tracedTx{Tx: tx, driver: d}, nil
+}
+
+// ExecContext executes a raw statement, if the wrapped driver supports it
+func (d *tracedDriver) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return execContext(ctx, d.Driver, d, query, args)
+}
+
+func (d *tracedDriver) start(ctx context.Context, query string) (context.Context, trace.Span) {
+	name := query
+	if i := strings.IndexByte(query, ' '); i > 0 {
+		name = query[:i]
+	}
+
+	return d.tracer.Start(ctx, "db "+strings.ToUpper(name),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			dbSystem(d.Dialect()),
+			semconv.DBStatement(query),
+		),
+	)
+}
+
+// tracedTx traces the queries executed in a transaction
+type tracedTx struct {
+	dialect.Tx
+	driver *tracedDriver
+}
+
+// Exec implements dialect.Tx
+func (t *tracedTx) Exec(ctx context.Context, query string, args, v any) error {
+	ctx, span := t.driver.start(ctx, query)
+	err := t.Tx.Exec(ctx, query, args, v)
+	endSpan(span, err)
+	return err
+}
+
+// Query implements dialect.Tx
+func (t *tracedTx) Query(ctx context.Context, query string, args, v any) error {
+	ctx, span := t.driver.start(ctx, query)
+	err := t.Tx.Query(ctx, query, args, v)
+	endSpan(span, err)
+	return err
+}
+
+// ExecContext executes a raw statement in the transaction, if the wrapped transaction supports it
+func (t *tracedTx) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return execContext(ctx, t.Tx, t.driver, query, args)
+}
+
+// execContext traces a raw statement executed with ExecContext of the wrapped driver or transaction
+func execContext(ctx context.Context, wrapped any, d *tracedDriver, query string, args []any) (sql.Result, error) {
+	ex, ok := wrapped.(interface {
+		ExecContext(context.Context, string, ...any) (sql.Result, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("ExecContext is not supported")
+	}
+
+	ctx, span := d.start(ctx, query)
+	res, err := ex.ExecContext(ctx, query, args...)
+	endSpan(span, err)
+	return res, err
+}
+
+// redisTracingHook traces the commands sent by a Redis client
+type redisTracingHook struct {
+	tracer trace.Tracer
+}
+
+// BeforeProcess implements redis.Hook
+func (h *redisTracingHook) BeforeProcess(ctx context.Context, cmd redis.Cmder) (context.Context, error) {
+	ctx, _ = h.tracer.Start(ctx, "redis "+cmd.FullName(),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemRedis),
+	)
+	return ctx, nil
+}
+
+// AfterProcess implements redis.Hook
+func (h *redisTracingHook) AfterProcess(ctx context.Context, cmd redis.Cmder) error {
+	err := cmd.Err()
+	if err == redis.Nil {
+		err = nil
+	}
+	endSpan(trace.SpanFromContext(ctx), err)
+	return nil
+}
+
+// BeforeProcessPipeline implements redis.Hook
+func (h *redisTracingHook) BeforeProcessPipeline(ctx context.Context, cmds []redis.Cmder) (context.Context, error) {
+	ctx, _ = h.tracer.Start(ctx, "redis pipeline",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemRedis,
+			attribute.Int("db.redis.num_cmd", len(cmds)),
+		),
+	)
+	return ctx, nil
+}
+
+// AfterProcessPipeline implements redis.Hook
+func (h *redisTracingHook) AfterProcessPipeline(ctx context.Context, cmds []redis.Cmder) error {
+	var err error
+	for _, cmd := range cmds {
+		if cmdErr := cmd.Err(); cmdErr != nil && cmdErr != redis.Nil {
+			err = cmdErr
+			break
+		}
+	}
+	endSpan(trace.SpanFromContext(ctx), err)
+	return nil
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/vovanwin/api-my-site/config"
+)
+
+func TestTaskTrace(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.App.Environment = config.EnvTest
+	client, err := NewTracingClient(cfg)
+	require.NoError(t, err)
+
+	payload := []byte(`{"user_id":1}`)
+	assert.Equal(t, payload, InjectTaskTrace(context.Background(), payload))
+
+	ctx, span := client.Tracer().Start(context.Background(), "test")
+	defer span.End()
+
+	traced := InjectTaskTrace(ctx, payload)
+	var fields map[string]interface{}
+	require.NoError(t, json.Unmarshal(traced, &fields))
+	assert.EqualValues(t, 1, fields["user_id"])
+	assert.Contains(t, fields, TaskTraceKey)
+
+	// Payloads which aren't objects can't carry the trace
+	assert.Equal(t, []byte(`"payload"`), InjectTaskTrace(ctx, []byte(`"payload"`)))
+
+	resumed := trace.SpanContextFromContext(ExtractTaskTrace(context.Background(), traced))
+	assert.True(t, resumed.IsRemote())
+	assert.Equal(t, span.SpanContext().TraceID(), resumed.TraceID())
+	assert.Equal(t, span.SpanContext().SpanID(), resumed.SpanID())
+
+	assert.False(t, trace.SpanContextFromContext(ExtractTaskTrace(context.Background(), payload)).IsValid())
+}
+
+func TestTracingClient_Spans(t *testing.T) {
+	require.NotNil(t, c.Tracing.Recorded)
+	c.Tracing.Recorded.Reset()
+
+	ctx, span := c.Tracing.Tracer().Start(context.Background(), "test")
+	_, err := c.ORM.User.Query().Count(ctx)
+	require.NoError(t, err)
+	require.NoError(t, c.Cache.Client.Set(ctx, "tracing", "1", 0).Err())
+	span.End()
+
+	children := make(map[string]tracetest.SpanStub)
+	for _, s := range c.Tracing.Recorded.GetSpans() {
+		if s.Parent.SpanID() == span.SpanContext().SpanID() {
+			children[s.Name] = s
+		}
+	}
+	assert.Contains(t, children, "db SELECT")
+	assert.Contains(t, children, "redis set")
+}
@@ -1,26 +1,299 @@
 package services
 
 import (
+	"bufio"
+	"context"
+	_ "embed"
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode"
+
 	"github.com/go-playground/validator/v10"
+
+	"github.com/vovanwin/api-my-site/ent"
+	"github.com/vovanwin/api-my-site/ent/user"
+	"github.com/vovanwin/api-my-site/pkg/i18n"
+	"github.com/vovanwin/api-my-site/pkg/logging"
+)
+
+const (
+	// PasswordMinLength stores the minimum length of a password
+	PasswordMinLength = 8
+
+	// PasswordMaxLength stores the maximum length of a password in bytes, which is all bcrypt uses
+	PasswordMaxLength = 72
+
+	// passwordMinClasses stores how many of the character classes (lowercase, uppercase, digits and
+	// symbols) a password must contain
+	passwordMinClasses = 3
+)
+
+var (
+	// SlugPattern matches lowercase words of latin letters and digits separated by single hyphens
+	SlugPattern = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+	// PhonePattern matches phone numbers in the E.164 format
+	PhonePattern = regexp.MustCompile(`^\+[1-9][0-9]{7,14}$`)
+
+	//go:embed common_passwords.txt
+	commonPasswordList string
+
+	// commonPasswords stores the bundled list of common passwords, lowercased
+	commonPasswords = parseCommonPasswords(commonPasswordList)
 )
 
 // Validator provides validation mainly validating structs within the web context
+//
+// Besides the built-in rules, the following domain rules are registered:
+//   - password: length, character classes and not being a common password
+//   - slug: lowercase latin letters and digits separated by hyphens
+//   - phone: a phone number in the E.164 format
+//   - locale: one of the locales supported by the i18n catalogs
+//   - unique_email: no user with the email exists, checked against the database
+//
+// Validation errors refer to fields by their json tag, falling back to the form tag and then the
+// struct field name, so the error keys match what clients send.
 type Validator struct {
 	// validator stores the underlying validator
 	validator *validator.Validate
+
+	// orm stores the ORM client used by rules which query the database
+	orm *ent.Client
 }
 
 // NewValidator creats a new Validator
-func NewValidator() *Validator {
-	return &Validator{
+func NewValidator(orm *ent.Client) *Validator {
+	v := &Validator{
 		validator: validator.New(),
+		orm:       orm,
 	}
+
+	v.validator.RegisterTagNameFunc(fieldName)
+	v.register("password", validatePassword)
+	v.register("slug", validateSlug)
+	v.register("phone", validatePhone)
+	v.register("locale", validateLocale)
+	v.registerCtx("unique_email", v.validateUniqueEmail)
+
+	return v
 }
 
 // Validate validates a struct
 func (v *Validator) Validate(i interface{}) error {
-	if err := v.validator.Struct(i); err != nil {
+	return v.ValidateCtx(context.Background(), i)
+}
+
+// ValidateCtx validates a struct using a context, which is passed to the rules querying the database
+func (v *Validator) ValidateCtx(ctx context.Context, i interface{}) error {
+	if err := v.validator.StructCtx(ctx, i); err != nil {
 		return err
 	}
 	return nil
 }
+
+// register registers a validation rule
+func (v *Validator) register(tag string, fn validator.Func) {
+	if err := v.validator.RegisterValidation(tag, fn); err != nil {
+		panic(fmt.Sprintf("failed to register validation rule %s: %v", tag, err))
+	}
+}
+
+// registerCtx registers a validation rule which uses the context
+func (v *Validator) registerCtx(tag string, fn validator.FuncCtx) {
+	if err := v.validator.RegisterValidationCtx(tag, fn); err != nil {
+		panic(fmt.Sprintf("failed to register validation rule %s: %v", tag, err))
+	}
+}
+
+// validateUniqueEmail checks that no user with the email exists
+// Database errors are logged and the value is considered valid, since saving the user will fail anyway
+func (v *Validator) validateUniqueEmail(ctx context.Context, fl validator.FieldLevel) bool {
+	exists, err := v.orm.User.
+		Query().
+		Where(user.Email(strings.ToLower(fl.Field().String()))).
+		Exist(ctx)
+
+	if err != nil {
+		logging.Errorf(ctx, "unable to check if the email is taken: %v", err)
+		return true
+	}
+
+	return !exists
+}
+
+// validatePassword checks the strength of a password
+func validatePassword(fl validator.FieldLevel) bool {
+	return IsStrongPassword(fl.Field().String())
+}
+
+// validateSlug checks the format of a slug
+func validateSlug(fl validator.FieldLevel) bool {
+	return SlugPattern.MatchString(fl.Field().String())
+}
+
+// validatePhone checks that a phone number is in the E.164 format
+func validatePhone(fl validator.FieldLevel) bool {
+	return PhonePattern.MatchString(fl.Field().String())
+}
+
+// validateLocale checks that a locale is supported
+func validateLocale(fl validator.FieldLevel) bool {
+	for _, locale := range i18n.Locales() {
+		if fl.Field().String() == locale {
+			return true
+		}
+	}
+	return false
+}
+
+// IsStrongPassword determines if a password is long enough, contains enough character classes and
+// is not one of the common passwords
+func IsStrongPassword(password string) bool {
+	if len([]rune(password)) < PasswordMinLength || len(password) > PasswordMaxLength {
+		return false
+	}
+
+	var lower, upper, digit, symbol int
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = 1
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			symbol = 1
+		}
+	}
+	if lower+upper+digit+symbol < passwordMinClasses {
+		return false
+	}
+
+	_, common := commonPasswords[strings.ToLower(password)]
+	return !common
+}
+
+// NormalizePhone converts a phone number to the E.164 format by removing formatting characters
+// Russian numbers starting with 8 are converted to the +7 country code
+func NormalizePhone(phone string) string {
+	var b strings.Builder
+	for i, r := range strings.TrimSpace(phone) {
+		switch {
+		case unicode.IsDigit(r):
+			b.WriteRune(r)
+		case r == '+' && i == 0:
+			b.WriteRune(r)
+		case strings.ContainsRune(" -().", r):
+		default:
+			// Leave values which aren't phone numbers for the validation to reject
+			return phone
+		}
+	}
+
+	n := b.String()
+	if n == "" {
+		return phone
+	}
+	if !strings.HasPrefix(n, "+") {
+		if len(n) == 11 && n[0] == '8' {
+			n = "7" + n[1:]
+		}
+		n = "+" + n
+	}
+	return n
+}
+
+// NormalizeURL trims a URL, adds the https scheme when it's missing and lowercases the scheme and host
+func NormalizeURL(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return raw
+	}
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return raw
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	return u.String()
+}
+
+// Normalize trims the string fields of a struct, unless tagged with `trim:"-"`, and applies the normalizers
+// listed in their normalize tags, such as `normalize:"lower"`. Supported normalizers are lower, phone
+// and url. Non-nil string pointers, such as the optional fields of partial updates, are normalized too.
+// Nothing happens unless a pointer to a struct is provided, since the fields can't be set otherwise
+func Normalize(i interface{}) {
+	rv := reflect.ValueOf(i)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return
+	}
+	rv = rv.Elem()
+	rt := rv.Type()
+
+	for n := 0; n < rt.NumField(); n++ {
+		sf := rt.Field(n)
+		field := rv.Field(n)
+		if field.Kind() == reflect.Ptr && field.Type().Elem().Kind() == reflect.String && !field.IsNil() {
+			field = field.Elem()
+		}
+		if field.Kind() != reflect.String || !field.CanSet() {
+			continue
+		}
+
+		value := field.String()
+		if sf.Tag.Get("trim") != "-" {
+			value = strings.TrimSpace(value)
+		}
+		if tag := sf.Tag.Get("normalize"); tag != "" {
+			for _, name := range strings.Split(tag, ",") {
+				switch name {
+				case "lower":
+					value = strings.ToLower(value)
+				case "phone":
+					value = NormalizePhone(value)
+				case "url":
+					value = NormalizeURL(value)
+				default:
+					panic(fmt.Sprintf("unknown normalizer %s of field %s", name, sf.Name))
+				}
+			}
+		}
+		field.SetString(value)
+	}
+}
+
+// fieldName returns the name of a field used in validation errors
+func fieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form"} {
+		name := strings.SplitN(field.Tag.Get(tag), ",", 2)[0]
+		switch name {
+		case "-":
+			return ""
+		case "":
+			continue
+		default:
+			return name
+		}
+	}
+	return field.Name
+}
+
+// parseCommonPasswords parses the list of common passwords, one per line
+func parseCommonPasswords(list string) map[string]struct{} {
+	passwords := make(map[string]struct{})
+	scanner := bufio.NewScanner(strings.NewReader(list))
+	for scanner.Scan() {
+		if p := strings.TrimSpace(scanner.Text()); p != "" && !strings.HasPrefix(p, "#") {
+			passwords[strings.ToLower(p)] = struct{}{}
+		}
+	}
+	return passwords
+}
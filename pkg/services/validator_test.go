@@ -1,9 +1,12 @@
 package services
 
 import (
+	"context"
 	"testing"
 
+	"github.com/go-playground/validator/v10"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestValidator(t *testing.T) {
@@ -17,3 +20,142 @@ func TestValidator(t *testing.T) {
 	err = c.Validator.Validate(e)
 	assert.NoError(t, err)
 }
+
+func TestValidator_FieldNames(t *testing.T) {
+	type example struct {
+		JSON   string `json:"json_name,omitempty" validate:"required"`
+		Form   string `form:"form_name" validate:"required"`
+		Struct string `validate:"required"`
+	}
+
+	err := NewValidator(nil).Validate(example{})
+	require.Error(t, err)
+
+	var fields []string
+	for _, fe := range err.(validator.ValidationErrors) {
+		fields = append(fields, fe.Field())
+	}
+	assert.Equal(t, []string{"json_name", "form_name", "Struct"}, fields)
+}
+
+func TestValidator_Rules(t *testing.T) {
+	type example struct {
+		Password string `validate:"omitempty,password"`
+		Slug     string `validate:"omitempty,slug"`
+		Phone    string `validate:"omitempty,phone"`
+		Locale   string `validate:"omitempty,locale"`
+	}
+	v := NewValidator(nil)
+
+	cases := []struct {
+		value example
+		valid bool
+	}{
+		{example{Password: "Correct-Horse7"}, true},
+		{example{Password: "Short1!"}, false},
+		{example{Password: "alllowercase"}, false},
+		{example{Password: "Password123"}, false},
+		{example{Password: "Пароль-без-цифр"}, true},
+		{example{Slug: "about-me-2"}, true},
+		{example{Slug: "About"}, false},
+		{example{Slug: "double--hyphen"}, false},
+		{example{Slug: "-leading"}, false},
+		{example{Phone: "+79991234567"}, true},
+		{example{Phone: "89991234567"}, false},
+		{example{Locale: "en"}, true},
+		{example{Locale: "de"}, false},
+	}
+
+	for _, tc := range cases {
+		err := v.Validate(tc.value)
+		if tc.valid {
+			assert.NoError(t, err, "%+v", tc.value)
+		} else {
+			assert.Error(t, err, "%+v", tc.value)
+		}
+	}
+}
+
+func TestValidator_UniqueEmail(t *testing.T) {
+	type example struct {
+		Email string `validate:"unique_email"`
+	}
+
+	u, err := c.ORM.User.
+		Create().
+		SetEmail("unique@localhost.localhost").
+		SetPassword("abc").
+		SetName("Unique").
+		Save(context.Background())
+	require.NoError(t, err)
+
+	assert.Error(t, c.Validator.ValidateCtx(context.Background(), example{Email: u.Email}))
+	assert.Error(t, c.Validator.ValidateCtx(context.Background(), example{Email: "UNIQUE@localhost.localhost"}))
+	assert.NoError(t, c.Validator.ValidateCtx(context.Background(), example{Email: "other@localhost.localhost"}))
+}
+
+func TestNormalize(t *testing.T) {
+	type example struct {
+		Email    string `normalize:"lower"`
+		Phone    string `normalize:"phone"`
+		Website  string `normalize:"url"`
+		Name     string
+		Password string `trim:"-"`
+	}
+
+	e := example{
+		Email:    "  John@Example.COM ",
+		Phone:    "8 (999) 123-45-67",
+		Website:  " Example.com/Path ",
+		Name:     " John ",
+		Password: " secret ",
+	}
+	Normalize(&e)
+	assert.Equal(t, example{
+		Email:    "john@example.com",
+		Phone:    "+79991234567",
+		Website:  "https://example.com/Path",
+		Name:     "John",
+		Password: " secret ",
+	}, e)
+
+	// Values can't be normalized
+	e2 := example{Email: " A "}
+	Normalize(e2)
+	assert.Equal(t, " A ", e2.Email)
+
+	// Pointers are normalized unless nil
+	type partial struct {
+		Email *string `normalize:"lower"`
+		Name  *string
+	}
+	email := " John@Example.COM "
+	p := partial{Email: &email}
+	Normalize(&p)
+	assert.Equal(t, "john@example.com", *p.Email)
+	assert.Nil(t, p.Name)
+}
+
+func TestNormalizePhone(t *testing.T) {
+	cases := map[string]string{
+		"+7 999 123-45-67":  "+79991234567",
+		"8 (999) 123 45 67": "+79991234567",
+		"1 555 123 4567":    "+15551234567",
+		"not a phone":       "not a phone",
+		"":                  "",
+	}
+	for in, want := range cases {
+		assert.Equal(t, want, NormalizePhone(in), in)
+	}
+}
+
+func TestNormalizeURL(t *testing.T) {
+	cases := map[string]string{
+		"github.com/user":          "https://github.com/user",
+		" HTTP://Example.com/A?b ": "http://example.com/A?b",
+		"":                         "",
+	}
+	for in, want := range cases {
+		assert.Equal(t, want, NormalizeURL(in), in)
+	}
+}
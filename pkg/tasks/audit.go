@@ -0,0 +1,36 @@
+package tasks
+
+import (
+	"context"
+	"time"
+
+	"github.com/hibiken/asynq"
+
+	"github.com/vovanwin/api-my-site/pkg/logging"
+	"github.com/vovanwin/api-my-site/pkg/services"
+)
+
+// TypeAuditPrune is the type for the periodic task that deletes audit events older than the retention period
+const TypeAuditPrune = "audit_prune"
+
+// AuditPruneProcessor processes audit prune tasks
+type AuditPruneProcessor struct {
+	Audit     *services.AuditClient
+	Retention time.Duration
+}
+
+// ProcessTask handles the processing of the task
+// Audit events are kept forever if no retention period is configured
+func (p *AuditPruneProcessor) ProcessTask(ctx context.Context, t *asynq.Task) error {
+	if p.Retention <= 0 {
+		return nil
+	}
+
+	count, err := p.Audit.Prune(ctx, time.Now().Add(-p.Retention))
+	if err != nil {
+		return err
+	}
+
+	logging.Infof(ctx, "pruned audit events: %d", count)
+	return nil
+}
@@ -0,0 +1,46 @@
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+
+	"github.com/vovanwin/api-my-site/ent"
+	"github.com/vovanwin/api-my-site/pkg/logging"
+	"github.com/vovanwin/api-my-site/pkg/services"
+)
+
+// TypeContactForward is the type for the task that emails a contact form message
+const TypeContactForward = "contact_forward"
+
+// ContactForwardPayload is the payload of the contact forward task
+type ContactForwardPayload struct {
+	MessageID int `json:"message_id"`
+}
+
+// ContactForwardProcessor processes contact forward tasks
+type ContactForwardProcessor struct {
+	Contact *services.ContactClient
+}
+
+// ProcessTask handles the processing of the task
+func (p *ContactForwardProcessor) ProcessTask(ctx context.Context, t *asynq.Task) error {
+	var payload ContactForwardPayload
+	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+		return fmt.Errorf("%v: %w", err, asynq.SkipRetry)
+	}
+
+	err := p.Contact.Forward(ctx, payload.MessageID)
+	switch err.(type) {
+	case nil:
+		logging.Infof(ctx, "forwarded contact message: %d", payload.MessageID)
+		return nil
+	case *ent.NotFoundError:
+		// The message was deleted before it was forwarded
+		return fmt.Errorf("%v: %w", err, asynq.SkipRetry)
+	default:
+		return err
+	}
+}
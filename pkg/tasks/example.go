@@ -2,9 +2,10 @@ package tasks
 
 import (
 	"context"
-	"log"
 
 	"github.com/hibiken/asynq"
+
+	"github.com/vovanwin/api-my-site/pkg/logging"
 )
 
 // TypeExample is the type for the example task.
@@ -17,6 +18,6 @@ type ExampleProcessor struct {
 
 // ProcessTask handles the processing of the task
 func (p *ExampleProcessor) ProcessTask(ctx context.Context, t *asynq.Task) error {
-	log.Printf("executing task: %s", t.Type())
+	logging.Infof(ctx, "executing task: %s", t.Type())
 	return nil
 }
@@ -0,0 +1,23 @@
+package tasks
+
+import (
+	"context"
+
+	"github.com/hibiken/asynq"
+
+	"github.com/vovanwin/api-my-site/pkg/services"
+)
+
+// TypeHeartbeat is the type for the periodic task that records the worker heartbeat checked by the readiness probe
+// Since the task is queued by the scheduler and processed by the worker, a recent heartbeat shows both are running
+const TypeHeartbeat = "heartbeat"
+
+// HeartbeatProcessor processes heartbeat tasks
+type HeartbeatProcessor struct {
+	Health *services.HealthClient
+}
+
+// ProcessTask handles the processing of the task
+func (p *HeartbeatProcessor) ProcessTask(ctx context.Context, t *asynq.Task) error {
+	return p.Health.Beat(ctx)
+}
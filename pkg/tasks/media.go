@@ -0,0 +1,49 @@
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+
+	"github.com/vovanwin/api-my-site/ent"
+	"github.com/vovanwin/api-my-site/pkg/logging"
+	"github.com/vovanwin/api-my-site/pkg/services"
+)
+
+// TypeMediaVariants is the type for the task that generates variants of an uploaded image
+const TypeMediaVariants = "media_variants"
+
+// MediaVariantsPayload is the payload of the media variants task
+type MediaVariantsPayload struct {
+	MediaID int `json:"media_id"`
+}
+
+// MediaVariantsProcessor processes media variants tasks
+type MediaVariantsProcessor struct {
+	Media *services.MediaClient
+}
+
+// ProcessTask handles the processing of the task
+func (p *MediaVariantsProcessor) ProcessTask(ctx context.Context, t *asynq.Task) error {
+	var payload MediaVariantsPayload
+	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+		return fmt.Errorf("%v: %w", err, asynq.SkipRetry)
+	}
+
+	err := p.Media.GenerateVariants(ctx, payload.MediaID)
+	switch err.(type) {
+	case nil:
+		logging.Infof(ctx, "generated variants for media: %d", payload.MediaID)
+		return nil
+	case *ent.NotFoundError:
+		// The media was deleted before it was processed
+		return fmt.Errorf("%v: %w", err, asynq.SkipRetry)
+	case services.MediaTooManyPixelsError:
+		// Retrying won't make the image any smaller
+		return fmt.Errorf("%v: %w", err, asynq.SkipRetry)
+	default:
+		return err
+	}
+}
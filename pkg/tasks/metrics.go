@@ -0,0 +1,22 @@
+package tasks
+
+import (
+	"context"
+	"time"
+
+	"github.com/hibiken/asynq"
+
+	"github.com/vovanwin/api-my-site/pkg/services"
+)
+
+// Metrics returns a middleware which records the processed tasks in the metrics
+func Metrics(m *services.MetricsClient) asynq.MiddlewareFunc {
+	return func(next asynq.Handler) asynq.Handler {
+		return asynq.HandlerFunc(func(ctx context.Context, t *asynq.Task) error {
+			start := time.Now()
+			err := next.ProcessTask(ctx, t)
+			m.ObserveTask(t.Type(), err, time.Since(start))
+			return err
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package tasks
+
+import (
+	"context"
+
+	"github.com/hibiken/asynq"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/vovanwin/api-my-site/pkg/services"
+)
+
+// Tracing returns a middleware which traces the processing of tasks, continuing the trace the task was created in
+func Tracing(t *services.TracingClient) asynq.MiddlewareFunc {
+	return func(next asynq.Handler) asynq.Handler {
+		return asynq.HandlerFunc(func(ctx context.Context, task *asynq.Task) error {
+			ctx = services.ExtractTaskTrace(ctx, task.Payload())
+
+			attrs := []attribute.KeyValue{
+				semconv.MessagingSystem("asynq"),
+				semconv.MessagingOperationProcess,
+				attribute.String("asynq.task.type", task.Type()),
+			}
+			if id, ok := asynq.GetTaskID(ctx); ok {
+				attrs = append(attrs, attribute.String("asynq.task.id", id))
+			}
+			if retry, ok := asynq.GetRetryCount(ctx); ok {
+				attrs = append(attrs, attribute.Int("asynq.task.retry", retry))
+			}
+
+			ctx, span := t.Tracer().Start(ctx, "task "+task.Type(),
+				trace.WithSpanKind(trace.SpanKindConsumer),
+				trace.WithAttributes(attrs...),
+			)
+			defer span.End()
+
+			err := next.ProcessTask(ctx, task)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			return err
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+
+	"github.com/vovanwin/api-my-site/ent"
+	"github.com/vovanwin/api-my-site/ent/media"
+	"github.com/vovanwin/api-my-site/ent/passwordtoken"
+	"github.com/vovanwin/api-my-site/ent/post"
+	"github.com/vovanwin/api-my-site/ent/user"
+	"github.com/vovanwin/api-my-site/pkg/logging"
+	"github.com/vovanwin/api-my-site/pkg/services"
+)
+
+// TypeUserDelete is the type for the task that deletes an account once its grace period is over
+const TypeUserDelete = "user_delete"
+
+// UserDeletePayload is the payload of the user delete task
+type UserDeletePayload struct {
+	UserID int `json:"user_id"`
+}
+
+// UserDeleteProcessor processes user delete tasks
+type UserDeleteProcessor struct {
+	ORM   *ent.Client
+	Media *services.MediaClient
+}
+
+// ProcessTask handles the processing of the task
+// Nothing is deleted if the deletion was cancelled by logging in or postponed, since the task of the later
+// deletion handles it
+func (p *UserDeleteProcessor) ProcessTask(ctx context.Context, t *asynq.Task) error {
+	var payload UserDeletePayload
+	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+		return fmt.Errorf("%v: %w", err, asynq.SkipRetry)
+	}
+
+	u, err := p.ORM.User.Get(ctx, payload.UserID)
+	switch err.(type) {
+	case nil:
+	case *ent.NotFoundError:
+		// The user was already deleted
+		return fmt.Errorf("%v: %w", err, asynq.SkipRetry)
+	default:
+		return err
+	}
+
+	if u.DeleteAt == nil || u.DeleteAt.After(time.Now()) {
+		logging.Infof(ctx, "deletion of user %d was cancelled or postponed", u.ID)
+		return nil
+	}
+
+	if err := p.delete(ctx, u); err != nil {
+		return err
+	}
+
+	logging.Infof(ctx, "deleted user: %d", u.ID)
+	return nil
+}
+
+// delete deletes a user along with the media, posts and password tokens of the user
+func (p *UserDeleteProcessor) delete(ctx context.Context, u *ent.User) error {
+	items, err := p.ORM.Media.Query().
+		Where(media.HasOwnerWith(user.ID(u.ID))).
+		All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, m := range items {
+		if err := p.Media.Delete(ctx, m); err != nil {
+			return err
+		}
+	}
+
+	if _, err := p.ORM.Post.Delete().
+		Where(post.HasAuthorWith(user.ID(u.ID))).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	if _, err := p.ORM.PasswordToken.Delete().
+		Where(passwordtoken.HasUserWith(user.ID(u.ID))).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	return p.ORM.User.DeleteOne(u).Exec(ctx)
+}
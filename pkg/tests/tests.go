@@ -2,14 +2,15 @@ package tests
 
 import (
 	"context"
-	"fmt"
-	"math/rand"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/vovanwin/api-my-site/ent"
+	"github.com/vovanwin/api-my-site/pkg/factory"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -46,13 +47,7 @@ func AssertHTTPErrorCode(t *testing.T, err error, code int) {
 	assert.Equal(t, code, httpError.Code)
 }
 
-// CreateUser creates a random user entity
+// CreateUser creates a random user entity whose password is factory.DefaultPassword
 func CreateUser(orm *ent.Client) (*ent.User, error) {
-	seed := fmt.Sprintf("%d-%d", time.Now().UnixMilli(), rand.Intn(1000000))
-	return orm.User.
-		Create().
-		SetEmail(fmt.Sprintf("testuser-%s@localhost.localhost", seed)).
-		SetPassword("password").
-		SetName(fmt.Sprintf("Test User %s", seed)).
-		Save(context.Background())
+	return factory.New(orm, time.Now().UnixNano()).User().Create(context.Background())
 }